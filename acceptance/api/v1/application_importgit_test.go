@@ -66,5 +66,37 @@ var _ = Describe("AppImportGit Endpoint", func() {
 			Expect(importResponse.BlobUID).ToNot(BeEmpty())
 			Expect(importResponse.BlobUID).To(MatchRegexp(".+-.+-.+-.+-.+"))
 		})
+
+		It("accepts patterns of files to exclude from the import", func() {
+			app := catalog.NewAppName()
+			gitURL := "https://github.com/epinio/example-wordpress"
+			data := url.Values{}
+			data.Set("giturl", gitURL)
+			data.Set("gitrev", "main")
+			data.Add("exclude", "*.md")
+			data.Add("exclude", "wp-content")
+
+			url := serverURL + v1.Root + "/" + v1.Routes.Path("AppImportGit", namespace, app)
+			request, err := http.NewRequest("POST", url, strings.NewReader(data.Encode()))
+			Expect(err).ToNot(HaveOccurred())
+			request.SetBasicAuth(env.EpinioUser, env.EpinioPassword)
+			request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+			request.Header.Add("Content-Length", strconv.Itoa(len(data.Encode())))
+
+			response, err := env.Client().Do(request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).ToNot(BeNil())
+
+			defer response.Body.Close()
+			bodyBytes, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred(), string(bodyBytes))
+			Expect(response.StatusCode).To(Equal(http.StatusOK), string(bodyBytes))
+
+			var importResponse models.ImportGitResponse
+			err = json.Unmarshal(bodyBytes, &importResponse)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(importResponse.BlobUID).ToNot(BeEmpty())
+			Expect(importResponse.BlobUID).To(MatchRegexp(".+-.+-.+-.+-.+"))
+		})
 	})
 })
@@ -90,6 +90,40 @@ var _ = Describe("AppUpdate Endpoint", func() {
 			Expect(errorResponse.Errors[0].Title).To(Equal("json: cannot unmarshal string into Go struct field ApplicationUpdateRequest.instances of type int32"))
 		})
 	})
+	When("binding options are given", func() {
+		It("returns BadRequest when the app chart does not render them", func() {
+			app := catalog.NewAppName()
+			env.MakeContainerImageApp(app, 1, containerImageURL)
+			defer env.DeleteApp(app)
+
+			configuration := catalog.NewConfigurationName()
+			env.MakeConfiguration(configuration)
+			defer env.CleanupConfiguration(configuration)
+
+			data, err := json.Marshal(models.ApplicationUpdateRequest{
+				Configurations: []string{configuration},
+				BindOptions: map[string]models.BindOptions{
+					configuration: {MountPath: "/etc/db"},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			response, err := env.Curl("PATCH",
+				fmt.Sprintf("%s%s/namespaces/%s/applications/%s",
+					serverURL, v1.Root, namespace, app),
+				strings.NewReader(string(data)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).ToNot(BeNil())
+
+			defer response.Body.Close()
+			bodyBytes, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusBadRequest), string(bodyBytes))
+
+			Expect(appFromAPI(namespace, app).Configuration.Configurations).ToNot(ContainElement(configuration))
+		})
+	})
+
 	When("routes have changed", func() {
 		// removes empty strings from the given slice
 		deleteEmpty := func(elements []string) []string {
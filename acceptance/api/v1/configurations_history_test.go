@@ -0,0 +1,94 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/epinio/epinio/acceptance/helpers/catalog"
+	api "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Configuration History Endpoints", func() {
+	var namespace, configuration string
+
+	request := func(method, path, body string) (int, []byte) {
+		response, err := env.Curl(method, fmt.Sprintf("%s%s/%s", serverURL, api.Root, path),
+			strings.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response).ToNot(BeNil())
+
+		defer response.Body.Close()
+		bodyBytes, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+
+		return response.StatusCode, bodyBytes
+	}
+
+	history := func() models.ConfigurationHistoryResponse {
+		status, bodyBytes := request("GET", api.Routes.Path("ConfigurationHistory", namespace, configuration), "")
+		Expect(status).To(Equal(http.StatusOK), string(bodyBytes))
+
+		var result models.ConfigurationHistoryResponse
+		err := json.Unmarshal(bodyBytes, &result)
+		Expect(err).ToNot(HaveOccurred(), string(bodyBytes))
+
+		return result
+	}
+
+	BeforeEach(func() {
+		namespace = catalog.NewNamespaceName()
+		env.SetupAndTargetNamespace(namespace)
+
+		configuration = catalog.NewConfigurationName()
+		env.MakeConfiguration(configuration)
+	})
+
+	AfterEach(func() {
+		env.DeleteConfiguration(configuration)
+		env.DeleteNamespace(namespace)
+	})
+
+	It("records every change as a revision", func() {
+		status, bodyBytes := request("PATCH", api.Routes.Path("ConfigurationUpdate", namespace, configuration),
+			`{"edit":{"password":"secret"}}`)
+		Expect(status).To(Equal(http.StatusOK), string(bodyBytes))
+
+		revisions := history()
+		Expect(revisions).To(HaveLen(2))
+		Expect(revisions[0].Revision).To(Equal(2))
+		Expect(revisions[0].Current).To(BeTrue())
+		Expect(revisions[0].Added).To(Equal([]string{"password"}))
+		Expect(revisions[1].Revision).To(Equal(1))
+		Expect(revisions[1].Added).To(Equal([]string{"username"}))
+	})
+
+	It("reverts to a revision", func() {
+		status, bodyBytes := request("PATCH", api.Routes.Path("ConfigurationUpdate", namespace, configuration),
+			`{"edit":{"username":"bogus"}}`)
+		Expect(status).To(Equal(http.StatusOK), string(bodyBytes))
+
+		status, bodyBytes = request("POST", api.Routes.Path("ConfigurationRevert", namespace, configuration, "1"), `{}`)
+		Expect(status).To(Equal(http.StatusOK), string(bodyBytes))
+
+		revisions := history()
+		Expect(revisions).To(HaveLen(3))
+		Expect(revisions[0].RevertedFrom).To(Equal(1))
+		Expect(revisions[0].Modified).To(Equal([]string{"username"}))
+
+		status, bodyBytes = request("GET", api.Routes.Path("ConfigurationShow", namespace, configuration), "")
+		Expect(status).To(Equal(http.StatusOK), string(bodyBytes))
+		Expect(string(bodyBytes)).To(ContainSubstring(`"username":"epinio-user"`))
+	})
+
+	It("returns 404 for an unknown revision", func() {
+		status, bodyBytes := request("POST", api.Routes.Path("ConfigurationRevert", namespace, configuration, "42"), `{}`)
+		Expect(status).To(Equal(http.StatusNotFound), string(bodyBytes))
+	})
+})
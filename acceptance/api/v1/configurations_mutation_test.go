@@ -10,6 +10,7 @@ import (
 	"github.com/epinio/epinio/acceptance/helpers/catalog"
 	api "github.com/epinio/epinio/internal/api/v1"
 	"github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -186,6 +187,78 @@ var _ = Describe("Configurations API Application Endpoints, Mutations", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusCreated), string(bodyBytes))
 				Expect(string(bodyBytes)).To(Equal(jsOK))
 			})
+
+			It("creates the configuration with binary data", func() {
+				response, err := env.Curl("POST",
+					fmt.Sprintf("%s%s/namespaces/%s/configurations",
+						serverURL, api.Root, namespace),
+					strings.NewReader(fmt.Sprintf(`{
+					    "name": "%s",
+					    "data": {"host":"localhost"},
+					    "binary_data": {"blob":"/wABAg=="}
+					}`, configuration)))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).ToNot(BeNil())
+
+				defer response.Body.Close()
+				bodyBytes, err := ioutil.ReadAll(response.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusCreated), string(bodyBytes))
+
+				response, err = env.Curl("GET",
+					fmt.Sprintf("%s%s/namespaces/%s/configurations/%s",
+						serverURL, api.Root, namespace, configuration),
+					strings.NewReader(""))
+				Expect(err).ToNot(HaveOccurred())
+				defer response.Body.Close()
+				bodyBytes, err = ioutil.ReadAll(response.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusOK), string(bodyBytes))
+
+				var show models.ConfigurationResponse
+				err = json.Unmarshal(bodyBytes, &show)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(show.Configuration.Type).To(Equal(models.ConfigurationTypeCustom))
+				Expect(show.Configuration.Details).To(Equal(map[string]string{"host": "localhost"}))
+				Expect(show.Configuration.BinaryDetails).To(Equal(map[string][]byte{"blob": {0xff, 0, 1, 2}}))
+			})
+
+			It("creates a typed configuration", func() {
+				response, err := env.Curl("POST",
+					fmt.Sprintf("%s%s/namespaces/%s/configurations",
+						serverURL, api.Root, namespace),
+					strings.NewReader(fmt.Sprintf(`{
+					    "name": "%s",
+					    "type": "basic-auth",
+					    "data": {"username":"epinio", "password":"secret"}
+					}`, configuration)))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).ToNot(BeNil())
+
+				defer response.Body.Close()
+				bodyBytes, err := ioutil.ReadAll(response.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusCreated), string(bodyBytes))
+			})
+
+			It("returns a 'bad request' for a typed configuration missing keys", func() {
+				response, err := env.Curl("POST",
+					fmt.Sprintf("%s%s/namespaces/%s/configurations",
+						serverURL, api.Root, namespace),
+					strings.NewReader(fmt.Sprintf(`{
+					    "name": "%s",
+					    "type": "docker-registry",
+					    "data": {"username":"epinio"}
+					}`, configuration)))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).ToNot(BeNil())
+
+				defer response.Body.Close()
+				bodyBytes, err := ioutil.ReadAll(response.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest), string(bodyBytes))
+				Expect(string(bodyBytes)).To(ContainSubstring("missing keys: server, password"))
+			})
 		})
 	})
 
@@ -511,6 +584,69 @@ var _ = Describe("Configurations API Application Endpoints, Mutations", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusOK), string(bodyBytes))
 				Expect(string(bodyBytes)).To(Equal(`{"wasbound":null}`))
 			})
+
+			It("binds the configuration with options", func() {
+				app := catalog.NewAppName()
+				out, err := env.Epinio("", "app", "create", app)
+				Expect(err).ToNot(HaveOccurred(), out)
+				defer env.DeleteApp(app)
+
+				response, err := env.Curl("POST",
+					fmt.Sprintf("%s%s/namespaces/%s/applications/%s/configurationbindings",
+						serverURL, api.Root, namespace, app),
+					strings.NewReader(fmt.Sprintf(`{
+					    "names": ["%[1]s"],
+					    "options": {"%[1]s": {"as_env": true, "env_prefix": "DB_", "keys": {"username": "USER"}}}
+					}`, configuration)))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).ToNot(BeNil())
+
+				defer response.Body.Close()
+				bodyBytes, err := ioutil.ReadAll(response.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusOK), string(bodyBytes))
+
+				theApp := appFromAPI(namespace, app)
+				Expect(theApp.Configuration.BindOptions).To(HaveKeyWithValue(configuration, models.BindOptions{
+					Keys:      map[string]string{"username": "USER"},
+					AsEnv:     true,
+					EnvPrefix: "DB_",
+				}))
+			})
+
+			It("returns a 'bad request' for inconsistent options", func() {
+				response, err := env.Curl("POST",
+					fmt.Sprintf("%s%s/namespaces/%s/applications/%s/configurationbindings",
+						serverURL, api.Root, namespace, app),
+					strings.NewReader(fmt.Sprintf(`{
+					    "names": ["%[1]s"],
+					    "options": {"%[1]s": {"as_env": true, "mount_path": "/etc/db"}}
+					}`, configuration)))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).ToNot(BeNil())
+
+				defer response.Body.Close()
+				bodyBytes, err := ioutil.ReadAll(response.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest), string(bodyBytes))
+			})
+
+			It("returns a 'bad request' for unknown keys", func() {
+				response, err := env.Curl("POST",
+					fmt.Sprintf("%s%s/namespaces/%s/applications/%s/configurationbindings",
+						serverURL, api.Root, namespace, app),
+					strings.NewReader(fmt.Sprintf(`{
+					    "names": ["%[1]s"],
+					    "options": {"%[1]s": {"keys": {"bogus": "bogus"}}}
+					}`, configuration)))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).ToNot(BeNil())
+
+				defer response.Body.Close()
+				bodyBytes, err := ioutil.ReadAll(response.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest), string(bodyBytes))
+			})
 		})
 	})
 
@@ -204,6 +204,35 @@ func appFromAPI(namespace, app string) models.App {
 	return responseApp
 }
 
+// serviceFromAPI returns the service and its configurations, as reported by the API
+func serviceFromAPI(namespace, service string) models.ServiceShowResponse {
+	response, err := env.Curl("GET",
+		fmt.Sprintf("%s%s/namespaces/%s/services/%s",
+			serverURL, v1.Root, namespace, service),
+		strings.NewReader(""))
+
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	ExpectWithOffset(1, response).ToNot(BeNil())
+	defer response.Body.Close()
+	bodyBytes, err := ioutil.ReadAll(response.Body)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	ExpectWithOffset(1, response.StatusCode).To(Equal(http.StatusOK), string(bodyBytes))
+
+	var showResponse models.ServiceShowResponse
+	err = json.Unmarshal(bodyBytes, &showResponse)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred(), string(bodyBytes))
+	ExpectWithOffset(1, showResponse.Service).ToNot(BeNil())
+
+	return showResponse
+}
+
+// waitForServiceReady waits until the service is deployed and all its pods are ready
+func waitForServiceReady(namespace, service string) {
+	EventuallyWithOffset(1, func() models.ServiceStatus {
+		return serviceFromAPI(namespace, service).Service.Status
+	}, "5m", "5s").Should(Equal(models.ServiceStatusDeployed))
+}
+
 func updateAppInstances(namespace string, app string, instances int32) (int, []byte) {
 	desired := instances
 	data, err := json.Marshal(models.ApplicationUpdateRequest{
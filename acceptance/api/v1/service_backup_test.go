@@ -0,0 +1,208 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/epinio/epinio/acceptance/helpers/catalog"
+	"github.com/epinio/epinio/acceptance/helpers/proc"
+	v1 "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceBackup Endpoints", func() {
+	var namespace, serviceName string
+	var catalogService models.CatalogService
+
+	// The backup saves the name of the release, the restore checks that it gets it back.
+	backupJob := `
+containers:
+- name: backup
+  image: curlimages/curl
+  command: ["sh", "-c", "echo {{ .Release }} > /tmp/artifact && curl -fsS -T /tmp/artifact \"$EPINIO_BACKUP_URL\""]
+`
+	restoreJob := `
+containers:
+- name: restore
+  image: curlimages/curl
+  command: ["sh", "-c", "curl -fsS \"$EPINIO_BACKUP_URL\" | grep {{ .Release }}"]
+`
+
+	serviceRequest := func(method, path string) (int, []byte) {
+		response, err := env.Curl(method, fmt.Sprintf("%s%s/%s", serverURL, v1.Root, path),
+			strings.NewReader(""))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response).ToNot(BeNil())
+
+		defer response.Body.Close()
+		bodyBytes, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+
+		return response.StatusCode, bodyBytes
+	}
+
+	// waitForJob polls the status of the job until it is done, and returns the final status
+	waitForJob := func(job models.ServiceJob) models.ServiceJob {
+		Eventually(func() models.ServiceJobStatus {
+			status, bodyBytes := serviceRequest("GET", v1.Routes.Path("ServiceJob", namespace, serviceName, job.Name))
+			ExpectWithOffset(1, status).To(Equal(http.StatusOK), string(bodyBytes))
+			ExpectWithOffset(1, json.Unmarshal(bodyBytes, &job)).To(Succeed(), string(bodyBytes))
+			return job.Status
+		}, "5m", "3s").ShouldNot(Equal(models.ServiceJobRunning))
+
+		// Finished jobs report the same status again, until deleted
+		var again models.ServiceJob
+		status, bodyBytes := serviceRequest("GET", v1.Routes.Path("ServiceJob", namespace, serviceName, job.Name))
+		ExpectWithOffset(1, status).To(Equal(http.StatusOK), string(bodyBytes))
+		ExpectWithOffset(1, json.Unmarshal(bodyBytes, &again)).To(Succeed(), string(bodyBytes))
+		ExpectWithOffset(1, again).To(Equal(job))
+
+		status, bodyBytes = serviceRequest("DELETE", v1.Routes.Path("ServiceJobDelete", namespace, serviceName, job.Name))
+		ExpectWithOffset(1, status).To(Equal(http.StatusOK), string(bodyBytes))
+
+		status, bodyBytes = serviceRequest("GET", v1.Routes.Path("ServiceJob", namespace, serviceName, job.Name))
+		ExpectWithOffset(1, status).To(Equal(http.StatusNotFound), string(bodyBytes))
+
+		return job
+	}
+
+	createCatalogService := func() {
+		b, err := json.Marshal(catalogService)
+		Expect(err).ToNot(HaveOccurred())
+
+		response, err := env.Curl("POST", fmt.Sprintf("%s%s/catalogservices", serverURL, v1.Root),
+			strings.NewReader(string(b)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response).ToNot(BeNil())
+		defer response.Body.Close()
+		bodyBytes, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusCreated), string(bodyBytes))
+	}
+
+	BeforeEach(func() {
+		namespace = catalog.NewNamespaceName()
+		env.SetupAndTargetNamespace(namespace)
+
+		serviceName = catalog.NewServiceName()
+		catalogService = models.CatalogService{
+			Meta: models.MetaLite{
+				Name: catalog.NewCatalogServiceName(),
+			},
+			HelmChart: "nginx",
+			HelmRepo: models.HelmRepo{
+				URL: "https://charts.bitnami.com/bitnami",
+			},
+			Values: "{'service': {'type': 'ClusterIP'}}",
+		}
+	})
+
+	AfterEach(func() {
+		out, err := proc.Kubectl("delete", "helmchart", "-n", "epinio", names.ServiceHelmChartName(serviceName, namespace))
+		Expect(err).ToNot(HaveOccurred(), out)
+
+		catalog.DeleteCatalogService(catalogService.Meta.Name)
+		env.DeleteNamespace(namespace)
+	})
+
+	When("the catalog service has no backup job", func() {
+		BeforeEach(func() {
+			createCatalogService()
+			catalog.CreateService(serviceName, namespace, catalogService)
+			waitForServiceReady(namespace, serviceName)
+		})
+
+		It("rejects the backup", func() {
+			status, bodyBytes := serviceRequest("POST", v1.Routes.Path("ServiceBackup", namespace, serviceName))
+			Expect(status).To(Equal(http.StatusBadRequest), string(bodyBytes))
+		})
+	})
+
+	When("the backup job of the catalog service fails", func() {
+		BeforeEach(func() {
+			catalogService.BackupJob = `
+containers:
+- name: backup
+  image: busybox
+  command: ["sh", "-c", "echo dump of {{ .Release }} failed; exit 1"]
+`
+			createCatalogService()
+			catalog.CreateService(serviceName, namespace, catalogService)
+			waitForServiceReady(namespace, serviceName)
+		})
+
+		It("reports the failure with the logs of the job", func() {
+			status, bodyBytes := serviceRequest("POST", v1.Routes.Path("ServiceBackup", namespace, serviceName))
+			Expect(status).To(Equal(http.StatusOK), string(bodyBytes))
+
+			var job models.ServiceJob
+			err := json.Unmarshal(bodyBytes, &job)
+			Expect(err).ToNot(HaveOccurred(), string(bodyBytes))
+
+			job = waitForJob(job)
+			Expect(job.Status).To(Equal(models.ServiceJobFailed))
+			Expect(job.Backup).To(BeNil())
+			Expect(job.Logs).To(ContainSubstring("dump of " + names.ServiceHelmChartName(serviceName, namespace) + " failed"))
+		})
+	})
+
+	When("the catalog service has backup and restore jobs", func() {
+		BeforeEach(func() {
+			catalogService.BackupJob = backupJob
+			catalogService.RestoreJob = restoreJob
+			createCatalogService()
+			catalog.CreateService(serviceName, namespace, catalogService)
+			waitForServiceReady(namespace, serviceName)
+		})
+
+		It("saves, lists, and restores backups", func() {
+			status, bodyBytes := serviceRequest("POST", v1.Routes.Path("ServiceBackup", namespace, serviceName))
+			Expect(status).To(Equal(http.StatusOK), string(bodyBytes))
+
+			var job models.ServiceJob
+			err := json.Unmarshal(bodyBytes, &job)
+			Expect(err).ToNot(HaveOccurred(), string(bodyBytes))
+			Expect(job.Kind).To(Equal(models.ServiceJobBackup))
+			Expect(job.Status).To(Equal(models.ServiceJobRunning))
+
+			job = waitForJob(job)
+			Expect(job.Status).To(Equal(models.ServiceJobSucceeded), job.Logs)
+			Expect(job.Backup).ToNot(BeNil())
+
+			backup := *job.Backup
+			Expect(backup.ID).To(Equal(job.BackupID))
+			Expect(backup.Size).To(BeNumerically(">", 0))
+
+			status, bodyBytes = serviceRequest("GET", v1.Routes.Path("ServiceBackups", namespace, serviceName))
+			Expect(status).To(Equal(http.StatusOK), string(bodyBytes))
+
+			var backups models.ServiceBackupList
+			err = json.Unmarshal(bodyBytes, &backups)
+			Expect(err).ToNot(HaveOccurred(), string(bodyBytes))
+			Expect(backups).To(HaveLen(1))
+			Expect(backups[0].ID).To(Equal(backup.ID))
+
+			status, bodyBytes = serviceRequest("POST", v1.Routes.Path("ServiceRestore", namespace, serviceName, backup.ID))
+			Expect(status).To(Equal(http.StatusOK), string(bodyBytes))
+
+			err = json.Unmarshal(bodyBytes, &job)
+			Expect(err).ToNot(HaveOccurred(), string(bodyBytes))
+			Expect(job.Kind).To(Equal(models.ServiceJobRestore))
+
+			job = waitForJob(job)
+			Expect(job.Status).To(Equal(models.ServiceJobSucceeded), job.Logs)
+		})
+
+		It("returns 404 when restoring an unknown backup", func() {
+			status, bodyBytes := serviceRequest("POST", v1.Routes.Path("ServiceRestore", namespace, serviceName, "bogus"))
+			Expect(status).To(Equal(http.StatusNotFound), string(bodyBytes))
+		})
+	})
+})
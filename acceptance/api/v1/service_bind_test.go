@@ -123,6 +123,7 @@ var _ = Describe("ServiceBind Endpoint", func() {
 
 			env.MakeContainerImageApp(app, 1, containerImageURL)
 			catalog.CreateService(serviceName, namespace, catalogService)
+			waitForServiceReady(namespace, serviceName)
 		})
 
 		AfterEach(func() {
@@ -153,5 +154,64 @@ var _ = Describe("ServiceBind Endpoint", func() {
 			matchString := fmt.Sprintf("Bound Configurations.*%s", chartName)
 			Expect(appShowOut).To(MatchRegexp(matchString))
 		})
+
+		It("binds the selected keys of the service's secrets", func() {
+			endpoint := fmt.Sprintf("%s%s/%s",
+				serverURL, apiv1.Root, apiv1.Routes.Path("ServiceBind", namespace, serviceName))
+			requestBody, err := json.Marshal(models.ServiceBindRequest{
+				AppName: app,
+				Secrets: map[string]models.BindOptions{
+					chartName: {
+						Keys:  map[string]string{"mysql-root-password": "DB_PASSWORD"},
+						AsEnv: true,
+					},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			response, err := env.Curl("POST", endpoint, strings.NewReader(string(requestBody)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).ToNot(BeNil())
+
+			defer response.Body.Close()
+			bodyBytes, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusOK), string(bodyBytes))
+
+			theApp := appFromAPI(namespace, app)
+			Expect(theApp.Configuration.Configurations).To(ContainElement(chartName))
+			Expect(theApp.Configuration.BindOptions).To(HaveKeyWithValue(chartName, models.BindOptions{
+				Keys:  map[string]string{"mysql-root-password": "DB_PASSWORD"},
+				AsEnv: true,
+			}))
+
+			// The standard app chart gets the key placed into the pod spec as variable
+			out, err := proc.Kubectl("get", "deployments",
+				"-l", fmt.Sprintf("app.kubernetes.io/name=%s,app.kubernetes.io/part-of=%s", app, namespace),
+				"--namespace", namespace,
+				"-o", "jsonpath={.items[].spec.template.spec.containers[0].env}")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(ContainSubstring(`"name":"DB_PASSWORD"`))
+			Expect(out).To(ContainSubstring(`"key":"mysql-root-password"`))
+		})
+
+		It("rejects the selection of an unknown secret", func() {
+			endpoint := fmt.Sprintf("%s%s/%s",
+				serverURL, apiv1.Root, apiv1.Routes.Path("ServiceBind", namespace, serviceName))
+			requestBody, err := json.Marshal(models.ServiceBindRequest{
+				AppName: app,
+				Secrets: map[string]models.BindOptions{"bogus": {}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			response, err := env.Curl("POST", endpoint, strings.NewReader(string(requestBody)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).ToNot(BeNil())
+
+			defer response.Body.Close()
+			bodyBytes, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusBadRequest), string(bodyBytes))
+		})
 	})
 })
@@ -157,6 +157,28 @@ var _ = Describe("ServiceShow Endpoint", func() {
 						return showResponse.Service.Status
 					}, "1m", "5s").Should(Equal(models.ServiceStatusDeployed))
 				})
+
+				It("returns the details of the service status", func() {
+					Eventually(func() models.ServiceStatusDetails {
+						endpoint := fmt.Sprintf("%s%s/namespaces/%s/services/%s", serverURL, v1.Root, namespace, serviceName)
+						response, err := env.Curl("GET", endpoint, strings.NewReader(""))
+						Expect(err).ToNot(HaveOccurred())
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						var showResponse models.ServiceShowResponse
+						err = json.NewDecoder(response.Body).Decode(&showResponse)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(showResponse.Service).ToNot(BeNil())
+
+						details := showResponse.Service.Details
+						details.PodsReady = 0
+						details.PodsTotal = 0
+						return details
+					}, "1m", "5s").Should(Equal(models.ServiceStatusDetails{
+						HelmStatus: "deployed",
+						JobStatus:  models.ServiceJobSucceeded,
+					}))
+				})
 			})
 
 			When("helmchart is ready and the catalog service is missing", func() {
@@ -0,0 +1,113 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/epinio/epinio/acceptance/helpers/catalog"
+	v1 "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceUpdate Endpoint", func() {
+	var namespace string
+	var catalogService models.CatalogService
+
+	BeforeEach(func() {
+		namespace = catalog.NewNamespaceName()
+		env.SetupAndTargetNamespace(namespace)
+
+		catalogService = models.CatalogService{
+			Meta: models.MetaLite{
+				Name: catalog.NewCatalogServiceName(),
+			},
+			HelmChart: "nginx",
+			HelmRepo: models.HelmRepo{
+				Name: "",
+				URL:  "https://charts.bitnami.com/bitnami",
+			},
+			Values: "{'service': {'type': 'ClusterIP'}}",
+		}
+		catalog.CreateCatalogService(catalogService)
+	})
+
+	AfterEach(func() {
+		catalog.DeleteCatalogService(catalogService.Meta.Name)
+		env.DeleteNamespace(namespace)
+	})
+
+	When("service instance doesn't exist", func() {
+		It("returns 404", func() {
+			endpoint := fmt.Sprintf("%s%s/namespaces/%s/services/notexists", serverURL, v1.Root, namespace)
+
+			requestBody, err := json.Marshal(models.ServiceUpdateRequest{})
+			Expect(err).ToNot(HaveOccurred())
+
+			response, err := env.Curl("PATCH", endpoint, strings.NewReader(string(requestBody)))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("service instance exists", func() {
+		var serviceName string
+
+		BeforeEach(func() {
+			serviceName = catalog.NewServiceName()
+			env.MakeServiceInstance(serviceName, catalogService.Meta.Name)
+		})
+
+		AfterEach(func() {
+			env.DeleteService(serviceName)
+		})
+
+		It("changes the settings of the service", func() {
+			endpoint := fmt.Sprintf("%s%s/namespaces/%s/services/%s", serverURL, v1.Root, namespace, serviceName)
+
+			requestBody, err := json.Marshal(models.ServiceUpdateRequest{
+				Set: map[string]string{"replicaCount": "2"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			response, err := env.Curl("PATCH", endpoint, strings.NewReader(string(requestBody)))
+			Expect(err).ToNot(HaveOccurred())
+
+			respBody, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusOK), string(respBody))
+
+			response, err = env.Curl("GET", endpoint, strings.NewReader(""))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			var showResponse models.ServiceShowResponse
+			err = json.NewDecoder(response.Body).Decode(&showResponse)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(showResponse.Service).ToNot(BeNil())
+			Expect(showResponse.Service.Settings).To(HaveKeyWithValue("replicaCount", "2"))
+		})
+
+		It("upgrades the service to the chart version of the catalog service", func() {
+			endpoint := fmt.Sprintf("%s%s/namespaces/%s/services/%s/upgrade", serverURL, v1.Root, namespace, serviceName)
+
+			response, err := env.Curl("POST", endpoint, strings.NewReader(""))
+			Expect(err).ToNot(HaveOccurred())
+
+			respBody, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusOK), string(respBody))
+
+			var upgradeResponse models.ServiceUpgradeResponse
+			err = json.Unmarshal(respBody, &upgradeResponse)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(upgradeResponse.NewChartVersion).To(Equal(catalogService.ChartVersion))
+		})
+	})
+})
@@ -0,0 +1,146 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/epinio/epinio/acceptance/helpers/catalog"
+	v1 "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceCatalog Management Endpoints", func() {
+	var catalogService models.CatalogService
+
+	catalogRequest := func(method, path string, body interface{}) (int, []byte) {
+		b, err := json.Marshal(body)
+		Expect(err).ToNot(HaveOccurred())
+
+		response, err := env.Curl(method, fmt.Sprintf("%s%s/catalogservices%s", serverURL, v1.Root, path),
+			strings.NewReader(string(b)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response).ToNot(BeNil())
+
+		defer response.Body.Close()
+		bodyBytes, err := ioutil.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+
+		return response.StatusCode, bodyBytes
+	}
+
+	showCatalogService := func(name string) models.CatalogService {
+		status, bodyBytes := catalogRequest("GET", "/"+name, nil)
+		Expect(status).To(Equal(http.StatusOK), string(bodyBytes))
+
+		var result models.ServiceCatalogShowResponse
+		err := json.Unmarshal(bodyBytes, &result)
+		Expect(err).ToNot(HaveOccurred(), string(bodyBytes))
+
+		return *result.CatalogService
+	}
+
+	BeforeEach(func() {
+		catalogService = models.CatalogService{
+			Meta: models.MetaLite{
+				Name: catalog.NewCatalogServiceName(),
+			},
+			HelmChart: "nginx",
+			HelmRepo: models.HelmRepo{
+				URL: "https://charts.bitnami.com/bitnami",
+			},
+			Values: "{'service': {'type': 'ClusterIP'}}",
+		}
+	})
+
+	It("adds a service to the catalog, pinning the chart version", func() {
+		status, bodyBytes := catalogRequest("POST", "", catalogService)
+		Expect(status).To(Equal(http.StatusCreated), string(bodyBytes))
+		defer catalog.DeleteCatalogService(catalogService.Meta.Name)
+
+		service := showCatalogService(catalogService.Meta.Name)
+		Expect(service.HelmChart).To(Equal("nginx"))
+		Expect(service.ChartVersion).ToNot(BeEmpty())
+	})
+
+	It("rejects a service whose chart does not exist", func() {
+		catalogService.HelmChart = "bogus-chart"
+
+		status, bodyBytes := catalogRequest("POST", "", catalogService)
+		Expect(status).To(Equal(http.StatusBadRequest), string(bodyBytes))
+	})
+
+	It("rejects a service whose values do not match the schema", func() {
+		catalogService.ValuesSchema = `{"properties":{"service":{"properties":{"type":{"type":"integer"}}}}}`
+
+		status, bodyBytes := catalogRequest("POST", "", catalogService)
+		Expect(status).To(Equal(http.StatusBadRequest), string(bodyBytes))
+	})
+
+	It("rejects a duplicate service", func() {
+		catalog.CreateCatalogService(catalogService)
+		defer catalog.DeleteCatalogService(catalogService.Meta.Name)
+
+		status, bodyBytes := catalogRequest("POST", "", catalogService)
+		Expect(status).To(Equal(http.StatusConflict), string(bodyBytes))
+	})
+
+	It("updates a catalog service", func() {
+		catalog.CreateCatalogService(catalogService)
+		defer catalog.DeleteCatalogService(catalogService.Meta.Name)
+
+		description := "a modified description"
+		status, bodyBytes := catalogRequest("PATCH", "/"+catalogService.Meta.Name,
+			models.ServiceCatalogUpdateRequest{Description: &description})
+		Expect(status).To(Equal(http.StatusOK), string(bodyBytes))
+
+		service := showCatalogService(catalogService.Meta.Name)
+		Expect(service.Description).To(Equal(description))
+	})
+
+	It("removes a catalog service", func() {
+		catalog.CreateCatalogService(catalogService)
+
+		status, bodyBytes := catalogRequest("DELETE", "/"+catalogService.Meta.Name, nil)
+		Expect(status).To(Equal(http.StatusOK), string(bodyBytes))
+
+		status, bodyBytes = catalogRequest("GET", "/"+catalogService.Meta.Name, nil)
+		Expect(status).To(Equal(http.StatusNotFound), string(bodyBytes))
+	})
+
+	It("fails to remove a catalog service which is not known", func() {
+		status, bodyBytes := catalogRequest("DELETE", "/bogus", nil)
+		Expect(status).To(Equal(http.StatusNotFound), string(bodyBytes))
+	})
+
+	When("the user is not an admin", func() {
+		var user, password string
+
+		BeforeEach(func() {
+			user, password = env.CreateEpinioUser("user", nil)
+		})
+
+		AfterEach(func() {
+			env.DeleteEpinioUser(user)
+		})
+
+		It("refuses to add a service to the catalog", func() {
+			b, err := json.Marshal(catalogService)
+			Expect(err).ToNot(HaveOccurred())
+
+			request, err := http.NewRequest(http.MethodPost,
+				fmt.Sprintf("%s%s/catalogservices", serverURL, v1.Root), strings.NewReader(string(b)))
+			Expect(err).ToNot(HaveOccurred())
+			request.SetBasicAuth(user, password)
+
+			response, err := env.Client().Do(request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+	})
+})
@@ -121,6 +121,82 @@ var _ = Describe("apps env", func() {
 				Expect(deployedEnv(namespace, appName)).To(MatchRegexp("MYVAR"))
 			})
 		})
+
+		When("setting an environment variable referencing a configuration key", func() {
+			var configurationName string
+
+			BeforeEach(func() {
+				configurationName = catalog.NewConfigurationName()
+				env.MakeConfiguration(configurationName)
+
+				out, err := env.Epinio("", "apps", "env", "set", appName, "DB_USER",
+					"--from", configurationName+"/username")
+				Expect(err).ToNot(HaveOccurred(), out)
+			})
+
+			AfterEach(func() {
+				env.DeleteConfigurationUnbind(configurationName)
+			})
+
+			It("is shown masked in the environment listing", func() {
+				out, err := env.Epinio("", "apps", "env", "list", appName)
+				Expect(err).ToNot(HaveOccurred(), out)
+				Expect(out).To(ContainSubstring(`DB_USER`))
+				Expect(out).To(ContainSubstring(configurationName + "/username"))
+				Expect(out).ToNot(ContainSubstring(`epinio-user`))
+			})
+
+			It("is shown in the environment listing when revealed", func() {
+				out, err := env.Epinio("", "apps", "env", "list", appName, "--reveal")
+				Expect(err).ToNot(HaveOccurred(), out)
+				Expect(out).To(ContainSubstring(`epinio-user`))
+			})
+
+			It("is retrieved masked with show, unless revealed", func() {
+				out, err := env.Epinio("", "apps", "env", "show", appName, "DB_USER")
+				Expect(err).ToNot(HaveOccurred(), out)
+				Expect(out).ToNot(ContainSubstring(`epinio-user`))
+
+				out, err = env.Epinio("", "apps", "env", "show", appName, "DB_USER", "--reveal")
+				Expect(err).ToNot(HaveOccurred(), out)
+				Expect(out).To(ContainSubstring(`epinio-user`))
+			})
+
+			It("is injected into the pushed workload as reference", func() {
+				appDir := "../assets/sample-app"
+				out, err := env.EpinioPush(appDir, appName, "--name", appName)
+				Expect(err).ToNot(HaveOccurred(), out)
+
+				deployed := deployedEnv(namespace, appName)
+				Expect(deployed).To(ContainSubstring(`"name":"DB_USER"`))
+				Expect(deployed).To(ContainSubstring(`"key":"username"`))
+				Expect(deployed).ToNot(ContainSubstring(`epinio-user`))
+			})
+
+			It("refuses the deletion of the referenced configuration", func() {
+				out, err := env.Epinio("", "configuration", "delete", configurationName)
+				Expect(err).To(HaveOccurred(), out)
+				Expect(out).To(ContainSubstring("applications referencing the configuration in their environment exist"))
+			})
+
+			It("drops the reference when deleting the configuration with unbind", func() {
+				out, err := env.Epinio("", "configuration", "delete", "--unbind", configurationName)
+				Expect(err).ToNot(HaveOccurred(), out)
+
+				out, err = env.Epinio("", "apps", "env", "list", appName)
+				Expect(err).ToNot(HaveOccurred(), out)
+				Expect(out).ToNot(ContainSubstring(`DB_USER`))
+
+				env.MakeConfiguration(configurationName) // For the AfterEach
+			})
+
+			It("rejects references to unknown keys", func() {
+				out, err := env.Epinio("", "apps", "env", "set", appName, "BOGUS",
+					"--from", configurationName+"/bogus")
+				Expect(err).To(HaveOccurred(), out)
+				Expect(out).To(ContainSubstring("references unknown configuration key"))
+			})
+		})
 	})
 
 	Describe("deployed app", func() {
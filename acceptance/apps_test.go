@@ -381,8 +381,20 @@ spec:
 		})
 	})
 	When("pushing with custom builder flag", func() {
+		var builderName string
+
+		BeforeEach(func() {
+			// Builder images other than the system image have to be approved
+			builderName = catalog.NewTmpName("builder-")
+			out, err := env.Epinio("", "builder", "set", builderName, "paketobuildpacks/builder:tiny")
+			Expect(err).ToNot(HaveOccurred(), out)
+		})
+
 		AfterEach(func() {
 			env.DeleteApp(appName)
+
+			out, err := env.Epinio("", "builder", "delete", builderName)
+			Expect(err).ToNot(HaveOccurred(), out)
 		})
 
 		It("uses the custom builder to stage", func() {
@@ -405,6 +417,25 @@ spec:
 		})
 	})
 
+	When("pushing with a dry run of the upload", func() {
+		It("shows the sources without pushing the app", func() {
+			appDir := "../assets/golang-sample-app"
+			out, err := env.EpinioPush(appDir, appName,
+				"--name", appName,
+				"--exclude", "Procfile",
+				"--dry-run-upload")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(`Sources to upload`))
+			Expect(out).To(MatchRegexp(`main\.go`))
+			Expect(out).ToNot(MatchRegexp(`\| Procfile`))
+			Expect(out).To(MatchRegexp(`Archive Size: \d`))
+
+			out, err = env.Epinio("", "app", "list")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(MatchRegexp(" " + appName + " "))
+		})
+	})
+
 	When("pushing an app multiple times", func() {
 		var (
 			timeout  = 30 * time.Second
@@ -438,6 +469,16 @@ spec:
 			env.DeleteApp(appName)
 		})
 
+		It("skips the upload of unchanged sources", func() {
+			out, err := act(appName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(ContainSubstring("Application code is unchanged"))
+
+			out, err = act(appName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(ContainSubstring("Application code is unchanged, skipped the upload."))
+		})
+
 		It("honours the given instance count", func() {
 			By("pushing without instance count", func() {
 				out, err := act(appName)
@@ -501,6 +542,43 @@ spec:
 				Expect(out).To(MatchRegexp("Reusing cached layer"))
 			})
 		})
+
+		When("managing the cache", func() {
+			AfterEach(func() {
+				env.DeleteApp(appName)
+			})
+
+			It("shows the cache", func() {
+				out, err := env.Epinio("", "app", "cache", "show", appName)
+				Expect(err).ToNot(HaveOccurred(), out)
+				Expect(out).To(MatchRegexp(`Capacity .*\| 1Gi`))
+				Expect(out).To(MatchRegexp(`Last Used .*\| \d{4}-\d{2}-\d{2}`))
+			})
+
+			It("clears the cache", func() {
+				out, err := env.Epinio("", "app", "cache", "clear", appName)
+				Expect(err).ToNot(HaveOccurred(), out)
+
+				Eventually(func() string {
+					out, _ := env.Epinio("", "app", "cache", "show", appName)
+					return out
+				}, "1m").Should(ContainSubstring(fmt.Sprintf("Application '%s' has no build cache", appName)))
+
+				out, err = push()
+				Expect(err).ToNot(HaveOccurred(), out)
+				Expect(out).ToNot(MatchRegexp("Reusing cached layer"))
+			})
+
+			It("restages without the cache", func() {
+				out, err := env.Epinio("", "app", "restage", appName, "--no-cache")
+				Expect(err).ToNot(HaveOccurred(), out)
+				Expect(out).ToNot(MatchRegexp("Reusing cached layer"))
+
+				out, err = env.Epinio("", "app", "restage", appName)
+				Expect(err).ToNot(HaveOccurred(), out)
+				Expect(out).To(MatchRegexp("Reusing cached layer"))
+			})
+		})
 		When("deleting the app", func() {
 			It("deletes the cache PVC too", func() {
 				out, err := proc.Kubectl("get", "pvc", "--namespace",
@@ -516,6 +594,57 @@ spec:
 		})
 	})
 
+	Describe("promote", func() {
+		var targetNamespace, configurationName string
+
+		BeforeEach(func() {
+			configurationName = catalog.NewConfigurationName()
+			env.MakeConfiguration(configurationName)
+
+			targetNamespace = catalog.NewNamespaceName()
+			out, err := env.Epinio("", "namespace", "create", targetNamespace)
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			env.MakeContainerImageApp(appName, 1, containerImageURL)
+			out, err = env.Epinio("", "configuration", "bind", configurationName, appName)
+			Expect(err).ToNot(HaveOccurred(), out)
+		})
+
+		AfterEach(func() {
+			env.DeleteNamespace(targetNamespace)
+		})
+
+		It("deploys the app with its configurations in the other namespace", func() {
+			out, err := env.Epinio("", "app", "promote", appName, "--to", targetNamespace,
+				"--override", configurationName+"/username=prod-user")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp("Application promoted"))
+			Expect(out).To(MatchRegexp(fmt.Sprintf(`%s-%s\.`, appName, targetNamespace)))
+			Expect(out).To(MatchRegexp("Configurations copied: " + configurationName))
+
+			env.TargetNamespace(targetNamespace)
+
+			Eventually(func() string {
+				out, err := env.Epinio("", "app", "list")
+				Expect(err).ToNot(HaveOccurred(), out)
+				return out
+			}, "5m").Should(MatchRegexp(fmt.Sprintf(`%s.*\|.*1\/1.*\|.*`, appName)))
+
+			out, err = env.Epinio("", "configuration", "show", configurationName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(`username .*\| prod-user`))
+
+			env.TargetNamespace(namespace)
+		})
+
+		It("rejects overrides for configurations not used by the app", func() {
+			out, err := env.Epinio("", "app", "promote", appName, "--to", targetNamespace,
+				"--override", "bogus/username=prod-user")
+			Expect(err).To(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp("Overrides given for a configuration not used by the application"))
+		})
+	})
+
 	Describe("push and delete", func() {
 		It("shows the staging logs", func() {
 			By("pushing the app")
@@ -559,6 +688,68 @@ spec:
 			})
 		})
 
+		It("deploys an app built from its Dockerfile", func() {
+			appDir := "../assets/dockerfile-app"
+			out, err := env.EpinioPush(appDir, appName,
+				"--name", appName,
+				"--strategy", "dockerfile",
+				"--dockerfile", "build/Dockerfile",
+				"--build-arg", "GREETING=Howdy")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(ContainSubstring("App is online."))
+
+			// WARNING -- Find may return a bad value for higher trace levels
+			routeRegexp := regexp.MustCompile(`https:\/\/.*omg.howdoi.website`)
+			route := string(routeRegexp.Find([]byte(out)))
+
+			Eventually(func() string {
+				resp, err := env.Curl("GET", route, strings.NewReader(""))
+				Expect(err).ToNot(HaveOccurred())
+				defer resp.Body.Close()
+				body, err := ioutil.ReadAll(resp.Body)
+				Expect(err).ToNot(HaveOccurred())
+				return string(body)
+			}, 30*time.Second, 1*time.Second).Should(Equal("Howdy from a Dockerfile build\n"))
+
+			By("restaging with the same strategy")
+			out, err = env.Epinio("", "app", "restage", appName)
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = proc.Kubectl("get", "app", "--namespace", namespace, appName,
+				"-o", "jsonpath={.metadata.annotations.epinio\\.suse\\.org/build-config}")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(ContainSubstring(`"strategy":"dockerfile"`))
+
+			By("deleting the app")
+			env.DeleteApp(appName)
+		})
+
+		It("masks the build secrets in the staging logs", func() {
+			configurationName := catalog.NewConfigurationName()
+			out, err := env.Epinio("", "configuration", "create", configurationName, "token", "s3cr3t-t0ken")
+			Expect(err).ToNot(HaveOccurred(), out)
+			defer env.DeleteConfiguration(configurationName)
+
+			appDir := "../assets/dockerfile-app"
+			out, err = env.EpinioPush(appDir, appName,
+				"--name", appName,
+				"--strategy", "dockerfile",
+				"--dockerfile", "build/Dockerfile",
+				"--build-env", "GREETING=Howdy",
+				"--build-secret", "TOKEN="+configurationName+"/token")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(ContainSubstring("token: *****"))
+			Expect(out).ToNot(ContainSubstring("s3cr3t-t0ken"))
+
+			By("not providing the build values to the running app")
+			out, err = env.Epinio("", "app", "env", "list", appName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(ContainSubstring("GREETING"))
+			Expect(out).ToNot(ContainSubstring("TOKEN"))
+
+			env.DeleteApp(appName)
+		})
+
 		It("deploys an app from the current dir", func() {
 			By("pushing the app in the current working directory")
 			out := env.MakeApp(appName, 1, true)
@@ -635,6 +826,39 @@ configuration:
 				By("deleting the app")
 				env.DeleteApp(appName)
 			})
+
+			It("stages with the job settings of the manifest", func() {
+				err := ioutil.WriteFile(manifestPath, []byte(fmt.Sprintf(`origin:
+  path: %s
+name: %s
+staging:
+  memory_limit: 3Gi
+  timeout: 20m
+  backoff_limit: 1
+`, origin, appName)), 0600)
+				Expect(err).ToNot(HaveOccurred())
+
+				out, err := env.EpinioPush("", appName, manifestPath)
+				Expect(err).ToNot(HaveOccurred(), out)
+				Expect(out).To(MatchRegexp(`Staging Memory\s*\|\s*default / 3Gi`))
+				Expect(out).To(MatchRegexp(`Staging Timeout\s*\|\s*20m`))
+
+				By("recording the settings for restaging")
+				out, err = proc.Kubectl("get", "app", "--namespace", namespace, appName,
+					"-o", `jsonpath={.metadata.annotations.epinio\.suse\.org/staging-settings}`)
+				Expect(err).ToNot(HaveOccurred(), out)
+				Expect(out).To(ContainSubstring(`"memory_limit":"3Gi"`))
+				Expect(out).To(ContainSubstring(`"timeout":"20m"`))
+
+				By("applying them to the staging job")
+				out, err = proc.Kubectl("get", "jobs", "--namespace", testenv.Namespace,
+					"-l", fmt.Sprintf("app.kubernetes.io/name=%s,app.kubernetes.io/part-of=%s", appName, namespace),
+					"-o", "jsonpath={.items[*].spec.activeDeadlineSeconds}")
+				Expect(err).ToNot(HaveOccurred(), out)
+				Expect(out).To(Equal("1200"))
+
+				env.DeleteApp(appName)
+			})
 		})
 
 		It("removes the app's ingress when deleting an app", func() {
@@ -830,6 +1054,59 @@ configuration:
 		})
 	})
 
+	Describe("labels and annotations", func() {
+		BeforeEach(func() {
+			env.MakeContainerImageApp(appName, 1, containerImageURL)
+		})
+
+		AfterEach(func() {
+			env.DeleteApp(appName)
+		})
+
+		It("sets labels and annotations, and selects apps by label", func() {
+			out, err := env.Epinio("", "app", "update", appName,
+				"--label", "team=payments", "--annotation", "example.com/docs=https://example.com")
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "app", "show", appName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(`Labels .*\| team=payments`))
+			Expect(out).To(MatchRegexp(`Annotations .*\| example.com/docs=https://example.com`))
+
+			out, err = proc.Kubectl("get", "deployments", "--namespace", namespace,
+				"--selector", "app.kubernetes.io/name="+appName+",team=payments",
+				"-o", "jsonpath={.items[*].spec.template.metadata}")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(ContainSubstring(`"team":"payments"`))
+			Expect(out).To(ContainSubstring(`"example.com/docs":"https://example.com"`))
+
+			out, err = env.Epinio("", "app", "list", "--selector", "team=payments")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(" " + appName + " "))
+
+			out, err = env.Epinio("", "app", "list", "--all", "--selector", "team=other")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(MatchRegexp(" " + appName + " "))
+
+			out, err = env.Epinio("", "app", "restart", "--selector", "team=payments")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp("Restarted: " + appName))
+
+			out, err = env.Epinio("", "app", "update", appName, "--unlabel", "team")
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "app", "list", "--selector", "team=payments")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(MatchRegexp(" " + appName + " "))
+		})
+
+		It("rejects reserved labels", func() {
+			out, err := env.Epinio("", "app", "update", appName, "--label", "app.kubernetes.io/name=x")
+			Expect(err).To(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp("label 'app.kubernetes.io/name' is reserved"))
+		})
+	})
+
 	Describe("list, show, and export", func() {
 		var configurationName string
 		BeforeEach(func() {
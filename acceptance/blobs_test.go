@@ -0,0 +1,46 @@
+package acceptance_test
+
+import (
+	"fmt"
+
+	"github.com/epinio/epinio/acceptance/helpers/catalog"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("blobs", func() {
+	var namespace, appName string
+
+	BeforeEach(func() {
+		namespace = catalog.NewNamespaceName()
+		env.SetupAndTargetNamespace(namespace)
+		appName = catalog.NewAppName()
+
+		env.MakeApp(appName, 1, true)
+	})
+
+	AfterEach(func() {
+		env.DeleteNamespace(namespace)
+	})
+
+	It("lists the blobs of the applications", func() {
+		out, err := env.Epinio("", "blobs", "list")
+		Expect(err).ToNot(HaveOccurred(), out)
+		Expect(out).To(MatchRegexp(fmt.Sprintf(`%s *\| *%s *\|.*\| *in use`, namespace, appName)))
+	})
+
+	It("keeps the blobs in use", func() {
+		out, err := env.Epinio("", "blobs", "gc", "--dry-run")
+		Expect(err).ToNot(HaveOccurred(), out)
+		Expect(out).ToNot(ContainSubstring(appName))
+
+		out, err = env.Epinio("", "blobs", "gc")
+		Expect(err).ToNot(HaveOccurred(), out)
+		Expect(out).ToNot(ContainSubstring(appName))
+
+		out, err = env.Epinio("", "blobs", "list")
+		Expect(err).ToNot(HaveOccurred(), out)
+		Expect(out).To(MatchRegexp(fmt.Sprintf(`%s *\| *%s *\|.*\| *in use`, namespace, appName)))
+	})
+})
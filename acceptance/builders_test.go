@@ -0,0 +1,93 @@
+package acceptance_test
+
+import (
+	"fmt"
+
+	"github.com/epinio/epinio/acceptance/helpers/catalog"
+	"github.com/epinio/epinio/acceptance/helpers/proc"
+	"github.com/epinio/epinio/acceptance/testenv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("builders", func() {
+	var namespace, appName string
+
+	BeforeEach(func() {
+		namespace = catalog.NewNamespaceName()
+		env.SetupAndTargetNamespace(namespace)
+		appName = catalog.NewAppName()
+
+		// Builder images other than the system image have to be approved
+		for name, image := range map[string]string{
+			"tiny": "paketobuildpacks/builder:tiny",
+			"full": "paketobuildpacks/builder:full",
+		} {
+			out, err := env.Epinio("", "builder", "set", name, image, "--description", "Paketo "+name+" builder")
+			Expect(err).ToNot(HaveOccurred(), out)
+		}
+	})
+
+	AfterEach(func() {
+		env.DeleteNamespace(namespace)
+
+		for _, name := range []string{"tiny", "full"} {
+			out, err := env.Epinio("", "builder", "delete", name)
+			Expect(err).ToNot(HaveOccurred(), out)
+		}
+	})
+
+	It("lists the approved builders", func() {
+		out, err := env.Epinio("", "builder", "list")
+		Expect(err).ToNot(HaveOccurred(), out)
+		Expect(out).To(MatchRegexp(`tiny *\| *paketobuildpacks/builder:tiny *\| *Paketo tiny builder`))
+		Expect(out).To(MatchRegexp(`full *\| *paketobuildpacks/builder:full`))
+	})
+
+	It("rejects unapproved builder images", func() {
+		out, err := env.EpinioPush("../assets/golang-sample-app", appName,
+			"--name", appName,
+			"--builder-image", "example.com/unknown/builder:latest")
+		Expect(err).To(HaveOccurred(), out)
+		Expect(out).To(ContainSubstring("Builder image 'example.com/unknown/builder:latest' is not approved"))
+	})
+
+	It("stages with the default builder of the namespace", func() {
+		out, err := env.Epinio("", "namespace", "builder", "set", namespace, "tiny")
+		Expect(err).ToNot(HaveOccurred(), out)
+
+		out, err = env.Epinio("", "namespace", "show", namespace)
+		Expect(err).ToNot(HaveOccurred(), out)
+		Expect(out).To(MatchRegexp(`Default Builder *\| *tiny`))
+
+		out, err = env.EpinioPush("../assets/golang-sample-app", appName, "--name", appName)
+		Expect(err).ToNot(HaveOccurred(), out)
+
+		labels := fmt.Sprintf("app.kubernetes.io/name=%s,app.kubernetes.io/component=staging", appName)
+		imageList, err := proc.Kubectl("get", "pod",
+			"--namespace", testenv.Namespace,
+			"-l", labels,
+			"-o", "jsonpath={.items[0].spec.containers[*].image}")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(imageList).To(ContainSubstring("paketobuildpacks/builder:tiny"))
+	})
+
+	It("refuses to delete the default builder of a namespace", func() {
+		out, err := env.Epinio("", "namespace", "builder", "set", namespace, "tiny")
+		Expect(err).ToNot(HaveOccurred(), out)
+
+		out, err = env.Epinio("", "builder", "delete", "tiny")
+		Expect(err).To(HaveOccurred(), out)
+		Expect(out).To(ContainSubstring("Builder 'tiny' is the default builder of namespaces"))
+
+		out, err = env.Epinio("", "namespace", "builder", "unset", namespace)
+		Expect(err).ToNot(HaveOccurred(), out)
+	})
+
+	It("rejects unknown builders as namespace default", func() {
+		out, err := env.Epinio("", "namespace", "builder", "set", namespace, "bogus")
+		Expect(err).To(HaveOccurred(), out)
+		Expect(out).To(ContainSubstring("Builder 'bogus' does not exist"))
+	})
+})
@@ -0,0 +1,46 @@
+package acceptance_test
+
+import (
+	"fmt"
+
+	"github.com/epinio/epinio/acceptance/helpers/catalog"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("images", func() {
+	var namespace, appName string
+
+	BeforeEach(func() {
+		namespace = catalog.NewNamespaceName()
+		env.SetupAndTargetNamespace(namespace)
+		appName = catalog.NewAppName()
+
+		env.MakeApp(appName, 1, true)
+	})
+
+	AfterEach(func() {
+		env.DeleteNamespace(namespace)
+	})
+
+	It("lists the images of the applications", func() {
+		out, err := env.Epinio("", "images", "list")
+		Expect(err).ToNot(HaveOccurred(), out)
+		Expect(out).To(MatchRegexp(fmt.Sprintf(`%s *\| *%s *\|.*\| *deployed`, namespace, appName)))
+	})
+
+	It("keeps the deployed images", func() {
+		out, err := env.Epinio("", "images", "gc", "--keep", "0", "--dry-run")
+		Expect(err).ToNot(HaveOccurred(), out)
+		Expect(out).ToNot(ContainSubstring(appName))
+
+		out, err = env.Epinio("", "images", "gc", "--keep", "0")
+		Expect(err).ToNot(HaveOccurred(), out)
+		Expect(out).ToNot(ContainSubstring(appName))
+
+		out, err = env.Epinio("", "images", "list")
+		Expect(err).ToNot(HaveOccurred(), out)
+		Expect(out).To(MatchRegexp(fmt.Sprintf(`%s *\| *%s *\|.*\| *deployed`, namespace, appName)))
+	})
+})
@@ -116,6 +116,226 @@ var _ = Describe("Namespaces", func() {
 		})
 	})
 
+	Describe("namespace defaults", func() {
+		var namespaceName string
+		var configurationName string
+
+		BeforeEach(func() {
+			namespaceName = catalog.NewNamespaceName()
+			env.SetupAndTargetNamespace(namespaceName)
+
+			configurationName = catalog.NewConfigurationName()
+			env.MakeConfiguration(configurationName)
+		})
+
+		AfterEach(func() {
+			env.DeleteNamespace(namespaceName)
+		})
+
+		It("manages the default environment", func() {
+			out, err := env.Epinio("", "namespace", "env", "set", namespaceName, "LOG_LEVEL", "debug")
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "namespace", "env", "list", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(`LOG_LEVEL .*\| debug`))
+
+			out, err = env.Epinio("", "namespace", "show", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(`Default Environment .*\| LOG_LEVEL=debug`))
+
+			out, err = env.Epinio("", "namespace", "env", "unset", namespaceName, "LOG_LEVEL")
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "namespace", "env", "list", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(MatchRegexp(`LOG_LEVEL`))
+		})
+
+		It("manages the default bindings", func() {
+			out, err := env.Epinio("", "namespace", "bind", namespaceName, "missing-configuration")
+			Expect(err).To(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp("Configuration 'missing-configuration' does not exist"))
+
+			out, err = env.Epinio("", "namespace", "bind", namespaceName, configurationName)
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "namespace", "show", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(fmt.Sprintf(`Default Bindings .*\| %s`, configurationName)))
+
+			out, err = env.Epinio("", "namespace", "unbind", namespaceName, configurationName)
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "namespace", "show", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(MatchRegexp(fmt.Sprintf(`Default Bindings .*\| %s`, configurationName)))
+		})
+
+		It("drops deleted configurations from the default bindings", func() {
+			out, err := env.Epinio("", "namespace", "bind", namespaceName, configurationName)
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			env.DeleteConfiguration(configurationName)
+
+			out, err = env.Epinio("", "namespace", "show", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(MatchRegexp(fmt.Sprintf(`Default Bindings .*\| %s`, configurationName)))
+		})
+	})
+
+	Describe("namespace quotas", func() {
+		var namespaceName string
+
+		BeforeEach(func() {
+			namespaceName = catalog.NewNamespaceName()
+		})
+
+		AfterEach(func() {
+			env.DeleteNamespace(namespaceName)
+		})
+
+		It("enforces the quota of the namespace", func() {
+			out, err := env.Epinio("", "namespace", "create", namespaceName, "--max-apps", "1")
+			Expect(err).ToNot(HaveOccurred(), out)
+			env.TargetNamespace(namespaceName)
+
+			out, err = env.Epinio("", "app", "create", catalog.NewAppName())
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "app", "create", catalog.NewAppName())
+			Expect(err).To(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(fmt.Sprintf("Quota of namespace '%s' exceeded: 2 apps requested, 1 allowed", namespaceName)))
+
+			out, err = env.Epinio("", "namespace", "show", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(`Quota Apps .*\| 1 / 1`))
+			Expect(out).To(MatchRegexp(`Quota Instances .*\| 1 / unlimited`))
+		})
+
+		It("changes nothing for an update exceeding the quota", func() {
+			out, err := env.Epinio("", "namespace", "create", namespaceName, "--max-instances", "1")
+			Expect(err).ToNot(HaveOccurred(), out)
+			env.TargetNamespace(namespaceName)
+
+			appName := catalog.NewAppName()
+			out, err = env.Epinio("", "app", "create", appName)
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "app", "update", appName, "--instances", "2", "--label", "team=payments")
+			Expect(err).To(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(fmt.Sprintf("Quota of namespace '%s' exceeded: 2 instances requested, 1 allowed", namespaceName)))
+
+			out, err = env.Epinio("", "app", "show", appName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(ContainSubstring("payments"))
+		})
+
+		It("updates the quota of the namespace", func() {
+			env.SetupAndTargetNamespace(namespaceName)
+
+			out, err := env.Epinio("", "namespace", "update", namespaceName, "--max-configurations", "1")
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			env.MakeConfiguration(catalog.NewConfigurationName())
+
+			out, err = env.Epinio("", "configuration", "create", catalog.NewConfigurationName(), "username", "epinio-user")
+			Expect(err).To(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp("2 configurations requested, 1 allowed"))
+
+			out, err = env.Epinio("", "namespace", "update", namespaceName, "--max-configurations", "0")
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "namespace", "show", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(MatchRegexp(`Quota Configurations`))
+		})
+	})
+
+	Describe("namespace metadata", func() {
+		var namespaceName string
+
+		BeforeEach(func() {
+			namespaceName = catalog.NewNamespaceName()
+		})
+
+		It("creates, lists, and updates the metadata of the namespace", func() {
+			out, err := env.Epinio("", "namespace", "create", namespaceName,
+				"--label", "team=payments", "--description", "payment services", "--owner", "payments-team")
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "namespace", "show", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(`Description .*\| payment services`))
+			Expect(out).To(MatchRegexp(`Owner .*\| payments-team`))
+			Expect(out).To(MatchRegexp(`Labels .*\| team=payments`))
+
+			out, err = env.Epinio("", "namespace", "list", "--selector", "team=payments")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(namespaceName))
+
+			out, err = env.Epinio("", "namespace", "update", namespaceName, "--unlabel", "team", "--owner", "")
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "namespace", "list", "--selector", "team=payments")
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(MatchRegexp(namespaceName))
+
+			out, err = env.Epinio("", "namespace", "show", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(MatchRegexp(`payments-team`))
+
+			env.DeleteNamespace(namespaceName)
+		})
+
+		It("rejects reserved labels", func() {
+			out, err := env.Epinio("", "namespace", "create", namespaceName, "--label", "app.kubernetes.io/component=x")
+			Expect(err).To(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp("label 'app.kubernetes.io/component' is reserved"))
+
+			env.VerifyNamespaceNotExist(namespaceName)
+		})
+	})
+
+	Describe("namespace clone", func() {
+		var namespaceName, cloneName, configurationName string
+
+		BeforeEach(func() {
+			namespaceName = catalog.NewNamespaceName()
+			cloneName = catalog.NewNamespaceName()
+			configurationName = catalog.NewConfigurationName()
+
+			env.SetupAndTargetNamespace(namespaceName)
+			env.MakeConfiguration(configurationName)
+
+			out, err := env.Epinio("", "namespace", "env", "set", namespaceName, "LOG_LEVEL", "debug")
+			Expect(err).ToNot(HaveOccurred(), out)
+		})
+
+		AfterEach(func() {
+			env.DeleteNamespace(namespaceName)
+		})
+
+		It("copies configurations and defaults", func() {
+			out, err := env.Epinio("", "namespace", "clone", namespaceName, cloneName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp("Namespace cloned"))
+
+			out, err = env.Epinio("", "namespace", "show", cloneName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(configurationName))
+			Expect(out).To(MatchRegexp("LOG_LEVEL=debug"))
+
+			env.DeleteNamespace(cloneName)
+		})
+
+		It("fails for an existing namespace", func() {
+			out, err := env.Epinio("", "namespace", "clone", namespaceName, namespaceName)
+			Expect(err).To(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp("Namespace '%s' already exists", namespaceName))
+		})
+	})
+
 	Describe("namespace delete", func() {
 		It("deletes an namespace", func() {
 			namespaceName := catalog.NewNamespaceName()
@@ -126,5 +346,61 @@ var _ = Describe("Namespaces", func() {
 
 			Expect(err).ToNot(HaveOccurred(), out)
 		})
+
+		It("previews the deletion", func() {
+			namespaceName := catalog.NewNamespaceName()
+			env.SetupAndTargetNamespace(namespaceName)
+			configurationName := catalog.NewConfigurationName()
+			env.MakeConfiguration(configurationName)
+
+			out, err := env.Epinio("", "namespace", "delete", "--preview", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(`Configurations .*\| ` + configurationName))
+
+			env.DeleteNamespace(namespaceName)
+		})
+
+		It("soft-deletes a namespace, and undoes that", func() {
+			namespaceName := catalog.NewNamespaceName()
+			env.SetupAndTargetNamespace(namespaceName)
+
+			out, err := env.Epinio("", "namespace", "delete", "-f", "--grace", "1h", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp("Namespace scheduled for deletion"))
+
+			out, err = env.Epinio("", "namespace", "show", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(`Deletion Scheduled`))
+
+			out, err = env.Epinio("", "app", "create", catalog.NewAppName())
+			Expect(err).To(HaveOccurred(), out)
+			Expect(out).To(ContainSubstring("is scheduled for deletion"))
+
+			out, err = env.Epinio("", "namespace", "undelete", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "namespace", "show", namespaceName)
+			Expect(err).ToNot(HaveOccurred(), out)
+			Expect(out).ToNot(MatchRegexp(`Deletion Scheduled`))
+
+			env.DeleteNamespace(namespaceName)
+		})
+
+		It("refuses to delete a protected namespace", func() {
+			namespaceName := catalog.NewNamespaceName()
+			env.SetupAndTargetNamespace(namespaceName)
+
+			out, err := env.Epinio("", "namespace", "update", namespaceName, "--protected")
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			out, err = env.Epinio("", "namespace", "delete", "-f", namespaceName)
+			Expect(err).To(HaveOccurred(), out)
+			Expect(out).To(MatchRegexp(fmt.Sprintf("Namespace '%s' is protected against deletion", namespaceName)))
+
+			out, err = env.Epinio("", "namespace", "update", namespaceName, "--protected=false")
+			Expect(err).ToNot(HaveOccurred(), out)
+
+			env.DeleteNamespace(namespaceName)
+		})
 	})
 })
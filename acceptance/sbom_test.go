@@ -0,0 +1,55 @@
+package acceptance_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/epinio/epinio/acceptance/helpers/catalog"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("app sbom", func() {
+	var namespace, appName, outputPath string
+
+	BeforeEach(func() {
+		namespace = catalog.NewNamespaceName()
+		env.SetupAndTargetNamespace(namespace)
+		appName = catalog.NewAppName()
+		outputPath = catalog.NewTmpName(appName + "-sbom")
+
+		env.MakeApp(appName, 1, true)
+	})
+
+	AfterEach(func() {
+		env.DeleteNamespace(namespace)
+
+		err := os.RemoveAll(outputPath)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("shows the staging and buildpacks of the deployed image", func() {
+		out, err := env.Epinio("", "app", "sbom", appName)
+		Expect(err).ToNot(HaveOccurred(), out)
+		Expect(out).To(MatchRegexp(`Strategy *\| *buildpacks`))
+		Expect(out).To(MatchRegexp(`Source Blob *\| *\S+`))
+		Expect(out).To(ContainSubstring("Buildpacks:"))
+	})
+
+	It("saves the SBOM documents", func() {
+		out, err := env.Epinio("", "app", "sbom", appName, "--output", outputPath)
+		Expect(err).ToNot(HaveOccurred(), out)
+		Expect(out).To(ContainSubstring("SBOM documents saved."))
+
+		documents, err := filepath.Glob(filepath.Join(outputPath, "launch", "*", "*", "sbom.*.json"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(documents).ToNot(BeEmpty())
+	})
+
+	It("fails for unknown stagings", func() {
+		out, err := env.Epinio("", "app", "sbom", appName, "unknown-stage")
+		Expect(err).To(HaveOccurred(), out)
+		Expect(out).To(ContainSubstring("Image of stage 'unknown-stage' of application '%s' does not exist", appName))
+	})
+})
@@ -0,0 +1,3 @@
+module github.com/epinio/epinio/dockerfile-app
+
+go 1.18
@@ -1,31 +1,100 @@
 package helpers
 
 import (
+	"bufio"
+	"io/fs"
 	"io/ioutil"
+	"os"
 	"path"
+	"path/filepath"
+	"strings"
 
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
 	"github.com/mholt/archiver/v3"
 	"github.com/pkg/errors"
 )
 
-func Tar(dir string) (string, string, error) {
-	files, err := ioutil.ReadDir(dir)
-	if err != nil {
-		return "", "", errors.Wrap(err, "cannot read the apps source files")
-	}
+// IgnoreFile is the name of the files listing the application sources which are not
+// uploaded, using gitignore syntax. A directory without such a file uses the patterns of
+// its .gitignore file instead, if any.
+const IgnoreFile = ".epinioignore"
+
+// alwaysIgnored are the patterns of the files which are never part of the uploaded
+// application sources, i.e. git configuration and the ignore files themselves.
+var alwaysIgnored = []string{
+	".git",
+	".gitignore",
+	".gitmodules",
+	".gitconfig",
+	".git-credentials",
+	IgnoreFile,
+}
+
+// Sources returns the paths of the files and directories in the application directory
+// which are uploaded, relative to that directory, in walk order. It skips everything
+// matched by the ignore files found in the directories, or by the excludes, which use
+// gitignore syntax as well. The excludes are relative to the application directory.
+func Sources(dir string, excludes []string) ([]string, error) {
 	sources := []string{}
-	for _, f := range files {
-		// The FileInfo entries returned by ReadDir provide
-		// only the base name of the file or directory they
-		// are for. We have to add back the path of the
-		// application directory to get the proper paths to
-		// the files and directories to assemble in the
-		// tarball.
-		// Ignore git config files in the app sources.
-		if f.Name() == ".git" || f.Name() == ".gitignore" || f.Name() == ".gitmodules" || f.Name() == ".gitconfig" || f.Name() == ".git-credentials" {
-			continue
+	patterns := []gitignore.Pattern{}
+
+	extra := []gitignore.Pattern{}
+	for _, p := range alwaysIgnored {
+		extra = append(extra, gitignore.ParsePattern(p, nil))
+	}
+	for _, p := range excludes {
+		extra = append(extra, gitignore.ParsePattern(p, nil))
+	}
+
+	err := filepath.WalkDir(dir, func(current string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, current)
+		if err != nil {
+			return err
+		}
+
+		if rel != "." {
+			// Patterns given later take precedence over earlier ones. The
+			// excludes are placed last to ensure that they cannot be undone
+			// by the ignore files.
+			matcher := gitignore.NewMatcher(append(patterns, extra...))
+			if matcher.Match(splitPath(rel), entry.IsDir()) {
+				if entry.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			sources = append(sources, filepath.ToSlash(rel))
 		}
-		sources = append(sources, path.Join(dir, f.Name()))
+
+		if entry.IsDir() {
+			filePatterns, err := readIgnorePatterns(current, splitPath(rel))
+			if err != nil {
+				return err
+			}
+			patterns = append(patterns, filePatterns...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read the apps source files")
+	}
+
+	return sources, nil
+}
+
+// Tar creates a tarball of the application sources in the directory, as selected by
+// Sources. It returns the temporary directory holding the tarball, and the path of the
+// tarball itself. The caller is responsible for the removal of the temporary directory.
+func Tar(dir string, excludes []string) (string, string, error) {
+	sources, err := Sources(dir, excludes)
+	if err != nil {
+		return "", "", err
 	}
 
 	// create a tmpDir - tarball dir and POST
@@ -35,10 +104,104 @@ func Tar(dir string) (string, string, error) {
 	}
 
 	tarball := path.Join(tmpDir, "blob.tar")
-	err = archiver.Archive(sources, tarball)
+	err = writeTar(dir, sources, tarball)
 	if err != nil {
 		return tmpDir, "", errors.Wrap(err, "can't create archive")
 	}
 
 	return tmpDir, tarball, nil
 }
+
+// writeTar is a helper for Tar. It writes the sources, relative to the directory, into the
+// tarball.
+func writeTar(dir string, sources []string, tarball string) error {
+	out, err := os.Create(tarball)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	archive := archiver.NewTar()
+	err = archive.Create(out)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	for _, source := range sources {
+		err := writeTarEntry(archive, dir, source)
+		if err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// writeTarEntry is a helper for writeTar. It writes a single file or directory into the
+// archive, under its path relative to the application directory.
+func writeTarEntry(archive *archiver.Tar, dir, source string) error {
+	sourcePath := filepath.Join(dir, filepath.FromSlash(source))
+
+	info, err := os.Lstat(sourcePath)
+	if err != nil {
+		return err
+	}
+
+	var file *os.File
+	if info.Mode().IsRegular() {
+		file, err = os.Open(sourcePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+	}
+
+	return archive.Write(archiver.File{
+		FileInfo: archiver.FileInfo{
+			FileInfo:   info,
+			CustomName: source,
+			SourcePath: sourcePath,
+		},
+		ReadCloser: file,
+	})
+}
+
+// readIgnorePatterns is a helper for Sources. It returns the patterns of the ignore file in
+// the directory, falling back to its .gitignore file. Neither file existing is not an
+// error, and results in no patterns.
+func readIgnorePatterns(dir string, domain []string) ([]gitignore.Pattern, error) {
+	patterns := []gitignore.Pattern{}
+
+	for _, name := range []string{IgnoreFile, ".gitignore"} {
+		file, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSuffix(scanner.Text(), "\r")
+			if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+				continue
+			}
+			patterns = append(patterns, gitignore.ParsePattern(line, domain))
+		}
+
+		return patterns, scanner.Err()
+	}
+
+	return patterns, nil
+}
+
+// splitPath splits a relative path into its elements, as used by gitignore matching
+func splitPath(rel string) []string {
+	if rel == "." {
+		return []string{}
+	}
+	return strings.Split(filepath.ToSlash(rel), "/")
+}
@@ -0,0 +1,112 @@
+package helpers_test
+
+import (
+	"archive/tar"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/epinio/epinio/helpers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sources and Tar", func() {
+	var dir string
+
+	write := func(name, content string) {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		Expect(os.MkdirAll(filepath.Dir(file), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(file, []byte(content), 0644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "epinio-sources")
+		Expect(err).ToNot(HaveOccurred())
+
+		write("main.go", "package main")
+		write(".env", "SECRET=x")
+		write(".git/config", "[core]")
+		write("node_modules/left-pad/index.js", "")
+		write("build/out.bin", "")
+		write("lib/util.go", "package lib")
+		write("lib/util_test.go", "package lib")
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("skips only the git configuration without ignore files", func() {
+		sources, err := helpers.Sources(dir, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sources).To(ConsistOf(".env", "build", "build/out.bin", "lib", "lib/util.go",
+			"lib/util_test.go", "main.go", "node_modules", "node_modules/left-pad",
+			"node_modules/left-pad/index.js"))
+	})
+
+	It("uses the .gitignore patterns", func() {
+		write(".gitignore", "# dependencies\nnode_modules/\n.env\n")
+
+		sources, err := helpers.Sources(dir, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sources).To(ConsistOf("build", "build/out.bin", "lib", "lib/util.go",
+			"lib/util_test.go", "main.go"))
+	})
+
+	It("prefers the .epinioignore patterns, with gitignore semantics", func() {
+		write(".gitignore", "node_modules/\n")
+		write(".epinioignore", "/build\n*.go\n!main.go\n")
+
+		sources, err := helpers.Sources(dir, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sources).To(ConsistOf(".env", "lib", "main.go", "node_modules",
+			"node_modules/left-pad", "node_modules/left-pad/index.js"))
+	})
+
+	It("applies the ignore files of subdirectories to them only", func() {
+		write("lib/.epinioignore", "*_test.go\n")
+		write("util_test.go", "")
+
+		sources, err := helpers.Sources(dir, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sources).To(ContainElement("util_test.go"))
+		Expect(sources).To(ContainElement("lib/util.go"))
+		Expect(sources).ToNot(ContainElement("lib/util_test.go"))
+		Expect(sources).ToNot(ContainElement("lib/.epinioignore"))
+	})
+
+	It("applies the excludes last", func() {
+		write(".epinioignore", "!.env\n")
+
+		sources, err := helpers.Sources(dir, []string{".env", "node_modules", "build/"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sources).To(ConsistOf("lib", "lib/util.go", "lib/util_test.go", "main.go"))
+	})
+
+	It("archives the selected sources under their relative paths", func() {
+		tmpDir, tarball, err := helpers.Tar(dir, []string{"node_modules", "build", ".env"})
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(tmpDir)
+
+		file, err := os.Open(tarball)
+		Expect(err).ToNot(HaveOccurred())
+		defer file.Close()
+
+		names := []string{}
+		reader := tar.NewReader(file)
+		for {
+			header, err := reader.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).ToNot(HaveOccurred())
+			names = append(names, header.Name)
+		}
+
+		Expect(names).To(ConsistOf("lib/", "lib/util.go", "lib/util_test.go", "main.go"))
+	})
+})
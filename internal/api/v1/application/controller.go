@@ -26,3 +26,22 @@ func (c Controller) validateNamespace(ctx context.Context, cluster *kubernetes.C
 
 	return nil
 }
+
+// validateActiveNamespace is validateNamespace for the endpoints changing the applications
+// of the namespace. These are refused while the namespace is scheduled for deletion, as
+// undeleting it restores the scaling of the applications from before the deletion.
+func (c Controller) validateActiveNamespace(ctx context.Context, cluster *kubernetes.Cluster, namespace string) apierror.APIErrors {
+	space, err := namespaces.Get(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if space == nil {
+		return apierror.NamespaceIsNotKnown(namespace)
+	}
+	if space.DeleteAfter != nil {
+		return apierror.NamespaceIsPendingDeletion(namespace)
+	}
+
+	return nil
+}
@@ -26,7 +26,7 @@ func (hc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
-	if err := hc.validateNamespace(ctx, cluster, namespace); err != nil {
+	if err := hc.validateActiveNamespace(ctx, cluster, namespace); err != nil {
 		return err
 	}
 
@@ -75,6 +75,18 @@ func (hc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.NewMultiError(theIssues)
 	}
 
+	err = application.ValidateMetadata(createRequest.Configuration.Labels,
+		createRequest.Configuration.Annotations, nil)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	apiErr := application.CheckEnvironmentReferences(ctx, cluster, namespace,
+		createRequest.Configuration.EnvironmentFrom)
+	if apiErr != nil {
+		return apiErr
+	}
+
 	var routes []string
 	if len(createRequest.Configuration.Routes) > 0 {
 		routes = createRequest.Configuration.Routes
@@ -101,6 +113,19 @@ func (hc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.AppChartIsNotKnown(chart)
 	}
 
+	desired := DefaultInstances
+	if createRequest.Configuration.Instances != nil {
+		desired = *createRequest.Configuration.Instances
+	}
+
+	apiErr = application.CheckNamespaceQuota(ctx, cluster, namespace, models.NamespaceUsage{
+		Apps:      1,
+		Instances: int64(desired),
+	})
+	if apiErr != nil {
+		return apiErr
+	}
+
 	// Arguments found OK, now we can modify the system state
 
 	err = application.Create(ctx, cluster, appRef, username, routes, chart)
@@ -108,9 +133,10 @@ func (hc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
-	desired := DefaultInstances
-	if createRequest.Configuration.Instances != nil {
-		desired = *createRequest.Configuration.Instances
+	err = application.MetadataSet(ctx, cluster, appRef, createRequest.Configuration.Labels,
+		createRequest.Configuration.Annotations, nil)
+	if err != nil {
+		return apierror.InternalError(err)
 	}
 
 	err = application.ScalingSet(ctx, cluster, appRef, desired)
@@ -131,6 +157,11 @@ func (hc Controller) Create(c *gin.Context) apierror.APIErrors {
 	if err != nil {
 		return apierror.InternalError(err)
 	}
+	err = application.EnvironmentSetReferences(ctx, cluster, appRef,
+		createRequest.Configuration.EnvironmentFrom, true)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
 
 	response.Created(c)
 	return nil
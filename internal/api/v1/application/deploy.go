@@ -44,6 +44,10 @@ func (hc Controller) Deploy(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err, "failed to get access to a kube client")
 	}
 
+	if err := hc.validateActiveNamespace(ctx, cluster, namespace); err != nil {
+		return err
+	}
+
 	applicationCR, err := application.Get(ctx, cluster, req.App)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
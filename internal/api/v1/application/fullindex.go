@@ -4,22 +4,30 @@ import (
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/namespaces"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 
 	"github.com/gin-gonic/gin"
 )
 
 // FullIndex handles the API endpoint GET /applications
-// It lists all the known applications in all namespaces, with and without workload.
+// It lists all the known applications in all namespaces, with and without workload,
+// or those whose labels match the `selector` query parameter.
 func (hc Controller) FullIndex(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
+	selector := c.Query("selector")
+
+	err := namespaces.ValidateSelector(selector)
+	if err != nil {
+		return apierror.NewBadRequest("invalid selector", err.Error())
+	}
 
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
 
-	allApps, err := application.List(ctx, cluster, "")
+	allApps, err := application.ListSelected(ctx, cluster, "", selector)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
@@ -21,7 +21,8 @@ import (
 
 // ImportGit handles the API endpoint /namespaces/:namespace/applications/:app/import-git.
 // It receives a Git repo url and revision, clones that (shallow clone), creates a tarball
-// of the repo and puts it on S3.
+// of the repo and puts it on S3. Files matching the optional exclude patterns, i.e. the
+// `staging.exclude` of the manifest, are left out of the tarball.
 func (hc Controller) ImportGit(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	log := requestctx.Logger(ctx)
@@ -31,6 +32,7 @@ func (hc Controller) ImportGit(c *gin.Context) apierror.APIErrors {
 
 	url := c.PostForm("giturl")
 	revision := c.PostForm("gitrev")
+	exclude := c.PostFormArray("exclude")
 
 	gitRepo, err := ioutil.TempDir("", "epinio-app")
 	if err != nil {
@@ -44,7 +46,7 @@ func (hc Controller) ImportGit(c *gin.Context) apierror.APIErrors {
 	// more appropriate. The "pull from git" feature may be redesigned and implemented
 	// through an "external" component that monitors git repos. In that case this code
 	// will be removed.
-	_, err = git.PlainCloneContext(ctx, gitRepo, false, &git.CloneOptions{
+	repo, err := git.PlainCloneContext(ctx, gitRepo, false, &git.CloneOptions{
 		URL:           url,
 		ReferenceName: plumbing.NewBranchReferenceName(revision),
 		SingleBranch:  true,
@@ -54,8 +56,14 @@ func (hc Controller) ImportGit(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err, fmt.Sprintf("cloning the git repository: %s, revision: %s", url, revision))
 	}
 
+	// The commit is recorded with the blob, for the stage records
+	head, err := repo.Head()
+	if err != nil {
+		return apierror.InternalError(err, fmt.Sprintf("reading the commit of the git repository: %s, revision: %s", url, revision))
+	}
+
 	// Create a tarball
-	tmpDir, tarball, err := helpers.Tar(gitRepo)
+	tmpDir, tarball, err := helpers.Tar(gitRepo, exclude)
 	defer func() {
 		if tmpDir != "" {
 			_ = os.RemoveAll(tmpDir)
@@ -82,6 +90,7 @@ func (hc Controller) ImportGit(c *gin.Context) apierror.APIErrors {
 	username := requestctx.User(ctx).Username
 	blobUID, err := manager.Upload(ctx, tarball, map[string]string{
 		"app": name, "namespace": namespace, "username": username,
+		"giturl": url, "gitcommit": head.Hash().String(),
 	})
 	if err != nil {
 		return apierror.InternalError(err, "uploading the application sources blob")
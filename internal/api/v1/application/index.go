@@ -4,14 +4,22 @@ import (
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/namespaces"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/gin-gonic/gin"
 )
 
 // Index handles the API endpoint GET /namespaces/:namespace/applications
-// It lists all the known applications in the specified namespace, with and without workload.
+// It lists all the known applications in the specified namespace, with and without workload,
+// or those whose labels match the `selector` query parameter.
 func (hc Controller) Index(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
+	selector := c.Query("selector")
+
+	err := namespaces.ValidateSelector(selector)
+	if err != nil {
+		return apierror.NewBadRequest("invalid selector", err.Error())
+	}
 	namespace := c.Param("namespace")
 
 	cluster, err := kubernetes.GetCluster(ctx)
@@ -23,7 +31,7 @@ func (hc Controller) Index(c *gin.Context) apierror.APIErrors {
 		return err
 	}
 
-	apps, err := application.List(ctx, cluster, namespace)
+	apps, err := application.ListSelected(ctx, cluster, namespace, selector)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
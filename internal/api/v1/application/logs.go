@@ -73,6 +73,16 @@ func (hc Controller) Logs(c *gin.Context) {
 		return
 	}
 
+	// The staging logs must not reveal the values of the build secrets
+	masked := []string{}
+	if stageID != "" {
+		masked, err = application.StagingMaskedValues(ctx, cluster, namespace, stageID)
+		if err != nil {
+			response.Error(c, apierror.InternalError(err))
+			return
+		}
+	}
+
 	log.Info("process query")
 
 	followStr := c.Query("follow")
@@ -91,7 +101,7 @@ func (hc Controller) Logs(c *gin.Context) {
 	log.Info("streaming mode", "follow", follow)
 	log.Info("streaming begin")
 
-	err = hc.streamPodLogs(ctx, conn, namespace, appName, stageID, cluster, follow)
+	err = hc.streamPodLogs(ctx, conn, namespace, appName, stageID, cluster, follow, masked)
 	if err != nil {
 		log.V(1).Error(err, "error occurred after upgrading the websockets connection")
 		return
@@ -102,7 +112,7 @@ func (hc Controller) Logs(c *gin.Context) {
 
 // streamPodLogs sends the logs of any containers matching namespaceName, appName
 // and stageID to hc.conn (websockets) until ctx is Done or the connection is
-// closed.
+// closed. The masked values are replaced in the log lines.
 // Internally this uses two concurrent "threads" talking with each other
 // over the logChan. This is a channel of ContainerLogLine.
 // The first thread runs `application.Logs` in a go routine. It spins up a number of supporting go routines
@@ -115,8 +125,10 @@ func (hc Controller) Logs(c *gin.Context) {
 // connection is closed. In any case it will call the cancel func that will stop
 // all the children go routines described above and then will wait for their parent
 // go routine to stop too (using another WaitGroup).
-func (hc Controller) streamPodLogs(ctx context.Context, conn *websocket.Conn, namespaceName, appName, stageID string, cluster *kubernetes.Cluster, follow bool) error {
+func (hc Controller) streamPodLogs(ctx context.Context, conn *websocket.Conn, namespaceName, appName, stageID string, cluster *kubernetes.Cluster, follow bool, masked []string) error {
 	logger := requestctx.Logger(ctx).WithName("streamer-to-websockets").V(1)
+	masker := application.NewLogMasker(masked)
+
 	logChan := make(chan tailer.ContainerLogLine)
 	logCtx, logCancelFunc := context.WithCancel(ctx)
 	var wg sync.WaitGroup
@@ -150,6 +162,8 @@ func (hc Controller) streamPodLogs(ctx context.Context, conn *websocket.Conn, na
 	// Send logs received on logChan to the websockets connection until either
 	// logChan is closed or websocket connection is closed.
 	for logLine := range logChan {
+		logLine.Message = masker.Replace(logLine.Message)
+
 		msg, err := json.Marshal(logLine)
 		if err != nil {
 			return err
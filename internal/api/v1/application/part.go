@@ -14,7 +14,10 @@ import (
 	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/images"
 	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/internal/registry"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/gin-gonic/gin"
@@ -26,8 +29,9 @@ import (
 )
 
 // GetPart handles the API endpoint GET /namespaces/:namespace/applications/:app/part/:part
-// It determines the contents of the requested part (values, chart, image) and returns as
-// the response of the handler.
+// It determines the contents of the requested part (values, chart, image, sbom) and returns as
+// the response of the handler. The sbom of an older staging is selected by the `stage` query
+// parameter.
 func (hc Controller) GetPart(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	namespace := c.Param("namespace")
@@ -35,8 +39,8 @@ func (hc Controller) GetPart(c *gin.Context) apierror.APIErrors {
 	partName := c.Param("part")
 	logger := requestctx.Logger(ctx)
 
-	if partName != "values" && partName != "chart" && partName != "image" {
-		return apierror.NewBadRequest("unknown part, expected chart, image, or values")
+	if partName != "values" && partName != "chart" && partName != "image" && partName != "sbom" {
+		return apierror.NewBadRequest("unknown part, expected chart, image, sbom, or values")
 	}
 
 	cluster, err := kubernetes.GetCluster(ctx)
@@ -57,6 +61,10 @@ func (hc Controller) GetPart(c *gin.Context) apierror.APIErrors {
 		return apierror.AppIsNotKnown(appName)
 	}
 
+	if partName == "sbom" {
+		return fetchAppSBOM(c, ctx, cluster, app, c.Query("stage"))
+	}
+
 	if app.Workload == nil {
 		// While the app exists it has no workload, and therefore no chart to export
 		return apierror.NewBadRequest("No chart available for application without workload")
@@ -71,7 +79,7 @@ func (hc Controller) GetPart(c *gin.Context) apierror.APIErrors {
 		return fetchAppValues(c, logger, cluster, app.Meta)
 	}
 
-	return apierror.NewBadRequest("unknown part, expected chart, image, or values")
+	return apierror.NewBadRequest("unknown part, expected chart, image, sbom, or values")
 }
 
 func fetchAppChart(c *gin.Context, ctx context.Context, logger logr.Logger, cluster *kubernetes.Cluster, app models.AppRef) apierror.APIErrors {
@@ -122,6 +130,113 @@ func fetchAppImage(c *gin.Context) apierror.APIErrors {
 	return apierror.NewBadRequest("image part not yet supported")
 }
 
+// fetchAppSBOM returns the record of the staging with the given id, and the buildpacks and
+// software bills of materials of its image. Without id it is the staging of the deployed
+// image.
+func fetchAppSBOM(c *gin.Context, ctx context.Context, cluster *kubernetes.Cluster, app *models.App, stageID string) apierror.APIErrors {
+	if app.Origin.Kind == models.OriginContainer {
+		return apierror.NewBadRequest("No stagings for application deployed from a container image")
+	}
+
+	details, err := registry.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), registry.CredentialsSecretName)
+	if err != nil {
+		return apierror.InternalError(err, "getting the registry connection details")
+	}
+
+	repository := images.Repository(details.Namespace, app.Meta)
+	tag := stageID
+	if stageID == "" {
+		// The deployed image may be in the repository of another application, e.g. for
+		// clones
+		if app.ImageURL == "" {
+			return apierror.NewBadRequest("No image available for application without stagings")
+		}
+		repository, tag, err = registry.ImageRepositoryTag(app.ImageURL)
+		if err != nil {
+			return apierror.InternalError(err, "parsing the image of the application")
+		}
+		stageID = tag
+	}
+
+	// The SBOM is recorded when the staging completes, and kept even when the retention of
+	// the images deleted the image itself.
+	manager, err := images.SBOMManager(ctx, cluster)
+	if err != nil {
+		return apierror.InternalError(err, "creating the SBOM storage manager")
+	}
+	stored, err := images.LoadSBOM(ctx, manager, app.Meta, stageID)
+	if err != nil {
+		return apierror.InternalError(err, "loading the stored SBOM")
+	}
+	if stored != nil {
+		if apierr := checkSBOMExported(stored.Stage); apierr != nil {
+			return apierr
+		}
+		response.OKReturn(c, *stored)
+		return nil
+	}
+
+	registryURL, err := details.PublicRegistryURL()
+	if err != nil {
+		return apierror.InternalError(err, "getting the registry URL")
+	}
+
+	client, err := images.NewClient(ctx, cluster)
+	if err != nil {
+		return apierror.InternalError(err, "creating a registry client")
+	}
+
+	tags, err := client.Tags(ctx, repository)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	found := false
+	for _, t := range tags {
+		if t == tag {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return apierror.StageImageIsNotKnown(app.Meta.Name, stageID)
+	}
+
+	appCR, err := application.Get(ctx, cluster, app.Meta)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	record, _, err := images.FindStageRecord(appCR, stageID)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if apierr := checkSBOMExported(record); apierr != nil {
+		return apierr
+	}
+
+	sbom, err := images.ReadSBOM(ctx, client, registryURL, app.Meta, record, repository, tag)
+	if err != nil {
+		return apierror.InternalError(err, "reading the SBOM of the image")
+	}
+
+	// Stagings completed before the recording of SBOMs are stored on first access
+	if err := images.StoreSBOM(ctx, manager, *sbom); err != nil {
+		requestctx.Logger(ctx).Error(err, "storing the SBOM", "namespace", app.Meta.Namespace, "app", app.Meta.Name, "stage", stageID)
+	}
+
+	response.OKReturn(c, *sbom)
+	return nil
+}
+
+// checkSBOMExported returns a bad request if the recorded staging used a buildpack platform
+// API under which the buildpacks export no SBOM.
+func checkSBOMExported(record models.StageRecord) apierror.APIErrors {
+	if images.ExportsSBOM(record.PlatformAPI) {
+		return nil
+	}
+	return apierror.NewBadRequest(fmt.Sprintf("Staging '%s' used buildpack platform API %s, which exports no software bill of materials; %s or higher is required",
+		record.ID, record.PlatformAPI, images.SBOMPlatformAPI))
+}
+
 func fetchAppValues(c *gin.Context, logger logr.Logger, cluster *kubernetes.Cluster, app models.AppRef) apierror.APIErrors {
 	yaml, err := helm.Values(cluster, logger, app)
 	if err != nil {
@@ -0,0 +1,103 @@
+package application
+
+import (
+	"net/http"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/promote"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/namespaces"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Promote handles the API endpoint POST /namespaces/:namespace/applications/:app/promote
+// It copies the application, with the configurations and services it uses, to another
+// namespace, and deploys the image it runs there. No staging takes place.
+func (hc Controller) Promote(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	appName := c.Param("app")
+	user := requestctx.User(ctx)
+
+	var promoteRequest models.AppPromoteRequest
+	err := c.BindJSON(&promoteRequest)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	target := promoteRequest.Namespace
+	if target == "" {
+		return apierror.NewBadRequest("namespace to promote to not specified")
+	}
+	if target == namespace {
+		return apierror.NewBadRequest("cannot promote an application to its own namespace")
+	}
+	if !user.CanAccess(target) {
+		return apierror.NewAPIError("user unauthorized", target, http.StatusUnauthorized)
+	}
+
+	err = promote.ValidateRoutePattern(promoteRequest.RoutePattern)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if err := hc.validateNamespace(ctx, cluster, namespace); err != nil {
+		return err
+	}
+
+	exists, err := namespaces.Exists(ctx, cluster, target)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.NamespaceIsNotKnown(target)
+	}
+
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if app == nil {
+		return apierror.AppIsNotKnown(appName)
+	}
+
+	// Overrides are accepted only for the configurations the app uses.
+	used := map[string]struct{}{}
+	for _, name := range app.Configuration.Configurations {
+		used[name] = struct{}{}
+	}
+	for _, source := range app.Configuration.EnvironmentFrom {
+		used[source.Configuration] = struct{}{}
+	}
+	for name := range promoteRequest.ConfigurationOverrides {
+		if _, ok := used[name]; !ok {
+			return apierror.NewBadRequest("Overrides given for a configuration not used by the application", name)
+		}
+	}
+
+	promoter, err := promote.New(ctx, cluster, user.Username, namespace, target, promote.Options{
+		RoutePattern:           promoteRequest.RoutePattern,
+		ConfigurationOverrides: promoteRequest.ConfigurationOverrides,
+		SkipServices:           promoteRequest.SkipServices,
+	})
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	apierr := promoter.App(*app)
+	if apierr != nil {
+		return apierr
+	}
+
+	response.OKReturn(c, promoter.Result())
+	return nil
+}
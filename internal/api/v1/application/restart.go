@@ -9,7 +9,9 @@ import (
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/namespaces"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/gin-gonic/gin"
 )
 
@@ -52,3 +54,52 @@ func (hc Controller) Restart(c *gin.Context) apierror.APIErrors {
 	response.OK(c)
 	return nil
 }
+
+// RestartSelected handles the API endpoint POST /namespaces/:namespace/restart
+// It restarts all applications of the namespace whose labels match the `selector` query
+// parameter. Applications without workload are skipped.
+func (hc Controller) RestartSelected(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	selector := c.Query("selector")
+	username := requestctx.User(ctx).Username
+
+	err := namespaces.ValidateSelector(selector)
+	if err != nil {
+		return apierror.NewBadRequest("invalid selector", err.Error())
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if err := hc.validateNamespace(ctx, cluster, namespace); err != nil {
+		return err
+	}
+
+	apps, err := application.ListSelected(ctx, cluster, namespace, selector)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	result := models.AppsRestartResponse{}
+	nano := time.Now().UnixNano()
+
+	for _, app := range apps {
+		if app.Workload == nil {
+			result.Skipped = append(result.Skipped, app.Meta.Name)
+			continue
+		}
+
+		_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, username, "", nil, &nano)
+		if apierr != nil {
+			return apierr
+		}
+
+		result.Restarted = append(result.Restarted, app.Meta.Name)
+	}
+
+	response.OKReturn(c, result)
+	return nil
+}
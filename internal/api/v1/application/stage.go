@@ -2,7 +2,12 @@ package application
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
@@ -15,16 +20,22 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/epinio/epinio/helpers/cahash"
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/helpers/randstr"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/builders"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/duration"
 	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/images"
 	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/internal/namespaces"
 	"github.com/epinio/epinio/internal/registry"
 	"github.com/epinio/epinio/internal/s3manager"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
@@ -46,8 +57,53 @@ type stageParam struct {
 	PreviousStageID     string
 	RegistryCASecret    string
 	RegistryCAHash      string
+	Build               buildConfig
+	BuildSecrets        models.EnvVariableMap
+	BuildkitImage       string
+	PlatformAPI         string
+	NoCache             bool
+	StagingSettings     models.StagingSettings
+	StagingJob          application.StagingJob
+	StageRecord         models.StageRecord
 }
 
+// buildConfig describes how the image of the application is built from its sources. It
+// is recorded in the application resource, for use by restaging. Only the references of
+// the build secrets are recorded, never their values.
+type buildConfig struct {
+	Strategy    string                   `json:"strategy"`
+	Dockerfile  string                   `json:"dockerfile,omitempty"`
+	BuildArgs   map[string]string        `json:"build_args,omitempty"`
+	Environment models.EnvVariableMap    `json:"environment,omitempty"`
+	Secrets     models.EnvVariableRefMap `json:"secrets,omitempty"`
+}
+
+// BuildConfigAnnotationKey is the annotation of the application resource holding the
+// build configuration of its last staging, as JSON.
+const BuildConfigAnnotationKey = "epinio.suse.org/build-config"
+
+// StagingSettingsAnnotationKey is the annotation of the application resource holding the
+// staging job settings of the application overriding the server defaults, as JSON.
+const StagingSettingsAnnotationKey = "epinio.suse.org/staging-settings"
+
+// buildSecretsDir is the directory of the buildkit container holding the build secrets,
+// one file per secret.
+const buildSecretsDir = "/workspace/build-env"
+
+// defaultBuildkitImage is the image building the Dockerfiles of applications, unless the
+// staging configuration specifies a different one.
+const defaultBuildkitImage = "moby/buildkit:v0.10.6-rootless"
+
+// defaultPlatformAPI is the buildpack platform API used by the staging jobs, unless the
+// staging configuration specifies a different one. Buildpacks export their SBOMs into the
+// image only for platform API 0.8 and higher, see images.SBOMPlatformAPI. The lifecycle of
+// the system builder image, paketobuildpacks/builder:full, supports it.
+const defaultPlatformAPI = images.SBOMPlatformAPI
+
+// appSourceDir is the directory of the staging job holding the unpacked application
+// sources.
+const appSourceDir = "/workspace/source/app"
+
 // ImageURL returns the URL of the container image to be, using the
 // ImageID. The ImageURL is later used in app.yml and to send in the
 // stage response.
@@ -60,32 +116,64 @@ func (app *stageParam) ImageURL(registryURL string) string {
 // on the "upload" endpoint). It is also mounted in the staging pod, as the
 // "source" workspace.
 // The same PVC stores the application's build cache (on a separate directory).
+// Its size and storage class are configurable, see `application.CacheSettings`. Each
+// call records the use of the cache.
 func ensurePVC(ctx context.Context, cluster *kubernetes.Cluster, ar models.AppRef) error {
-	_, err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	pvc, err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
 		Get(ctx, ar.MakePVCName(), metav1.GetOptions{})
 	if err != nil && !apierrors.IsNotFound(err) { // Unknown error, irrelevant to non-existence
 		return err
 	}
-	if err == nil { // pvc already exists
-		return nil
+	if err == nil && pvc.DeletionTimestamp != nil { // pvc is being cleared, wait for it to be gone
+		err = wait.PollImmediate(time.Second, duration.ToCacheDeletion(), func() (bool, error) {
+			_, err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
+				Get(ctx, ar.MakePVCName(), metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+		if err != nil {
+			return errors.Wrap(err, "waiting for the cleared build cache to be gone")
+		}
+	} else if err == nil { // pvc already exists
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, application.CacheLastUsedAnnotationKey, now)
+		_, err = cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
+			Patch(ctx, ar.MakePVCName(), types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+		return err
 	}
 
 	// From here on, only if the PVC is missing
-	_, err = cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
-		Create(ctx, &corev1.PersistentVolumeClaim{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      ar.MakePVCName(),
-				Namespace: helmchart.Namespace(),
+	size, storageClass, err := application.CacheSettings()
+	if err != nil {
+		return err
+	}
+
+	pvc = &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ar.MakePVCName(),
+			Namespace: helmchart.Namespace(),
+			Annotations: map[string]string{
+				application.CacheLastUsedAnnotationKey: now,
 			},
-			Spec: corev1.PersistentVolumeClaimSpec{
-				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
-				Resources: corev1.ResourceRequirements{
-					Requests: map[corev1.ResourceName]resource.Quantity{
-						corev1.ResourceStorage: resource.MustParse("1Gi"),
-					},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: map[corev1.ResourceName]resource.Quantity{
+					corev1.ResourceStorage: size,
 				},
 			},
-		}, metav1.CreateOptions{})
+		},
+	}
+	if storageClass != "" {
+		pvc.Spec.StorageClassName = &storageClass
+	}
+
+	_, err = cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
+		Create(ctx, pvc, metav1.CreateOptions{})
 
 	return err
 }
@@ -116,6 +204,10 @@ func (hc Controller) Stage(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err, "failed to get access to a kube client")
 	}
 
+	if err := hc.validateActiveNamespace(ctx, cluster, namespace); err != nil {
+		return err
+	}
+
 	// check application resource
 	app, err := application.Get(ctx, cluster, req.App)
 	if err != nil {
@@ -130,14 +222,44 @@ func (hc Controller) Stage(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err, "failed to retrieve staging image refs")
 	}
 
-	// get builder image from either request, application, or default as final fallback
+	// get builder image from either request, application, namespace default, or system
+	// default as final fallback. It has to be approved.
 
 	builderImage, builderErr := getBuilderImage(req, app)
 	if builderErr != nil {
 		return builderErr
 	}
-	if builderImage == "" {
-		builderImage = config.Data["builderImage"]
+	builderImage, builderErr = approveBuilderImage(ctx, cluster, namespace, builderImage, config.Data["builderImage"])
+	if builderErr != nil {
+		return builderErr
+	}
+
+	build, buildErr := getBuildConfig(req, app)
+	if buildErr != nil {
+		return buildErr
+	}
+
+	stagingSettings, stagingJob, stagingErr := getStagingSettings(req, app)
+	if stagingErr != nil {
+		return stagingErr
+	}
+
+	buildSecrets, err := application.ResolveEnvironmentReferences(ctx, cluster, namespace, build.Secrets)
+	if err != nil {
+		if _, ok := err.(application.UnknownReferenceError); ok {
+			return apierror.BadRequest(err)
+		}
+		return apierror.InternalError(err, "failed to resolve the build secrets")
+	}
+
+	buildkitImage := config.Data["buildkitImage"]
+	if buildkitImage == "" {
+		buildkitImage = defaultBuildkitImage
+	}
+
+	platformAPI := config.Data["platformAPI"]
+	if platformAPI == "" {
+		platformAPI = defaultPlatformAPI
 	}
 
 	downloadImage := config.Data["downloadImage"]
@@ -222,6 +344,18 @@ func (hc Controller) Stage(c *gin.Context) apierror.APIErrors {
 		Username:            username,
 		RegistryCAHash:      registryCertificateHash,
 		RegistryCASecret:    registryCertificateSecret,
+		Build:               build,
+		BuildSecrets:        buildSecrets,
+		BuildkitImage:       buildkitImage,
+		PlatformAPI:         platformAPI,
+		NoCache:             req.NoCache,
+		StagingSettings:     stagingSettings,
+		StagingJob:          stagingJob,
+	}
+
+	params.StageRecord, err = newStageRecord(ctx, params)
+	if err != nil {
+		return apierror.InternalError(err, "failed to record the staging")
 	}
 
 	err = ensurePVC(ctx, cluster, req.App)
@@ -288,8 +422,13 @@ func (hc Controller) Staged(c *gin.Context) apierror.APIErrors {
 	}
 
 	for _, job := range jobList.Items {
-		// Wait for job to be done
-		err = cluster.WaitForJobDone(ctx, helmchart.Namespace(), job.Name, duration.ToAppBuilt())
+		// Wait for job to be done. A job with a deadline is ended by kubernetes when
+		// it runs out of time, so waiting for the deadline suffices.
+		timeout := duration.ToAppBuilt()
+		if job.Spec.ActiveDeadlineSeconds != nil {
+			timeout = time.Duration(*job.Spec.ActiveDeadlineSeconds)*time.Second + time.Minute
+		}
+		err = cluster.WaitForJobDone(ctx, helmchart.Namespace(), job.Name, timeout)
 		if err != nil {
 			return apierror.InternalError(err)
 		}
@@ -299,15 +438,57 @@ func (hc Controller) Staged(c *gin.Context) apierror.APIErrors {
 			return apierror.InternalError(err)
 		}
 		if failed {
+			done, err := cluster.Kubectl.BatchV1().Jobs(helmchart.Namespace()).Get(ctx, job.Name, metav1.GetOptions{})
+			if err != nil {
+				return apierror.InternalError(err)
+			}
+			if jobDeadlineExceeded(done) {
+				return apierror.NewInternalError("Failed to stage, staging timed out",
+					fmt.Sprintf("stage-id = %s", id),
+					fmt.Sprintf("timeout = %s", time.Duration(*done.Spec.ActiveDeadlineSeconds)*time.Second))
+			}
 			return apierror.NewInternalError("Failed to stage",
 				fmt.Sprintf("stage-id = %s", id))
 		}
+
+		// Record the SBOM of the staged image, keeping it available after the
+		// retention of the images deleted the image. A failure does not fail the
+		// staging, the SBOM is recorded on first access instead.
+		appRef := models.NewAppRef(job.Labels["app.kubernetes.io/name"], namespace)
+		if err := recordSBOM(ctx, cluster, appRef, id); err != nil {
+			requestctx.Logger(ctx).Error(err, "recording the SBOM",
+				"namespace", namespace, "app", appRef.Name, "stage", id)
+		}
 	}
 
 	response.OK(c)
 	return nil
 }
 
+// recordSBOM stores the SBOM of the image built by the staging of the application.
+func recordSBOM(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, stageID string) error {
+	client, err := images.NewClient(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	return images.RecordSBOM(ctx, cluster, client, appRef, stageID)
+}
+
+// jobDeadlineExceeded returns true if the job failed for running longer than its deadline.
+func jobDeadlineExceeded(job *batchv1.Job) bool {
+	if job.Spec.ActiveDeadlineSeconds == nil {
+		return false
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue &&
+			condition.Reason == "DeadlineExceeded" {
+			return true
+		}
+	}
+	return false
+}
+
 func validateBlob(ctx context.Context, blobUID string, app models.AppRef, s3ConnectionDetails s3manager.ConnectionDetails) apierror.APIErrors {
 
 	manager, err := s3manager.New(s3ConnectionDetails)
@@ -503,18 +684,74 @@ func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 	volumes, volumeMounts = mountS3Certs(volumes, volumeMounts)
 	volumes, volumeMounts = mountRegistryCerts(app, volumes, volumeMounts)
 
+	podAnnotations := map[string]string{
+		// Allow communication with the Registry even before the proxy is ready
+		"config.linkerd.io/skip-outbound-ports": "443",
+	}
+
+	buildContainer := corev1.Container{
+		Name:    "buildpack",
+		Image:   app.BuilderImage,
+		Command: []string{"/bin/bash"},
+		Args: []string{
+			"-c",
+			buildpackScript,
+		},
+		Env:          stageEnv,
+		VolumeMounts: volumeMounts,
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:  pointer.Int64(1000),
+			RunAsGroup: pointer.Int64(1000),
+		},
+	}
+
+	if app.Build.Strategy == models.StrategyDockerfile {
+		buildContainer = newBuildkitContainer(app)
+
+		volumes = append(volumes, corev1.Volume{
+			Name: "buildkit",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+
+		// Rootless buildkit needs to create mounts for the build steps
+		podAnnotations["container.apparmor.security.beta.kubernetes.io/"+buildContainer.Name] = "unconfined"
+	}
+
+	buildContainer.Resources = app.StagingJob.Resources
+
 	// Create job environment as a copy of the app environment, plus standard variable.
+	// The build environment and secrets take precedence over the app environment.
 	env := make(map[string][]byte)
 
-	env["CNB_PLATFORM_API"] = []byte("0.4")
+	env["CNB_PLATFORM_API"] = []byte(app.PlatformAPI)
 	for _, ev := range app.Environment {
 		env[ev.Name] = []byte(ev.Value)
 	}
+	for name, value := range app.Build.Environment {
+		env[name] = []byte(value)
+	}
+
+	masked := []string{}
+	for name, value := range app.BuildSecrets {
+		env[name] = []byte(value)
+		masked = append(masked, name)
+	}
+	sort.Strings(masked)
+
+	jobenvAnnotations := map[string]string{}
+	if len(masked) > 0 {
+		// Cannot fail for a list of strings
+		encoded, _ := json.Marshal(masked)
+		jobenvAnnotations[application.MaskedEnvAnnotationKey] = string(encoded)
+	}
 
 	jobenv := &corev1.Secret{
 		Data: env,
 		ObjectMeta: metav1.ObjectMeta{
-			Name: jobName,
+			Name:        jobName,
+			Annotations: jobenvAnnotations,
 			Labels: map[string]string{
 				"app.kubernetes.io/name":       app.Name,
 				"app.kubernetes.io/part-of":    app.Namespace,
@@ -528,6 +765,53 @@ func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 		},
 	}
 
+	initContainers := []corev1.Container{
+		{
+			Name:         "download-s3-blob",
+			Image:        app.DownloadImage,
+			VolumeMounts: volumeMounts,
+			Command:      []string{"/bin/bash"},
+			Args: []string{
+				"-c",
+				awsScript,
+			},
+			Env: stageEnv,
+		},
+		{
+			Name:         "unpack-blob",
+			Image:        app.UnpackImage,
+			VolumeMounts: volumeMounts,
+			Command:      []string{"bash"},
+			Args: []string{
+				"-c",
+				unpackScript,
+			},
+			Env: stageEnv,
+		},
+	}
+
+	if app.NoCache {
+		// Empty the build cache, for a build from scratch which fills it anew
+		initContainers = append([]corev1.Container{
+			{
+				Name:  "clear-cache",
+				Image: app.UnpackImage,
+				VolumeMounts: []corev1.VolumeMount{
+					{
+						Name:      "cache",
+						SubPath:   "cache",
+						MountPath: "/workspace/cache",
+					},
+				},
+				Command: []string{"bash"},
+				Args: []string{
+					"-c",
+					"find /workspace/cache -mindepth 1 -delete",
+				},
+			},
+		}, initContainers...)
+	}
+
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: jobName,
@@ -543,7 +827,8 @@ func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 			},
 		},
 		Spec: batchv1.JobSpec{
-			BackoffLimit: pointer.Int32(0),
+			BackoffLimit:          pointer.Int32(app.StagingJob.BackoffLimit),
+			ActiveDeadlineSeconds: app.StagingJob.ActiveDeadlineSeconds,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
@@ -556,55 +841,15 @@ func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 						"app.kubernetes.io/managed-by": "epinio",
 						"app.kubernetes.io/component":  "staging",
 					},
-					Annotations: map[string]string{
-						// Allow communication with the Registry even before the proxy is ready
-						"config.linkerd.io/skip-outbound-ports": "443",
-					},
+					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
-					InitContainers: []corev1.Container{
-						{
-							Name:         "download-s3-blob",
-							Image:        app.DownloadImage,
-							VolumeMounts: volumeMounts,
-							Command:      []string{"/bin/bash"},
-							Args: []string{
-								"-c",
-								awsScript,
-							},
-							Env: stageEnv,
-						},
-						{
-							Name:         "unpack-blob",
-							Image:        app.UnpackImage,
-							VolumeMounts: volumeMounts,
-							Command:      []string{"bash"},
-							Args: []string{
-								"-c",
-								unpackScript,
-							},
-							Env: stageEnv,
-						},
-					},
-					Containers: []corev1.Container{
-						{
-							Name:    "buildpack",
-							Image:   app.BuilderImage,
-							Command: []string{"/bin/bash"},
-							Args: []string{
-								"-c",
-								buildpackScript,
-							},
-							Env:          stageEnv,
-							VolumeMounts: volumeMounts,
-							SecurityContext: &corev1.SecurityContext{
-								RunAsUser:  pointer.Int64(1000),
-								RunAsGroup: pointer.Int64(1000),
-							},
-						},
-					},
-					RestartPolicy: corev1.RestartPolicyNever,
-					Volumes:       volumes,
+					InitContainers: initContainers,
+					Containers:     []corev1.Container{buildContainer},
+					RestartPolicy:  corev1.RestartPolicyNever,
+					Volumes:        volumes,
+					NodeSelector:   app.StagingJob.NodeSelector,
+					Tolerations:    app.StagingJob.Tolerations,
 				},
 			},
 		},
@@ -613,6 +858,98 @@ func newJobRun(app stageParam) (*batchv1.Job, *corev1.Secret) {
 	return job, jobenv
 }
 
+// newBuildkitContainer is a helper for newJobRun. It returns the container building the
+// application image from the Dockerfile in the sources, and pushing it to the registry.
+// The build runs rootless, and without a daemon.
+func newBuildkitContainer(app stageParam) corev1.Container {
+	dir, file := path.Split(app.Build.Dockerfile)
+
+	args := []string{
+		"build",
+		"--frontend", "dockerfile.v0",
+		"--local", "context=" + appSourceDir,
+		"--local", "dockerfile=" + path.Join(appSourceDir, dir),
+		"--opt", "filename=" + file,
+	}
+
+	// The build environment is provided as build args. Explicit build args take
+	// precedence.
+	buildArgs := map[string]string{}
+	for name, value := range app.Build.Environment {
+		buildArgs[name] = value
+	}
+	for name, value := range app.Build.BuildArgs {
+		buildArgs[name] = value
+	}
+
+	names := make([]string, 0, len(buildArgs))
+	for name := range buildArgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, "--opt", fmt.Sprintf("build-arg:%s=%s", name, buildArgs[name]))
+	}
+
+	// The build secrets are available to `RUN --mount=type=secret,id=NAME` instructions
+	secrets := make([]string, 0, len(app.BuildSecrets))
+	for name := range app.BuildSecrets {
+		secrets = append(secrets, name)
+	}
+	sort.Strings(secrets)
+	for _, name := range secrets {
+		args = append(args, "--secret", fmt.Sprintf("id=%s,src=%s", name, path.Join(buildSecretsDir, name)))
+	}
+
+	args = append(args, "--output",
+		fmt.Sprintf("type=image,name=%s,push=true", app.ImageURL(app.RegistryURL)))
+
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "source",
+			SubPath:   "source",
+			MountPath: "/workspace/source",
+		},
+		{
+			Name:      "registry-creds",
+			MountPath: "/home/user/.docker/",
+			ReadOnly:  true,
+		},
+		{
+			Name:      "buildkit",
+			MountPath: "/home/user/.local/share/buildkit",
+		},
+		{
+			Name:      "app-environment",
+			MountPath: buildSecretsDir,
+			ReadOnly:  true,
+		},
+	}
+	// The volume itself is already part of the job
+	_, volumeMounts = mountRegistryCerts(app, nil, volumeMounts)
+
+	return corev1.Container{
+		Name:    "buildkit",
+		Image:   app.BuildkitImage,
+		Command: []string{"buildctl-daemonless.sh"},
+		Args:    args,
+		Env: []corev1.EnvVar{
+			{
+				Name:  "BUILDKITD_FLAGS",
+				Value: "--oci-worker-no-process-sandbox",
+			},
+		},
+		VolumeMounts: volumeMounts,
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:  pointer.Int64(1000),
+			RunAsGroup: pointer.Int64(1000),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeUnconfined,
+			},
+		},
+	}
+}
+
 func getRegistryURL(ctx context.Context, cluster *kubernetes.Cluster) (string, error) {
 	cd, err := registry.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), registry.CredentialsSecretName)
 	if err != nil {
@@ -671,6 +1008,148 @@ func getBuilderImage(req models.StageRequest, app *unstructured.Unstructured) (s
 	return builderImage, nil
 }
 
+// approveBuilderImage resolves the requested builder against the registry of approved
+// builders, see package builders. Without a request the default builder of the namespace
+// is used, falling back to the system builder image. Unapproved images are rejected.
+func approveBuilderImage(ctx context.Context, cluster *kubernetes.Cluster, namespace, requested, systemImage string) (string, apierror.APIErrors) {
+	approved, err := builders.List(ctx, cluster)
+	if err != nil {
+		return "", apierror.InternalError(err, "failed to retrieve the approved builders")
+	}
+
+	if requested == "" {
+		requested, err = namespaces.DefaultBuilder(ctx, cluster, namespace)
+		if err != nil {
+			return "", apierror.InternalError(err, "failed to retrieve the default builder of the namespace")
+		}
+	}
+	if requested == "" {
+		return systemImage, nil
+	}
+
+	image, ok := builders.Resolve(approved, systemImage, requested)
+	if !ok {
+		names := []string{}
+		for _, builder := range approved {
+			names = append(names, builder.Name)
+		}
+		return "", apierror.BuilderImageNotApproved(requested, names)
+	}
+
+	return image, nil
+}
+
+// getBuildConfig returns the build configuration defined on the request. If that one is
+// not defined, it returns the configuration previously used for the Application CR,
+// falling back to buildpacks. Invalid configurations are rejected.
+func getBuildConfig(req models.StageRequest, app *unstructured.Unstructured) (buildConfig, apierror.APIErrors) {
+	build := buildConfig{
+		Strategy:    req.Strategy,
+		Dockerfile:  req.Dockerfile,
+		BuildArgs:   req.BuildArgs,
+		Environment: req.BuildEnvironment,
+		Secrets:     req.BuildSecrets,
+	}
+
+	if build.Strategy == "" && build.Dockerfile == "" && len(build.BuildArgs) == 0 &&
+		len(build.Environment) == 0 && len(build.Secrets) == 0 {
+
+		build.Strategy = models.StrategyBuildpacks
+		if encoded, ok := app.GetAnnotations()[BuildConfigAnnotationKey]; ok {
+			if err := json.Unmarshal([]byte(encoded), &build); err != nil {
+				return build, apierror.InternalError(err, "bad build configuration of the application")
+			}
+		}
+		return build, nil
+	}
+
+	if build.Strategy == "" {
+		build.Strategy = models.StrategyBuildpacks
+	}
+
+	switch build.Strategy {
+	case models.StrategyBuildpacks:
+		if build.Dockerfile != "" || len(build.BuildArgs) > 0 {
+			return build, apierror.NewBadRequest("dockerfile and build args require the dockerfile strategy")
+		}
+	case models.StrategyDockerfile:
+		if req.BuilderImage != "" {
+			return build, apierror.NewBadRequest("a builder image cannot be used with the dockerfile strategy")
+		}
+		if build.Dockerfile == "" {
+			build.Dockerfile = "Dockerfile"
+		}
+		dockerfile := path.Clean(build.Dockerfile)
+		if path.IsAbs(dockerfile) || dockerfile == ".." || strings.HasPrefix(dockerfile, "../") {
+			return build, apierror.NewBadRequest("dockerfile has to be a path in the application sources",
+				build.Dockerfile)
+		}
+		build.Dockerfile = dockerfile
+		for name := range build.BuildArgs {
+			if errs := validation.IsEnvVarName(name); len(errs) > 0 {
+				return build, apierror.NewBadRequest(fmt.Sprintf("invalid build arg '%s'", name),
+					strings.Join(errs, ", "))
+			}
+		}
+	default:
+		return build, apierror.NewBadRequest("unknown staging strategy", build.Strategy)
+	}
+
+	for name := range build.Environment {
+		if errs := validation.IsEnvVarName(name); len(errs) > 0 {
+			return build, apierror.NewBadRequest(fmt.Sprintf("invalid build environment variable '%s'", name),
+				strings.Join(errs, ", "))
+		}
+	}
+	for name := range build.Secrets {
+		if errs := validation.IsEnvVarName(name); len(errs) > 0 {
+			return build, apierror.NewBadRequest(fmt.Sprintf("invalid build secret '%s'", name),
+				strings.Join(errs, ", "))
+		}
+		if _, ok := build.Environment[name]; ok {
+			return build, apierror.NewBadRequest(fmt.Sprintf("build secret '%s' is also a build environment variable", name))
+		}
+	}
+
+	return build, nil
+}
+
+// getStagingSettings returns the staging job settings overriding the server defaults
+// defined on the request. If none are defined, it returns the overrides previously used
+// for the Application CR. It further returns the staging job resulting from merging the
+// overrides into the server defaults. Invalid overrides are rejected.
+func getStagingSettings(req models.StageRequest, app *unstructured.Unstructured) (models.StagingSettings, application.StagingJob, apierror.APIErrors) {
+	settings := req.Job
+	if settings.IsEmpty() {
+		if encoded, ok := app.GetAnnotations()[StagingSettingsAnnotationKey]; ok {
+			if err := json.Unmarshal([]byte(encoded), &settings); err != nil {
+				return settings, application.StagingJob{}, apierror.InternalError(err, "bad staging settings of the application")
+			}
+		}
+	}
+
+	if _, err := application.NewStagingJob(settings); err != nil {
+		return settings, application.StagingJob{}, apierror.NewBadRequest("invalid staging settings", err.Error())
+	}
+
+	defaults, err := application.StagingDefaults()
+	if err != nil {
+		return settings, application.StagingJob{}, apierror.InternalError(err, "bad staging defaults")
+	}
+
+	merged, err := application.MergeStagingSettings(defaults, settings)
+	if err != nil {
+		return settings, application.StagingJob{}, apierror.NewBadRequest("invalid staging settings", err.Error())
+	}
+
+	job, err := application.NewStagingJob(merged)
+	if err != nil {
+		return settings, application.StagingJob{}, apierror.InternalError(err, "bad staging defaults")
+	}
+
+	return settings, job, nil
+}
+
 func getBlobUID(ctx context.Context, s3ConnectionDetails s3manager.ConnectionDetails, req models.StageRequest, app *unstructured.Unstructured) (string, apierror.APIErrors) {
 	var blobUID string
 	var err error
@@ -700,6 +1179,38 @@ func getBlobUID(ctx context.Context, s3ConnectionDetails s3manager.ConnectionDet
 	return blobUID, nil
 }
 
+// newStageRecord returns the record of the staging. The git repository and commit of the
+// sources are known for blobs imported from git.
+func newStageRecord(ctx context.Context, params stageParam) (models.StageRecord, error) {
+	record := models.StageRecord{
+		ID:           params.Stage.ID,
+		CreatedAt:    metav1.Now(),
+		Username:     params.Username,
+		BlobUID:      params.BlobUID,
+		Strategy:     params.Build.Strategy,
+		BuilderImage: params.BuilderImage,
+	}
+	if record.Strategy == models.StrategyDockerfile {
+		record.BuilderImage = params.BuildkitImage
+	} else {
+		record.PlatformAPI = params.PlatformAPI
+	}
+
+	manager, err := s3manager.New(params.S3ConnectionDetails)
+	if err != nil {
+		return record, errors.Wrap(err, "creating an S3 manager")
+	}
+
+	blobMeta, err := manager.Meta(ctx, params.BlobUID)
+	if err != nil {
+		return record, errors.Wrap(err, "querying blob id meta-data")
+	}
+	record.GitURL = blobMeta["Giturl"]
+	record.GitCommit = blobMeta["Gitcommit"]
+
+	return record, nil
+}
+
 func findPreviousBlobUID(app *unstructured.Unstructured) (string, error) {
 	blobUID, _, err := unstructured.NestedString(app.UnstructuredContent(), "spec", "blobuid")
 	if err != nil {
@@ -720,6 +1231,34 @@ func updateApp(ctx context.Context, cluster *kubernetes.Cluster, app *unstructur
 		return err
 	}
 
+	build, err := json.Marshal(params.Build)
+	if err != nil {
+		return err
+	}
+	annotations := app.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[BuildConfigAnnotationKey] = string(build)
+
+	if params.StagingSettings.IsEmpty() {
+		delete(annotations, StagingSettingsAnnotationKey)
+	} else {
+		staging, err := json.Marshal(params.StagingSettings)
+		if err != nil {
+			return err
+		}
+		annotations[StagingSettingsAnnotationKey] = string(staging)
+	}
+	app.SetAnnotations(annotations)
+
+	if err := images.RecordStage(app, params.Stage.ID); err != nil {
+		return err
+	}
+	if err := images.AddStageRecord(app, params.StageRecord); err != nil {
+		return err
+	}
+
 	client, err := cluster.ClientApp()
 	if err != nil {
 		return err
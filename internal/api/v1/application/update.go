@@ -30,7 +30,7 @@ func (hc Controller) Update(c *gin.Context) apierror.APIErrors { // nolint:gocyc
 		return apierror.InternalError(err)
 	}
 
-	if err := hc.validateNamespace(ctx, cluster, namespace); err != nil {
+	if err := hc.validateActiveNamespace(ctx, cluster, namespace); err != nil {
 		return err
 	}
 
@@ -56,6 +56,12 @@ func (hc Controller) Update(c *gin.Context) apierror.APIErrors { // nolint:gocyc
 		return apierror.NewBadRequest("instances param should be integer equal or greater than zero")
 	}
 
+	err = application.ValidateMetadata(updateRequest.Labels, updateRequest.Annotations,
+		updateRequest.RemoveLabels)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
 	app, err := application.Lookup(ctx, cluster, namespace, appName)
 	if err != nil {
 		return apierror.InternalError(err)
@@ -66,13 +72,28 @@ func (hc Controller) Update(c *gin.Context) apierror.APIErrors { // nolint:gocyc
 	// if there is nothing to change
 	if updateRequest.Instances == nil &&
 		len(updateRequest.Environment) == 0 &&
+		len(updateRequest.EnvironmentFrom) == 0 &&
 		updateRequest.Configurations == nil &&
 		len(updateRequest.Routes) == 0 &&
-		updateRequest.AppChart == "" {
+		updateRequest.AppChart == "" &&
+		len(updateRequest.Labels) == 0 &&
+		len(updateRequest.Annotations) == 0 &&
+		len(updateRequest.RemoveLabels) == 0 {
 		response.OK(c)
 		return nil
 	}
 
+	// Check the quota before any change, for a request exceeding it to change nothing.
+
+	if updateRequest.Instances != nil {
+		apierr := application.CheckNamespaceQuota(ctx, cluster, namespace, models.NamespaceUsage{
+			Instances: int64(*updateRequest.Instances - *app.Configuration.Instances),
+		})
+		if apierr != nil {
+			return apierr
+		}
+	}
+
 	// Save all changes to the relevant parts of the app resources (CRD, secrets, and the like).
 
 	if updateRequest.AppChart != "" && updateRequest.AppChart != app.Configuration.AppChart {
@@ -106,6 +127,12 @@ func (hc Controller) Update(c *gin.Context) apierror.APIErrors { // nolint:gocyc
 		}
 	}
 
+	err = application.MetadataSet(ctx, cluster, app.Meta, updateRequest.Labels,
+		updateRequest.Annotations, updateRequest.RemoveLabels)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
 	if updateRequest.Instances != nil {
 		desired := *updateRequest.Instances
 
@@ -123,9 +150,27 @@ func (hc Controller) Update(c *gin.Context) apierror.APIErrors { // nolint:gocyc
 		}
 	}
 
+	if len(updateRequest.EnvironmentFrom) > 0 {
+		apierr := application.CheckEnvironmentReferences(ctx, cluster, namespace, updateRequest.EnvironmentFrom)
+		if apierr != nil {
+			return apierr
+		}
+
+		err := application.EnvironmentSetReferences(ctx, cluster, app.Meta, updateRequest.EnvironmentFrom, true)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
 	if updateRequest.Configurations != nil {
 		var okToBind []string
 
+		for configurationName, options := range updateRequest.BindOptions {
+			if err := options.Validate(); err != nil {
+				return apierror.NewBadRequest(err.Error(), configurationName)
+			}
+		}
+
 		if len(updateRequest.Configurations) > 0 {
 			for _, configurationName := range updateRequest.Configurations {
 				_, err := configurations.Lookup(ctx, cluster, namespace, configurationName)
@@ -145,6 +190,20 @@ func (hc Controller) Update(c *gin.Context) apierror.APIErrors { // nolint:gocyc
 			if err != nil {
 				return apierror.InternalError(err)
 			}
+
+			// Apply the binding options given for the configurations now bound.
+			bindOptions := map[string]models.BindOptions{}
+			for _, configurationName := range okToBind {
+				if options, ok := updateRequest.BindOptions[configurationName]; ok {
+					bindOptions[configurationName] = options
+				}
+			}
+			if len(bindOptions) > 0 {
+				err = application.BoundConfigurationsSetOptions(ctx, cluster, app.Meta, bindOptions)
+				if err != nil {
+					return apierror.InternalError(err)
+				}
+			}
 		} else {
 			// remove all bound configurations
 			err = application.BoundConfigurationsSet(ctx, cluster, app.Meta, []string{}, true)
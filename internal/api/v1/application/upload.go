@@ -1,6 +1,17 @@
 package application
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
@@ -31,28 +42,244 @@ func (hc Controller) Upload(c *gin.Context) apierror.APIErrors {
 	}
 	defer file.Close()
 
-	cluster, err := kubernetes.GetCluster(ctx)
+	manager, apierr := uploadManager(ctx)
+	if apierr != nil {
+		return apierr
+	}
+
+	username := requestctx.User(ctx).Username
+	blobUID, err := manager.UploadStream(ctx, file, fileheader.Size, map[string]string{
+		"app": name, "namespace": namespace, "username": username,
+	})
 	if err != nil {
-		return apierror.InternalError(err, "failed to get access to a kube client")
+		return apierror.InternalError(err, "uploading the application sources blob")
 	}
 
-	connectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	log.Info("uploaded app", "namespace", namespace, "app", name, "blobUID", blobUID)
+
+	response.OKReturn(c, models.UploadResponse{
+		BlobUID: blobUID,
+	})
+	return nil
+}
+
+// maxUploadPartSize is the largest part of a chunked upload accepted by the server. The
+// parts are held in memory for their verification.
+const maxUploadPartSize = 64 * 1024 * 1024
+
+// checksumRegex matches hex encoded SHA-256 checksums
+var checksumRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// UploadStart handles the API endpoint POST /namespaces/:namespace/applications/:app/uploads
+// It starts the chunked upload of the application sources, identified by their SHA-256
+// checksum. When the sources were stored before nothing has to be uploaded. Otherwise an
+// incomplete upload of the same sources is resumed, returning the parts already uploaded,
+// or a new one started.
+func (hc Controller) UploadStart(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+
+	namespace := c.Param("namespace")
+	name := c.Param("app")
+
+	var req models.UploadStartRequest
+	if err := c.BindJSON(&req); err != nil {
+		return apierror.BadRequest(err)
+	}
+	if !checksumRegex.MatchString(req.Checksum) {
+		return apierror.NewBadRequest("invalid sha256 checksum", req.Checksum)
+	}
+
+	manager, apierr := uploadManager(ctx)
+	if apierr != nil {
+		return apierr
+	}
+
+	blobUID := s3manager.ContentKey(namespace, name, req.Checksum)
+
+	exists, err := manager.Exists(ctx, blobUID)
 	if err != nil {
-		return apierror.InternalError(err, "fetching the S3 connection details from the Kubernetes secret")
+		return apierror.InternalError(err)
 	}
-	manager, err := s3manager.New(connectionDetails)
+	if exists {
+		log.Info("app sources already stored", "namespace", namespace, "app", name, "blobUID", blobUID)
+
+		response.OKReturn(c, models.UploadStartResponse{
+			BlobUID:  blobUID,
+			Existing: true,
+		})
+		return nil
+	}
+
+	uploadID, err := manager.MultipartUploadID(ctx, blobUID)
 	if err != nil {
-		return apierror.InternalError(err, "creating an S3 manager")
+		return apierror.InternalError(err)
 	}
 
-	username := requestctx.User(ctx).Username
-	blobUID, err := manager.UploadStream(ctx, file, fileheader.Size, map[string]string{
-		"app": name, "namespace": namespace, "username": username,
+	parts := []models.UploadPart{}
+	if uploadID == "" {
+		username := requestctx.User(ctx).Username
+		_, err = manager.MultipartStart(ctx, blobUID, map[string]string{
+			"app": name, "namespace": namespace, "username": username,
+		})
+		if err != nil {
+			return apierror.InternalError(err, "starting the upload of the application sources")
+		}
+	} else {
+		uploaded, err := manager.MultipartParts(ctx, blobUID, uploadID)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+		for _, part := range uploaded {
+			parts = append(parts, models.UploadPart{
+				Number: part.Number,
+				Size:   part.Size,
+				ETag:   part.ETag,
+			})
+		}
+	}
+
+	log.Info("started upload", "namespace", namespace, "app", name, "blobUID", blobUID,
+		"resumed", uploadID != "", "parts", len(parts))
+
+	response.OKReturn(c, models.UploadStartResponse{
+		BlobUID: blobUID,
+		Parts:   parts,
 	})
+	return nil
+}
+
+// UploadPart handles the API endpoint PUT /namespaces/:namespace/applications/:app/uploads/:sha256/parts/:part
+// It stores the part of the chunked upload after verifying its data against the SHA-256
+// checksum given in the query. An existing part with the same number is replaced.
+func (hc Controller) UploadPart(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+
+	namespace := c.Param("namespace")
+	name := c.Param("app")
+	checksum := c.Param("sha256")
+	partChecksum := c.Query("sha256")
+
+	number, err := strconv.Atoi(c.Param("part"))
+	if err != nil || number < 1 || number > 10000 {
+		return apierror.NewBadRequest("invalid part number", c.Param("part"))
+	}
+	if !checksumRegex.MatchString(partChecksum) {
+		return apierror.NewBadRequest("invalid sha256 checksum of the part", partChecksum)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(c.Request.Body, maxUploadPartSize+1))
+	if err != nil {
+		return apierror.BadRequest(err, "can't read the part")
+	}
+	if len(data) > maxUploadPartSize {
+		return apierror.NewBadRequest(fmt.Sprintf("part is larger than %d bytes", maxUploadPartSize))
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != partChecksum {
+		return apierror.NewBadRequest("sha256 checksum mismatch of the part", strconv.Itoa(number))
+	}
+
+	manager, apierr := uploadManager(ctx)
+	if apierr != nil {
+		return apierr
+	}
+
+	blobUID := s3manager.ContentKey(namespace, name, checksum)
+
+	uploadID, apierr := uploadInProgress(ctx, manager, blobUID)
+	if apierr != nil {
+		return apierr
+	}
+
+	part, err := manager.MultipartPut(ctx, blobUID, uploadID, number,
+		bytes.NewReader(data), int64(len(data)), partChecksum)
 	if err != nil {
 		return apierror.InternalError(err, "uploading the application sources blob")
 	}
 
+	log.V(1).Info("uploaded part", "namespace", namespace, "app", name, "blobUID", blobUID,
+		"part", number, "size", part.Size)
+
+	response.OKReturn(c, models.UploadPart{
+		Number: part.Number,
+		Size:   part.Size,
+		ETag:   part.ETag,
+	})
+	return nil
+}
+
+// UploadComplete handles the API endpoint POST /namespaces/:namespace/applications/:app/uploads/:sha256/complete
+// It assembles the application sources from the uploaded parts, and verifies them against
+// their SHA-256 checksum. Sources failing the verification are discarded.
+func (hc Controller) UploadComplete(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+
+	namespace := c.Param("namespace")
+	name := c.Param("app")
+	checksum := c.Param("sha256")
+
+	var req models.UploadCompleteRequest
+	if err := c.BindJSON(&req); err != nil {
+		return apierror.BadRequest(err)
+	}
+	if req.Parts < 1 {
+		return apierror.NewBadRequest("upload has no parts")
+	}
+
+	manager, apierr := uploadManager(ctx)
+	if apierr != nil {
+		return apierr
+	}
+
+	blobUID := s3manager.ContentKey(namespace, name, checksum)
+
+	uploadID, apierr := uploadInProgress(ctx, manager, blobUID)
+	if apierr != nil {
+		return apierr
+	}
+
+	uploaded, err := manager.MultipartParts(ctx, blobUID, uploadID)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	// Parts beyond the requested number are left over from an earlier attempt using a
+	// different size for the chunks, and are not part of the sources.
+	parts := []s3manager.Part{}
+	for _, part := range uploaded {
+		if part.Number <= req.Parts {
+			parts = append(parts, part)
+		}
+	}
+	for i, part := range parts {
+		if part.Number != i+1 {
+			return apierror.NewBadRequest("part is missing", strconv.Itoa(i+1))
+		}
+	}
+	if len(parts) != req.Parts {
+		return apierror.NewBadRequest("part is missing", strconv.Itoa(len(parts)+1))
+	}
+
+	err = manager.MultipartComplete(ctx, blobUID, uploadID, parts)
+	if err != nil {
+		return apierror.InternalError(err, "completing the upload of the application sources")
+	}
+
+	actual, err := manager.Checksum(ctx, blobUID)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if actual != checksum {
+		if err := manager.DeleteObject(ctx, blobUID); err != nil {
+			return apierror.InternalError(err)
+		}
+		return apierror.NewBadRequest("sha256 checksum mismatch of the uploaded sources", actual)
+	}
+
 	log.Info("uploaded app", "namespace", namespace, "app", name, "blobUID", blobUID)
 
 	response.OKReturn(c, models.UploadResponse{
@@ -60,3 +287,35 @@ func (hc Controller) Upload(c *gin.Context) apierror.APIErrors {
 	})
 	return nil
 }
+
+// uploadManager returns the S3 manager for the storage of the application sources
+func uploadManager(ctx context.Context) (*s3manager.Manager, apierror.APIErrors) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return nil, apierror.InternalError(err, "failed to get access to a kube client")
+	}
+
+	connectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	if err != nil {
+		return nil, apierror.InternalError(err, "fetching the S3 connection details from the Kubernetes secret")
+	}
+	manager, err := s3manager.New(connectionDetails)
+	if err != nil {
+		return nil, apierror.InternalError(err, "creating an S3 manager")
+	}
+
+	return manager, nil
+}
+
+// uploadInProgress returns the id of the incomplete upload of the blob, started by UploadStart
+func uploadInProgress(ctx context.Context, manager *s3manager.Manager, blobUID string) (string, apierror.APIErrors) {
+	uploadID, err := manager.MultipartUploadID(ctx, blobUID)
+	if err != nil {
+		return "", apierror.InternalError(err)
+	}
+	if uploadID == "" {
+		return "", apierror.NewAPIError("upload not found, it has to be started first", blobUID, http.StatusNotFound)
+	}
+
+	return uploadID, nil
+}
@@ -28,7 +28,7 @@ func AuthorizationMiddleware(c *gin.Context) {
 	case "admin":
 		authorized = authorizeAdmin(logger)
 	case "user":
-		authorized = authorizeUser(logger, user, path, namespace)
+		authorized = authorizeUser(logger, user, method, path, c.FullPath(), namespace)
 	}
 
 	logger.Info(fmt.Sprintf("user [%s] with role [%s] authorized [%t] for namespace [%s]", user.Username, user.Role, authorized, namespace))
@@ -45,7 +45,7 @@ func authorizeAdmin(logger logr.Logger) bool {
 	return true
 }
 
-func authorizeUser(logger logr.Logger, user auth.User, path, namespace string) bool {
+func authorizeUser(logger logr.Logger, user auth.User, method, path, pattern, namespace string) bool {
 	logger = logger.V(1).WithName("authorizeUser")
 
 	// check if the requested path is restricted
@@ -54,6 +54,12 @@ func authorizeUser(logger logr.Logger, user auth.User, path, namespace string) b
 		return false
 	}
 
+	// check if the requested method on the route is restricted
+	if _, found := AdminMethodRoutes[AdminRouteKey(method, pattern)]; found {
+		logger.Info(fmt.Sprintf("route [%s %s] is an admin route, user unauthorized", method, pattern))
+		return false
+	}
+
 	// check if the user has permission on the requested namespace
 	if namespace != "" {
 		for _, ns := range user.Namespaces {
@@ -64,6 +64,41 @@ var _ = Describe("Authorization Middleware", func() {
 			})
 		})
 
+		When("method on the route is restricted", func() {
+			var router *gin.Engine
+
+			BeforeEach(func() {
+				v1.AdminMethodRoutes = map[string]struct{}{
+					v1.AdminRouteKey(http.MethodPost, "/items/:item"): {},
+				}
+
+				router = gin.New()
+				router.Use(func(c *gin.Context) {
+					c.Request = c.Request.WithContext(ctx)
+				}, v1.AuthorizationMiddleware)
+
+				ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+				router.GET("/items/:item", ok)
+				router.POST("/items/:item", ok)
+			})
+
+			It("returns status code 401 for the restricted method", func() {
+				req, err := http.NewRequest(http.MethodPost, "/items/foo", nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("returns status code 200 for other methods", func() {
+				req, err := http.NewRequest(http.MethodGet, "/items/foo", nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				router.ServeHTTP(w, req)
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+
 		When("url is namespaced", func() {
 			It("returns status code 401 for another namespace", func() {
 				c.Params = []gin.Param{{Key: "namespace", Value: "another-workspace"}}
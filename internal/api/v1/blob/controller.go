@@ -0,0 +1,7 @@
+// Package blob contains the API handlers to inspect the S3 blobs holding application
+// sources, and to collect those not in use anymore.
+package blob
+
+// Controller represents all functionality of the API related to blobs
+type Controller struct {
+}
@@ -0,0 +1,87 @@
+package blob
+
+import (
+	"context"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/blobs"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/reaper"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/go-logr/logr"
+	"github.com/spf13/viper"
+)
+
+// GC handles the API endpoint POST /blobs/gc
+// It deletes the blobs not in use anymore, and aborts stale incomplete uploads. A dry run
+// only reports what would be deleted.
+func (hc Controller) GC(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+
+	var gcRequest models.BlobsGCRequest
+	err := c.BindJSON(&gcRequest)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	manager, err := blobManager(ctx, cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	report, err := blobs.Collect(ctx, cluster, manager, blobs.Retention(), gcRequest.DryRun)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	log.Info("collected blobs", "dryRun", report.DryRun, "deleted", len(report.Deleted),
+		"aborted", len(report.Aborted), "freed", report.Freed)
+
+	response.OKReturn(c, report)
+	return nil
+}
+
+// Reap collects the blobs not in use anymore. It runs every interval, until the context is
+// done. With `blobs-gc-dry-run` configured it only logs what it would collect.
+func Reap(ctx context.Context, logger logr.Logger, interval time.Duration) {
+	reaper.Run(ctx, logger, interval, "collecting blobs", reapOnce)
+}
+
+// reapOnce is a helper for Reap. It collects the blobs not in use at the time of the call.
+func reapOnce(ctx context.Context, logger logr.Logger) error {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	manager, err := blobManager(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	report, err := blobs.Collect(ctx, cluster, manager, blobs.Retention(), viper.GetBool("blobs-gc-dry-run"))
+	if err != nil {
+		return err
+	}
+
+	for _, blob := range report.Deleted {
+		logger.Info("deleted blob", "blob", blob.ID, "status", blob.Status,
+			"namespace", blob.Namespace, "app", blob.App, "dryRun", report.DryRun)
+	}
+	for _, upload := range report.Aborted {
+		logger.Info("aborted upload", "blob", upload.ID, "initiated", upload.Initiated,
+			"dryRun", report.DryRun)
+	}
+
+	return nil
+}
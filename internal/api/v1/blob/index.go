@@ -0,0 +1,49 @@
+package blob
+
+import (
+	"context"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/blobs"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/s3manager"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Index handles the API endpoint GET /blobs
+// It lists the blobs of application sources, with their status for the garbage collection
+func (hc Controller) Index(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	manager, err := blobManager(ctx, cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	list, err := blobs.List(ctx, cluster, manager, blobs.Retention())
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, list)
+	return nil
+}
+
+// blobManager returns the S3 manager for the storage of the blobs
+func blobManager(ctx context.Context, cluster *kubernetes.Cluster) (*s3manager.Manager, error) {
+	connectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster,
+		helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s3manager.New(connectionDetails)
+}
@@ -0,0 +1,81 @@
+package builder
+
+import (
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/builders"
+	"github.com/epinio/epinio/internal/namespaces"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Set handles the API endpoint PUT /builders/:builder
+// It adds the builder to the registry, or replaces it. Restricted to admins.
+func (hc Controller) Set(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+
+	var builder models.Builder
+	err := c.BindJSON(&builder)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+	builder.Name = c.Param("builder")
+
+	err = builders.Validate(builder)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = builders.Set(ctx, cluster, builder)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// Delete handles the API endpoint DELETE /builders/:builder
+// It removes the builder from the registry, unless it is the default builder of a
+// namespace. Restricted to admins.
+func (hc Controller) Delete(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	name := c.Param("builder")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	builder, err := builders.Lookup(ctx, cluster, name)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if builder == nil {
+		return apierror.BuilderIsNotKnown(name)
+	}
+
+	// Namespaces defaulting to the builder would otherwise fail to stage
+	users, err := namespaces.NamesUsingBuilder(ctx, cluster, name)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if len(users) > 0 {
+		return apierror.BuilderIsInUse(name, users)
+	}
+
+	err = builders.Delete(ctx, cluster, name)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
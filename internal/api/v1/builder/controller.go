@@ -0,0 +1,6 @@
+// Package builder contains the API handlers to manage the registry of approved builders.
+package builder
+
+// Controller represents all functionality of the API related to builders
+type Controller struct {
+}
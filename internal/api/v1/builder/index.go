@@ -0,0 +1,29 @@
+package builder
+
+import (
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/builders"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Index handles the API endpoint GET /builders
+// It lists the approved builders
+func (hc Controller) Index(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	list, err := builders.List(ctx, cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, list)
+	return nil
+}
@@ -3,6 +3,7 @@ package configuration
 import (
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/configurations"
 	"github.com/epinio/epinio/internal/namespaces"
@@ -28,10 +29,32 @@ func (sc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.NewBadRequest("Cannot create configuration without a name")
 	}
 
-	if len(createRequest.Data) < 1 {
+	if len(createRequest.Data)+len(createRequest.BinaryData) < 1 {
 		return apierror.NewBadRequest("Cannot create configuration without data")
 	}
 
+	// Merge text and binary data into the `string -> []byte` map expected by kube.
+	data := make(map[string][]byte)
+	for k, v := range createRequest.Data {
+		data[k] = []byte(v)
+	}
+	for k, v := range createRequest.BinaryData {
+		if _, ok := data[k]; ok {
+			return apierror.NewBadRequest("Key specified as both text and binary data", k)
+		}
+		data[k] = v
+	}
+
+	configurationType := createRequest.Type
+	if configurationType == "" {
+		configurationType = models.ConfigurationTypeCustom
+	}
+
+	data, err = configurations.ValidateType(configurationType, data)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err)
@@ -57,8 +80,16 @@ func (sc Controller) Create(c *gin.Context) apierror.APIErrors {
 	}
 	// any error here is `configuration not found`, and we can continue
 
+	apierr := application.CheckNamespaceQuota(ctx, cluster, namespace, models.NamespaceUsage{
+		Configurations: 1,
+	})
+	if apierr != nil {
+		return apierr
+	}
+
 	// Create the new configuration. At last.
-	_, err = configurations.CreateConfiguration(ctx, cluster, createRequest.Name, namespace, username, createRequest.Data)
+	_, err = configurations.CreateConfiguration(ctx, cluster, createRequest.Name, namespace, username,
+		configurationType, data)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
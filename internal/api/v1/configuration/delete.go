@@ -1,10 +1,12 @@
 package configuration
 
 import (
+	"context"
 	"strings"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/configurationbinding"
+	"github.com/epinio/epinio/internal/api/v1/deploy"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
@@ -72,6 +74,49 @@ func (sc Controller) Delete(c *gin.Context) apierror.APIErrors {
 		}
 	}
 
+	// Environment variables referencing keys of the configuration are handled like the
+	// bindings. Without automatic unbind their applications are reported as error.
+	// Otherwise the variables are removed.
+
+	referencingAppNames, err := application.ReferencingAppsNamesFor(ctx, cluster, namespace, configurationName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if len(referencingAppNames) > 0 {
+		if !deleteRequest.Unbind {
+			return apierror.NewBadRequest("applications referencing the configuration in their environment exist",
+				strings.Join(referencingAppNames, ","))
+		}
+
+		for _, appName := range referencingAppNames {
+			apiErr := unsetReferences(ctx, cluster, namespace, appName, configurationName, username)
+			if apiErr != nil {
+				return apiErr
+			}
+			if !containsName(boundAppNames, appName) {
+				boundAppNames = append(boundAppNames, appName)
+			}
+		}
+	}
+
+	// Drop the configuration from the namespace defaults, so that future deployments do
+	// not try to bind it.
+
+	_, defaultConfigurations, err := namespaces.Defaults(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	for _, name := range defaultConfigurations {
+		if name == configurationName {
+			err = namespaces.DefaultConfigurationsRemove(ctx, cluster, namespace, configurationName)
+			if err != nil {
+				return apierror.InternalError(err)
+			}
+			break
+		}
+	}
+
 	// Everything looks to be ok. Delete.
 
 	err = configuration.Delete(ctx)
@@ -84,3 +129,39 @@ func (sc Controller) Delete(c *gin.Context) apierror.APIErrors {
 	})
 	return nil
 }
+
+// unsetReferences removes the environment variables of the application referencing keys of
+// the configuration, and redeploys the application, if it is active.
+func unsetReferences(ctx context.Context, cluster *kubernetes.Cluster, namespace, appName, configurationName, username string) apierror.APIErrors {
+	app, err := application.Lookup(ctx, cluster, namespace, appName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if app == nil {
+		return nil
+	}
+
+	err = application.EnvironmentUnsetReferencesTo(ctx, cluster, app.Meta, configurationName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if app.Workload != nil {
+		_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, username, "", nil, nil)
+		if apierr != nil {
+			return apierr
+		}
+	}
+
+	return nil
+}
+
+// containsName returns true if the name is in the list.
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
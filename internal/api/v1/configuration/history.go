@@ -0,0 +1,140 @@
+package configuration
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/deploy"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/namespaces"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// History handles the API endpoint GET /namespaces/:namespace/configurations/:configuration/history
+// It returns the revisions of the named configuration, newest first.
+func (sc Controller) History(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	configurationName := c.Param("configuration")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	exists, err := namespaces.Exists(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.NamespaceIsNotKnown(namespace)
+	}
+
+	configuration, err := configurations.Lookup(ctx, cluster, namespace, configurationName)
+	if err != nil {
+		if err.Error() == "configuration not found" {
+			return apierror.ConfigurationIsNotKnown(configurationName)
+		}
+		return apierror.InternalError(err)
+	}
+
+	history, err := configurations.History(ctx, cluster, configuration)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, history)
+	return nil
+}
+
+// Revert handles the API endpoint POST /namespaces/:namespace/configurations/:configuration/revisions/:revision/revert
+// It replaces the data of the named configuration with the data of the revision. The
+// applications bound to the configuration are restarted only on request.
+func (sc Controller) Revert(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	configurationName := c.Param("configuration")
+
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		return apierror.NewBadRequest(fmt.Sprintf("invalid revision '%s'", c.Param("revision")))
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	exists, err := namespaces.Exists(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if !exists {
+		return apierror.NamespaceIsNotKnown(namespace)
+	}
+
+	configuration, err := configurations.Lookup(ctx, cluster, namespace, configurationName)
+	if err != nil {
+		if err.Error() == "configuration not found" {
+			return apierror.ConfigurationIsNotKnown(configurationName)
+		}
+		return apierror.InternalError(err)
+	}
+
+	var revertRequest models.ConfigurationRevertRequest
+	err = c.BindJSON(&revertRequest)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	username := requestctx.User(ctx).Username
+
+	changed, err := configurations.Revert(ctx, cluster, configuration, username, revision)
+	if err != nil {
+		if errors.As(err, &configurations.RevisionNotFoundError{}) {
+			return apierror.NewNotFoundError(err.Error())
+		}
+		if errors.As(err, &configurations.TypeError{}) {
+			return apierror.BadRequest(err)
+		}
+		return apierror.InternalError(err)
+	}
+
+	if changed && revertRequest.Restart {
+		appNames, err := application.BoundAppsNamesFor(ctx, cluster, namespace, configurationName)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		for _, appName := range appNames {
+			app, err := application.Lookup(ctx, cluster, namespace, appName)
+			if err != nil {
+				return apierror.InternalError(err)
+			}
+			if app == nil {
+				// Application deleted since the lookup of the bound apps
+				continue
+			}
+
+			// Restart workload, if any. See Update for notes.
+			if app.Workload != nil {
+				nano := time.Now().UnixNano()
+				_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, username, "", nil, &nano)
+				if apierr != nil {
+					return apierr
+				}
+			}
+		}
+	}
+
+	response.OK(c)
+	return nil
+}
@@ -57,7 +57,7 @@ func makeResponse(ctx context.Context, appsOf map[string][]string, configuration
 	response := models.ConfigurationResponseList{}
 
 	for _, configuration := range configurations {
-		configurationDetails, err := configuration.Details(ctx)
+		configurationDetails, binaryDetails, err := configuration.Details(ctx)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				continue // Configuration was deleted, ignore it
@@ -78,9 +78,11 @@ func makeResponse(ctx context.Context, appsOf map[string][]string, configuration
 				},
 			},
 			Configuration: models.ConfigurationShowResponse{
-				Username:  configuration.User(),
-				Details:   configurationDetails,
-				BoundApps: appNames,
+				Username:      configuration.User(),
+				Type:          configuration.Type,
+				Details:       configurationDetails,
+				BinaryDetails: binaryDetails,
+				BoundApps:     appNames,
 			},
 		})
 	}
@@ -13,6 +13,7 @@ import (
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 )
 
 // Replace handles the API endpoint PUT /namespaces/:namespace/configurations/:app
@@ -52,15 +53,18 @@ func (sc Controller) Replace(c *gin.Context) apierror.APIErrors { // nolint:gocy
 		return apierror.BadRequest(err)
 	}
 
-	restart, err := configurations.ReplaceConfiguration(ctx, cluster, configuration, replaceRequest)
+	username := requestctx.User(ctx).Username
+
+	restart, err := configurations.ReplaceConfiguration(ctx, cluster, configuration, username, replaceRequest)
 	if err != nil {
+		if errors.As(err, &configurations.TypeError{}) {
+			return apierror.BadRequest(err)
+		}
 		return apierror.InternalError(err)
 	}
 
 	// Perform restart on the candidates which are actually running
 	if restart {
-		username := requestctx.User(ctx).Username
-
 		// Determine bound apps, as candidates for restart.
 		appNames, err := application.BoundAppsNamesFor(ctx, cluster, namespace, configurationName)
 		if err != nil {
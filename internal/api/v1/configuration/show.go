@@ -46,7 +46,7 @@ func (sc Controller) Show(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
-	configurationDetails, err := configuration.Details(ctx)
+	configurationDetails, binaryDetails, err := configuration.Details(ctx)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
@@ -60,9 +60,11 @@ func (sc Controller) Show(c *gin.Context) apierror.APIErrors {
 			},
 		},
 		Configuration: models.ConfigurationShowResponse{
-			Username:  configuration.User(),
-			Details:   configurationDetails,
-			BoundApps: appNames,
+			Username:      configuration.User(),
+			Type:          configuration.Type,
+			Details:       configurationDetails,
+			BinaryDetails: binaryDetails,
+			BoundApps:     appNames,
 		},
 	})
 	return nil
@@ -13,6 +13,7 @@ import (
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 )
 
 // Update handles the API endpoint PATCH /namespaces/:namespace/configurations/:app
@@ -54,10 +55,15 @@ func (sc Controller) Update(c *gin.Context) apierror.APIErrors { // nolint:gocyc
 		return apierror.BadRequest(err)
 	}
 
+	username := requestctx.User(ctx).Username
+
 	// Save changes to resource
 
-	err = configurations.UpdateConfiguration(ctx, cluster, configuration, updateRequest)
+	err = configurations.UpdateConfiguration(ctx, cluster, configuration, username, updateRequest)
 	if err != nil {
+		if errors.As(err, &configurations.TypeError{}) {
+			return apierror.BadRequest(err)
+		}
 		return apierror.InternalError(err)
 	}
 
@@ -69,8 +75,6 @@ func (sc Controller) Update(c *gin.Context) apierror.APIErrors { // nolint:gocyc
 	}
 
 	// Perform restart on the candidates which are actually running
-	username := requestctx.User(ctx).Username
-
 	for _, appName := range appNames {
 		app, err := application.Lookup(ctx, cluster, namespace, appName)
 		if err != nil {
@@ -3,6 +3,7 @@ package configurationbinding
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/deploy"
@@ -41,11 +42,20 @@ func (hc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.BadRequest(err)
 	}
 
+	named := map[string]struct{}{}
 	for _, configurationName := range bindRequest.Names {
 		if configurationName == "" {
 			err := errors.New("Cannot bind configuration with empty name")
 			return apierror.BadRequest(err)
 		}
+		named[configurationName] = struct{}{}
+	}
+
+	for configurationName := range bindRequest.Options {
+		if _, ok := named[configurationName]; !ok {
+			return apierror.NewBadRequest("Binding options given for a configuration not to bind",
+				configurationName)
+		}
 	}
 
 	cluster, err := kubernetes.GetCluster(ctx)
@@ -69,7 +79,7 @@ func (hc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.AppIsNotKnown(appName)
 	}
 
-	boundedConfigs, errors := CreateConfigurationBinding(ctx, cluster, namespace, *app, bindRequest.Names)
+	boundedConfigs, errors := CreateConfigurationBinding(ctx, cluster, namespace, *app, bindRequest.Names, bindRequest.Options)
 	if errors != nil {
 		return errors
 	}
@@ -83,12 +93,17 @@ func (hc Controller) Create(c *gin.Context) apierror.APIErrors {
 	return nil
 }
 
+// CreateConfigurationBinding binds the named configurations to the application, and
+// redeploys it, if it is active. The options, if any, specify how to bind the configurations
+// they mention. Configurations already bound with the same options are reported back, and
+// not bound again.
 func CreateConfigurationBinding(
 	ctx context.Context,
 	cluster *kubernetes.Cluster,
 	namespace string,
 	app models.App,
 	configurationNames []string,
+	options map[string]models.BindOptions,
 ) ([]string, apierror.APIErrors) {
 	logger := requestctx.Logger(ctx).WithName("CreateConfigurationBinding")
 
@@ -114,13 +129,19 @@ func CreateConfigurationBinding(
 
 	logger.Info(fmt.Sprintf("configurationNames loop: %#v", configurationNames))
 
+	oldOptions, err := application.BoundConfigurationOptions(ctx, cluster, app.Meta)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
 	for _, configurationName := range configurationNames {
-		if _, ok := oldBound[configurationName]; ok {
+		if _, ok := oldBound[configurationName]; ok &&
+			reflect.DeepEqual(oldOptions[configurationName], options[configurationName]) {
 			boundedConfigs = append(boundedConfigs, configurationName)
 			continue
 		}
 
-		_, err = configurations.Lookup(ctx, cluster, namespace, configurationName)
+		configuration, err := configurations.Lookup(ctx, cluster, namespace, configurationName)
 		if err != nil {
 			if err.Error() == "configuration not found" {
 				theIssues = append(theIssues, apierror.ConfigurationIsNotKnown(configurationName))
@@ -131,6 +152,16 @@ func CreateConfigurationBinding(
 			return nil, apierror.NewMultiError(theIssues)
 		}
 
+		issue, err := validateOptions(ctx, configuration, options[configurationName])
+		if err != nil {
+			theIssues = append([]apierror.APIError{apierror.InternalError(err)}, theIssues...)
+			return nil, apierror.NewMultiError(theIssues)
+		}
+		if issue != nil {
+			theIssues = append(theIssues, *issue)
+			continue
+		}
+
 		okToBind = append(okToBind, configurationName)
 	}
 
@@ -140,8 +171,13 @@ func CreateConfigurationBinding(
 		// Save those that were valid and not yet bound to the
 		// application. Extends the set.
 
-		logger.Info("BoundConfigurationsSet")
-		err := application.BoundConfigurationsSet(ctx, cluster, app.Meta, okToBind, false)
+		logger.Info("BoundConfigurationsSetOptions")
+		bindOptions := map[string]models.BindOptions{}
+		for _, configurationName := range okToBind {
+			bindOptions[configurationName] = options[configurationName]
+		}
+
+		err := application.BoundConfigurationsSetOptions(ctx, cluster, app.Meta, bindOptions)
 		if err != nil {
 			theIssues = append([]apierror.APIError{apierror.InternalError(err)}, theIssues...)
 			return nil, apierror.NewMultiError(theIssues)
@@ -164,3 +200,30 @@ func CreateConfigurationBinding(
 
 	return boundedConfigs, nil
 }
+
+// validateOptions checks the binding options against the configuration they are for. The
+// selected keys have to exist in it. Problems with the options are returned as issue, the
+// error is for internal trouble.
+func validateOptions(ctx context.Context, configuration *configurations.Configuration, options models.BindOptions) (*apierror.APIError, error) {
+	if err := options.Validate(); err != nil {
+		issue := apierror.NewBadRequest(err.Error(), configuration.Name)
+		return &issue, nil
+	}
+	if len(options.Keys) == 0 {
+		return nil, nil
+	}
+
+	secret, err := configuration.GetSecret(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for key := range options.Keys {
+		if _, ok := secret.Data[key]; !ok {
+			issue := apierror.NewBadRequest(
+				fmt.Sprintf("configuration '%s' has no key '%s'", configuration.Name, key))
+			return &issue, nil
+		}
+	}
+
+	return nil, nil
+}
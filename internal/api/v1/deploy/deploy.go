@@ -10,8 +10,10 @@ import (
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/configurations"
 	"github.com/epinio/epinio/internal/helm"
 	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/namespaces"
 	"github.com/epinio/epinio/internal/registry"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
@@ -41,19 +43,69 @@ func DeployApp(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 	routes := appObj.Configuration.Routes
 	chartName := appObj.Configuration.AppChart
 
+	// Configurations bound with non-default options are handed to the chart as ready-made
+	// volumes, mounts, and environment variables, placed into the pod spec for charts not
+	// rendering these themselves. The others are handed over by name.
+	boundConfigurations, err := application.BoundConfigurations(ctx, cluster, app)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+	binds, err := application.ToBinds(ctx, boundConfigurations, appObj.Configuration.BindOptions)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+	optionBinds := binds.WithOptions()
+	configurationNames := binds.ToDefaultNames()
+
+	// Environment variables referencing configuration keys are handed to the chart as
+	// references to the configuration secrets, together with the bound keys above.
+	environmentFrom := appObj.Configuration.EnvironmentFrom
+	apierr := application.CheckEnvironmentReferences(ctx, cluster, app.Namespace, environmentFrom)
+	if apierr != nil {
+		return nil, apierr
+	}
+	configurationEnv := append(optionBinds.ToEnvArray(),
+		application.EnvironmentReferencesToEnvArray(environmentFrom)...)
+
+	// The defaults of the namespace are merged in, with the settings of the app taking
+	// precedence. Default configurations which do not exist (anymore) are skipped.
+	defaultEnvironment, defaultConfigurations, err := namespaces.Defaults(ctx, cluster, app.Namespace)
+	if err != nil {
+		return nil, apierror.InternalError(err, "reading the namespace defaults")
+	}
+	environment := namespaces.MergeEnvironment(defaultEnvironment,
+		appObj.Configuration.Environment, environmentFrom)
+	boundNames := []string{}
+	for _, configuration := range boundConfigurations {
+		boundNames = append(boundNames, configuration.Name)
+	}
+	for _, name := range namespaces.MergeConfigurations(defaultConfigurations, boundNames) {
+		_, err := configurations.Lookup(ctx, cluster, app.Namespace, name)
+		if err != nil {
+			log.Info("skipping default configuration", "namespace", app.Namespace, "configuration", name, "error", err.Error())
+			continue
+		}
+		configurationNames = append(configurationNames, name)
+	}
+
 	deployParams := helm.ChartParameters{
-		Context:        ctx,
-		Cluster:        cluster,
-		AppRef:         app,
-		Chart:          chartName,
-		Environment:    appObj.Configuration.Environment,
-		Configurations: appObj.Configuration.Configurations,
-		Instances:      *appObj.Configuration.Instances,
-		ImageURL:       imageURL,
-		Username:       username,
-		StageID:        stageID,
-		Routes:         routes,
-		Start:          start,
+		Context:              ctx,
+		Cluster:              cluster,
+		AppRef:               app,
+		Chart:                chartName,
+		Environment:          environment,
+		Configurations:       configurationNames,
+		ConfigurationVolumes: optionBinds.ToVolumesArray(),
+		ConfigurationMounts:  optionBinds.ToMountsArray(),
+		ConfigurationEnv:     configurationEnv,
+		Instances:            *appObj.Configuration.Instances,
+		ImageURL:             imageURL,
+		Username:             username,
+		StageID:              stageID,
+		Routes:               routes,
+		Start:                start,
+		Labels:               appObj.Configuration.Labels,
+		Annotations:          appObj.Configuration.Annotations,
 	}
 
 	log.Info("deploying app", "namespace", app.Namespace, "app", app.Name)
@@ -109,10 +161,10 @@ func DeployApp(ctx context.Context, cluster *kubernetes.Cluster, app models.AppR
 // This is not a trivial process. For non-production deployments, pulling images
 // without TLS is fine.
 // When a localhost url doesn't exist, it means one of the following:
-// - the Epinio registry is deployed on Kubernetes with a valid cert (e.g. letsencrypt) and the
-//   "force-kube-internal-registry-tls" was set to "true" during deployment.
-// - the Epinio registry is an external one (if Epinio was deployed that way)
-// - a pre-existing image is being deployed (coming from an outer registry, not ours)
+//   - the Epinio registry is deployed on Kubernetes with a valid cert (e.g. letsencrypt) and the
+//     "force-kube-internal-registry-tls" was set to "true" during deployment.
+//   - the Epinio registry is an external one (if Epinio was deployed that way)
+//   - a pre-existing image is being deployed (coming from an outer registry, not ours)
 func replaceInternalRegistry(ctx context.Context, cluster *kubernetes.Cluster, imageURL string) (string, error) {
 	registryDetails, err := registry.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), registry.CredentialsSecretName)
 	if err != nil {
@@ -5,17 +5,20 @@ package docs
 import "github.com/epinio/epinio/pkg/api/core/v1/models"
 
 // swagger:route GET /applications application AllApps
-// Return list of applications in all namespaces.
+// Return list of applications in all namespaces, or of those whose labels match the `selector`.
 // responses:
 //   200: AppsResponse
 
 // swagger:parameters AllApps
-type AllAppsParam struct{}
+type AllAppsParam struct {
+	// in: query
+	Selector string
+}
 
 // response: See Apps.
 
 // swagger:route GET /namespaces/{Namespace}/applications application Apps
-// Return list of applications in the `Namespace`.
+// Return list of applications in the `Namespace`, or of those whose labels match the `selector`.
 // responses:
 //   200: AppsResponse
 
@@ -23,6 +26,8 @@ type AllAppsParam struct{}
 type AppsParam struct {
 	// in: path
 	Namespace string
+	// in: query
+	Selector string
 }
 
 // swagger:response AppsResponse
@@ -70,7 +75,8 @@ type AppShowResponse struct {
 }
 
 // swagger:route GET /namespaces/{Namespace}/applications/{App}/part/{Part} application AppPart
-// Return parts of the named `App` in the `Namespace`.
+// Return parts of the named `App` in the `Namespace`. The `sbom` part is the `AppSBOM` of
+// the deployed image, or of the image of the `Stage`.
 // responses:
 //   200: AppPartResponse
 
@@ -82,6 +88,8 @@ type AppPartParam struct {
 	App string
 	// in: path
 	Part string
+	// in: query
+	Stage string
 }
 
 // swagger:response AppPartResponse
@@ -215,6 +223,110 @@ type AppUploadResponse struct {
 	Body models.UploadResponse
 }
 
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/uploads application AppUploadStart
+// Start or resume the chunked upload of the sources of the named `App` in the `Namespace`.
+// responses:
+//   200: AppUploadStartResponse
+
+// swagger:parameters AppUploadStart
+type AppUploadStartParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: body
+	Configuration models.UploadStartRequest
+}
+
+// swagger:response AppUploadStartResponse
+type AppUploadStartResponse struct {
+	// in: body
+	Body models.UploadStartResponse
+}
+
+// swagger:route PUT /namespaces/{Namespace}/applications/{App}/uploads/{Sha256}/parts/{Part} application AppUploadPart
+// Upload a part of the chunked upload of the sources of the named `App` in the `Namespace`.
+// responses:
+//   200: AppUploadPartResponse
+
+// swagger:parameters AppUploadPart
+type AppUploadPartParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: path
+	Sha256 string
+	// in: path
+	Part int
+	// in: query
+	// The SHA-256 checksum of the part
+	Checksum string `json:"sha256"`
+	// in: body
+	Data []byte
+}
+
+// swagger:response AppUploadPartResponse
+type AppUploadPartResponse struct {
+	// in: body
+	Body models.UploadPart
+}
+
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/uploads/{Sha256}/complete application AppUploadComplete
+// Complete the chunked upload of the sources of the named `App` in the `Namespace`.
+// responses:
+//   200: AppUploadResponse
+
+// swagger:parameters AppUploadComplete
+type AppUploadCompleteParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: path
+	Sha256 string
+	// in: body
+	Configuration models.UploadCompleteRequest
+}
+
+// swagger:route GET /namespaces/{Namespace}/applications/{App}/cache application AppCacheShow
+// Return the capacity, storage class and last use of the build cache of the `App` in the `Namespace`.
+// responses:
+//   200: AppCacheShowResponse
+
+// swagger:parameters AppCacheShow
+type AppCacheShowParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response AppCacheShowResponse
+type AppCacheShowResponse struct {
+	// in: body
+	Body models.AppCache
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/applications/{App}/cache application AppCacheClear
+// Remove the build cache of the `App` in the `Namespace`. The next staging builds from scratch.
+// responses:
+//   200: AppCacheClearResponse
+
+// swagger:parameters AppCacheClear
+type AppCacheClearParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+}
+
+// swagger:response AppCacheClearResponse
+type AppCacheClearResponse struct {
+	// in: body
+	Body models.Response
+}
+
 // swagger:route POST /namespaces/{Namespace}/applications/{App}/restart application AppRestart
 // Restart the named `App` in the `Namespace`.
 // responses:
@@ -234,6 +346,48 @@ type AppRestartResponse struct {
 	Body models.Response
 }
 
+// swagger:route POST /namespaces/{Namespace}/restart application AppsRestart
+// Restart the applications in the `Namespace` whose labels match the `selector`.
+// Applications without workload are skipped.
+// responses:
+//   200: AppsRestartResponse
+
+// swagger:parameters AppsRestart
+type AppsRestartParam struct {
+	// in: path
+	Namespace string
+	// in: query
+	Selector string
+}
+
+// swagger:response AppsRestartResponse
+type AppsRestartResponse struct {
+	// in: body
+	Body models.AppsRestartResponse
+}
+
+// swagger:route POST /namespaces/{Namespace}/applications/{App}/promote application AppPromote
+// Copy the named `App` in the `Namespace`, with the configurations and services it uses,
+// to another namespace, and deploy the image it runs there, without staging.
+// responses:
+//   200: AppPromoteResponse
+
+// swagger:parameters AppPromote
+type AppPromoteParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	App string
+	// in: body
+	Body models.AppPromoteRequest
+}
+
+// swagger:response AppPromoteResponse
+type AppPromoteResponse struct {
+	// in: body
+	Body models.PromoteResponse
+}
+
 // swagger:route POST /namespaces/{Namespace}/applications/{App}/import-git application AppImportGit
 // Store the named `App` from a Git repo in the `Namespace`.
 // responses:
@@ -244,9 +398,10 @@ type AppImportGitParam struct {
 	// in: path
 	Namespace string
 	// in: path
-	App    string
-	GitUrl string
-	GitRev string
+	App     string
+	GitUrl  string
+	GitRev  string
+	Exclude []string
 }
 
 // swagger:response AppImportGitResponse
@@ -0,0 +1,36 @@
+package docs
+
+//go:generate swagger generate spec
+
+import "github.com/epinio/epinio/pkg/api/core/v1/models"
+
+// swagger:route GET /blobs blob Blobs
+// Return list of the blobs holding application sources, with their status for the garbage collection. Admin only.
+// responses:
+//   200: BlobsResponse
+
+// swagger:parameters Blobs
+type BlobsParam struct{}
+
+// swagger:response BlobsResponse
+type BlobsResponse struct {
+	// in: body
+	Body models.BlobList
+}
+
+// swagger:route POST /blobs/gc blob BlobsGC
+// Delete the blobs not in use anymore, and abort stale incomplete uploads. A dry run only reports what would be deleted. Admin only.
+// responses:
+//   200: BlobsGCResponse
+
+// swagger:parameters BlobsGC
+type BlobsGCParam struct {
+	// in: body
+	Body models.BlobsGCRequest
+}
+
+// swagger:response BlobsGCResponse
+type BlobsGCResponse struct {
+	// in: body
+	Body models.BlobsGCResponse
+}
@@ -0,0 +1,55 @@
+package docs
+
+//go:generate swagger generate spec
+
+import "github.com/epinio/epinio/pkg/api/core/v1/models"
+
+// swagger:route GET /builders builder Builders
+// Return list of the approved builders. Besides the system builder image only approved builders are accepted.
+// responses:
+//   200: BuildersResponse
+
+// swagger:parameters Builders
+type BuildersParam struct{}
+
+// swagger:response BuildersResponse
+type BuildersResponse struct {
+	// in: body
+	Body models.BuilderList
+}
+
+// swagger:route PUT /builders/{Builder} builder BuilderSet
+// Add the named `Builder` to the approved builders, or replace it. Admin only.
+// responses:
+//   200: BuilderSetResponse
+
+// swagger:parameters BuilderSet
+type BuilderSetParam struct {
+	// in: path
+	Builder string
+	// in: body
+	Body models.Builder
+}
+
+// swagger:response BuilderSetResponse
+type BuilderSetResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route DELETE /builders/{Builder} builder BuilderDelete
+// Remove the named `Builder` from the approved builders. Admin only.
+// responses:
+//   200: BuilderDeleteResponse
+
+// swagger:parameters BuilderDelete
+type BuilderDeleteParam struct {
+	// in: path
+	Builder string
+}
+
+// swagger:response BuilderDeleteResponse
+type BuilderDeleteResponse struct {
+	// in: body
+	Body models.Response
+}
@@ -82,6 +82,7 @@ type ConfigurationShowResponse struct {
 
 // swagger:route POST /namespaces/{Namespace}/configurations configuration ConfigurationCreate
 // Create the posted new configuration in the `Namespace`.
+// Configurations of a type other than `custom` are checked to contain the keys required by the type.
 // responses:
 //   200: ConfigurationCreateResponse
 
@@ -141,6 +142,50 @@ type ConfigurationReplaceResponse struct {
 	Body models.Response
 }
 
+// swagger:route GET /namespaces/{Namespace}/configurations/{Configuration}/history configuration ConfigurationHistory
+// Return the revisions of the named `Configuration` in the `Namespace`, newest first.
+// Each revision lists the keys added, modified, and removed by the change it records.
+// responses:
+//   200: ConfigurationHistoryResponse
+
+// swagger:parameters ConfigurationHistory
+type ConfigurationHistoryParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Configuration string
+}
+
+// swagger:response ConfigurationHistoryResponse
+type ConfigurationHistoryResponse struct {
+	// in: body
+	Body models.ConfigurationHistoryResponse
+}
+
+// swagger:route POST /namespaces/{Namespace}/configurations/{Configuration}/revisions/{Revision}/revert configuration ConfigurationRevert
+// Replace the data of the named `Configuration` in the `Namespace` with the data of the
+// `Revision`. This is recorded as a new revision. Bound apps are restarted on request.
+// responses:
+//   200: ConfigurationRevertResponse
+
+// swagger:parameters ConfigurationRevert
+type ConfigurationRevertParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Configuration string
+	// in: path
+	Revision int
+	// in: body
+	Body models.ConfigurationRevertRequest
+}
+
+// swagger:response ConfigurationRevertResponse
+type ConfigurationRevertResponse struct {
+	// in: body
+	Body models.Response
+}
+
 // swagger:route GET /configurations configuration AllConfigurations
 // Return list of configurations in all namespaces.
 // responses:
@@ -8,6 +8,7 @@ import "github.com/epinio/epinio/pkg/api/core/v1/models"
 
 // swagger:route GET /namespaces/{Namespace}/applications/{App}/environment app-env EnvList
 // Return the environment variable assignments for the `App` in the namespace`.
+// The values of variables referencing configuration keys are only returned when revealed.
 // responses:
 //   200: EnvListResponse
 
@@ -17,12 +18,14 @@ type EnvListParams struct {
 	Namespace string
 	// in: path
 	App string
+	// in: query
+	Reveal bool
 }
 
 // swagger:response EnvListResponse
 type EnvListResponse struct {
 	// in: body
-	Body models.EnvVariableMap
+	Body models.EnvVariableDefinitions
 }
 
 // swagger:route GET /namespaces/{Namespace}/applications/{App}/environmentmatch/{Pattern} app-env EnvMatch
@@ -73,7 +76,7 @@ type EnvSetParams struct {
 	// in: path
 	App string
 	// in: body
-	Body models.EnvVariableMap
+	Body models.EnvVariableDefinitions
 }
 
 // swagger:response EnvSetResponse
@@ -84,6 +87,7 @@ type EnvSetResponse struct {
 
 // swagger:route GET /namespaces/{Namespace}/applications/{App}/environment/{Env} app-env EnvShow
 // Return the named `Env` variable assignment for the `App` in the `Namespace`.
+// The value of a variable referencing a configuration key is only returned when revealed.
 // responses:
 //   200: EnvShowResponse
 
@@ -95,6 +99,8 @@ type EnvShowParams struct {
 	App string
 	// in: path
 	Env string
+	// in: query
+	Reveal bool
 }
 
 // swagger:response EnvShowResponse
@@ -0,0 +1,36 @@
+package docs
+
+//go:generate swagger generate spec
+
+import "github.com/epinio/epinio/pkg/api/core/v1/models"
+
+// swagger:route GET /images image Images
+// Return list of the stage images of the applications in the Epinio registry, with their status for the retention policy. Admin only.
+// responses:
+//   200: ImagesResponse
+
+// swagger:parameters Images
+type ImagesParam struct{}
+
+// swagger:response ImagesResponse
+type ImagesResponse struct {
+	// in: body
+	Body models.AppImageList
+}
+
+// swagger:route POST /images/gc image ImagesGC
+// Delete the stage images expired by the retention policy. Deployed images are never deleted. A dry run only reports what would be deleted. Admin only.
+// responses:
+//   200: ImagesGCResponse
+
+// swagger:parameters ImagesGC
+type ImagesGCParam struct {
+	// in: body
+	Body models.ImagesGCRequest
+}
+
+// swagger:response ImagesGCResponse
+type ImagesGCResponse struct {
+	// in: body
+	Body models.ImagesGCResponse
+}
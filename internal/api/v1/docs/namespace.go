@@ -5,10 +5,16 @@ import "github.com/epinio/epinio/pkg/api/core/v1/models"
 //go:generate swagger generate spec
 
 // swagger:route GET /namespaces namespace Namespaces
-// Return list of all controlled namespaces.
+// Return list of all controlled namespaces, or of those whose labels match the `selector`.
 // responses:
 //   200: NamespacesResponse
 
+// swagger:parameters Namespaces
+type NamespacesParam struct {
+	// in: query
+	Selector string
+}
+
 // swagger:response NamespacesResponse
 type NamespacesResponse struct {
 	// in: body
@@ -33,7 +39,9 @@ type NamespaceCreateResponse struct {
 }
 
 // swagger:route DELETE /namespaces/{Namespace} namespace NamespaceDelete
-// Delete the named `Namespace`.
+// Delete the named `Namespace`. With a `grace` duration the applications are scaled to
+// zero, and the namespace is deleted when the grace period is over. Protected namespaces
+// are not deleted.
 // responses:
 //   200: NamespaceDeleteResponse
 
@@ -41,6 +49,8 @@ type NamespaceCreateResponse struct {
 type NamespaceDeleteParam struct {
 	// in: path
 	Namespace string
+	// in: query
+	Grace string
 }
 
 // swagger:response NamespaceDeleteResponse
@@ -66,6 +76,121 @@ type NamespaceShowResponse struct {
 	Body models.Namespace
 }
 
+// swagger:route PATCH /namespaces/{Namespace} namespace NamespaceUpdate
+// Change the labels, description and owner of the named `Namespace`.
+// responses:
+//   200: NamespaceUpdateResponse
+
+// swagger:parameters NamespaceUpdate
+type NamespaceUpdateParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Body models.NamespaceUpdateRequest
+}
+
+// swagger:response NamespaceUpdateResponse
+type NamespaceUpdateResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route GET /namespaces/{Namespace}/deletion namespace NamespaceDeletionPreview
+// Return what the deletion of the named `Namespace` would destroy.
+// responses:
+//   200: NamespaceDeletionPreviewResponse
+
+// swagger:parameters NamespaceDeletionPreview
+type NamespaceDeletionPreviewParam struct {
+	// in: path
+	Namespace string
+}
+
+// swagger:response NamespaceDeletionPreviewResponse
+type NamespaceDeletionPreviewResponse struct {
+	// in: body
+	Body models.NamespaceDeletionPreview
+}
+
+// swagger:route POST /namespaces/{Namespace}/undelete namespace NamespaceUndelete
+// Cancel the pending deletion of the named, soft-deleted `Namespace`, and scale its
+// applications back up.
+// responses:
+//   200: NamespaceUndeleteResponse
+
+// swagger:parameters NamespaceUndelete
+type NamespaceUndeleteParam struct {
+	// in: path
+	Namespace string
+}
+
+// swagger:response NamespaceUndeleteResponse
+type NamespaceUndeleteResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route PUT /namespaces/{Namespace}/quota namespace NamespaceQuotaUpdate
+// Replace the quota of the named `Namespace` with the posted one. An empty quota removes it.
+// Admin only.
+// responses:
+//   200: NamespaceQuotaUpdateResponse
+
+// swagger:parameters NamespaceQuotaUpdate
+type NamespaceQuotaUpdateParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Body models.NamespaceQuota
+}
+
+// swagger:response NamespaceQuotaUpdateResponse
+type NamespaceQuotaUpdateResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route PUT /namespaces/{Namespace}/builder namespace NamespaceBuilderUpdate
+// Set the builder staging the apps of the named `Namespace` which do not specify one. An
+// empty builder name removes the default. Admin only.
+// responses:
+//   200: NamespaceBuilderUpdateResponse
+
+// swagger:parameters NamespaceBuilderUpdate
+type NamespaceBuilderUpdateParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Body models.NamespaceBuilderRequest
+}
+
+// swagger:response NamespaceBuilderUpdateResponse
+type NamespaceBuilderUpdateResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route POST /namespaces/{Namespace}/clone namespace NamespaceClone
+// Create a new namespace as copy of the named `Namespace`, with its quota, defaults,
+// configurations, services and applications. The applications are deployed with the
+// images they run in the source, without staging.
+// responses:
+//   200: NamespaceCloneResponse
+
+// swagger:parameters NamespaceClone
+type NamespaceCloneParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Body models.NamespaceCloneRequest
+}
+
+// swagger:response NamespaceCloneResponse
+type NamespaceCloneResponse struct {
+	// in: body
+	Body models.PromoteResponse
+}
+
 // swagger:route GET /namespacematches/{Pattern} namespace NamespaceMatch
 // Return list of names for all controlled namespaces whose name matches the prefix `Pattern`.
 // responses:
@@ -92,3 +217,101 @@ type NamespaceMatchResponse struct {
 type NamespaceMatch0Param struct{}
 
 // response: See NamespaceMatch.
+
+// swagger:route GET /namespaces/{Namespace}/environment namespace NamespaceEnvList
+// Return the default environment variables of the apps in the named `Namespace`.
+// responses:
+//   200: NamespaceEnvListResponse
+
+// swagger:parameters NamespaceEnvList
+type NamespaceEnvListParam struct {
+	// in: path
+	Namespace string
+}
+
+// swagger:response NamespaceEnvListResponse
+type NamespaceEnvListResponse struct {
+	// in: body
+	Body models.EnvVariableMap
+}
+
+// swagger:route POST /namespaces/{Namespace}/environment namespace NamespaceEnvSet
+// Add or change the default environment variables of the apps in the named `Namespace`.
+// Values set by an app take precedence over the defaults.
+// responses:
+//   200: NamespaceEnvSetResponse
+
+// swagger:parameters NamespaceEnvSet
+type NamespaceEnvSetParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Body models.NamespaceEnvSetRequest
+}
+
+// swagger:response NamespaceEnvSetResponse
+type NamespaceEnvSetResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/environment/{Env} namespace NamespaceEnvUnset
+// Remove the default environment variable `Env` of the apps in the named `Namespace`.
+// responses:
+//   200: NamespaceEnvUnsetResponse
+
+// swagger:parameters NamespaceEnvUnset
+type NamespaceEnvUnsetParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Env string
+	// in: query
+	Restart bool
+}
+
+// swagger:response NamespaceEnvUnsetResponse
+type NamespaceEnvUnsetResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route POST /namespaces/{Namespace}/bindings namespace NamespaceBind
+// Bind the posted configurations to all apps in the named `Namespace`, present and future.
+// responses:
+//   200: NamespaceBindResponse
+
+// swagger:parameters NamespaceBind
+type NamespaceBindParam struct {
+	// in: path
+	Namespace string
+	// in: body
+	Body models.NamespaceBindRequest
+}
+
+// swagger:response NamespaceBindResponse
+type NamespaceBindResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/bindings/{Configuration} namespace NamespaceUnbind
+// Remove the `Configuration` from the configurations bound to all apps in the named `Namespace`.
+// responses:
+//   200: NamespaceUnbindResponse
+
+// swagger:parameters NamespaceUnbind
+type NamespaceUnbindParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Configuration string
+	// in: query
+	Restart bool
+}
+
+// swagger:response NamespaceUnbindResponse
+type NamespaceUnbindResponse struct {
+	// in: body
+	Body models.Response
+}
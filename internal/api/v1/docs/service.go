@@ -35,6 +35,59 @@ type ServiceCatalogShowResponse struct {
 	Body models.ServiceCatalogShowResponse
 }
 
+// swagger:route POST /catalogservices service ServiceCatalogCreate
+// Add a service to the Epinio catalog. Admin only.
+// responses:
+//   200: ServiceCatalogCreateResponse
+
+// swagger:parameters ServiceCatalogCreate
+type ServiceCatalogCreateParam struct {
+	// in: body
+	Configuration models.CatalogService
+}
+
+// swagger:response ServiceCatalogCreateResponse
+type ServiceCatalogCreateResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route PATCH /catalogservices/{CatalogService} service ServiceCatalogUpdate
+// Modify the named Epinio `CatalogService`. Admin only.
+// responses:
+//   200: ServiceCatalogUpdateResponse
+
+// swagger:parameters ServiceCatalogUpdate
+type ServiceCatalogUpdateParam struct {
+	// in: path
+	CatalogService string
+	// in: body
+	Configuration models.ServiceCatalogUpdateRequest
+}
+
+// swagger:response ServiceCatalogUpdateResponse
+type ServiceCatalogUpdateResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route DELETE /catalogservices/{CatalogService} service ServiceCatalogDelete
+// Remove the named Epinio `CatalogService`, if it has no service instances. Admin only.
+// responses:
+//   200: ServiceCatalogDeleteResponse
+
+// swagger:parameters ServiceCatalogDelete
+type ServiceCatalogDeleteParam struct {
+	// in: path
+	CatalogService string
+}
+
+// swagger:response ServiceCatalogDeleteResponse
+type ServiceCatalogDeleteResponse struct {
+	// in: body
+	Body models.Response
+}
+
 // swagger:route GET /services service AllServices
 // Return all the `Services` where the User has authorization.
 // responses:
@@ -77,7 +130,8 @@ type ServiceListResponse struct {
 }
 
 // swagger:route GET /namespaces/{Namespace}/services/{Service} service ServiceShow
-// Return details of the named `Service` in the `Namespace`.
+// Return details of the named `Service` in the `Namespace`, including the keys of the
+// configurations it provides. Their values are only returned when `Secrets` is `true`.
 // responses:
 //   200: ServiceShowResponse
 
@@ -93,6 +147,8 @@ type ServiceShowParam struct {
 	Namespace string
 	// in: path
 	Service string
+	// in: query
+	Secrets bool
 }
 
 // swagger:route DELETE /namespaces/{Namespace}/services/{Service} service ServiceDelete
@@ -116,6 +172,48 @@ type ServiceDeleteResponse struct {
 	Body models.ServiceDeleteResponse
 }
 
+// swagger:route PATCH /namespaces/{Namespace}/services/{Service} service ServiceUpdate
+// Modify the helm values of the named `Service` in the `Namespace`. Apps bound to the service
+// are restarted when this changes the service's configurations.
+// responses:
+//   200: ServiceUpdateResponse
+
+// swagger:parameters ServiceUpdate
+type ServiceUpdateParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: body
+	Configuration models.ServiceUpdateRequest
+}
+
+// swagger:response ServiceUpdateResponse
+type ServiceUpdateResponse struct {
+	// in: body
+	Body models.Response
+}
+
+// swagger:route POST /namespaces/{Namespace}/services/{Service}/upgrade service ServiceUpgrade
+// Upgrade the named `Service` in the `Namespace` to the chart version of its catalog service.
+// Apps bound to the service are restarted when this changes the service's configurations.
+// responses:
+//   200: ServiceUpgradeResponse
+
+// swagger:parameters ServiceUpgrade
+type ServiceUpgradeParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceUpgradeResponse
+type ServiceUpgradeResponse struct {
+	// in: body
+	Body models.ServiceUpgradeResponse
+}
+
 // swagger:route POST /namespaces/{Namespace}/services/{Service}/bind service ServiceBind
 // Bind the named `Service` in the `Namespace` to an App.
 // responses:
@@ -157,3 +255,111 @@ type ServiceUnbindResponse struct {
 	// in: body
 	Body models.Response
 }
+
+// swagger:route POST /namespaces/{Namespace}/services/{Service}/backups service ServiceBackup
+// Start saving the data of the named `Service` in the `Namespace`, using the backup job of
+// its catalog service. The backup is stored in the Epinio S3 storage. See ServiceJob for
+// the progress of the job.
+// responses:
+//   200: ServiceBackupResponse
+
+// swagger:parameters ServiceBackup
+type ServiceBackupParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceBackupResponse
+type ServiceBackupResponse struct {
+	// in: body
+	Body models.ServiceJob
+}
+
+// swagger:route GET /namespaces/{Namespace}/services/{Service}/backups service ServiceBackups
+// Return the backups of the named `Service` in the `Namespace`, oldest first.
+// responses:
+//   200: ServiceBackupsResponse
+
+// swagger:parameters ServiceBackups
+type ServiceBackupsParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+}
+
+// swagger:response ServiceBackupsResponse
+type ServiceBackupsResponse struct {
+	// in: body
+	Body models.ServiceBackupList
+}
+
+// swagger:route POST /namespaces/{Namespace}/services/{Service}/backups/{Backup}/restore service ServiceRestore
+// Start restoring the data of the named `Service` in the `Namespace` from the specified
+// `Backup`, using the restore job of its catalog service. See ServiceJob for the progress
+// of the job.
+// responses:
+//   200: ServiceRestoreResponse
+
+// swagger:parameters ServiceRestore
+type ServiceRestoreParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: path
+	Backup string
+}
+
+// swagger:response ServiceRestoreResponse
+type ServiceRestoreResponse struct {
+	// in: body
+	Body models.ServiceJob
+}
+
+// swagger:route GET /namespaces/{Namespace}/services/{Service}/jobs/{Job} service ServiceJob
+// Return the status of the backup or restore `Job` of the named `Service` in the
+// `Namespace`. Failed jobs include the logs of their containers. Finished jobs are kept
+// for a day, unless deleted with ServiceJobDelete.
+// responses:
+//   200: ServiceJobResponse
+
+// swagger:parameters ServiceJob
+type ServiceJobParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: path
+	Job string
+}
+
+// swagger:response ServiceJobResponse
+type ServiceJobResponse struct {
+	// in: body
+	Body models.ServiceJob
+}
+
+// swagger:route DELETE /namespaces/{Namespace}/services/{Service}/jobs/{Job} service ServiceJobDelete
+// Delete the backup or restore `Job` of the named `Service` in the `Namespace`, together
+// with its logs. Running jobs are stopped.
+// responses:
+//   200: ServiceJobDeleteResponse
+
+// swagger:parameters ServiceJobDelete
+type ServiceJobDeleteParam struct {
+	// in: path
+	Namespace string
+	// in: path
+	Service string
+	// in: path
+	Job string
+}
+
+// swagger:response ServiceJobDeleteResponse
+type ServiceJobDeleteResponse struct {
+	// in: body
+	Body models.Response
+}
@@ -14,13 +14,15 @@ import (
 
 // Index handles the API endpoint /namespaces/:namespace/applications/:app/environment
 // It receives the namespace, application name and returns the environment
-// associated with that application
+// associated with that application. The values of variables referencing configuration
+// keys are only returned when revealed (query parameter `reveal=true`).
 func (hc Controller) Index(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	log := requestctx.Logger(ctx)
 
 	namespaceName := c.Param("namespace")
 	appName := c.Param("app")
+	reveal := c.Query("reveal") == "true"
 
 	log.Info("returning environment", "namespace", namespaceName, "app", appName)
 
@@ -54,6 +56,23 @@ func (hc Controller) Index(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
-	response.OKReturn(c, environment)
+	references, err := application.EnvironmentReferences(ctx, cluster, app)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	definitions := models.EnvVariableDefinitions{
+		Values:     environment,
+		References: references,
+	}
+
+	if reveal {
+		definitions.Revealed, err = application.ResolveEnvironmentReferences(ctx, cluster, namespaceName, references)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	response.OKReturn(c, definitions)
 	return nil
 }
@@ -58,13 +58,13 @@ func (hc Controller) Match(c *gin.Context) apierror.APIErrors {
 	// EnvList, with post-processing - selection of matches, and
 	// projection to deliver only names
 
-	environment, err := application.Environment(ctx, cluster, app)
+	names, err := application.EnvironmentNames(ctx, cluster, app)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
 
 	matches := []string{}
-	for evName := range environment {
+	for _, evName := range names {
 		if strings.HasPrefix(evName, prefix) {
 			matches = append(matches, evName)
 		}
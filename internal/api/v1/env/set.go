@@ -15,6 +15,7 @@ import (
 // Set handles the API endpoint /namespaces/:namespace/applications/:app/environment (POST)
 // It receives the namespace, application name, var name and value,
 // and add/modifies the variable in the  application's environment.
+// Values can be references to configuration keys, which have to exist.
 func (hc Controller) Set(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	log := requestctx.Logger(ctx)
@@ -48,13 +49,23 @@ func (hc Controller) Set(c *gin.Context) apierror.APIErrors {
 		return apierror.AppIsNotKnown(appName)
 	}
 
-	var setRequest models.EnvVariableMap
+	var setRequest models.EnvVariableDefinitions
 	err = c.BindJSON(&setRequest)
 	if err != nil {
 		return apierror.BadRequest(err)
 	}
 
-	err = application.EnvironmentSet(ctx, cluster, app.Meta, setRequest, false)
+	apierr := application.CheckEnvironmentReferences(ctx, cluster, namespaceName, setRequest.References)
+	if apierr != nil {
+		return apierr
+	}
+
+	err = application.EnvironmentSet(ctx, cluster, app.Meta, setRequest.Values, false)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = application.EnvironmentSetReferences(ctx, cluster, app.Meta, setRequest.References, false)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
@@ -14,7 +14,8 @@ import (
 
 // EnvShow handles the API endpoint /namespaces/:namespace/applications/:app/environment/:env
 // It receives the namespace, application name, var name, and returns
-// the variable's value in the application's environment.
+// the variable's value in the application's environment. The value of a variable
+// referencing a configuration key is only returned when revealed (`reveal=true`).
 func (hc Controller) Show(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	log := requestctx.Logger(ctx)
@@ -22,6 +23,7 @@ func (hc Controller) Show(c *gin.Context) apierror.APIErrors {
 	namespaceName := c.Param("namespace")
 	appName := c.Param("app")
 	varName := c.Param("env")
+	reveal := c.Query("reveal") == "true"
 
 	log.Info("processing environment variable request",
 		"namespace", namespaceName, "app", appName, "var", varName)
@@ -58,6 +60,11 @@ func (hc Controller) Show(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	references, err := application.EnvironmentReferences(ctx, cluster, app)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
 	match := models.EnvVariable{}
 
 	value, ok := environment[varName]
@@ -65,6 +72,21 @@ func (hc Controller) Show(c *gin.Context) apierror.APIErrors {
 		match.Name = varName
 		match.Value = value
 	}
+
+	source, ok := references[varName]
+	if ok {
+		match.Name = varName
+		match.ValueFrom = &source
+
+		if reveal {
+			revealed, err := application.ResolveEnvironmentReferences(ctx, cluster, namespaceName,
+				models.EnvVariableRefMap{varName: source})
+			if err != nil {
+				return apierror.InternalError(err)
+			}
+			match.Value = revealed[varName]
+		}
+	}
 	// Not found: Returns an empty object.
 
 	response.OKReturn(c, match)
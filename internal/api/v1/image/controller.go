@@ -0,0 +1,7 @@
+// Package image contains the API handlers to inspect the stage images of the applications
+// in the Epinio registry, and to delete those expired by the retention policy.
+package image
+
+// Controller represents all functionality of the API related to images
+type Controller struct {
+}
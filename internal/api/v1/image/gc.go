@@ -0,0 +1,93 @@
+package image
+
+import (
+	"context"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/images"
+	"github.com/epinio/epinio/internal/reaper"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// GC handles the API endpoint POST /images/gc
+// It deletes the stage images expired by the retention policy. The request may override
+// the number of images kept per application. A dry run only reports what would be deleted.
+func (hc Controller) GC(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	log := requestctx.Logger(ctx)
+
+	var gcRequest models.ImagesGCRequest
+	err := c.BindJSON(&gcRequest)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	keep := images.Retention()
+	if gcRequest.Keep != nil {
+		if *gcRequest.Keep < 0 {
+			return apierror.BadRequest(errors.New("the number of images to keep cannot be negative"))
+		}
+		keep = *gcRequest.Keep
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	client, err := images.NewClient(ctx, cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	report, err := images.Collect(ctx, cluster, client, keep, gcRequest.DryRun)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	log.Info("collected images", "dryRun", report.DryRun, "keep", report.Keep,
+		"deleted", len(report.Deleted))
+
+	response.OKReturn(c, report)
+	return nil
+}
+
+// Reap deletes the stage images expired by the retention policy. It runs every interval,
+// until the context is done. With `image-gc-dry-run` configured it only logs what it would
+// delete.
+func Reap(ctx context.Context, logger logr.Logger, interval time.Duration) {
+	reaper.Run(ctx, logger, interval, "collecting images", reapOnce)
+}
+
+// reapOnce is a helper for Reap. It deletes the images expired at the time of the call.
+func reapOnce(ctx context.Context, logger logr.Logger) error {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, err := images.NewClient(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	report, err := images.Collect(ctx, cluster, client, images.Retention(), viper.GetBool("image-gc-dry-run"))
+	if err != nil {
+		return err
+	}
+
+	for _, image := range report.Deleted {
+		logger.Info("deleted image", "repository", image.Repository, "tag", image.Tag,
+			"namespace", image.Namespace, "app", image.App, "dryRun", report.DryRun)
+	}
+
+	return nil
+}
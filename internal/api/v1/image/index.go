@@ -0,0 +1,34 @@
+package image
+
+import (
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/images"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Index handles the API endpoint GET /images
+// It lists the stage images of the applications, with their status for the retention policy
+func (hc Controller) Index(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	client, err := images.NewClient(ctx, cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	list, err := images.List(ctx, cluster, client, images.Retention())
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, list)
+	return nil
+}
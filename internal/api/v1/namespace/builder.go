@@ -0,0 +1,47 @@
+package namespace
+
+import (
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/builders"
+	"github.com/epinio/epinio/internal/namespaces"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// BuilderUpdate handles the API endpoint PUT /namespaces/:namespace/builder
+// It sets the builder used to stage the apps of the namespace which do not specify one.
+// An empty builder name removes the default.
+func (hc Controller) BuilderUpdate(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	var builderRequest models.NamespaceBuilderRequest
+	err := c.BindJSON(&builderRequest)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	cluster, apierr := namespaceCluster(ctx, namespace)
+	if apierr != nil {
+		return apierr
+	}
+
+	if builderRequest.Builder != "" {
+		builder, err := builders.Lookup(ctx, cluster, builderRequest.Builder)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+		if builder == nil {
+			return apierror.BuilderIsNotKnown(builderRequest.Builder)
+		}
+	}
+
+	err = namespaces.DefaultBuilderSet(ctx, cluster, namespace, builderRequest.Builder)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
@@ -0,0 +1,214 @@
+package namespace
+
+import (
+	"context"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/promote"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/internal/services"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// Clone handles the API endpoint POST /namespaces/:namespace/clone
+// It creates a new namespace as copy of the specified one, i.e. with the same metadata,
+// quota, defaults, configurations, services and apps. The apps are deployed with the images they
+// run in the source namespace. No staging takes place. A failed clone deletes the new
+// namespace again.
+func (hc Controller) Clone(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	username := requestctx.User(ctx).Username
+
+	var cloneRequest models.NamespaceCloneRequest
+	err := c.BindJSON(&cloneRequest)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	target := cloneRequest.Name
+	if target == "" {
+		return apierror.BadRequest(errors.New("name of namespace to create not found"))
+	}
+
+	err = promote.ValidateRoutePattern(cloneRequest.RoutePattern)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	cluster, apierr := namespaceCluster(ctx, namespace)
+	if apierr != nil {
+		return apierr
+	}
+
+	exists, err := namespaces.Exists(ctx, cluster, target)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if exists {
+		return apierror.NamespaceAlreadyKnown(target)
+	}
+
+	configurationList, err := configurations.List(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	// Overrides are accepted only for configurations which are copied.
+	copied := map[string]struct{}{}
+	for _, configuration := range configurationList {
+		if configuration.Type != models.ConfigurationTypeService {
+			copied[configuration.Name] = struct{}{}
+		}
+	}
+	for name := range cloneRequest.ConfigurationOverrides {
+		if _, ok := copied[name]; !ok {
+			return apierror.NewBadRequest("Overrides given for a configuration not copied", name)
+		}
+	}
+
+	source, err := namespaces.Get(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	// Arguments found OK, now we can create the new namespace
+
+	err = namespaces.Create(ctx, cluster, target, source.Metadata)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	result, apierr := cloneInto(ctx, cluster, username, namespace, target, cloneRequest, configurationList, copied)
+	if apierr != nil {
+		// Roll back. A partial copy of the namespace is of no use, and blocks the retry
+		// under the same name.
+		if err := destroy(ctx, cluster, target); err != nil {
+			requestctx.Logger(ctx).Error(err, "deleting the partially cloned namespace", "namespace", target)
+		}
+		return apierr
+	}
+
+	response.OKReturn(c, result)
+	return nil
+}
+
+// cloneInto is a helper for Clone. It copies the quota, defaults, configurations, services
+// and apps of the source namespace into the newly created target namespace.
+func cloneInto(ctx context.Context, cluster *kubernetes.Cluster, username, namespace, target string,
+	cloneRequest models.NamespaceCloneRequest, configurationList configurations.ConfigurationList,
+	copied map[string]struct{}) (models.PromoteResponse, apierror.APIErrors) {
+
+	var none models.PromoteResponse
+
+	err := addNamespaceToUser(ctx, target)
+	if err != nil {
+		return none, apierror.InternalError(err)
+	}
+
+	quota, err := namespaces.Quota(ctx, cluster, namespace)
+	if err != nil {
+		return none, apierror.InternalError(err)
+	}
+	if quota != nil {
+		err = namespaces.QuotaSet(ctx, cluster, target, *quota)
+		if err != nil {
+			return none, apierror.InternalError(err)
+		}
+	}
+
+	promoter, err := promote.New(ctx, cluster, username, namespace, target, promote.Options{
+		RoutePattern:           cloneRequest.RoutePattern,
+		ConfigurationOverrides: cloneRequest.ConfigurationOverrides,
+		SkipServices:           cloneRequest.SkipServices,
+	})
+	if err != nil {
+		return none, apierror.InternalError(err)
+	}
+
+	for _, configuration := range configurationList {
+		if _, ok := copied[configuration.Name]; !ok {
+			continue
+		}
+
+		apierr := promoter.Configuration(configuration.Name)
+		if apierr != nil {
+			return none, apierr
+		}
+	}
+
+	// Defaults referencing configurations of services are dropped. These do not exist
+	// in the new namespace yet.
+	defaultEnvironment, defaultConfigurations, err := namespaces.Defaults(ctx, cluster, namespace)
+	if err != nil {
+		return none, apierror.InternalError(err)
+	}
+	if len(defaultEnvironment) > 0 {
+		err = namespaces.DefaultEnvironmentSet(ctx, cluster, target, defaultEnvironment)
+		if err != nil {
+			return none, apierror.InternalError(err)
+		}
+	}
+	defaultBindings := []string{}
+	for _, name := range defaultConfigurations {
+		if _, ok := copied[name]; ok {
+			defaultBindings = append(defaultBindings, name)
+		}
+	}
+	if len(defaultBindings) > 0 {
+		err = namespaces.DefaultConfigurationsAdd(ctx, cluster, target, defaultBindings)
+		if err != nil {
+			return none, apierror.InternalError(err)
+		}
+	}
+	defaultBuilder, err := namespaces.DefaultBuilder(ctx, cluster, namespace)
+	if err != nil {
+		return none, apierror.InternalError(err)
+	}
+	if defaultBuilder != "" {
+		err = namespaces.DefaultBuilderSet(ctx, cluster, target, defaultBuilder)
+		if err != nil {
+			return none, apierror.InternalError(err)
+		}
+	}
+
+	if !cloneRequest.SkipServices {
+		serviceClient, err := services.NewKubernetesServiceClient(cluster)
+		if err != nil {
+			return none, apierror.InternalError(err)
+		}
+
+		serviceList, err := serviceClient.ListInNamespace(ctx, namespace)
+		if err != nil {
+			return none, apierror.InternalError(err)
+		}
+
+		for _, service := range serviceList {
+			apierr := promoter.Service(service.Meta.Name)
+			if apierr != nil {
+				return none, apierr
+			}
+		}
+	}
+
+	apps, err := application.List(ctx, cluster, namespace)
+	if err != nil {
+		return none, apierror.InternalError(err)
+	}
+
+	for _, app := range apps {
+		apierr := promoter.App(app)
+		if apierr != nil {
+			return none, apierr
+		}
+	}
+
+	return promoter.Result(), nil
+}
@@ -3,6 +3,7 @@ package namespace
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
@@ -38,6 +39,22 @@ func (oc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.BadRequest(err)
 	}
 
+	if request.Quota != nil {
+		if requestctx.User(ctx).Role != "admin" {
+			return apierror.NewAPIError("only admins can set namespace quotas", "", http.StatusForbidden)
+		}
+
+		err := namespaces.ValidateQuota(*request.Quota)
+		if err != nil {
+			return apierror.BadRequest(err)
+		}
+	}
+
+	err = namespaces.ValidateLabels(request.Labels)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
 	exists, err := namespaces.Exists(ctx, cluster, namespaceName)
 	if err != nil {
 		return apierror.InternalError(err)
@@ -46,11 +63,22 @@ func (oc Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.NamespaceAlreadyKnown(namespaceName)
 	}
 
-	err = namespaces.Create(ctx, cluster, namespaceName)
+	err = namespaces.Create(ctx, cluster, namespaceName, namespaces.Metadata{
+		Labels:      request.Labels,
+		Description: request.Description,
+		Owner:       request.Owner,
+	})
 	if err != nil {
 		return apierror.InternalError(err)
 	}
 
+	if request.Quota != nil {
+		err = namespaces.QuotaSet(ctx, cluster, namespaceName, *request.Quota)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
 	err = addNamespaceToUser(ctx, namespaceName)
 	if err != nil {
 		return apierror.InternalError(err)
@@ -0,0 +1,233 @@
+package namespace
+
+import (
+	"context"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/deploy"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/namespaces"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// EnvIndex handles the API endpoint GET /namespaces/:namespace/environment
+// It returns the default environment variables of the apps in the namespace.
+func (hc Controller) EnvIndex(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	cluster, apierr := namespaceCluster(ctx, namespace)
+	if apierr != nil {
+		return apierr
+	}
+
+	environment, _, err := namespaces.Defaults(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OKReturn(c, environment)
+	return nil
+}
+
+// EnvSet handles the API endpoint POST /namespaces/:namespace/environment
+// It adds or modifies default environment variables of the apps in the namespace.
+func (hc Controller) EnvSet(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	var setRequest models.NamespaceEnvSetRequest
+	err := c.BindJSON(&setRequest)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	for name := range setRequest.Environment {
+		if name == "" {
+			return apierror.BadRequest(errors.New("Cannot set environment variable with empty name"))
+		}
+	}
+
+	cluster, apierr := namespaceCluster(ctx, namespace)
+	if apierr != nil {
+		return apierr
+	}
+
+	err = namespaces.DefaultEnvironmentSet(ctx, cluster, namespace, setRequest.Environment)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if setRequest.Restart {
+		apierr := restartApps(ctx, cluster, namespace)
+		if apierr != nil {
+			return apierr
+		}
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// EnvUnset handles the API endpoint DELETE /namespaces/:namespace/environment/:env
+// It removes a default environment variable of the apps in the namespace.
+func (hc Controller) EnvUnset(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	varName := c.Param("env")
+	restart := c.Query("restart") == "true"
+
+	cluster, apierr := namespaceCluster(ctx, namespace)
+	if apierr != nil {
+		return apierr
+	}
+
+	err := namespaces.DefaultEnvironmentUnset(ctx, cluster, namespace, varName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if restart {
+		apierr := restartApps(ctx, cluster, namespace)
+		if apierr != nil {
+			return apierr
+		}
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// Bind handles the API endpoint POST /namespaces/:namespace/bindings
+// It binds the named configurations to all apps in the namespace, present and future.
+func (hc Controller) Bind(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	var bindRequest models.NamespaceBindRequest
+	err := c.BindJSON(&bindRequest)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	if len(bindRequest.Names) == 0 {
+		return apierror.BadRequest(errors.New("Cannot bind configuration without names"))
+	}
+
+	cluster, apierr := namespaceCluster(ctx, namespace)
+	if apierr != nil {
+		return apierr
+	}
+
+	for _, configurationName := range bindRequest.Names {
+		if configurationName == "" {
+			return apierror.BadRequest(errors.New("Cannot bind configuration with empty name"))
+		}
+
+		_, err := configurations.Lookup(ctx, cluster, namespace, configurationName)
+		if err != nil {
+			if err.Error() == "configuration not found" {
+				return apierror.ConfigurationIsNotKnown(configurationName)
+			}
+			return apierror.InternalError(err)
+		}
+	}
+
+	err = namespaces.DefaultConfigurationsAdd(ctx, cluster, namespace, bindRequest.Names)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if bindRequest.Restart {
+		apierr := restartApps(ctx, cluster, namespace)
+		if apierr != nil {
+			return apierr
+		}
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// Unbind handles the API endpoint DELETE /namespaces/:namespace/bindings/:configuration
+// It removes the named configuration from the configurations bound to all apps in the
+// namespace. Bindings made to individual apps are not affected.
+func (hc Controller) Unbind(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	configurationName := c.Param("configuration")
+	restart := c.Query("restart") == "true"
+
+	cluster, apierr := namespaceCluster(ctx, namespace)
+	if apierr != nil {
+		return apierr
+	}
+
+	err := namespaces.DefaultConfigurationsRemove(ctx, cluster, namespace, configurationName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if restart {
+		apierr := restartApps(ctx, cluster, namespace)
+		if apierr != nil {
+			return apierr
+		}
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// namespaceCluster returns the cluster to operate on, after checking that the namespace
+// exists.
+func namespaceCluster(ctx context.Context, namespace string) (*kubernetes.Cluster, apierror.APIErrors) {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
+	exists, err := namespaces.Exists(ctx, cluster, namespace)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+	if !exists {
+		return nil, apierror.NamespaceIsNotKnown(namespace)
+	}
+
+	return cluster, nil
+}
+
+// restartApps redeploys all active apps in the namespace, to pick up changed defaults.
+func restartApps(ctx context.Context, cluster *kubernetes.Cluster, namespace string) apierror.APIErrors {
+	log := requestctx.Logger(ctx)
+	username := requestctx.User(ctx).Username
+
+	apps, err := application.List(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	for _, app := range apps {
+		if app.Workload == nil {
+			continue
+		}
+
+		log.Info("restarting app for changed namespace defaults", "namespace", namespace, "app", app.Meta.Name)
+
+		nano := time.Now().UnixNano()
+		_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, username, "", nil, &nano)
+		if apierr != nil {
+			return apierr
+		}
+	}
+
+	return nil
+}
@@ -26,58 +26,88 @@ import (
 // Delete handles the API endpoint /namespaces/:namespace (DELETE).
 // It destroys the namespace specified by its name.
 // This includes all the applications and configurations in it.
+// With a `grace` duration the namespace is soft-deleted instead, see softDelete.
+// Protected namespaces are not deleted.
 func (oc Controller) Delete(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	namespace := c.Param("namespace")
 
+	var grace time.Duration
+	if value := c.Query("grace"); value != "" {
+		var err error
+		grace, err = time.ParseDuration(value)
+		if err != nil || grace <= 0 {
+			return apierror.NewBadRequest("grace period must be a positive duration", value)
+		}
+	}
+
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
 
-	exists, err := namespaces.Exists(ctx, cluster, namespace)
+	space, err := namespaces.Get(ctx, cluster, namespace)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
-	if !exists {
+	if space == nil {
 		return apierror.NamespaceIsNotKnown(namespace)
 	}
+	if space.Protected {
+		return apierror.NamespaceIsProtected(namespace)
+	}
+
+	if grace > 0 {
+		apierr := softDelete(ctx, cluster, namespace, grace)
+		if apierr != nil {
+			return apierr
+		}
+
+		response.OK(c)
+		return nil
+	}
 
-	err = deleteApps(ctx, cluster, namespace)
+	err = destroy(ctx, cluster, namespace)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
 
+	response.OK(c)
+	return nil
+}
+
+// destroy removes the namespace with all the applications, services and configurations
+// in it.
+func destroy(ctx context.Context, cluster *kubernetes.Cluster, namespace string) error {
+	err := deleteApps(ctx, cluster, namespace)
+	if err != nil {
+		return err
+	}
+
 	err = deleteServices(ctx, cluster, namespace)
 	if err != nil {
-		return apierror.InternalError(err)
+		return err
 	}
 
 	err = deleteNamespaceFromUsers(ctx, namespace)
 	if err != nil {
-		return apierror.InternalError(err)
+		return err
 	}
 
 	configurationList, err := configurations.List(ctx, cluster, namespace)
 	if err != nil {
-		return apierror.InternalError(err)
+		return err
 	}
 
 	for _, configuration := range configurationList {
 		err = configuration.Delete(ctx)
 		if err != nil && !apierrors.IsNotFound(err) {
-			return apierror.InternalError(err)
+			return err
 		}
 	}
 
 	// Deleting the namespace here. That will automatically delete the application resources.
-	err = namespaces.Delete(ctx, cluster, namespace)
-	if err != nil {
-		return apierror.InternalError(err)
-	}
-
-	response.OK(c)
-	return nil
+	return namespaces.Delete(ctx, cluster, namespace)
 }
 
 // deleteApps removes the application and its resources
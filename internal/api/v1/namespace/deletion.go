@@ -0,0 +1,244 @@
+package namespace
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/deploy"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/internal/reaper"
+	"github.com/epinio/epinio/internal/services"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeletionPreview handles the API endpoint GET /namespaces/:namespace/deletion
+// It returns what the deletion of the namespace would destroy.
+func (hc Controller) DeletionPreview(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	space, err := namespaces.Get(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if space == nil {
+		return apierror.NamespaceIsNotKnown(namespace)
+	}
+
+	preview := models.NamespaceDeletionPreview{
+		Apps:           []string{},
+		Routes:         []string{},
+		Services:       []string{},
+		Configurations: []string{},
+		Volumes:        []string{},
+		Blobs:          []string{},
+		Protected:      space.Protected,
+	}
+
+	apps, err := application.List(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	for _, app := range apps {
+		preview.Apps = append(preview.Apps, app.Meta.Name)
+		preview.Routes = append(preview.Routes, app.Configuration.Routes...)
+
+		blobs, pvc, err := application.StagingResources(ctx, cluster, app.Meta)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+		preview.Blobs = append(preview.Blobs, blobs...)
+		if pvc != "" {
+			preview.Volumes = append(preview.Volumes, pvc)
+		}
+	}
+
+	pvcs, err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	for _, pvc := range pvcs.Items {
+		preview.Volumes = append(preview.Volumes, pvc.Name)
+	}
+
+	serviceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	serviceList, err := serviceClient.ListInNamespace(ctx, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	for _, service := range serviceList {
+		preview.Services = append(preview.Services, service.Meta.Name)
+	}
+
+	configurationList, err := configurations.List(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	for _, configuration := range configurationList {
+		preview.Configurations = append(preview.Configurations, configuration.Name)
+	}
+
+	for _, names := range [][]string{preview.Apps, preview.Routes, preview.Services,
+		preview.Configurations, preview.Volumes, preview.Blobs} {
+		sort.Strings(names)
+	}
+
+	response.OKReturn(c, preview)
+	return nil
+}
+
+// Undelete handles the API endpoint POST /namespaces/:namespace/undelete
+// It cancels the pending deletion of a soft-deleted namespace, and scales its applications
+// back up.
+func (hc Controller) Undelete(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	space, err := namespaces.Get(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if space == nil {
+		return apierror.NamespaceIsNotKnown(namespace)
+	}
+	if space.DeleteAfter == nil {
+		return apierror.NewBadRequest("namespace is not scheduled for deletion", namespace)
+	}
+
+	err = namespaces.CancelDeletion(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	apierr := scaleApps(ctx, cluster, namespace, application.ScalingResume)
+	if apierr != nil {
+		return apierr
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// softDelete is a helper for Delete. It scales the applications of the namespace to zero,
+// and marks the namespace for deletion after the grace period. Reap deletes it then,
+// unless Undelete was called before.
+func softDelete(ctx context.Context, cluster *kubernetes.Cluster, namespace string, grace time.Duration) apierror.APIErrors {
+	err := namespaces.ScheduleDeletion(ctx, cluster, namespace, time.Now().Add(grace))
+	if err != nil {
+		if _, ok := err.(namespaces.ProtectedError); ok {
+			return apierror.NamespaceIsProtected(namespace)
+		}
+		return apierror.InternalError(err)
+	}
+
+	return scaleApps(ctx, cluster, namespace, application.ScalingSuspend)
+}
+
+// scaleApps applies the scaling change to all apps in the namespace, and redeploys those
+// which are active.
+func scaleApps(ctx context.Context, cluster *kubernetes.Cluster, namespace string,
+	scale func(context.Context, *kubernetes.Cluster, models.AppRef) error) apierror.APIErrors {
+
+	username := requestctx.User(ctx).Username
+
+	apps, err := application.List(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	for _, app := range apps {
+		err := scale(ctx, cluster, app.Meta)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		if app.Workload == nil {
+			continue
+		}
+
+		_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, username, "", nil, nil)
+		if apierr != nil {
+			return apierr
+		}
+	}
+
+	return nil
+}
+
+// deleteAfter returns the time after which the soft-deleted namespace is deleted, for the
+// API, or nil if it is not scheduled for deletion.
+func deleteAfter(namespace *namespaces.Namespace) *metav1.Time {
+	if namespace.DeleteAfter == nil {
+		return nil
+	}
+	when := metav1.NewTime(*namespace.DeleteAfter)
+	return &when
+}
+
+// Reap deletes the soft-deleted namespaces whose grace period is over. It checks every
+// interval, until the context is done.
+//
+// Note that the reaper runs in every replica of the API server, without coordination
+// between them. Replicas may delete the same namespace concurrently. A replica losing the
+// race fails on resources deleted by another replica already. Such failures are logged, and
+// the namespace is checked again with the next interval, when it is gone.
+func Reap(ctx context.Context, logger logr.Logger, interval time.Duration) {
+	reaper.Run(ctx, logger, interval, "reaping deleted namespaces", reapOnce)
+}
+
+// reapOnce is a helper for Reap. It deletes the namespaces due at the time of the call. The
+// failure to delete a namespace is logged, and does not prevent the deletion of the others.
+func reapOnce(ctx context.Context, logger logr.Logger) error {
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	namespaceList, err := namespaces.List(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, namespace := range namespaceList {
+		if namespace.DeleteAfter == nil || namespace.DeleteAfter.After(now) {
+			continue
+		}
+		if namespace.Protected {
+			logger.Info("skipping protected namespace scheduled for deletion", "namespace", namespace.Name)
+			continue
+		}
+
+		logger.Info("deleting namespace", "namespace", namespace.Name, "deleteAfter", namespace.DeleteAfter)
+
+		err := destroy(ctx, cluster, namespace.Name)
+		if err != nil {
+			logger.Error(err, "deleting namespace", "namespace", namespace.Name)
+		}
+	}
+
+	return nil
+}
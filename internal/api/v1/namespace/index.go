@@ -16,17 +16,25 @@ import (
 )
 
 // Index handles the API endpoint /namespaces (GET)
-// It returns a list of all Epinio-controlled namespaces
+// It returns a list of all Epinio-controlled namespaces, or of those whose labels match
+// the `selector` query parameter.
 // An Epinio namespace is nothing but a kubernetes namespace which has a
 // special Label (Look at the code to see which).
 func (oc Controller) Index(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
+	selector := c.Query("selector")
+
+	err := namespaces.ValidateSelector(selector)
+	if err != nil {
+		return apierror.NewBadRequest("invalid selector", err.Error())
+	}
+
 	cluster, err := kubernetes.GetCluster(ctx)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
 
-	namespaceList, err := namespaces.List(ctx, cluster)
+	namespaceList, err := namespaces.ListSelected(ctx, cluster, selector)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
@@ -52,6 +60,11 @@ func (oc Controller) Index(c *gin.Context) apierror.APIErrors {
 				Name:      namespace.Name,
 				CreatedAt: namespace.CreatedAt,
 			},
+			Labels:         namespace.Labels,
+			Description:    namespace.Description,
+			Owner:          namespace.Owner,
+			Protected:      namespace.Protected,
+			DeleteAfter:    deleteAfter(&namespace),
 			Apps:           appNames,
 			Configurations: configurationNames,
 		})
@@ -0,0 +1,40 @@
+package namespace
+
+import (
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/namespaces"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// QuotaUpdate handles the API endpoint PUT /namespaces/:namespace/quota
+// It replaces the quota of the namespace. An empty quota removes it.
+func (hc Controller) QuotaUpdate(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	var quota models.NamespaceQuota
+	err := c.BindJSON(&quota)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	err = namespaces.ValidateQuota(quota)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	cluster, apierr := namespaceCluster(ctx, namespace)
+	if apierr != nil {
+		return apierr
+	}
+
+	err = namespaces.QuotaSet(ctx, cluster, namespace, quota)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
@@ -3,6 +3,7 @@ package namespace
 import (
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/namespaces"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
@@ -45,13 +46,43 @@ func (hc Controller) Show(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	defaultEnvironment, defaultConfigurations, err := namespaces.Defaults(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	defaultBuilder, err := namespaces.DefaultBuilder(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	quota, err := namespaces.Quota(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	usage, err := application.NamespaceUsage(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
 	response.OKReturn(c, models.Namespace{
 		Meta: models.MetaLite{
 			Name:      namespace,
 			CreatedAt: space.CreatedAt,
 		},
-		Apps:           appNames,
-		Configurations: configurationNames,
+		Labels:                space.Labels,
+		Description:           space.Description,
+		Owner:                 space.Owner,
+		Protected:             space.Protected,
+		DeleteAfter:           deleteAfter(space),
+		Apps:                  appNames,
+		Configurations:        configurationNames,
+		DefaultEnvironment:    defaultEnvironment,
+		DefaultConfigurations: defaultConfigurations,
+		DefaultBuilder:        defaultBuilder,
+		Quota:                 quota,
+		Usage:                 &usage,
 	})
 	return nil
 }
@@ -0,0 +1,60 @@
+package namespace
+
+import (
+	"net/http"
+
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/namespaces"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Update handles the API endpoint PATCH /namespaces/:namespace
+// It changes the labels, description and owner of the namespace, and its protection
+// against deletion. The latter is for admins only.
+func (hc Controller) Update(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+
+	var updateRequest models.NamespaceUpdateRequest
+	err := c.BindJSON(&updateRequest)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	err = namespaces.ValidateLabels(updateRequest.Labels)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	if updateRequest.Protected != nil && requestctx.User(ctx).Role != "admin" {
+		return apierror.NewAPIError("only admins can change the protection of namespaces", "", http.StatusForbidden)
+	}
+
+	cluster, apierr := namespaceCluster(ctx, namespace)
+	if apierr != nil {
+		return apierr
+	}
+
+	err = namespaces.UpdateMetadata(ctx, cluster, namespace, namespaces.MetadataUpdate{
+		Labels:       updateRequest.Labels,
+		RemoveLabels: updateRequest.RemoveLabels,
+		Description:  updateRequest.Description,
+		Owner:        updateRequest.Owner,
+	})
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if updateRequest.Protected != nil {
+		err = namespaces.ProtectedSet(ctx, cluster, namespace, *updateRequest.Protected)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	response.OK(c)
+	return nil
+}
@@ -0,0 +1,487 @@
+// Package promote provides the functionality to copy applications, together with the
+// configurations and services they use, from one namespace to another. It is the backend
+// for the namespace clone and app promote API endpoints.
+package promote
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/deploy"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/domain"
+	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/internal/services"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// DefaultRoutePattern is the pattern used to rewrite the routes of apps copied to a
+// namespace, when no pattern is specified. See RewriteRoutes for the placeholders.
+const DefaultRoutePattern = "{host}-{namespace}.{domain}"
+
+// Options specify how to copy apps, configurations and services.
+type Options struct {
+	// RoutePattern is the pattern to rewrite the routes of new apps with.
+	RoutePattern string
+	// ConfigurationOverrides are values to set in the copied configurations, by
+	// configuration name and key.
+	ConfigurationOverrides map[string]map[string]string
+	// SkipServices disables the copying of service instances.
+	SkipServices bool
+}
+
+// Promoter copies apps, configurations and services from a source namespace to a target
+// namespace, remembering what it copied already, and what it did for the final report.
+type Promoter struct {
+	ctx      context.Context
+	cluster  *kubernetes.Cluster
+	username string
+	source   string
+	target   string
+	options  Options
+
+	serviceClient *services.ServiceClient
+	instances     map[string]string // helm chart name of source service -> service name
+	configured    map[string]struct{}
+	serviced      map[string]struct{}
+	result        models.PromoteResponse
+}
+
+// New returns a Promoter copying from the source to the target namespace. Both
+// namespaces are expected to exist.
+func New(ctx context.Context, cluster *kubernetes.Cluster, username, source, target string, options Options) (*Promoter, error) {
+	serviceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.RoutePattern == "" {
+		options.RoutePattern = DefaultRoutePattern
+	}
+
+	return &Promoter{
+		ctx:           ctx,
+		cluster:       cluster,
+		username:      username,
+		source:        source,
+		target:        target,
+		options:       options,
+		serviceClient: serviceClient,
+		configured:    map[string]struct{}{},
+		serviced:      map[string]struct{}{},
+		result: models.PromoteResponse{
+			Apps:            []string{},
+			Routes:          map[string][]string{},
+			Configurations:  []string{},
+			Services:        []string{},
+			PendingBindings: map[string][]string{},
+		},
+	}, nil
+}
+
+// Result returns the report of what was copied.
+func (p *Promoter) Result() models.PromoteResponse {
+	return p.result
+}
+
+// ValidateRoutePattern checks that the pattern uses only known placeholders.
+func ValidateRoutePattern(pattern string) error {
+	rewritten := rewriteRoute("host", pattern, "app", "namespace", "domain")
+	if strings.ContainsAny(rewritten, "{}") {
+		return fmt.Errorf("route pattern '%s' uses unknown placeholders, expected {host}, {app}, {namespace}, and {domain}", pattern)
+	}
+	return nil
+}
+
+// RewriteRoutes applies the pattern to the routes of an app copied to the namespace. The
+// placeholders `{host}` (the first label of the route's domain), `{app}`, `{namespace}` and
+// `{domain}` (the main domain of the installation) are replaced. The path of a route, if
+// any, is kept. Duplicate results are dropped.
+func RewriteRoutes(routes []string, pattern, app, namespace, domain string) []string {
+	if pattern == "" {
+		pattern = DefaultRoutePattern
+	}
+
+	result := []string{}
+	seen := map[string]struct{}{}
+	for _, route := range routes {
+		rewritten := rewriteRoute(route, pattern, app, namespace, domain)
+		if _, ok := seen[rewritten]; ok {
+			continue
+		}
+		seen[rewritten] = struct{}{}
+		result = append(result, rewritten)
+	}
+
+	return result
+}
+
+// rewriteRoute applies the pattern to a single route. See RewriteRoutes.
+func rewriteRoute(route, pattern, app, namespace, domain string) string {
+	host, path := route, ""
+	if i := strings.Index(route, "/"); i >= 0 {
+		host, path = route[:i], route[i:]
+	}
+	if i := strings.Index(host, "."); i >= 0 {
+		host = host[:i]
+	}
+
+	return strings.NewReplacer(
+		"{host}", host,
+		"{app}", app,
+		"{namespace}", namespace,
+		"{domain}", domain,
+	).Replace(pattern) + path
+}
+
+// Configuration copies the named configuration to the target namespace, if it is not
+// present there yet, and applies the overrides for it. An existing configuration keeps its
+// values, except for the overrides.
+func (p *Promoter) Configuration(name string) apierror.APIErrors {
+	if _, ok := p.configured[name]; ok {
+		return nil
+	}
+
+	_, err := configurations.Lookup(p.ctx, p.cluster, p.target, name)
+	if err != nil && err.Error() != "configuration not found" {
+		return apierror.InternalError(err)
+	}
+
+	if err != nil {
+		configuration, err := configurations.Lookup(p.ctx, p.cluster, p.source, name)
+		if err != nil {
+			if err.Error() == "configuration not found" {
+				return apierror.ConfigurationIsNotKnown(name)
+			}
+			return apierror.InternalError(err)
+		}
+
+		apierr := application.CheckNamespaceQuota(p.ctx, p.cluster, p.target, models.NamespaceUsage{
+			Configurations: 1,
+		})
+		if apierr != nil {
+			return apierr
+		}
+
+		secret, err := configuration.GetSecret(p.ctx)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		_, err = configurations.CreateConfiguration(p.ctx, p.cluster, name, p.target, p.username,
+			configuration.Type, secret.Data)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		p.result.Configurations = append(p.result.Configurations, name)
+	}
+
+	if overrides, ok := p.options.ConfigurationOverrides[name]; ok {
+		configuration, err := configurations.Lookup(p.ctx, p.cluster, p.target, name)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		err = configurations.UpdateConfiguration(p.ctx, p.cluster, configuration, p.username,
+			models.ConfigurationUpdateRequest{Set: overrides})
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	p.configured[name] = struct{}{}
+	return nil
+}
+
+// Service copies the named service instance to the target namespace, with its settings,
+// if it is not present there yet.
+func (p *Promoter) Service(name string) apierror.APIErrors {
+	if _, ok := p.serviced[name]; ok {
+		return nil
+	}
+	p.serviced[name] = struct{}{}
+
+	existing, err := p.serviceClient.Get(p.ctx, p.target, name)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if existing != nil {
+		return nil
+	}
+
+	service, err := p.serviceClient.Get(p.ctx, p.source, name)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if service == nil {
+		return apierror.ServiceIsNotKnown(name)
+	}
+
+	catalogServiceName, err := p.serviceClient.CatalogServiceOf(p.ctx, p.source, name)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogService, err := p.serviceClient.GetCatalogService(p.ctx, catalogServiceName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return apierror.NewBadRequest(
+				fmt.Sprintf("Catalog service %s of service %s not found", catalogServiceName, name))
+		}
+		return apierror.InternalError(err)
+	}
+
+	apierr := application.CheckNamespaceQuota(p.ctx, p.cluster, p.target, models.NamespaceUsage{
+		Services: 1,
+	})
+	if apierr != nil {
+		return apierr
+	}
+
+	err = p.serviceClient.Create(p.ctx, p.target, name, *catalogService)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if len(service.Settings) > 0 {
+		err = p.serviceClient.UpdateSettings(p.ctx, p.target, name,
+			models.ServiceUpdateRequest{Set: service.Settings})
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	p.result.Services = append(p.result.Services, name)
+	return nil
+}
+
+// App copies the app to the target namespace, or updates the app of the same name there.
+// It copies the configurations and services the app uses, as needed. New apps get their
+// routes rewritten with the route pattern, while existing apps keep theirs. Apps which
+// were built are deployed with the image of the source, without staging.
+func (p *Promoter) App(app models.App) apierror.APIErrors {
+	log := requestctx.Logger(p.ctx)
+	appRef := models.NewAppRef(app.Meta.Name, p.target)
+
+	// Configurations, translated to their names in the target namespace
+
+	bindings := []string{}
+	bindOptions := map[string]models.BindOptions{}
+	for _, name := range app.Configuration.Configurations {
+		targetName, pending, apierr := p.resolve(name)
+		if apierr != nil {
+			return apierr
+		}
+		if pending {
+			p.pending(app.Meta.Name, targetName)
+			continue
+		}
+
+		bindings = append(bindings, targetName)
+		if options, ok := app.Configuration.BindOptions[name]; ok {
+			bindOptions[targetName] = options
+		}
+	}
+
+	references := models.EnvVariableRefMap{}
+	for variable, source := range app.Configuration.EnvironmentFrom {
+		targetName, pending, apierr := p.resolve(source.Configuration)
+		if apierr != nil {
+			return apierr
+		}
+		if pending {
+			p.pending(app.Meta.Name, fmt.Sprintf("%s=%s/%s", variable, targetName, source.Key))
+			continue
+		}
+
+		references[variable] = models.EnvVariableSource{Configuration: targetName, Key: source.Key}
+	}
+
+	// The app itself
+
+	instances := int32(1)
+	if app.Configuration.Instances != nil {
+		instances = *app.Configuration.Instances
+	}
+
+	current, err := application.Lookup(p.ctx, p.cluster, p.target, app.Meta.Name)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if current == nil {
+		apierr := application.CheckNamespaceQuota(p.ctx, p.cluster, p.target, models.NamespaceUsage{
+			Apps:      1,
+			Instances: int64(instances),
+		})
+		if apierr != nil {
+			return apierr
+		}
+
+		mainDomain, err := domain.MainDomain(p.ctx)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		routes := RewriteRoutes(app.Configuration.Routes, p.options.RoutePattern,
+			app.Meta.Name, p.target, mainDomain)
+
+		err = application.Create(p.ctx, p.cluster, appRef, p.username, routes, app.Configuration.AppChart)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		p.result.Routes[app.Meta.Name] = routes
+	} else {
+		apierr := application.CheckNamespaceQuota(p.ctx, p.cluster, p.target, models.NamespaceUsage{
+			Instances: int64(instances - *current.Configuration.Instances),
+		})
+		if apierr != nil {
+			return apierr
+		}
+
+		p.result.Routes[app.Meta.Name] = current.Configuration.Routes
+	}
+
+	err = application.ScalingSet(p.ctx, p.cluster, appRef, instances)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = application.MetadataSet(p.ctx, p.cluster, appRef, app.Configuration.Labels,
+		app.Configuration.Annotations, nil)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = application.EnvironmentSet(p.ctx, p.cluster, appRef, app.Configuration.Environment, true)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = application.EnvironmentSetReferences(p.ctx, p.cluster, appRef, references, true)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = application.BoundConfigurationsSet(p.ctx, p.cluster, appRef, bindings, true)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if len(bindOptions) > 0 {
+		err = application.BoundConfigurationsSetOptions(p.ctx, p.cluster, appRef, bindOptions)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+	}
+
+	p.result.Apps = append(p.result.Apps, app.Meta.Name)
+
+	if app.ImageURL == "" {
+		log.Info("promoted app was never built, not deploying", "namespace", p.target, "app", app.Meta.Name)
+		return nil
+	}
+
+	applicationCR, err := application.Get(p.ctx, p.cluster, appRef)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = deploy.UpdateImageURL(p.ctx, p.cluster, applicationCR, app.ImageURL)
+	if err != nil {
+		return apierror.InternalError(err, "failed to set application's image url")
+	}
+
+	_, apierr := deploy.DeployApp(p.ctx, p.cluster, appRef, p.username, "", &app.Origin, nil)
+	if apierr != nil {
+		return apierr
+	}
+
+	return nil
+}
+
+// resolve copies the named configuration of the source namespace as needed, and returns
+// its name in the target namespace. The configurations of services are named after the
+// service and the namespace. They exist in the target only after the (copied) service is
+// deployed there. The result is flagged as pending if that has not happened yet.
+func (p *Promoter) resolve(name string) (string, bool, apierror.APIErrors) {
+	configuration, err := configurations.Lookup(p.ctx, p.cluster, p.source, name)
+	if err != nil {
+		if err.Error() == "configuration not found" {
+			return "", false, apierror.ConfigurationIsNotKnown(name)
+		}
+		return "", false, apierror.InternalError(err)
+	}
+
+	if configuration.Type != models.ConfigurationTypeService {
+		return name, false, p.Configuration(name)
+	}
+
+	secret, err := configuration.GetSecret(p.ctx)
+	if err != nil {
+		return "", false, apierror.InternalError(err)
+	}
+
+	serviceName, err := p.serviceOf(secret.Labels["app.kubernetes.io/instance"])
+	if err != nil {
+		return "", false, apierror.InternalError(err)
+	}
+	if serviceName == "" {
+		return name, true, nil
+	}
+
+	if !p.options.SkipServices {
+		apierr := p.Service(serviceName)
+		if apierr != nil {
+			return "", false, apierr
+		}
+	}
+
+	sourceInstance := names.ServiceHelmChartName(serviceName, p.source)
+	targetInstance := names.ServiceHelmChartName(serviceName, p.target)
+	targetName := targetInstance + strings.TrimPrefix(name, sourceInstance)
+
+	_, err = configurations.Lookup(p.ctx, p.cluster, p.target, targetName)
+	if err != nil {
+		if err.Error() == "configuration not found" {
+			return targetName, true, nil
+		}
+		return "", false, apierror.InternalError(err)
+	}
+
+	return targetName, false, nil
+}
+
+// serviceOf returns the name of the source service deployed as the named helm chart, or
+// the empty string if there is no such service.
+func (p *Promoter) serviceOf(instance string) (string, error) {
+	if p.instances == nil {
+		serviceList, err := p.serviceClient.ListInNamespace(p.ctx, p.source)
+		if err != nil {
+			return "", errors.Wrap(err, "listing services")
+		}
+
+		p.instances = map[string]string{}
+		for _, service := range serviceList {
+			p.instances[names.ServiceHelmChartName(service.Meta.Name, p.source)] = service.Meta.Name
+		}
+	}
+
+	return p.instances[instance], nil
+}
+
+// pending records a binding of the app which could not be made yet.
+func (p *Promoter) pending(app, binding string) {
+	p.result.PendingBindings[app] = append(p.result.PendingBindings[app], binding)
+	sort.Strings(p.result.PendingBindings[app])
+}
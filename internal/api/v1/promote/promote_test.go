@@ -0,0 +1,44 @@
+package promote_test
+
+import (
+	"github.com/epinio/epinio/internal/api/v1/promote"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Promotion unit tests", func() {
+	Describe("RewriteRoutes", func() {
+		It("uses the default pattern when none is given", func() {
+			routes := promote.RewriteRoutes([]string{"myapp.example.com"}, "", "myapp", "prod", "epinio.io")
+			Expect(routes).To(Equal([]string{"myapp-prod.epinio.io"}))
+		})
+
+		It("applies all placeholders", func() {
+			routes := promote.RewriteRoutes([]string{"web.example.com"}, "{app}.{host}.{namespace}.{domain}", "myapp", "prod", "epinio.io")
+			Expect(routes).To(Equal([]string{"myapp.web.prod.epinio.io"}))
+		})
+
+		It("keeps the path of a route", func() {
+			routes := promote.RewriteRoutes([]string{"myapp.example.com/api/v1"}, "", "myapp", "prod", "epinio.io")
+			Expect(routes).To(Equal([]string{"myapp-prod.epinio.io/api/v1"}))
+		})
+
+		It("drops duplicates", func() {
+			routes := promote.RewriteRoutes([]string{"myapp.example.com", "myapp.other.org"}, "", "myapp", "prod", "epinio.io")
+			Expect(routes).To(Equal([]string{"myapp-prod.epinio.io"}))
+		})
+	})
+
+	Describe("ValidateRoutePattern", func() {
+		It("accepts known placeholders", func() {
+			Expect(promote.ValidateRoutePattern("{app}-{host}.{namespace}.{domain}")).To(Succeed())
+			Expect(promote.ValidateRoutePattern("")).To(Succeed())
+		})
+
+		It("rejects unknown placeholders", func() {
+			err := promote.ValidateRoutePattern("{host}-{stage}.{domain}")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unknown placeholders"))
+		})
+	})
+})
@@ -11,9 +11,12 @@ import (
 	"github.com/epinio/epinio/helpers/routes"
 	"github.com/epinio/epinio/internal/api/v1/appchart"
 	"github.com/epinio/epinio/internal/api/v1/application"
+	"github.com/epinio/epinio/internal/api/v1/blob"
+	"github.com/epinio/epinio/internal/api/v1/builder"
 	"github.com/epinio/epinio/internal/api/v1/configuration"
 	"github.com/epinio/epinio/internal/api/v1/configurationbinding"
 	"github.com/epinio/epinio/internal/api/v1/env"
+	"github.com/epinio/epinio/internal/api/v1/image"
 	"github.com/epinio/epinio/internal/api/v1/namespace"
 	"github.com/epinio/epinio/internal/api/v1/response"
 	"github.com/epinio/epinio/internal/api/v1/service"
@@ -72,26 +75,60 @@ func put(path string, h gin.HandlerFunc) routes.Route {
 // AdminRoutes is the list of restricted routes, only accessible by admins
 var AdminRoutes map[string]struct{} = map[string]struct{}{}
 
+// AdminRouteNames is the list of named routes only accessible by admins. Contrary to the
+// plain paths in AdminRoutes these restrict a specific method on a path pattern, leaving
+// the other methods on the same path accessible.
+var AdminRouteNames = []string{
+	"ServiceCatalogCreate",
+	"ServiceCatalogUpdate",
+	"ServiceCatalogDelete",
+	"NamespaceQuotaUpdate",
+	"NamespaceBuilderUpdate",
+	"BuilderSet",
+	"BuilderDelete",
+	"Blobs",
+	"BlobsGC",
+	"Images",
+	"ImagesGC",
+}
+
+// AdminMethodRoutes is the set of restricted method and path pattern combinations,
+// only accessible by admins. It is filled by `Lemon` from `AdminRouteNames`. See
+// `AdminRouteKey` for the structure of the keys.
+var AdminMethodRoutes map[string]struct{} = map[string]struct{}{}
+
+// AdminRouteKey returns the key for the method and path pattern in AdminMethodRoutes
+func AdminRouteKey(method, pattern string) string {
+	return method + " " + pattern
+}
+
 var Routes = routes.NamedRoutes{
 	"Info":      get("/info", errorHandler(Info)),
 	"AuthToken": get("/authtoken", errorHandler(AuthToken)),
 
 	// app controller files see application/*.go
 
-	"AllApps":         get("/applications", errorHandler(application.Controller{}.FullIndex)),
-	"Apps":            get("/namespaces/:namespace/applications", errorHandler(application.Controller{}.Index)),
-	"AppCreate":       post("/namespaces/:namespace/applications", errorHandler(application.Controller{}.Create)),
-	"AppShow":         get("/namespaces/:namespace/applications/:app", errorHandler(application.Controller{}.Show)),
-	"StagingComplete": get("/namespaces/:namespace/staging/:stage_id/complete", errorHandler(application.Controller{}.Staged)), // See stage.go
-	"AppDelete":       delete("/namespaces/:namespace/applications/:app", errorHandler(application.Controller{}.Delete)),
-	"AppUpload":       post("/namespaces/:namespace/applications/:app/store", errorHandler(application.Controller{}.Upload)), // See upload.go
-	"AppImportGit":    post("/namespaces/:namespace/applications/:app/import-git", errorHandler(application.Controller{}.ImportGit)),
-	"AppStage":        post("/namespaces/:namespace/applications/:app/stage", errorHandler(application.Controller{}.Stage)), // See stage.go
-	"AppDeploy":       post("/namespaces/:namespace/applications/:app/deploy", errorHandler(application.Controller{}.Deploy)),
-	"AppRestart":      post("/namespaces/:namespace/applications/:app/restart", errorHandler(application.Controller{}.Restart)),
-	"AppUpdate":       patch("/namespaces/:namespace/applications/:app", errorHandler(application.Controller{}.Update)),
-	"AppRunning":      get("/namespaces/:namespace/applications/:app/running", errorHandler(application.Controller{}.Running)),
-	"AppPart":         get("/namespaces/:namespace/applications/:app/part/:part", errorHandler(application.Controller{}.GetPart)),
+	"AllApps":           get("/applications", errorHandler(application.Controller{}.FullIndex)),
+	"Apps":              get("/namespaces/:namespace/applications", errorHandler(application.Controller{}.Index)),
+	"AppCreate":         post("/namespaces/:namespace/applications", errorHandler(application.Controller{}.Create)),
+	"AppShow":           get("/namespaces/:namespace/applications/:app", errorHandler(application.Controller{}.Show)),
+	"StagingComplete":   get("/namespaces/:namespace/staging/:stage_id/complete", errorHandler(application.Controller{}.Staged)), // See stage.go
+	"AppDelete":         delete("/namespaces/:namespace/applications/:app", errorHandler(application.Controller{}.Delete)),
+	"AppUpload":         post("/namespaces/:namespace/applications/:app/store", errorHandler(application.Controller{}.Upload)), // See upload.go
+	"AppUploadStart":    post("/namespaces/:namespace/applications/:app/uploads", errorHandler(application.Controller{}.UploadStart)),
+	"AppUploadPart":     put("/namespaces/:namespace/applications/:app/uploads/:sha256/parts/:part", errorHandler(application.Controller{}.UploadPart)),
+	"AppUploadComplete": post("/namespaces/:namespace/applications/:app/uploads/:sha256/complete", errorHandler(application.Controller{}.UploadComplete)),
+	"AppCacheShow":      get("/namespaces/:namespace/applications/:app/cache", errorHandler(application.Controller{}.CacheShow)), // See cache.go
+	"AppCacheClear":     delete("/namespaces/:namespace/applications/:app/cache", errorHandler(application.Controller{}.CacheClear)),
+	"AppImportGit":      post("/namespaces/:namespace/applications/:app/import-git", errorHandler(application.Controller{}.ImportGit)),
+	"AppStage":          post("/namespaces/:namespace/applications/:app/stage", errorHandler(application.Controller{}.Stage)), // See stage.go
+	"AppDeploy":         post("/namespaces/:namespace/applications/:app/deploy", errorHandler(application.Controller{}.Deploy)),
+	"AppRestart":        post("/namespaces/:namespace/applications/:app/restart", errorHandler(application.Controller{}.Restart)),
+	"AppsRestart":       post("/namespaces/:namespace/restart", errorHandler(application.Controller{}.RestartSelected)),
+	"AppPromote":        post("/namespaces/:namespace/applications/:app/promote", errorHandler(application.Controller{}.Promote)), // See promote.go
+	"AppUpdate":         patch("/namespaces/:namespace/applications/:app", errorHandler(application.Controller{}.Update)),
+	"AppRunning":        get("/namespaces/:namespace/applications/:app/running", errorHandler(application.Controller{}.Running)),
+	"AppPart":           get("/namespaces/:namespace/applications/:app/part/:part", errorHandler(application.Controller{}.GetPart)),
 
 	// See env.go
 	"EnvList": get("/namespaces/:namespace/applications/:app/environment", errorHandler(env.Controller{}.Index)),
@@ -110,11 +147,32 @@ var Routes = routes.NamedRoutes{
 	"ConfigurationBindingDelete": delete("/namespaces/:namespace/applications/:app/configurationbindings/:configuration",
 		errorHandler(configurationbinding.Controller{}.Delete)),
 
-	// List, create, show and delete namespaces
+	// List, create, show, update and delete namespaces
 	"Namespaces":      get("/namespaces", errorHandler(namespace.Controller{}.Index)),
 	"NamespaceCreate": post("/namespaces", errorHandler(namespace.Controller{}.Create)),
 	"NamespaceDelete": delete("/namespaces/:namespace", errorHandler(namespace.Controller{}.Delete)),
 	"NamespaceShow":   get("/namespaces/:namespace", errorHandler(namespace.Controller{}.Show)),
+	"NamespaceUpdate": patch("/namespaces/:namespace", errorHandler(namespace.Controller{}.Update)),
+
+	// Preview of a namespace deletion, and undo of a soft deletion
+	"NamespaceDeletionPreview": get("/namespaces/:namespace/deletion", errorHandler(namespace.Controller{}.DeletionPreview)),
+	"NamespaceUndelete":        post("/namespaces/:namespace/undelete", errorHandler(namespace.Controller{}.Undelete)),
+
+	// Copy a namespace, with all its apps, configurations, and services
+	"NamespaceClone": post("/namespaces/:namespace/clone", errorHandler(namespace.Controller{}.Clone)),
+
+	// Quota of a namespace. Admin only, see AdminRouteNames.
+	"NamespaceQuotaUpdate": put("/namespaces/:namespace/quota", errorHandler(namespace.Controller{}.QuotaUpdate)),
+
+	// Default builder of the apps in a namespace. Admin only, see AdminRouteNames.
+	"NamespaceBuilderUpdate": put("/namespaces/:namespace/builder", errorHandler(namespace.Controller{}.BuilderUpdate)),
+
+	// Default environment and bindings of the apps in a namespace
+	"NamespaceEnvList":  get("/namespaces/:namespace/environment", errorHandler(namespace.Controller{}.EnvIndex)),
+	"NamespaceEnvSet":   post("/namespaces/:namespace/environment", errorHandler(namespace.Controller{}.EnvSet)),
+	"NamespaceEnvUnset": delete("/namespaces/:namespace/environment/:env", errorHandler(namespace.Controller{}.EnvUnset)),
+	"NamespaceBind":     post("/namespaces/:namespace/bindings", errorHandler(namespace.Controller{}.Bind)),
+	"NamespaceUnbind":   delete("/namespaces/:namespace/bindings/:configuration", errorHandler(namespace.Controller{}.Unbind)),
 
 	// Note, the second registration catches calls with an empty pattern!
 	"NamespacesMatch":  get("/namespacematches/:pattern", errorHandler(namespace.Controller{}.Match)),
@@ -131,16 +189,32 @@ var Routes = routes.NamedRoutes{
 	"ConfigurationUpdate":  patch("/namespaces/:namespace/configurations/:configuration", errorHandler(configuration.Controller{}.Update)),
 	"ConfigurationReplace": put("/namespaces/:namespace/configurations/:configuration", errorHandler(configuration.Controller{}.Replace)),
 
+	// Configuration revisions, see history.go
+	"ConfigurationHistory": get("/namespaces/:namespace/configurations/:configuration/history", errorHandler(configuration.Controller{}.History)),
+	"ConfigurationRevert": post("/namespaces/:namespace/configurations/:configuration/revisions/:revision/revert",
+		errorHandler(configuration.Controller{}.Revert)),
+
 	// Service Catalog
 	"ServiceCatalog":     get("/catalogservices", errorHandler(service.Controller{}.Catalog)),
 	"ServiceCatalogShow": get("/catalogservices/:catalogservice", errorHandler(service.Controller{}.CatalogShow)),
 
+	// Service Catalog management, see catalogchange.go. Admin only, see AdminRouteNames.
+	"ServiceCatalogCreate": post("/catalogservices", errorHandler(service.Controller{}.CatalogCreate)),
+	"ServiceCatalogUpdate": patch("/catalogservices/:catalogservice", errorHandler(service.Controller{}.CatalogUpdate)),
+	"ServiceCatalogDelete": delete("/catalogservices/:catalogservice", errorHandler(service.Controller{}.CatalogDelete)),
+
 	// Services
 	"AllServices":   get("/services", errorHandler(service.Controller{}.FullIndex)),
 	"ServiceCreate": post("/namespaces/:namespace/services", errorHandler(service.Controller{}.Create)),
 	"ServiceList":   get("/namespaces/:namespace/services", errorHandler(service.Controller{}.List)),
 	"ServiceShow":   get("/namespaces/:namespace/services/:service", errorHandler(service.Controller{}.Show)),
 	"ServiceDelete": delete("/namespaces/:namespace/services/:service", errorHandler(service.Controller{}.Delete)),
+	"ServiceUpdate": patch("/namespaces/:namespace/services/:service", errorHandler(service.Controller{}.Update)),
+
+	// Upgrade a service to the current chart version of its catalog service
+	"ServiceUpgrade": post(
+		"/namespaces/:namespace/services/:service/upgrade",
+		errorHandler(service.Controller{}.Upgrade)),
 
 	// Bind a service to/from applications
 	"ServiceBind": post(
@@ -152,11 +226,41 @@ var Routes = routes.NamedRoutes{
 		"/namespaces/:namespace/services/:service/unbind",
 		errorHandler(service.Controller{}.Unbind)),
 
+	// Backup and restore the data of a service, see backup.go
+	"ServiceBackup": post(
+		"/namespaces/:namespace/services/:service/backups",
+		errorHandler(service.Controller{}.Backup)),
+	"ServiceBackups": get(
+		"/namespaces/:namespace/services/:service/backups",
+		errorHandler(service.Controller{}.Backups)),
+	"ServiceRestore": post(
+		"/namespaces/:namespace/services/:service/backups/:backup/restore",
+		errorHandler(service.Controller{}.Restore)),
+	"ServiceJob": get(
+		"/namespaces/:namespace/services/:service/jobs/:job",
+		errorHandler(service.Controller{}.Job)),
+	"ServiceJobDelete": delete(
+		"/namespaces/:namespace/services/:service/jobs/:job",
+		errorHandler(service.Controller{}.JobDelete)),
+
 	// App charts
 	"ChartList":   get("/appcharts", errorHandler(appchart.Controller{}.Index)),
 	"ChartMatch":  get("/appchartsmatch/:pattern", errorHandler(appchart.Controller{}.Match)),
 	"ChartMatch0": get("/appchartsmatch", errorHandler(appchart.Controller{}.Match)),
 	"ChartShow":   get("/appcharts/:name", errorHandler(appchart.Controller{}.Show)),
+
+	// Approved builders. Changes are admin only, see AdminRouteNames.
+	"Builders":      get("/builders", errorHandler(builder.Controller{}.Index)),
+	"BuilderSet":    put("/builders/:builder", errorHandler(builder.Controller{}.Set)),
+	"BuilderDelete": delete("/builders/:builder", errorHandler(builder.Controller{}.Delete)),
+
+	// Blobs of application sources. Admin only, see AdminRouteNames.
+	"Blobs":   get("/blobs", errorHandler(blob.Controller{}.Index)),
+	"BlobsGC": post("/blobs/gc", errorHandler(blob.Controller{}.GC)),
+
+	// Stage images of the applications. Admin only, see AdminRouteNames.
+	"Images":   get("/images", errorHandler(image.Controller{}.Index)),
+	"ImagesGC": post("/images/gc", errorHandler(image.Controller{}.GC)),
 }
 
 var WsRoutes = routes.NamedRoutes{
@@ -172,6 +276,11 @@ func Lemon(router *gin.RouterGroup) {
 	for _, r := range Routes {
 		router.Handle(r.Method, r.Path, r.Handler)
 	}
+
+	for _, name := range AdminRouteNames {
+		r := Routes[name]
+		AdminMethodRoutes[AdminRouteKey(r.Method, router.BasePath()+r.Path)] = struct{}{}
+	}
 }
 
 // Spice extends the specified router with the methods and urls
@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/helpers/randstr"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/duration"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/s3manager"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Backup handles the API endpoint POST /namespaces/:namespace/services/:service/backups
+// It starts the backup job of the service instance's catalog service, which stores the
+// artifact in the Epinio S3 storage, and returns the description of the job. The progress
+// of the job, and the new backup, are reported by the Job endpoint.
+func (ctr Controller) Backup(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("Backup")
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if err := ctr.validateNamespace(ctx, cluster, namespace); err != nil {
+		return err
+	}
+
+	apiErr := ValidateService(ctx, cluster, logger, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogService, apiErr := catalogServiceOfInstance(ctx, kubeServiceClient, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+	if catalogService.BackupJob == "" {
+		return apierror.NewBadRequest(
+			fmt.Sprintf("Catalog service %s does not support backups", catalogService.Meta.Name))
+	}
+
+	manager, err := backupStorage(ctx, cluster)
+	if err != nil {
+		return apierror.InternalError(err, "creating an S3 manager")
+	}
+
+	backupID, err := randstr.Hex16()
+	if err != nil {
+		return apierror.InternalError(err, "failed to generate a backup id")
+	}
+	key := services.BackupKey(namespace, serviceName, backupID)
+
+	artifactURL, err := manager.PresignedUploadURL(ctx, key, duration.ToServiceBackup())
+	if err != nil {
+		return apierror.InternalError(err, "creating the upload url for the backup")
+	}
+
+	job, err := services.NewBackupJob(catalogService.BackupJob, services.BackupComponent,
+		namespace, serviceName, backupID, artifactURL.String())
+	if err != nil {
+		return apierror.InternalError(err, "creating the backup job")
+	}
+
+	logger.Info("backup service", "namespace", namespace, "service", serviceName, "backup", backupID)
+
+	err = kubeServiceClient.StartJob(ctx, job)
+	if err != nil {
+		return apierror.InternalError(err, "starting the backup job")
+	}
+
+	response.OKReturn(c, models.ServiceJob{
+		Name:     job.Name,
+		Kind:     models.ServiceJobBackup,
+		BackupID: backupID,
+		Status:   models.ServiceJobRunning,
+	})
+	return nil
+}
+
+// Backups handles the API endpoint GET /namespaces/:namespace/services/:service/backups
+// It returns the backups of the service instance, oldest first.
+func (ctr Controller) Backups(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if err := ctr.validateNamespace(ctx, cluster, namespace); err != nil {
+		return err
+	}
+
+	manager, err := backupStorage(ctx, cluster)
+	if err != nil {
+		return apierror.InternalError(err, "creating an S3 manager")
+	}
+
+	// Note: The backups of a deleted service instance are kept, and can still be listed.
+	backups, err := services.Backups(ctx, manager, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err, "listing the backups")
+	}
+
+	response.OKReturn(c, backups)
+	return nil
+}
+
+// Restore handles the API endpoint POST /namespaces/:namespace/services/:service/backups/:backup/restore
+// It starts the restore job of the service instance's catalog service, which retrieves
+// the specified backup from the Epinio S3 storage, and returns the description of the job.
+// The progress of the job is reported by the Job endpoint.
+func (ctr Controller) Restore(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("Restore")
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+	backupID := c.Param("backup")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if err := ctr.validateNamespace(ctx, cluster, namespace); err != nil {
+		return err
+	}
+
+	apiErr := ValidateService(ctx, cluster, logger, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogService, apiErr := catalogServiceOfInstance(ctx, kubeServiceClient, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+	if catalogService.RestoreJob == "" {
+		return apierror.NewBadRequest(
+			fmt.Sprintf("Catalog service %s does not support restoring backups", catalogService.Meta.Name))
+	}
+
+	manager, err := backupStorage(ctx, cluster)
+	if err != nil {
+		return apierror.InternalError(err, "creating an S3 manager")
+	}
+
+	_, apiErr = findBackup(ctx, manager, namespace, serviceName, backupID)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	artifactURL, err := manager.PresignedDownloadURL(ctx,
+		services.BackupKey(namespace, serviceName, backupID), duration.ToServiceBackup())
+	if err != nil {
+		return apierror.InternalError(err, "creating the download url for the backup")
+	}
+
+	job, err := services.NewBackupJob(catalogService.RestoreJob, services.RestoreComponent,
+		namespace, serviceName, backupID, artifactURL.String())
+	if err != nil {
+		return apierror.InternalError(err, "creating the restore job")
+	}
+
+	logger.Info("restore service", "namespace", namespace, "service", serviceName, "backup", backupID)
+
+	err = kubeServiceClient.StartJob(ctx, job)
+	if err != nil {
+		return apierror.InternalError(err, "starting the restore job")
+	}
+
+	response.OKReturn(c, models.ServiceJob{
+		Name:     job.Name,
+		Kind:     models.ServiceJobRestore,
+		BackupID: backupID,
+		Status:   models.ServiceJobRunning,
+	})
+	return nil
+}
+
+// Job handles the API endpoint GET /namespaces/:namespace/services/:service/jobs/:job
+// It returns the status of the backup or restore job of the service instance, for failed
+// jobs with the logs of their containers. Finished jobs are kept until they expire, or are
+// deleted with JobDelete.
+func (ctr Controller) Job(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("Job")
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+	jobName := c.Param("job")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if err := ctr.validateNamespace(ctx, cluster, namespace); err != nil {
+		return err
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	job, err := kubeServiceClient.JobStatus(ctx, namespace, serviceName, jobName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if job == nil {
+		return apierror.ServiceJobIsNotKnown(serviceName, jobName)
+	}
+
+	if job.Status == models.ServiceJobRunning {
+		response.OKReturn(c, job)
+		return nil
+	}
+
+	if job.Status == models.ServiceJobSucceeded && job.Kind == models.ServiceJobBackup {
+		manager, err := backupStorage(ctx, cluster)
+		if err != nil {
+			return apierror.InternalError(err, "creating an S3 manager")
+		}
+
+		backup, apiErr := findBackup(ctx, manager, namespace, serviceName, job.BackupID)
+		if apiErr != nil && apiErr.FirstStatus() != http.StatusNotFound {
+			return apiErr
+		}
+		if apiErr != nil {
+			job.Status = models.ServiceJobFailed
+			job.Message = "backup job did not store a backup"
+		}
+		job.Backup = backup
+	}
+
+	logger.Info("service job done", "namespace", namespace, "service", serviceName,
+		"job", job.Name, "status", job.Status)
+
+	response.OKReturn(c, job)
+	return nil
+}
+
+// JobDelete handles the API endpoint DELETE /namespaces/:namespace/services/:service/jobs/:job
+// It deletes the backup or restore job of the service instance, together with its logs.
+// Running jobs are stopped.
+func (ctr Controller) JobDelete(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+	jobName := c.Param("job")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if err := ctr.validateNamespace(ctx, cluster, namespace); err != nil {
+		return err
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	job, err := kubeServiceClient.JobStatus(ctx, namespace, serviceName, jobName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if job == nil {
+		return apierror.ServiceJobIsNotKnown(serviceName, jobName)
+	}
+
+	err = kubeServiceClient.DeleteJob(ctx, namespace, jobName)
+	if err != nil {
+		return apierror.InternalError(err, "deleting the job")
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// catalogServiceOfInstance returns the catalog service the named service instance was
+// created from. A catalog service removed since then is reported as bad request.
+func catalogServiceOfInstance(ctx context.Context, kubeServiceClient *services.ServiceClient,
+	namespace, serviceName string) (*models.CatalogService, apierror.APIErrors) {
+
+	catalogServiceName, err := kubeServiceClient.CatalogServiceOf(ctx, namespace, serviceName)
+	if err != nil {
+		return nil, apierror.InternalError(err)
+	}
+
+	catalogService, err := kubeServiceClient.GetCatalogService(ctx, catalogServiceName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, apierror.NewBadRequest(
+				fmt.Sprintf("Catalog service %s not found", catalogServiceName))
+		}
+		return nil, apierror.InternalError(err)
+	}
+
+	return catalogService, nil
+}
+
+// backupStorage returns the manager for the Epinio S3 storage holding the backups.
+func backupStorage(ctx context.Context, cluster *kubernetes.Cluster) (*s3manager.Manager, error) {
+	connectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster,
+		helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s3manager.New(connectionDetails)
+}
+
+// findBackup returns the description of the specified backup of the service instance.
+func findBackup(ctx context.Context, manager *s3manager.Manager,
+	namespace, serviceName, backupID string) (*models.ServiceBackup, apierror.APIErrors) {
+
+	backups, err := services.Backups(ctx, manager, namespace, serviceName)
+	if err != nil {
+		return nil, apierror.InternalError(err, "listing the backups")
+	}
+
+	for _, backup := range backups {
+		if backup.ID == backupID {
+			return &backup, nil
+		}
+	}
+
+	return nil, apierror.ServiceBackupIsNotKnown(serviceName, backupID)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/configurationbinding"
@@ -9,10 +10,12 @@ import (
 	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/cli/server/requestctx"
 	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/services"
 	"github.com/gin-gonic/gin"
 
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	v1 "k8s.io/api/core/v1"
 )
 
 // Bind handles the API endpoint /namespaces/:namespace/services/:service/bind (POST)
@@ -49,28 +52,69 @@ func (ctr Controller) Bind(c *gin.Context) apierror.APIErrors {
 		return apiErr
 	}
 
+	// Binding a service which is not fully up leaves the application crashing on
+	// missing or incomplete configurations. Refuse that.
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	service, err := kubeServiceClient.Get(ctx, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if service.Status != models.ServiceStatusDeployed {
+		return apierror.ServiceIsNotReady(serviceName, fmt.Sprintf("status %s, pods ready %d/%d %s",
+			service.Status, service.Details.PodsReady, service.Details.PodsTotal,
+			service.Details.LastError))
+	}
+
 	// A service has one or more associated secrets containing its attributes. Adding
-	// a specific set of labels turns these secrets into valid epinio
+	// a specific set of labels turns the selected secrets into valid epinio
 	// configurations. These configurations are then bound to the application.
 
 	logger.Info("looking for secrets to label")
 
-	configurationSecrets, err := configurations.LabelServiceSecrets(ctx, cluster, namespace, serviceName)
+	serviceSecrets, err := configurations.ServiceSecrets(ctx, cluster, namespace, serviceName)
 	if err != nil {
 		return apierror.InternalError(err)
 	}
 
-	logger.Info(fmt.Sprintf("configurationSecrets found %+v\n", configurationSecrets))
+	logger.Info(fmt.Sprintf("serviceSecrets found %+v\n", serviceSecrets))
+
+	configurationSecrets, apiErr := selectServiceSecrets(serviceSecrets, bindRequest.Secrets)
+	if apiErr != nil {
+		return apiErr
+	}
 
+	// Secrets turned into configurations by an earlier binding count against the quota
+	// already.
+	added := int64(0)
 	configurationNames := []string{}
 	for _, secret := range configurationSecrets {
+		if !configurations.IsConfiguration(secret) {
+			added++
+		}
 		configurationNames = append(configurationNames, secret.Name)
 	}
 
+	apiErr = application.CheckNamespaceQuota(ctx, cluster, namespace, models.NamespaceUsage{
+		Configurations: added,
+	})
+	if apiErr != nil {
+		return apiErr
+	}
+
+	err = configurations.LabelServiceSecrets(ctx, cluster, namespace, configurationSecrets)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
 	logger.Info("binding service configuration")
 
 	_, errors := configurationbinding.CreateConfigurationBinding(
-		ctx, cluster, namespace, *app, configurationNames,
+		ctx, cluster, namespace, *app, configurationNames, bindRequest.Secrets,
 	)
 
 	if errors != nil {
@@ -80,3 +124,45 @@ func (ctr Controller) Bind(c *gin.Context) apierror.APIErrors {
 	response.OK(c)
 	return nil
 }
+
+// selectServiceSecrets returns the service secrets to bind. Without a
+// selection these are all the secrets. Otherwise the selected secrets, which have to
+// exist, as do the keys chosen from them.
+func selectServiceSecrets(secrets []v1.Secret, selection map[string]models.BindOptions) ([]v1.Secret, apierror.APIErrors) {
+	if len(selection) == 0 {
+		return secrets, nil
+	}
+
+	names := []string{}
+
+	known := map[string]v1.Secret{}
+	for _, secret := range secrets {
+		known[secret.Name] = secret
+	}
+
+	for name, options := range selection {
+		secret, ok := known[name]
+		if !ok {
+			return nil, apierror.NewBadRequest(fmt.Sprintf("service has no secret '%s'", name))
+		}
+
+		for key, as := range options.Keys {
+			if _, ok := secret.Data[key]; !ok {
+				return nil, apierror.NewBadRequest(fmt.Sprintf("secret '%s' has no key '%s'", name, key))
+			}
+			if as == "" {
+				return nil, apierror.NewBadRequest(fmt.Sprintf("empty name for key '%s' of secret '%s'", key, name))
+			}
+		}
+
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	selected := []v1.Secret{}
+	for _, name := range names {
+		selected = append(selected, known[name])
+	}
+
+	return selected, nil
+}
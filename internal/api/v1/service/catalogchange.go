@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// CatalogCreate handles the API endpoint POST /catalogservices
+// It adds a service to the catalog. Restricted to admins.
+func (ctr Controller) CatalogCreate(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+
+	var catalogService models.CatalogService
+	err := c.BindJSON(&catalogService)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	if apiErr := validateCatalogService(&catalogService); apiErr != nil {
+		return apiErr
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	_, err = kubeServiceClient.GetCatalogService(ctx, catalogService.Meta.Name)
+	if err == nil {
+		return apierror.CatalogServiceAlreadyKnown(catalogService.Meta.Name)
+	}
+	if !k8serrors.IsNotFound(errors.Cause(err)) {
+		return apierror.InternalError(err)
+	}
+
+	err = kubeServiceClient.CreateCatalogService(ctx, catalogService)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.Created(c)
+	return nil
+}
+
+// CatalogUpdate handles the API endpoint PATCH /catalogservices/:catalogservice
+// It modifies the named catalog service. Restricted to admins.
+func (ctr Controller) CatalogUpdate(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	serviceName := c.Param("catalogservice")
+
+	var updateRequest models.ServiceCatalogUpdateRequest
+	err := c.BindJSON(&updateRequest)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogService, apiErr := lookupCatalogService(ctx, kubeServiceClient, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	// A change of the chart invalidates the version derived from the old chart, unless
+	// the request specifies a version as well.
+	if updateRequest.HelmChart != nil || updateRequest.HelmRepoURL != nil {
+		catalogService.ChartVersion = ""
+	}
+
+	applyCatalogServiceChanges(catalogService, updateRequest)
+
+	if apiErr := validateCatalogService(catalogService); apiErr != nil {
+		return apiErr
+	}
+
+	err = kubeServiceClient.UpdateCatalogService(ctx, *catalogService)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// CatalogDelete handles the API endpoint DELETE /catalogservices/:catalogservice
+// It removes the named service from the catalog. Restricted to admins. Services still
+// having instances cannot be removed.
+func (ctr Controller) CatalogDelete(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	serviceName := c.Param("catalogservice")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	_, apiErr := lookupCatalogService(ctx, kubeServiceClient, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	instances, err := kubeServiceClient.InstancesOf(ctx, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if len(instances) > 0 {
+		return apierror.NewBadRequest("service instances exist", strings.Join(instances, ","))
+	}
+
+	err = kubeServiceClient.DeleteCatalogService(ctx, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// lookupCatalogService returns the named catalog service, or an API error if it does not exist.
+func lookupCatalogService(ctx context.Context, kubeServiceClient *services.ServiceClient, serviceName string) (*models.CatalogService, apierror.APIErrors) {
+	catalogService, err := kubeServiceClient.GetCatalogService(ctx, serviceName)
+	if err != nil {
+		if k8serrors.IsNotFound(errors.Cause(err)) {
+			return nil, apierror.CatalogServiceIsNotKnown(serviceName)
+		}
+		return nil, apierror.InternalError(err)
+	}
+
+	return catalogService, nil
+}
+
+// applyCatalogServiceChanges transfers the specified changes into the catalog service.
+func applyCatalogServiceChanges(catalogService *models.CatalogService, changes models.ServiceCatalogUpdateRequest) {
+	set := func(field *string, value *string) {
+		if value != nil {
+			*field = *value
+		}
+	}
+
+	set(&catalogService.Description, changes.Description)
+	set(&catalogService.ShortDescription, changes.ShortDescription)
+	set(&catalogService.HelmChart, changes.HelmChart)
+	set(&catalogService.ChartVersion, changes.ChartVersion)
+	set(&catalogService.AppVersion, changes.AppVersion)
+	set(&catalogService.HelmRepo.URL, changes.HelmRepoURL)
+	set(&catalogService.Values, changes.Values)
+	set(&catalogService.ValuesSchema, changes.ValuesSchema)
+	set(&catalogService.BackupJob, changes.BackupJob)
+	set(&catalogService.RestoreJob, changes.RestoreJob)
+}
+
+// validateCatalogService checks the definition of a catalog service for completeness, and
+// that its chart can be found in the helm repository. Missing chart and app versions are
+// filled in from the chart found. This pins the catalog service to a specific chart
+// version, which service instances can later be upgraded from.
+func validateCatalogService(catalogService *models.CatalogService) apierror.APIErrors {
+	name := catalogService.Meta.Name
+	if name == "" {
+		return apierror.NewBadRequest("name of catalog service not found")
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return apierror.NewBadRequest(fmt.Sprintf("invalid catalog service name '%s'", name),
+			strings.Join(errs, ", "))
+	}
+	if catalogService.HelmChart == "" {
+		return apierror.NewBadRequest("helm chart of catalog service not found")
+	}
+	if catalogService.HelmRepo.URL == "" {
+		return apierror.NewBadRequest("helm repository of catalog service not found")
+	}
+
+	err := services.ValidateValues(catalogService.Values, nil, catalogService.ValuesSchema)
+	if err != nil {
+		return apierror.NewBadRequest("invalid values for catalog service", err.Error())
+	}
+
+	// Check the job templates with placeholder data. A template which cannot be rendered
+	// into a job would otherwise only be noticed when the first backup is attempted.
+	for component, jobTemplate := range map[string]string{
+		services.BackupComponent:  catalogService.BackupJob,
+		services.RestoreComponent: catalogService.RestoreJob,
+	} {
+		if jobTemplate == "" {
+			continue
+		}
+		_, err := services.NewBackupJob(jobTemplate, component, "namespace", "name", "id", "url")
+		if err != nil {
+			return apierror.NewBadRequest("invalid "+component+" job template", err.Error())
+		}
+	}
+
+	chart, err := helm.ResolveChart(catalogService.HelmRepo.URL, catalogService.HelmChart, catalogService.ChartVersion)
+	if err != nil {
+		return apierror.NewBadRequest("unable to resolve the helm chart", err.Error())
+	}
+
+	if catalogService.ChartVersion == "" {
+		catalogService.ChartVersion = chart.Version
+	}
+	if catalogService.AppVersion == "" {
+		catalogService.AppVersion = chart.AppVersion
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
 	"github.com/epinio/epinio/internal/services"
 	"github.com/gin-gonic/gin"
 
@@ -28,6 +29,13 @@ func (ctr Controller) Create(c *gin.Context) apierror.APIErrors {
 		return apierror.InternalError(err)
 	}
 
+	apierr := application.CheckNamespaceQuota(ctx, cluster, namespace, models.NamespaceUsage{
+		Services: 1,
+	})
+	if apierr != nil {
+		return apierr
+	}
+
 	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
 	if err != nil {
 		return apierror.InternalError(err)
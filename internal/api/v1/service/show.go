@@ -1,14 +1,22 @@
 package service
 
 import (
+	"sort"
+
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/configurations"
 	"github.com/epinio/epinio/internal/services"
 	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/gin-gonic/gin"
+	v1 "k8s.io/api/core/v1"
 )
 
+// Show handles the API endpoint GET /namespaces/:namespace/services/:service
+// It returns the details of the specified service instance, including the keys of the
+// configurations it provides. Their values are returned when the query parameter
+// `secrets` is `true`.
 func (ctr Controller) Show(c *gin.Context) apierror.APIErrors {
 	ctx := c.Request.Context()
 	namespace := c.Param("namespace")
@@ -37,11 +45,47 @@ func (ctr Controller) Show(c *gin.Context) apierror.APIErrors {
 		return apierror.ServiceIsNotKnown(serviceName)
 	}
 
+	secrets, err := configurations.ForService(ctx, cluster, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	// The values of the configurations are sensitive, and only returned on request.
+	withValues := c.Query("secrets") == "true"
+
 	resp := models.ServiceShowResponse{
-		Service: srv,
+		Service:        srv,
+		Configurations: serviceConfigurations(secrets, withValues),
 	}
 
 	response.OKReturn(c, resp)
 
 	return nil
 }
+
+// serviceConfigurations converts the configuration secrets of a service into their
+// descriptions, with or without the values.
+func serviceConfigurations(secrets []v1.Secret, withValues bool) []models.ServiceConfiguration {
+	result := []models.ServiceConfiguration{}
+	for _, secret := range secrets {
+		configuration := models.ServiceConfiguration{
+			Name: secret.Name,
+			Keys: []string{},
+		}
+		if withValues {
+			configuration.Values = map[string]string{}
+		}
+
+		for key, value := range secret.Data {
+			configuration.Keys = append(configuration.Keys, key)
+			if withValues {
+				configuration.Values[key] = string(value)
+			}
+		}
+		sort.Strings(configuration.Keys)
+
+		result = append(result, configuration)
+	}
+
+	return result
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/deploy"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/duration"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Update handles the API endpoint PATCH /namespaces/:namespace/services/:service
+// It modifies the helm values of the specified service instance.
+func (ctr Controller) Update(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("Update")
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	var updateRequest models.ServiceUpdateRequest
+	err := c.BindJSON(&updateRequest)
+	if err != nil {
+		return apierror.BadRequest(err)
+	}
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if err := ctr.validateNamespace(ctx, cluster, namespace); err != nil {
+		return err
+	}
+
+	apiErr := ValidateService(ctx, cluster, logger, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	apiErr = validateSettings(ctx, kubeServiceClient, namespace, serviceName, updateRequest)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	apiErr = changeService(ctx, cluster, logger, kubeServiceClient, namespace, serviceName,
+		func() error {
+			return kubeServiceClient.UpdateSettings(ctx, namespace, serviceName, updateRequest)
+		})
+	if apiErr != nil {
+		return apiErr
+	}
+
+	response.OK(c)
+	return nil
+}
+
+// validateSettings checks the settings of the service instance, as modified by the
+// request, against the values schema of its catalog service, if there is any.
+func validateSettings(
+	ctx context.Context,
+	kubeServiceClient *services.ServiceClient,
+	namespace, serviceName string,
+	changes models.ServiceUpdateRequest,
+) apierror.APIErrors {
+	service, err := kubeServiceClient.Get(ctx, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogServiceName, err := kubeServiceClient.CatalogServiceOf(ctx, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogService, err := kubeServiceClient.GetCatalogService(ctx, catalogServiceName)
+	if err != nil {
+		// Without catalog service there is no schema to validate against.
+		if k8serrors.IsNotFound(errors.Cause(err)) {
+			return nil
+		}
+		return apierror.InternalError(err)
+	}
+
+	settings := map[string]string{}
+	for key, value := range service.Settings {
+		settings[key] = value
+	}
+	for _, key := range changes.Remove {
+		delete(settings, key)
+	}
+	for key, value := range changes.Set {
+		settings[key] = value
+	}
+
+	err = services.ValidateValues(catalogService.Values, settings, catalogService.ValuesSchema)
+	if err != nil {
+		return apierror.NewBadRequest("invalid service settings", err.Error())
+	}
+
+	return nil
+}
+
+// changeService is the common core of the endpoints modifying a service instance. It
+// invokes the change function, and when the service is bound to applications it waits
+// for the helm controller to apply the change. Then it restarts the applications bound
+// to any of the service's configurations whose secret data was changed by that.
+func changeService(
+	ctx context.Context, cluster *kubernetes.Cluster, logger logr.Logger,
+	kubeServiceClient *services.ServiceClient,
+	namespace, serviceName string,
+	change func() error,
+) apierror.APIErrors {
+	logger.Info("looking for bound service configurations")
+
+	serviceConfigurations, err := configurations.ForService(ctx, cluster, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	boundApps := map[string][]string{}
+	oldData := map[string]map[string][]byte{}
+	for _, secret := range serviceConfigurations {
+		bound, err := application.BoundAppsNamesFor(ctx, cluster, namespace, secret.Name)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+		if len(bound) == 0 {
+			continue
+		}
+		boundApps[secret.Name] = bound
+		oldData[secret.Name] = secret.Data
+	}
+
+	revision, err := kubeServiceClient.ReleaseRevision(ctx, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	logger.Info("changing service", "revision", revision)
+
+	err = change()
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	// Without bound applications there is nothing to restart, and no need to wait for
+	// the helm controller.
+	if len(boundApps) == 0 {
+		return nil
+	}
+
+	logger.Info("waiting for service release")
+
+	err = kubeServiceClient.WaitForRelease(ctx, namespace, serviceName, revision, duration.ToServiceReady())
+	if err != nil {
+		return apierror.InternalError(errors.Wrap(err, "waiting for the service release"))
+	}
+
+	serviceConfigurations, err = configurations.ForService(ctx, cluster, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	appNames := []string{}
+	for _, secret := range serviceConfigurations {
+		bound, ok := boundApps[secret.Name]
+		if !ok || reflect.DeepEqual(oldData[secret.Name], secret.Data) {
+			continue
+		}
+		appNames = append(appNames, bound...)
+	}
+
+	appNames = helpers.UniqueStrings(appNames)
+
+	logger.Info("restarting bound applications", "apps", appNames)
+
+	username := requestctx.User(ctx).Username
+
+	for _, appName := range appNames {
+		app, err := application.Lookup(ctx, cluster, namespace, appName)
+		if err != nil {
+			return apierror.InternalError(err)
+		}
+
+		// Restart workload, if any. See the note in configuration/update.go about
+		// the nature of this restart.
+		if app != nil && app.Workload != nil {
+			nano := time.Now().UnixNano()
+			_, apierr := deploy.DeployApp(ctx, cluster, app.Meta, username, "", nil, &nano)
+			if apierr != nil {
+				return apierr
+			}
+		}
+	}
+
+	return nil
+}
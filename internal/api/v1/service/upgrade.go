@@ -0,0 +1,87 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/api/v1/response"
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/epinio/epinio/internal/services"
+	"github.com/gin-gonic/gin"
+
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Upgrade handles the API endpoint POST /namespaces/:namespace/services/:service/upgrade
+// It moves the service instance to the current chart version of its catalog service.
+func (ctr Controller) Upgrade(c *gin.Context) apierror.APIErrors {
+	ctx := c.Request.Context()
+	logger := requestctx.Logger(ctx).WithName("Upgrade")
+	namespace := c.Param("namespace")
+	serviceName := c.Param("service")
+
+	cluster, err := kubernetes.GetCluster(ctx)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	if err := ctr.validateNamespace(ctx, cluster, namespace); err != nil {
+		return err
+	}
+
+	apiErr := ValidateService(ctx, cluster, logger, namespace, serviceName)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	service, err := kubeServiceClient.Get(ctx, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if service == nil {
+		return apierror.ServiceIsNotKnown(serviceName)
+	}
+
+	catalogServiceName, err := kubeServiceClient.CatalogServiceOf(ctx, namespace, serviceName)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	catalogService, err := kubeServiceClient.GetCatalogService(ctx, catalogServiceName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return apierror.NewBadRequest(
+				fmt.Sprintf("Catalog service %s not found", catalogServiceName))
+		}
+		return apierror.InternalError(err)
+	}
+
+	resp := models.ServiceUpgradeResponse{
+		OldChartVersion: service.ChartVersion,
+		NewChartVersion: catalogService.ChartVersion,
+	}
+
+	if service.ChartVersion == catalogService.ChartVersion {
+		response.OKReturn(c, resp)
+		return nil
+	}
+
+	apiErr = changeService(ctx, cluster, logger, kubeServiceClient, namespace, serviceName,
+		func() error {
+			_, err := kubeServiceClient.Upgrade(ctx, namespace, serviceName, *catalogService)
+			return err
+		})
+	if apiErr != nil {
+		return apiErr
+	}
+
+	response.OKReturn(c, resp)
+	return nil
+}
@@ -68,7 +68,7 @@ func ValidateService(
 
 	logger.Info(fmt.Sprintf("service found %+v\n", service))
 	if srv.Info.Status != helmrelease.StatusDeployed {
-		return apierror.InternalError(err)
+		return apierror.ServiceIsNotReady(service, fmt.Sprintf("helm release %s", srv.Info.Status))
 	}
 
 	return nil
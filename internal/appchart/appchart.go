@@ -4,6 +4,7 @@ package appchart
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/helmchart"
@@ -13,6 +14,10 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+// FeaturesAnnotationKey is the annotation of app chart CRs listing, comma-separated, the
+// optional features the helm chart supports. See the AppChartFeature constants of the models.
+const FeaturesAnnotationKey = "application.epinio.io/chart-features"
+
 // List returns a slice of all known app chart CRs.
 func List(ctx context.Context, cluster *kubernetes.Cluster) (models.AppChartList, error) {
 	client, err := cluster.ClientAppChart()
@@ -104,6 +109,13 @@ func toChart(chart *unstructured.Unstructured) (*models.AppChart, error) {
 		return nil, errors.New("helm repo should be string")
 	}
 
+	features := []string{}
+	for _, feature := range strings.Split(chart.GetAnnotations()[FeaturesAnnotationKey], ",") {
+		if feature = strings.TrimSpace(feature); feature != "" {
+			features = append(features, feature)
+		}
+	}
+
 	createdAt := chart.GetCreationTimestamp()
 
 	return &models.AppChart{
@@ -115,5 +127,6 @@ func toChart(chart *unstructured.Unstructured) (*models.AppChart, error) {
 		ShortDescription: short,
 		HelmChart:        helmChart,
 		HelmRepo:         helmRepo,
+		Features:         features,
 	}, nil
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/epinio/epinio/internal/duration"
 	"github.com/epinio/epinio/internal/helm"
 	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/images"
 	"github.com/epinio/epinio/internal/namespaces"
 	"github.com/epinio/epinio/internal/s3manager"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
@@ -167,12 +168,21 @@ func Lookup(ctx context.Context, cluster *kubernetes.Cluster, namespace, appName
 // namespace. If no namespace is specified (empty string) then apps across all namespaces are
 // returned.
 func ListAppRefs(ctx context.Context, cluster *kubernetes.Cluster, namespace string) ([]models.AppRef, error) {
+	return listAppRefs(ctx, cluster, namespace, "")
+}
+
+// listAppRefs is a helper for ListAppRefs and ListSelected. It returns an app reference for
+// every application resource in the specified namespace which matches the label selector.
+// An empty selector matches all.
+func listAppRefs(ctx context.Context, cluster *kubernetes.Cluster, namespace, selector string) ([]models.AppRef, error) {
 	client, err := cluster.ClientApp()
 	if err != nil {
 		return nil, err
 	}
 
-	list, err := client.Namespace(namespace).List(ctx, metav1.ListOptions{})
+	list, err := client.Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -189,6 +199,13 @@ func ListAppRefs(ctx context.Context, cluster *kubernetes.Cluster, namespace str
 // List returns a list of all available apps in the specified namespace. If no namespace is
 // specified (empty string) then apps across all namespaces are returned.
 func List(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (models.AppList, error) {
+	return ListSelected(ctx, cluster, namespace, "")
+}
+
+// ListSelected returns a list of the apps in the specified namespace which match the label
+// selector. If no namespace is specified (empty string) then apps across all namespaces are
+// returned. An empty selector matches all apps.
+func ListSelected(ctx context.Context, cluster *kubernetes.Cluster, namespace, selector string) (models.AppList, error) {
 
 	// Verify namespace, if specified
 
@@ -202,9 +219,9 @@ func List(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (m
 		}
 	}
 
-	// Get references for all apps, deployed or not
+	// Get references for all matching apps, deployed or not
 
-	appRefs, err := ListAppRefs(ctx, cluster, namespace)
+	appRefs, err := listAppRefs(ctx, cluster, namespace, selector)
 	if err != nil {
 		return models.AppList{}, err
 	}
@@ -267,6 +284,13 @@ func Delete(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppR
 		return err
 	}
 
+	// delete the stage images. The registry may not allow this, which does not prevent
+	// the deletion of the application.
+	err = images.Cleanup(ctx, cluster, appRef)
+	if err != nil {
+		log.Error(err, "deleting the images of the application", "app", appRef)
+	}
+
 	err = cluster.WaitForPodBySelectorMissing(ctx,
 		appRef.Namespace,
 		fmt.Sprintf("app.kubernetes.io/name=%s", appRef.Name),
@@ -284,6 +308,40 @@ func deleteStagePVC(ctx context.Context, cluster *kubernetes.Cluster, appRef mod
 		PersistentVolumeClaims(helmchart.Namespace()).Delete(ctx, appRef.MakePVCName(), metav1.DeleteOptions{})
 }
 
+// StagingResources returns the S3 blobs holding the sources staged for the named
+// application, and the name of the PVC holding its sources and build cache, or an empty
+// string if there is none.
+func StagingResources(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) ([]string, string, error) {
+	jobs, err := cluster.ListJobs(ctx, helmchart.Namespace(),
+		fmt.Sprintf("app.kubernetes.io/name=%s,app.kubernetes.io/part-of=%s",
+			appRef.Name, appRef.Namespace))
+	if err != nil {
+		return nil, "", err
+	}
+
+	blobs := []string{}
+	seen := map[string]struct{}{}
+	for _, job := range jobs.Items {
+		blob := job.Labels[models.EpinioStageBlobUIDLabel]
+		if _, ok := seen[blob]; ok || blob == "" {
+			continue
+		}
+		seen[blob] = struct{}{}
+		blobs = append(blobs, blob)
+	}
+
+	pvc := appRef.MakePVCName()
+	_, err = cluster.Kubectl.CoreV1().
+		PersistentVolumeClaims(helmchart.Namespace()).Get(ctx, pvc, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		pvc = ""
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	return blobs, pvc, nil
+}
+
 // AppChart returns the app chart (to be) used for application deployment, if one exists. It returns
 // an empty string otherwise. The information is pulled out of the app resource itself,
 // saved there by the deploy endpoint.
@@ -474,6 +532,11 @@ func fetch(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) er
 		return errors.Wrap(err, "finding env")
 	}
 
+	environmentFrom, err := EnvironmentReferences(ctx, cluster, app.Meta)
+	if err != nil {
+		return errors.Wrap(err, "finding env references")
+	}
+
 	instances, err := Scaling(ctx, cluster, app.Meta)
 	if err != nil {
 		return errors.Wrap(err, "finding scaling")
@@ -484,6 +547,11 @@ func fetch(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) er
 		return errors.Wrap(err, "finding configurations")
 	}
 
+	bindOptions, err := BoundConfigurationOptions(ctx, cluster, app.Meta)
+	if err != nil {
+		return errors.Wrap(err, "finding configuration binding options")
+	}
+
 	chartName, err := AppChart(applicationCR)
 	if err != nil {
 		return errors.Wrap(err, "finding app chart")
@@ -503,9 +571,21 @@ func fetch(ctx context.Context, cluster *kubernetes.Cluster, app *models.App) er
 
 	app.Configuration.Instances = &instances
 	app.Configuration.Configurations = configurations
+	if len(bindOptions) > 0 {
+		app.Configuration.BindOptions = bindOptions
+	}
 	app.Configuration.Environment = environment
+	if len(environmentFrom) > 0 {
+		app.Configuration.EnvironmentFrom = environmentFrom
+	}
 	app.Configuration.Routes = desiredRoutes
 	app.Configuration.AppChart = chartName
+	if labels := UserLabels(applicationCR); len(labels) > 0 {
+		app.Configuration.Labels = labels
+	}
+	if annotations := UserAnnotations(applicationCR); len(annotations) > 0 {
+		app.Configuration.Annotations = annotations
+	}
 	app.Origin = origin
 	app.StageID = stageID
 	app.ImageURL = imageURL
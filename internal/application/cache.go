@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CacheLastUsedAnnotationKey is the annotation of the build cache PVC holding the
+	// time of the last staging using it, in RFC3339 format.
+	CacheLastUsedAnnotationKey = "epinio.suse.org/cache-last-used"
+	// DefaultCacheSize is the size of new build caches, unless configured otherwise
+	// with `staging-cache-size`.
+	DefaultCacheSize = "1Gi"
+)
+
+// CacheSettings returns the size and storage class of new build caches, as configured
+// for the server. An empty storage class means the cluster default.
+func CacheSettings() (resource.Quantity, string, error) {
+	size := viper.GetString("staging-cache-size")
+	if size == "" {
+		size = DefaultCacheSize
+	}
+
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return quantity, "", errors.Wrapf(err, "bad staging cache size '%s'", size)
+	}
+
+	return quantity, viper.GetString("staging-cache-storage-class"), nil
+}
+
+// Cache returns the build cache of the application, or nil if it has none.
+func Cache(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (*models.AppCache, error) {
+	pvc, err := cluster.Kubectl.CoreV1().PersistentVolumeClaims(helmchart.Namespace()).
+		Get(ctx, appRef.MakePVCName(), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return cacheFromPVC(pvc), nil
+}
+
+// CacheClear removes the build cache of the application. The next staging starts with an
+// empty cache. Clearing a missing cache is a no-op.
+func CacheClear(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) error {
+	err := deleteStagePVC(ctx, cluster, appRef)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// cacheFromPVC returns the description of the build cache held by the PVC. The capacity is
+// the provisioned one, falling back to the requested size while the PVC is pending.
+func cacheFromPVC(pvc *corev1.PersistentVolumeClaim) *models.AppCache {
+	cache := &models.AppCache{
+		CreatedAt: pvc.CreationTimestamp,
+	}
+
+	if size, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		cache.Capacity = size.String()
+	} else if size, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		cache.Capacity = size.String()
+	}
+
+	if pvc.Spec.StorageClassName != nil {
+		cache.StorageClass = *pvc.Spec.StorageClassName
+	}
+
+	if lastUsed, err := time.Parse(time.RFC3339, pvc.Annotations[CacheLastUsedAnnotationKey]); err == nil {
+		cache.LastUsed = metav1.NewTime(lastUsed)
+	}
+
+	return cache
+}
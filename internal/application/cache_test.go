@@ -0,0 +1,85 @@
+package application
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build cache", func() {
+	Describe("CacheSettings", func() {
+		AfterEach(func() {
+			viper.Set("staging-cache-size", "")
+			viper.Set("staging-cache-storage-class", "")
+		})
+
+		It("defaults to 1Gi of the default storage class", func() {
+			size, storageClass, err := CacheSettings()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(size.String()).To(Equal("1Gi"))
+			Expect(storageClass).To(BeEmpty())
+		})
+
+		It("uses the configured size and storage class", func() {
+			viper.Set("staging-cache-size", "5Gi")
+			viper.Set("staging-cache-storage-class", "fast")
+
+			size, storageClass, err := CacheSettings()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(size.String()).To(Equal("5Gi"))
+			Expect(storageClass).To(Equal("fast"))
+		})
+
+		It("rejects bad sizes", func() {
+			viper.Set("staging-cache-size", "lots")
+
+			_, _, err := CacheSettings()
+			Expect(err).To(MatchError(ContainSubstring("bad staging cache size 'lots'")))
+		})
+	})
+
+	Describe("cacheFromPVC", func() {
+		storageClass := "fast"
+		lastUsed := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+		pvc := func() *corev1.PersistentVolumeClaim {
+			return &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						CacheLastUsedAnnotationKey: lastUsed.Format(time.RFC3339),
+					},
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: &storageClass,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			}
+		}
+
+		It("describes a pending cache by its requested size", func() {
+			cache := cacheFromPVC(pvc())
+			Expect(cache.Capacity).To(Equal("1Gi"))
+			Expect(cache.StorageClass).To(Equal("fast"))
+			Expect(cache.LastUsed.Time.Equal(lastUsed)).To(BeTrue())
+		})
+
+		It("describes a bound cache by its capacity", func() {
+			bound := pvc()
+			bound.Status.Capacity = corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("2Gi"),
+			}
+
+			Expect(cacheFromPVC(bound).Capacity).To(Equal("2Gi"))
+		})
+	})
+})
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/configurations"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
@@ -197,17 +199,68 @@ func BoundConfigurationNames(ctx context.Context, cluster *kubernetes.Cluster, a
 	return result, nil
 }
 
+// BoundConfigurationOptions returns the binding options of the configurations bound to
+// the application, for the configurations having options. The others use the default.
+func BoundConfigurationOptions(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (map[string]models.BindOptions, error) {
+	svcSecret, err := svcLoad(ctx, cluster, appRef)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]models.BindOptions{}
+	for name, value := range svcSecret.Data {
+		if len(value) == 0 {
+			continue
+		}
+
+		var options models.BindOptions
+		if err := json.Unmarshal(value, &options); err != nil {
+			return nil, errors.Wrapf(err, "decoding binding options of configuration %s", name)
+		}
+		result[name] = options
+	}
+
+	return result, nil
+}
+
 // BoundConfigurationsSet replaces or adds the specified configuration names to the named application.
 // When the function returns the configuration set will be extended.
-// Adding a known configuration is a no-op.
+// Adding a known configuration is a no-op. Replacement keeps the binding options of the
+// configurations which stay bound.
 func BoundConfigurationsSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, configurationNames []string, replace bool) error {
 	return svcUpdate(ctx, cluster, appRef, func(svcSecret *v1.Secret) {
+		old := svcSecret.Data
+
 		// Replacement is adding to a clear structure
 		if replace {
 			svcSecret.Data = make(map[string][]byte)
 		}
 		for _, configurationName := range configurationNames {
-			svcSecret.Data[configurationName] = nil
+			svcSecret.Data[configurationName] = old[configurationName]
+		}
+	})
+}
+
+// BoundConfigurationsSetOptions binds the specified configurations to the named
+// application, with the given options. Default options are stored as absent.
+func BoundConfigurationsSetOptions(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, options map[string]models.BindOptions) error {
+	encoded := map[string][]byte{}
+	for name, option := range options {
+		if option.IsDefault() {
+			encoded[name] = nil
+			continue
+		}
+
+		value, err := json.Marshal(option)
+		if err != nil {
+			return errors.Wrapf(err, "encoding binding options of configuration %s", name)
+		}
+		encoded[name] = value
+	}
+
+	return svcUpdate(ctx, cluster, appRef, func(svcSecret *v1.Secret) {
+		for name, value := range encoded {
+			svcSecret.Data[name] = value
 		}
 	})
 }
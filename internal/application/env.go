@@ -2,14 +2,25 @@ package application
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/configurations"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 )
 
+// EnvReferencesAnnotationKey is the annotation of the application's environment secret
+// holding the variables referencing configuration keys, as JSON. Only the references are
+// stored, never the values.
+const EnvReferencesAnnotationKey = "epinio.suse.org/env-references"
+
 // EnvironmentNames returns the names of all environment variables which are set on the named application by users.
 // It does not return values.
 func EnvironmentNames(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) ([]string, error) {
@@ -18,15 +29,24 @@ func EnvironmentNames(ctx context.Context, cluster *kubernetes.Cluster, appRef m
 		return nil, err
 	}
 
+	references, err := envReferences(evSecret)
+	if err != nil {
+		return nil, err
+	}
+
 	result := []string{}
 	for name := range evSecret.Data {
 		result = append(result, name)
 	}
+	for name := range references {
+		result = append(result, name)
+	}
 
 	return result, nil
 }
 
-// Environment returns the environment variables and their values which are set on the named application by users
+// Environment returns the environment variables and their values which are set on the named application by users.
+// Variables referencing configuration keys are not included, see EnvironmentReferences.
 func Environment(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (models.EnvVariableMap, error) {
 	evSecret, err := envLoad(ctx, cluster, appRef)
 	if err != nil {
@@ -41,19 +61,49 @@ func Environment(ctx context.Context, cluster *kubernetes.Cluster, appRef models
 	return result, nil
 }
 
+// EnvironmentReferences returns the environment variables of the named application which
+// reference configuration keys, and these references.
+func EnvironmentReferences(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) (models.EnvVariableRefMap, error) {
+	evSecret, err := envLoad(ctx, cluster, appRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return envReferences(evSecret)
+}
+
 // EnvironmentSet adds or modifies the specified environment variable
 // for the named application. When the function returns the variable
 // will have the specified value. If the application is active the
 // workload is restarted to update it to the new settings. The
 // function will __not__ wait on this to complete.
+// A reference to a configuration key of the same name is removed.
 func EnvironmentSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, assignments models.EnvVariableMap, replace bool) error {
-	return envUpdate(ctx, cluster, appRef, func(evSecret *v1.Secret) {
+	return envUpdate(ctx, cluster, appRef, func(evSecret *v1.Secret, references models.EnvVariableRefMap) {
 		// Replacement is adding to a clear structure
 		if replace {
 			evSecret.Data = make(map[string][]byte)
 		}
 		for name, value := range assignments {
 			evSecret.Data[name] = []byte(value)
+			delete(references, name)
+		}
+	})
+}
+
+// EnvironmentSetReferences adds or modifies the specified environment variables of the
+// named application to reference configuration keys. Plain variables of the same name
+// are removed. Replacement affects only the references.
+func EnvironmentSetReferences(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, assignments models.EnvVariableRefMap, replace bool) error {
+	return envUpdate(ctx, cluster, appRef, func(evSecret *v1.Secret, references models.EnvVariableRefMap) {
+		if replace {
+			for name := range references {
+				delete(references, name)
+			}
+		}
+		for name, source := range assignments {
+			references[name] = source
+			delete(evSecret.Data, name)
 		}
 	})
 }
@@ -64,17 +114,175 @@ func EnvironmentSet(ctx context.Context, cluster *kubernetes.Cluster, appRef mod
 // update it to the new settings. The function will __not__ wait on
 // this to complete.
 func EnvironmentUnset(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, varName string) error {
-	return envUpdate(ctx, cluster, appRef, func(evSecret *v1.Secret) {
+	return envUpdate(ctx, cluster, appRef, func(evSecret *v1.Secret, references models.EnvVariableRefMap) {
 		delete(evSecret.Data, varName)
+		delete(references, varName)
+	})
+}
+
+// EnvironmentUnsetReferencesTo removes the environment variables of the named application
+// which reference keys of the named configuration.
+func EnvironmentUnsetReferencesTo(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef, configurationName string) error {
+	return envUpdate(ctx, cluster, appRef, func(evSecret *v1.Secret, references models.EnvVariableRefMap) {
+		for name, source := range references {
+			if source.Configuration == configurationName {
+				delete(references, name)
+			}
+		}
 	})
 }
 
+// ReferencingAppsNamesFor returns the names of the applications in the namespace which
+// have environment variables referencing keys of the named configuration.
+func ReferencingAppsNamesFor(ctx context.Context, cluster *kubernetes.Cluster, namespace, configurationName string) ([]string, error) {
+	result := []string{}
+
+	// locate environments managed by epinio applications
+	selector := EpinioApplicationAreaLabel + "=environment"
+	selector += ",app.kubernetes.io/component=application"
+	selector += ",app.kubernetes.io/managed-by=epinio"
+
+	appEnvironments, err := cluster.Kubectl.CoreV1().Secrets(namespace).List(ctx,
+		metav1.ListOptions{
+			LabelSelector: selector,
+		})
+	if err != nil {
+		return result, err
+	}
+
+	for i := range appEnvironments.Items {
+		references, err := envReferences(&appEnvironments.Items[i])
+		if err != nil {
+			return result, err
+		}
+		for _, source := range references {
+			if source.Configuration == configurationName {
+				appName := appEnvironments.Items[i].ObjectMeta.Labels["app.kubernetes.io/name"]
+				result = append(result, appName)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// ResolveEnvironmentReferences returns the current values of the configuration keys
+// referenced by the variables.
+func ResolveEnvironmentReferences(ctx context.Context, cluster *kubernetes.Cluster, namespace string, references models.EnvVariableRefMap) (models.EnvVariableMap, error) {
+	result := models.EnvVariableMap{}
+
+	for name, source := range references {
+		secret, err := referencedSecret(ctx, cluster, namespace, name, source)
+		if err != nil {
+			return nil, err
+		}
+		result[name] = string(secret.Data[source.Key])
+	}
+
+	return result, nil
+}
+
+// CheckEnvironmentReferences returns a bad request if any of the referenced configuration
+// keys does not exist.
+func CheckEnvironmentReferences(ctx context.Context, cluster *kubernetes.Cluster, namespace string, references models.EnvVariableRefMap) apierror.APIErrors {
+	_, err := ResolveEnvironmentReferences(ctx, cluster, namespace, references)
+	if err != nil {
+		if _, ok := err.(UnknownReferenceError); ok {
+			return apierror.BadRequest(err)
+		}
+		return apierror.InternalError(err)
+	}
+	return nil
+}
+
+// EnvironmentReferencesToEnvArray returns the environment variables for the references,
+// sorted by name. The variables reference the configuration secrets, their values are
+// not copied.
+func EnvironmentReferencesToEnvArray(references models.EnvVariableRefMap) []v1.EnvVar {
+	names := []string{}
+	for name := range references {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	env := []v1.EnvVar{}
+	for _, name := range names {
+		source := references[name]
+		env = append(env, v1.EnvVar{
+			Name: name,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: source.Configuration,
+					},
+					Key: source.Key,
+				},
+			},
+		})
+	}
+
+	return env
+}
+
+// UnknownReferenceError is returned when an environment variable references a
+// configuration or key which does not exist.
+type UnknownReferenceError struct {
+	Variable string
+	Source   models.EnvVariableSource
+}
+
+func (e UnknownReferenceError) Error() string {
+	return fmt.Sprintf("environment variable %s references unknown configuration key %s",
+		e.Variable, e.Source.String())
+}
+
+// referencedSecret returns the secret of the configuration referenced by the variable,
+// after checking that it has the referenced key.
+func referencedSecret(ctx context.Context, cluster *kubernetes.Cluster, namespace, name string, source models.EnvVariableSource) (*v1.Secret, error) {
+	configuration, err := configurations.Lookup(ctx, cluster, namespace, source.Configuration)
+	if err != nil {
+		if err.Error() == "configuration not found" {
+			return nil, UnknownReferenceError{Variable: name, Source: source}
+		}
+		return nil, err
+	}
+
+	secret, err := configuration.GetSecret(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := secret.Data[source.Key]; !ok {
+		return nil, UnknownReferenceError{Variable: name, Source: source}
+	}
+
+	return secret, nil
+}
+
+// envReferences decodes the references stored in the environment secret.
+func envReferences(evSecret *v1.Secret) (models.EnvVariableRefMap, error) {
+	references := models.EnvVariableRefMap{}
+
+	encoded, ok := evSecret.Annotations[EnvReferencesAnnotationKey]
+	if !ok || encoded == "" {
+		return references, nil
+	}
+
+	err := json.Unmarshal([]byte(encoded), &references)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding environment references")
+	}
+
+	return references, nil
+}
+
 // envUpdate is the helper for the public function encapsulating the
 // read/modify/write cycle necessary to update the application's kube
 // resource holding the application's environment, and the logic to
 // restart the workload so that it may gain the changed settings.
+// The modifier gets the references to configuration keys in decoded form.
 func envUpdate(ctx context.Context, cluster *kubernetes.Cluster,
-	appRef models.AppRef, modifyEnvironment func(*v1.Secret)) error {
+	appRef models.AppRef, modifyEnvironment func(*v1.Secret, models.EnvVariableRefMap)) error {
 
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		evSecret, err := envLoad(ctx, cluster, appRef)
@@ -86,7 +294,25 @@ func envUpdate(ctx context.Context, cluster *kubernetes.Cluster,
 			evSecret.Data = make(map[string][]byte)
 		}
 
-		modifyEnvironment(evSecret)
+		references, err := envReferences(evSecret)
+		if err != nil {
+			return err
+		}
+
+		modifyEnvironment(evSecret, references)
+
+		if len(references) > 0 {
+			encoded, err := json.Marshal(references)
+			if err != nil {
+				return errors.Wrap(err, "encoding environment references")
+			}
+			if evSecret.Annotations == nil {
+				evSecret.Annotations = map[string]string{}
+			}
+			evSecret.Annotations[EnvReferencesAnnotationKey] = string(encoded)
+		} else {
+			delete(evSecret.Annotations, EnvReferencesAnnotationKey)
+		}
 
 		_, err = cluster.Kubectl.CoreV1().Secrets(appRef.Namespace).Update(
 			ctx, evSecret, metav1.UpdateOptions{})
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"encoding/json"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Environment references", func() {
+	It("decodes the references stored in the environment secret", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					EnvReferencesAnnotationKey: `{"DB_URL":{"configuration":"db","key":"url"}}`,
+				},
+			},
+		}
+
+		references, err := envReferences(secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(references).To(Equal(models.EnvVariableRefMap{
+			"DB_URL": {Configuration: "db", Key: "url"},
+		}))
+
+		references, err = envReferences(&corev1.Secret{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(references).To(BeEmpty())
+	})
+
+	It("converts references into variables referencing the configuration secrets", func() {
+		env := EnvironmentReferencesToEnvArray(models.EnvVariableRefMap{
+			"B": {Configuration: "db", Key: "password"},
+			"A": {Configuration: "db", Key: "url"},
+		})
+
+		Expect(env).To(HaveLen(2))
+		Expect(env[0].Name).To(Equal("A"))
+		Expect(env[0].Value).To(BeEmpty())
+		Expect(env[0].ValueFrom.SecretKeyRef.Name).To(Equal("db"))
+		Expect(env[0].ValueFrom.SecretKeyRef.Key).To(Equal("url"))
+		Expect(env[1].Name).To(Equal("B"))
+	})
+
+	It("encodes plain values and references into a single JSON object", func() {
+		definitions := models.EnvVariableDefinitions{
+			Values:     models.EnvVariableMap{"PLAIN": "value"},
+			References: models.EnvVariableRefMap{"REF": {Configuration: "db", Key: "url"}},
+		}
+
+		encoded, err := json.Marshal(definitions)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(encoded)).To(MatchJSON(
+			`{"PLAIN":"value","REF":{"value_from":{"configuration":"db","key":"url"}}}`))
+
+		var decoded models.EnvVariableDefinitions
+		err = json.Unmarshal(encoded, &decoded)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decoded).To(Equal(definitions))
+
+		definitions.Revealed = models.EnvVariableMap{"REF": "postgres://"}
+		list := definitions.List()
+		Expect(list).To(HaveLen(2))
+		Expect(list[1].Name).To(Equal("REF"))
+		Expect(list[1].Value).To(Equal("postgres://"))
+		Expect(list[1].ValueFrom.String()).To(Equal("db/url"))
+	})
+
+	It("rejects malformed references", func() {
+		var decoded models.EnvVariableDefinitions
+		Expect(json.Unmarshal([]byte(`{"REF":{"value_from":{"configuration":"db"}}}`), &decoded)).To(HaveOccurred())
+		Expect(json.Unmarshal([]byte(`{"REF":42}`), &decoded)).To(HaveOccurred())
+
+		_, err := models.ParseEnvVariableSource("db")
+		Expect(err).To(HaveOccurred())
+		source, err := models.ParseEnvVariableSource("db/url")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(source).To(Equal(models.EnvVariableSource{Configuration: "db", Key: "url"}))
+	})
+})
@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+)
+
+// ValidateMetadata checks that the labels are valid kubernetes labels, and the annotations
+// valid kubernetes annotations, both not reserved for the system.
+func ValidateMetadata(labels, annotations map[string]string, removeLabels []string) error {
+	if err := namespaces.ValidateLabels(labels); err != nil {
+		return err
+	}
+	for _, key := range removeLabels {
+		if err := namespaces.ValidateLabelKey(key); err != nil {
+			return err
+		}
+	}
+	for key := range annotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid annotation '%s': %s", key, strings.Join(errs, ", "))
+		}
+		if namespaces.ReservedKey(key) {
+			return fmt.Errorf("annotation '%s' is reserved", key)
+		}
+	}
+	return nil
+}
+
+// MetadataSet adds the labels and annotations to the application resource, replacing
+// existing values, and removes the named labels. Annotations with an empty value are
+// removed as well.
+func MetadataSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef,
+	labels, annotations map[string]string, removeLabels []string) error {
+
+	if len(labels) == 0 && len(annotations) == 0 && len(removeLabels) == 0 {
+		return nil
+	}
+
+	client, err := cluster.ClientApp()
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		app, err := client.Namespace(appRef.Namespace).Get(ctx, appRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		appLabels := app.GetLabels()
+		if appLabels == nil {
+			appLabels = map[string]string{}
+		}
+		for _, key := range removeLabels {
+			delete(appLabels, key)
+		}
+		for key, value := range labels {
+			appLabels[key] = value
+		}
+		app.SetLabels(appLabels)
+
+		appAnnotations := app.GetAnnotations()
+		if appAnnotations == nil {
+			appAnnotations = map[string]string{}
+		}
+		for key, value := range annotations {
+			if value == "" {
+				delete(appAnnotations, key)
+				continue
+			}
+			appAnnotations[key] = value
+		}
+		app.SetAnnotations(appAnnotations)
+
+		_, err = client.Namespace(appRef.Namespace).Update(ctx, app, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// UserLabels returns the user labels of the application resource
+func UserLabels(app *unstructured.Unstructured) map[string]string {
+	return namespaces.UserLabels(app.GetLabels())
+}
+
+// UserAnnotations returns the user annotations of the application resource
+func UserAnnotations(app *unstructured.Unstructured) map[string]string {
+	return namespaces.UserLabels(app.GetAnnotations())
+}
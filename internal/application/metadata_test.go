@@ -0,0 +1,63 @@
+package application_test
+
+import (
+	"github.com/epinio/epinio/internal/application"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Application metadata", func() {
+	Describe("ValidateMetadata", func() {
+		It("accepts user labels and annotations", func() {
+			err := application.ValidateMetadata(
+				map[string]string{"team": "payments", "example.com/tier": ""},
+				map[string]string{"example.com/docs": "https://example.com/docs, and more"},
+				[]string{"stage"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("rejects invalid labels", func() {
+			err := application.ValidateMetadata(map[string]string{"team": "pay ments"}, nil, nil)
+			Expect(err).To(MatchError(ContainSubstring("invalid value 'pay ments' of label 'team'")))
+		})
+
+		It("rejects reserved labels", func() {
+			err := application.ValidateMetadata(map[string]string{"app.kubernetes.io/name": "x"}, nil, nil)
+			Expect(err).To(MatchError("label 'app.kubernetes.io/name' is reserved"))
+		})
+
+		It("rejects the removal of reserved labels", func() {
+			err := application.ValidateMetadata(nil, nil, []string{"epinio.suse.org/area"})
+			Expect(err).To(MatchError("label 'epinio.suse.org/area' is reserved"))
+		})
+
+		It("rejects invalid annotations", func() {
+			err := application.ValidateMetadata(nil, map[string]string{"bad key": "x"}, nil)
+			Expect(err).To(MatchError(ContainSubstring("invalid annotation 'bad key'")))
+		})
+
+		It("rejects reserved annotations", func() {
+			err := application.ValidateMetadata(nil, map[string]string{"epinio.suse.org/stage": "x"}, nil)
+			Expect(err).To(MatchError("annotation 'epinio.suse.org/stage' is reserved"))
+		})
+	})
+
+	Describe("UserLabels and UserAnnotations", func() {
+		It("drop the keys reserved for the system", func() {
+			app := &unstructured.Unstructured{}
+			app.SetLabels(map[string]string{
+				"team":                   "payments",
+				"app.kubernetes.io/name": "x",
+			})
+			app.SetAnnotations(map[string]string{
+				"example.com/docs": "https://example.com",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			})
+
+			Expect(application.UserLabels(app)).To(Equal(map[string]string{"team": "payments"}))
+			Expect(application.UserAnnotations(app)).To(Equal(map[string]string{"example.com/docs": "https://example.com"}))
+		})
+	})
+})
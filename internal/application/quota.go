@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/configurations"
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/internal/services"
+	apierror "github.com/epinio/epinio/pkg/api/core/v1/errors"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// CheckNamespaceQuota checks that adding the delta to the current usage of the namespace
+// stays within its quota, if it has one. Exceeding the quota is reported as error.
+func CheckNamespaceQuota(ctx context.Context, cluster *kubernetes.Cluster, namespace string, delta models.NamespaceUsage) apierror.APIErrors {
+	quota, err := namespaces.Quota(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+	if quota == nil {
+		return nil
+	}
+
+	usage, err := NamespaceUsage(ctx, cluster, namespace)
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	err = namespaces.CheckQuota(*quota, usage, delta)
+	if quotaErr, ok := err.(namespaces.QuotaError); ok {
+		return apierror.QuotaExceeded(namespace, quotaErr.Resource, quotaErr.Limit, quotaErr.Requested)
+	}
+	if err != nil {
+		return apierror.InternalError(err)
+	}
+
+	return nil
+}
+
+// NamespaceUsage returns the resources used by the namespace, in the terms of its quota. The
+// instances are the desired instances of the apps.
+func NamespaceUsage(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (models.NamespaceUsage, error) {
+	usage := models.NamespaceUsage{}
+
+	apps, err := List(ctx, cluster, namespace)
+	if err != nil {
+		return usage, err
+	}
+	usage.Apps = int64(len(apps))
+	for _, app := range apps {
+		if app.Configuration.Instances != nil {
+			usage.Instances += int64(*app.Configuration.Instances)
+		}
+	}
+
+	kubeServiceClient, err := services.NewKubernetesServiceClient(cluster)
+	if err != nil {
+		return usage, err
+	}
+	serviceList, err := kubeServiceClient.ListInNamespace(ctx, namespace)
+	if err != nil {
+		return usage, err
+	}
+	usage.Services = int64(len(serviceList))
+
+	configurationList, err := configurations.List(ctx, cluster, namespace)
+	if err != nil {
+		return usage, err
+	}
+	usage.Configurations = int64(len(configurationList))
+
+	usage.CPU, usage.Memory, err = namespaces.QuotaUsed(ctx, cluster, namespace)
+	if err != nil {
+		return usage, err
+	}
+
+	return usage, nil
+}
@@ -13,7 +13,8 @@ import (
 )
 
 const (
-	instanceKey = "desired"
+	instanceKey  = "desired"
+	suspendedKey = "suspended"
 )
 
 // Scaling returns the number of desired instances set by a user for the application
@@ -44,6 +45,31 @@ func ScalingSet(ctx context.Context, cluster *kubernetes.Cluster, appRef models.
 	})
 }
 
+// ScalingSuspend scales the named application to zero instances, remembering the desired
+// number for ScalingResume. Suspending a suspended application does nothing.
+func ScalingSuspend(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) error {
+	return scaleUpdate(ctx, cluster, appRef, func(scaleSecret *v1.Secret) {
+		if _, ok := scaleSecret.Data[suspendedKey]; ok {
+			return
+		}
+		scaleSecret.Data[suspendedKey] = scaleSecret.Data[instanceKey]
+		scaleSecret.Data[instanceKey] = []byte(`0`)
+	})
+}
+
+// ScalingResume restores the number of instances the named application had before it was
+// suspended. Resuming an application which is not suspended does nothing.
+func ScalingResume(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) error {
+	return scaleUpdate(ctx, cluster, appRef, func(scaleSecret *v1.Secret) {
+		desired, ok := scaleSecret.Data[suspendedKey]
+		if !ok {
+			return
+		}
+		scaleSecret.Data[instanceKey] = desired
+		delete(scaleSecret.Data, suspendedKey)
+	})
+}
+
 // scaleUpdate is a helper for the public functions. It encapsulates the read/modify/write cycle
 // necessary to update the application's kube resource holding the application's number of desired
 // instances
@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaskedEnvAnnotationKey is the annotation of the environment secret of a staging job
+// listing the variables whose values are masked in the staging logs, as JSON. These are
+// the build secrets.
+const MaskedEnvAnnotationKey = "epinio.suse.org/masked-env"
+
+// maskedValue replaces the masked values in the staging logs
+const maskedValue = "*****"
+
+// StagingMaskedValues returns the values to mask in the logs of the staging job with the
+// given id, for an application in the namespace.
+func StagingMaskedValues(ctx context.Context, cluster *kubernetes.Cluster, namespace, stageID string) ([]string, error) {
+	selector := fmt.Sprintf("app.kubernetes.io/component=staging,app.kubernetes.io/part-of=%s,%s=%s",
+		namespace, models.EpinioStageIDLabel, stageID)
+
+	secrets, err := cluster.Kubectl.CoreV1().Secrets(helmchart.Namespace()).List(ctx,
+		metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	values := []string{}
+	for _, secret := range secrets.Items {
+		encoded, ok := secret.Annotations[MaskedEnvAnnotationKey]
+		if !ok {
+			continue
+		}
+
+		names := []string{}
+		if err := json.Unmarshal([]byte(encoded), &names); err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if value, ok := secret.Data[name]; ok {
+				values = append(values, string(value))
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// NewLogMasker returns a replacer masking the values in log lines. The values are masked
+// with and without surrounding whitespace.
+func NewLogMasker(values []string) *strings.Replacer {
+	masked := []string{}
+	for _, value := range values {
+		masked = append(masked, value, strings.TrimSpace(value))
+	}
+
+	// Longer values first, so that values containing other values are masked completely
+	sort.Slice(masked, func(i, j int) bool {
+		return len(masked[i]) > len(masked[j])
+	})
+
+	pairs := []string{}
+	for _, value := range masked {
+		if value == "" {
+			continue
+		}
+		pairs = append(pairs, value, maskedValue)
+	}
+
+	return strings.NewReplacer(pairs...)
+}
@@ -0,0 +1,26 @@
+package application_test
+
+import (
+	"github.com/epinio/epinio/internal/application"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewLogMasker", func() {
+	It("masks the values", func() {
+		masker := application.NewLogMasker([]string{"t0ken", "pass\n"})
+		Expect(masker.Replace("using t0ken and pass, t0ken again")).To(
+			Equal("using ***** and *****, ***** again"))
+	})
+
+	It("masks values containing other values completely", func() {
+		masker := application.NewLogMasker([]string{"abc", "abcdef"})
+		Expect(masker.Replace("x abcdef y abc")).To(Equal("x ***** y *****"))
+	})
+
+	It("ignores empty values", func() {
+		masker := application.NewLogMasker([]string{"", "  "})
+		Expect(masker.Replace("nothing to hide")).To(Equal("nothing to hide"))
+	})
+})
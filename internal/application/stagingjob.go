@@ -0,0 +1,257 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// StagingJob holds the kubernetes settings of a staging job, as derived from the
+// StagingSettings of the application and the server.
+type StagingJob struct {
+	Resources             corev1.ResourceRequirements
+	NodeSelector          map[string]string
+	Tolerations           []corev1.Toleration
+	ActiveDeadlineSeconds *int64
+	BackoffLimit          int32
+}
+
+// StagingDefaults returns the staging job settings configured for the server. The node
+// selector and tolerations are comma-separated lists of KEY=VALUE, and of tolerations,
+// respectively.
+func StagingDefaults() (models.StagingSettings, error) {
+	backoffLimit := viper.GetInt32("staging-backoff-limit")
+	settings := models.StagingSettings{
+		CPURequest:    viper.GetString("staging-cpu-request"),
+		MemoryRequest: viper.GetString("staging-memory-request"),
+		CPULimit:      viper.GetString("staging-cpu-limit"),
+		MemoryLimit:   viper.GetString("staging-memory-limit"),
+		Timeout:       viper.GetString("staging-timeout"),
+		BackoffLimit:  &backoffLimit,
+	}
+
+	for _, entry := range strings.Split(viper.GetString("staging-node-selector"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return settings, fmt.Errorf("bad staging node selector '%s', expected KEY=VALUE", entry)
+		}
+		if settings.NodeSelector == nil {
+			settings.NodeSelector = map[string]string{}
+		}
+		settings.NodeSelector[key] = value
+	}
+
+	for _, entry := range strings.Split(viper.GetString("staging-tolerations"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		settings.Tolerations = append(settings.Tolerations, entry)
+	}
+
+	return settings, nil
+}
+
+// MergeStagingSettings returns the defaults with the overrides of the application applied.
+// The node selector and tolerations of the application extend those of the defaults, which
+// stay in effect. The resources and the timeout of the application are bounded by the
+// limits and the timeout of the defaults, if set. Exceeding these is reported as error.
+func MergeStagingSettings(defaults, overrides models.StagingSettings) (models.StagingSettings, error) {
+	result := defaults
+
+	bounds := []struct {
+		value   string
+		maximum string
+		what    string
+	}{
+		{overrides.CPURequest, defaults.CPULimit, "cpu request"},
+		{overrides.CPULimit, defaults.CPULimit, "cpu limit"},
+		{overrides.MemoryRequest, defaults.MemoryLimit, "memory request"},
+		{overrides.MemoryLimit, defaults.MemoryLimit, "memory limit"},
+	}
+	for _, b := range bounds {
+		if b.value == "" || b.maximum == "" {
+			continue
+		}
+		value, err := resource.ParseQuantity(b.value)
+		if err != nil {
+			return result, errors.Wrapf(err, "bad staging %s '%s'", b.what, b.value)
+		}
+		maximum, err := resource.ParseQuantity(b.maximum)
+		if err != nil {
+			return result, errors.Wrapf(err, "bad staging limit '%s'", b.maximum)
+		}
+		if value.Cmp(maximum) > 0 {
+			return result, fmt.Errorf("staging %s '%s' exceeds the maximum '%s'", b.what, b.value, b.maximum)
+		}
+	}
+
+	if overrides.Timeout != "" && defaults.Timeout != "" {
+		value, err := time.ParseDuration(overrides.Timeout)
+		if err != nil {
+			return result, errors.Wrapf(err, "bad staging timeout '%s'", overrides.Timeout)
+		}
+		maximum, err := time.ParseDuration(defaults.Timeout)
+		if err != nil {
+			return result, errors.Wrapf(err, "bad staging timeout '%s'", defaults.Timeout)
+		}
+		if value > maximum {
+			return result, fmt.Errorf("staging timeout '%s' exceeds the maximum '%s'", overrides.Timeout, defaults.Timeout)
+		}
+	}
+
+	override := func(field *string, value string) {
+		if value != "" {
+			*field = value
+		}
+	}
+	override(&result.CPURequest, overrides.CPURequest)
+	override(&result.MemoryRequest, overrides.MemoryRequest)
+	override(&result.CPULimit, overrides.CPULimit)
+	override(&result.MemoryLimit, overrides.MemoryLimit)
+	override(&result.Timeout, overrides.Timeout)
+
+	if len(overrides.NodeSelector) > 0 {
+		result.NodeSelector = map[string]string{}
+		for key, value := range overrides.NodeSelector {
+			result.NodeSelector[key] = value
+		}
+		for key, value := range defaults.NodeSelector {
+			result.NodeSelector[key] = value
+		}
+	}
+	if len(overrides.Tolerations) > 0 {
+		result.Tolerations = append([]string{}, defaults.Tolerations...)
+		for _, toleration := range overrides.Tolerations {
+			if !contains(result.Tolerations, toleration) {
+				result.Tolerations = append(result.Tolerations, toleration)
+			}
+		}
+	}
+	if overrides.BackoffLimit != nil {
+		result.BackoffLimit = overrides.BackoffLimit
+	}
+
+	return result, nil
+}
+
+// contains returns true if the value is an element of the slice.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// NewStagingJob validates the settings and converts them into their kubernetes form.
+func NewStagingJob(settings models.StagingSettings) (StagingJob, error) {
+	job := StagingJob{
+		NodeSelector: settings.NodeSelector,
+	}
+
+	quantities := []struct {
+		value string
+		name  corev1.ResourceName
+		list  *corev1.ResourceList
+		what  string
+	}{
+		{settings.CPURequest, corev1.ResourceCPU, &job.Resources.Requests, "cpu request"},
+		{settings.MemoryRequest, corev1.ResourceMemory, &job.Resources.Requests, "memory request"},
+		{settings.CPULimit, corev1.ResourceCPU, &job.Resources.Limits, "cpu limit"},
+		{settings.MemoryLimit, corev1.ResourceMemory, &job.Resources.Limits, "memory limit"},
+	}
+	for _, q := range quantities {
+		if q.value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(q.value)
+		if err != nil {
+			return job, errors.Wrapf(err, "bad staging %s '%s'", q.what, q.value)
+		}
+		if *q.list == nil {
+			*q.list = corev1.ResourceList{}
+		}
+		(*q.list)[q.name] = quantity
+	}
+
+	for key, value := range settings.NodeSelector {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return job, fmt.Errorf("bad staging node selector key '%s': %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return job, fmt.Errorf("bad staging node selector value '%s': %s", value, strings.Join(errs, ", "))
+		}
+	}
+
+	for _, spec := range settings.Tolerations {
+		toleration, err := parseToleration(spec)
+		if err != nil {
+			return job, err
+		}
+		job.Tolerations = append(job.Tolerations, toleration)
+	}
+
+	if settings.Timeout != "" {
+		timeout, err := time.ParseDuration(settings.Timeout)
+		if err != nil {
+			return job, errors.Wrapf(err, "bad staging timeout '%s'", settings.Timeout)
+		}
+		if timeout < time.Second {
+			return job, fmt.Errorf("bad staging timeout '%s', expected at least a second", settings.Timeout)
+		}
+		seconds := int64(timeout.Seconds())
+		job.ActiveDeadlineSeconds = &seconds
+	}
+
+	if settings.BackoffLimit != nil {
+		if *settings.BackoffLimit < 0 {
+			return job, fmt.Errorf("bad staging backoff limit %d, expected a count", *settings.BackoffLimit)
+		}
+		job.BackoffLimit = *settings.BackoffLimit
+	}
+
+	return job, nil
+}
+
+// parseToleration converts a toleration in the syntax of taints, KEY[=VALUE][:EFFECT],
+// into its kubernetes form. Without a value the toleration matches all values of the
+// key, and without an effect all effects.
+func parseToleration(spec string) (corev1.Toleration, error) {
+	toleration := corev1.Toleration{}
+
+	keyValue, effect, _ := strings.Cut(spec, ":")
+	key, value, hasValue := strings.Cut(keyValue, "=")
+
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return toleration, fmt.Errorf("bad staging toleration '%s': %s", spec, strings.Join(errs, ", "))
+	}
+
+	toleration.Key = key
+	toleration.Operator = corev1.TolerationOpExists
+	if hasValue {
+		toleration.Operator = corev1.TolerationOpEqual
+		toleration.Value = value
+	}
+
+	switch corev1.TaintEffect(effect) {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		toleration.Effect = corev1.TaintEffect(effect)
+	default:
+		return toleration, fmt.Errorf("bad staging toleration '%s': unknown effect '%s'", spec, effect)
+	}
+
+	return toleration, nil
+}
@@ -0,0 +1,169 @@
+package application
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Staging job", func() {
+	Describe("StagingDefaults", func() {
+		AfterEach(func() {
+			viper.Set("staging-memory-limit", "")
+			viper.Set("staging-node-selector", "")
+			viper.Set("staging-tolerations", "")
+			viper.Set("staging-backoff-limit", 0)
+		})
+
+		It("reads the configured settings", func() {
+			viper.Set("staging-memory-limit", "2Gi")
+			viper.Set("staging-node-selector", "pool=builders, zone=a")
+			viper.Set("staging-tolerations", "dedicated=builders:NoSchedule,spot")
+			viper.Set("staging-backoff-limit", 2)
+
+			settings, err := StagingDefaults()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(settings.MemoryLimit).To(Equal("2Gi"))
+			Expect(settings.NodeSelector).To(Equal(map[string]string{"pool": "builders", "zone": "a"}))
+			Expect(settings.Tolerations).To(Equal([]string{"dedicated=builders:NoSchedule", "spot"}))
+			Expect(*settings.BackoffLimit).To(Equal(int32(2)))
+		})
+
+		It("rejects bad node selectors", func() {
+			viper.Set("staging-node-selector", "pool")
+
+			_, err := StagingDefaults()
+			Expect(err).To(MatchError("bad staging node selector 'pool', expected KEY=VALUE"))
+		})
+	})
+
+	Describe("MergeStagingSettings", func() {
+		It("replaces the defaults set by the application", func() {
+			one, two := int32(1), int32(2)
+			defaults := models.StagingSettings{
+				CPURequest:   "100m",
+				MemoryLimit:  "4Gi",
+				BackoffLimit: &one,
+			}
+			overrides := models.StagingSettings{
+				MemoryRequest: "1Gi",
+				MemoryLimit:   "2Gi",
+				BackoffLimit:  &two,
+			}
+
+			Expect(MergeStagingSettings(defaults, overrides)).To(Equal(models.StagingSettings{
+				CPURequest:    "100m",
+				MemoryRequest: "1Gi",
+				MemoryLimit:   "2Gi",
+				BackoffLimit:  &two,
+			}))
+		})
+
+		It("keeps the node selector and tolerations of the defaults", func() {
+			defaults := models.StagingSettings{
+				NodeSelector: map[string]string{"pool": "staging"},
+				Tolerations:  []string{"staging:NoSchedule"},
+			}
+			overrides := models.StagingSettings{
+				NodeSelector: map[string]string{"pool": "large", "disk": "ssd"},
+				Tolerations:  []string{"ssd", "staging:NoSchedule"},
+			}
+
+			merged, err := MergeStagingSettings(defaults, overrides)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(merged.NodeSelector).To(Equal(map[string]string{"pool": "staging", "disk": "ssd"}))
+			Expect(merged.Tolerations).To(Equal([]string{"staging:NoSchedule", "ssd"}))
+			Expect(defaults.NodeSelector).To(Equal(map[string]string{"pool": "staging"}))
+		})
+
+		It("rejects resources and timeouts beyond the limits of the defaults", func() {
+			defaults := models.StagingSettings{
+				CPULimit:    "2",
+				MemoryLimit: "4Gi",
+				Timeout:     "30m",
+			}
+
+			_, err := MergeStagingSettings(defaults, models.StagingSettings{CPULimit: "4"})
+			Expect(err).To(MatchError("staging cpu limit '4' exceeds the maximum '2'"))
+
+			_, err = MergeStagingSettings(defaults, models.StagingSettings{MemoryRequest: "8Gi"})
+			Expect(err).To(MatchError("staging memory request '8Gi' exceeds the maximum '4Gi'"))
+
+			_, err = MergeStagingSettings(defaults, models.StagingSettings{Timeout: "2h"})
+			Expect(err).To(MatchError("staging timeout '2h' exceeds the maximum '30m'"))
+
+			_, err = MergeStagingSettings(models.StagingSettings{}, models.StagingSettings{CPULimit: "4", Timeout: "2h"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("NewStagingJob", func() {
+		It("converts the settings", func() {
+			backoffLimit := int32(3)
+			job, err := NewStagingJob(models.StagingSettings{
+				CPURequest:   "500m",
+				MemoryLimit:  "2Gi",
+				NodeSelector: map[string]string{"pool": "builders"},
+				Tolerations:  []string{"dedicated=builders:NoSchedule", "spot"},
+				Timeout:      "30m",
+				BackoffLimit: &backoffLimit,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(job.Resources.Requests.Cpu().String()).To(Equal("500m"))
+			Expect(job.Resources.Limits.Memory().String()).To(Equal("2Gi"))
+			Expect(job.Resources.Limits).ToNot(HaveKey(corev1.ResourceCPU))
+			Expect(job.NodeSelector).To(Equal(map[string]string{"pool": "builders"}))
+			Expect(job.Tolerations).To(Equal([]corev1.Toleration{
+				{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "builders",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+				{
+					Key:      "spot",
+					Operator: corev1.TolerationOpExists,
+				},
+			}))
+			Expect(*job.ActiveDeadlineSeconds).To(Equal(int64(1800)))
+			Expect(job.BackoffLimit).To(Equal(int32(3)))
+		})
+
+		It("leaves the job unconstrained without settings", func() {
+			job, err := NewStagingJob(models.StagingSettings{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(job.Resources.Requests).To(BeNil())
+			Expect(job.Resources.Limits).To(BeNil())
+			Expect(job.ActiveDeadlineSeconds).To(BeNil())
+			Expect(job.BackoffLimit).To(BeZero())
+		})
+
+		It("rejects bad quantities", func() {
+			_, err := NewStagingJob(models.StagingSettings{MemoryLimit: "lots"})
+			Expect(err).To(MatchError(ContainSubstring("bad staging memory limit 'lots'")))
+		})
+
+		It("rejects bad timeouts", func() {
+			_, err := NewStagingJob(models.StagingSettings{Timeout: "forever"})
+			Expect(err).To(MatchError(ContainSubstring("bad staging timeout 'forever'")))
+
+			_, err = NewStagingJob(models.StagingSettings{Timeout: "10ms"})
+			Expect(err).To(MatchError(ContainSubstring("expected at least a second")))
+		})
+
+		It("rejects bad tolerations", func() {
+			_, err := NewStagingJob(models.StagingSettings{Tolerations: []string{"spot:Sometimes"}})
+			Expect(err).To(MatchError("bad staging toleration 'spot:Sometimes': unknown effect 'Sometimes'"))
+		})
+
+		It("rejects negative backoff limits", func() {
+			backoffLimit := int32(-1)
+			_, err := NewStagingJob(models.StagingSettings{BackoffLimit: &backoffLimit})
+			Expect(err).To(MatchError(ContainSubstring("bad staging backoff limit -1")))
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
@@ -24,8 +25,10 @@ import (
 )
 
 type AppConfigurationBind struct {
-	configuration string // name of the configuration getting bound
-	resource      string // name of the kube secret to mount as volume to make the configuration params available in the app
+	configuration string             // name of the configuration getting bound
+	resource      string             // name of the kube secret to mount as volume to make the configuration params available in the app
+	keys          []string           // keys of the kube secret, sorted
+	options       models.BindOptions // how to make the configuration params available
 }
 
 type AppConfigurationBindList []AppConfigurationBind
@@ -43,7 +46,9 @@ func NewWorkload(cluster *kubernetes.Cluster, app models.AppRef) *Workload {
 	return &Workload{cluster: cluster, app: app}
 }
 
-func ToBinds(ctx context.Context, configurations configurations.ConfigurationList, appName string, userName string) (AppConfigurationBindList, error) {
+// ToBinds converts the configurations bound to an application into binding descriptions,
+// using the binding options of the configurations having any.
+func ToBinds(ctx context.Context, configurations configurations.ConfigurationList, options map[string]models.BindOptions) (AppConfigurationBindList, error) {
 	bindings := AppConfigurationBindList{}
 
 	for _, configuration := range configurations {
@@ -51,25 +56,72 @@ func ToBinds(ctx context.Context, configurations configurations.ConfigurationLis
 		if err != nil {
 			return AppConfigurationBindList{}, err
 		}
+
+		keys := []string{}
+		for key := range bindResource.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
 		bindings = append(bindings, AppConfigurationBind{
 			resource:      bindResource.Name,
 			configuration: configuration.Name,
+			keys:          keys,
+			options:       options[configuration.Name],
 		})
 	}
 
 	return bindings, nil
 }
 
+// exposed returns the keys of the binding made available to the application, and the
+// names the application sees them under. Keys missing from the configuration are skipped.
+func (b AppConfigurationBind) exposed() ([]string, map[string]string) {
+	if len(b.options.Keys) == 0 {
+		names := map[string]string{}
+		for _, key := range b.keys {
+			names[key] = key
+		}
+		return b.keys, names
+	}
+
+	keys := []string{}
+	for _, key := range b.keys {
+		if _, ok := b.options.Keys[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys, b.options.Keys
+}
+
+// ToVolumesArray returns the volumes for the bindings mounted as files. Bindings with
+// selected keys project only these, under their mapped names, and with the chosen mode.
 func (b AppConfigurationBindList) ToVolumesArray() []corev1.Volume {
 	volumes := []corev1.Volume{}
 
 	for _, binding := range b {
+		if binding.options.AsEnv {
+			continue
+		}
+
+		source := &corev1.SecretVolumeSource{
+			SecretName:  binding.resource,
+			DefaultMode: binding.options.Mode,
+		}
+		if len(binding.options.Keys) > 0 {
+			keys, names := binding.exposed()
+			for _, key := range keys {
+				source.Items = append(source.Items, corev1.KeyToPath{
+					Key:  key,
+					Path: names[key],
+				})
+			}
+		}
+
 		volumes = append(volumes, corev1.Volume{
 			Name: binding.configuration,
 			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: binding.resource,
-				},
+				Secret: source,
 			},
 		})
 	}
@@ -77,20 +129,62 @@ func (b AppConfigurationBindList) ToVolumesArray() []corev1.Volume {
 	return volumes
 }
 
+// ToMountsArray returns the mounts for the bindings mounted as files, at their chosen
+// paths, if any.
 func (b AppConfigurationBindList) ToMountsArray() []corev1.VolumeMount {
 	mounts := []corev1.VolumeMount{}
 
 	for _, binding := range b {
+		if binding.options.AsEnv {
+			continue
+		}
+
+		mountPath := binding.options.MountPath
+		if mountPath == "" {
+			mountPath = fmt.Sprintf("/configurations/%s", binding.configuration)
+		}
+
 		mounts = append(mounts, corev1.VolumeMount{
 			Name:      binding.configuration,
 			ReadOnly:  true,
-			MountPath: fmt.Sprintf("/configurations/%s", binding.configuration),
+			MountPath: mountPath,
 		})
 	}
 
 	return mounts
 }
 
+// ToEnvArray returns the environment variables for the bindings injected as such, named
+// with the binding's prefix, if any. The variables reference the secrets, their values
+// are not copied.
+func (b AppConfigurationBindList) ToEnvArray() []corev1.EnvVar {
+	env := []corev1.EnvVar{}
+
+	for _, binding := range b {
+		if !binding.options.AsEnv {
+			continue
+		}
+
+		keys, names := binding.exposed()
+		for _, key := range keys {
+			env = append(env, corev1.EnvVar{
+				Name: binding.options.EnvPrefix + names[key],
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: binding.resource,
+						},
+						Key: key,
+					},
+				},
+			})
+		}
+	}
+
+	return env
+}
+
+// ToNames returns the names of the bound configurations.
 func (b AppConfigurationBindList) ToNames() []string {
 	names := []string{}
 
@@ -101,6 +195,33 @@ func (b AppConfigurationBindList) ToNames() []string {
 	return names
 }
 
+// WithOptions returns the bindings which do not use the default options.
+func (b AppConfigurationBindList) WithOptions() AppConfigurationBindList {
+	result := AppConfigurationBindList{}
+
+	for _, binding := range b {
+		if !binding.options.IsDefault() {
+			result = append(result, binding)
+		}
+	}
+
+	return result
+}
+
+// ToDefaultNames returns the names of the bound configurations using the default
+// options, i.e. mounting all their keys as files.
+func (b AppConfigurationBindList) ToDefaultNames() []string {
+	names := []string{}
+
+	for _, binding := range b {
+		if binding.options.IsDefault() {
+			names = append(names, binding.configuration)
+		}
+	}
+
+	return names
+}
+
 // Deployment is a helper, it returns the kube deployment resource of the workload.
 // The result is memoized so that subsequent calls to this method, don't call
 // the kubernetes api.
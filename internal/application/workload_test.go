@@ -0,0 +1,118 @@
+package application
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Configuration bindings", func() {
+	var binds AppConfigurationBindList
+
+	BeforeEach(func() {
+		binds = AppConfigurationBindList{
+			{
+				configuration: "plain",
+				resource:      "plain",
+				keys:          []string{"password", "username"},
+			},
+			{
+				configuration: "mapped",
+				resource:      "mapped",
+				keys:          []string{"password", "username"},
+				options: models.BindOptions{
+					Keys: map[string]string{"password": "DB_PASSWORD", "missing": "X"},
+				},
+			},
+			{
+				configuration: "env",
+				resource:      "env",
+				keys:          []string{"password", "username"},
+				options:       models.BindOptions{AsEnv: true},
+			},
+		}
+	})
+
+	It("mounts at the chosen path, with the chosen mode", func() {
+		mode := int32(0400)
+		binds := AppConfigurationBindList{
+			{
+				configuration: "placed",
+				resource:      "placed",
+				keys:          []string{"tls.crt", "tls.key"},
+				options:       models.BindOptions{MountPath: "/etc/tls", Mode: &mode},
+			},
+		}
+
+		volumes := binds.ToVolumesArray()
+		Expect(volumes).To(HaveLen(1))
+		Expect(*volumes[0].Secret.DefaultMode).To(Equal(int32(0400)))
+
+		mounts := binds.ToMountsArray()
+		Expect(mounts).To(HaveLen(1))
+		Expect(mounts[0].MountPath).To(Equal("/etc/tls"))
+	})
+
+	It("prefixes the names of injected environment variables", func() {
+		binds := AppConfigurationBindList{
+			{
+				configuration: "db",
+				resource:      "db",
+				keys:          []string{"url"},
+				options: models.BindOptions{
+					AsEnv:     true,
+					EnvPrefix: "DATABASE_",
+					Keys:      map[string]string{"url": "URL"},
+				},
+			},
+		}
+
+		env := binds.ToEnvArray()
+		Expect(env).To(HaveLen(1))
+		Expect(env[0].Name).To(Equal("DATABASE_URL"))
+		Expect(env[0].ValueFrom.SecretKeyRef.Key).To(Equal("url"))
+	})
+
+	It("rejects inconsistent options", func() {
+		mode := int32(01777)
+		Expect(models.BindOptions{AsEnv: true, MountPath: "/etc/x"}.Validate()).To(HaveOccurred())
+		Expect(models.BindOptions{EnvPrefix: "X_"}.Validate()).To(HaveOccurred())
+		Expect(models.BindOptions{AsEnv: true, EnvPrefix: "1X"}.Validate()).To(HaveOccurred())
+		Expect(models.BindOptions{MountPath: "relative"}.Validate()).To(HaveOccurred())
+		Expect(models.BindOptions{MountPath: "/etc/../x"}.Validate()).To(HaveOccurred())
+		Expect(models.BindOptions{Mode: &mode}.Validate()).To(HaveOccurred())
+		Expect(models.BindOptions{AsEnv: true, EnvPrefix: "APP_"}.Validate()).ToNot(HaveOccurred())
+		Expect(models.BindOptions{MountPath: "/etc/app"}.Validate()).ToNot(HaveOccurred())
+	})
+
+	It("separates the bindings using the default options", func() {
+		Expect(binds.ToDefaultNames()).To(Equal([]string{"plain"}))
+		Expect(binds.WithOptions().ToNames()).To(Equal([]string{"mapped", "env"}))
+	})
+
+	It("projects only the selected keys into volumes, renamed", func() {
+		volumes := binds.ToVolumesArray()
+		Expect(volumes).To(HaveLen(2))
+		Expect(volumes[0].Secret.Items).To(BeEmpty())
+		Expect(volumes[1].Name).To(Equal("mapped"))
+		Expect(volumes[1].Secret.Items).To(Equal([]corev1.KeyToPath{
+			{Key: "password", Path: "DB_PASSWORD"},
+		}))
+
+		mounts := binds.ToMountsArray()
+		Expect(mounts).To(HaveLen(2))
+		Expect(mounts[1].MountPath).To(Equal("/configurations/mapped"))
+	})
+
+	It("injects keys as environment variables referencing the secret", func() {
+		env := binds.ToEnvArray()
+		Expect(env).To(HaveLen(2))
+		Expect(env[0].Name).To(Equal("password"))
+		Expect(env[0].Value).To(BeEmpty())
+		Expect(env[0].ValueFrom.SecretKeyRef.Name).To(Equal("env"))
+		Expect(env[0].ValueFrom.SecretKeyRef.Key).To(Equal("password"))
+		Expect(env[1].Name).To(Equal("username"))
+	})
+})
@@ -81,6 +81,22 @@ func (u *User) RemoveNamespace(namespace string) bool {
 	return removed
 }
 
+// CanAccess returns true if the User may work with the namespace. Admins may access all
+// namespaces, other users only their own.
+func (u User) CanAccess(namespace string) bool {
+	if u.Role == "admin" {
+		return true
+	}
+
+	for _, ns := range u.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
 // MakeGinAccountsFromUsers is a utility func to convert the Epinio users to gin.Accounts,
 // that can be passed to the BasicAuth middleware.
 func MakeGinAccountsFromUsers(users []User) gin.Accounts {
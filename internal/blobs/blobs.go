@@ -0,0 +1,202 @@
+// Package blobs implements the garbage collection of the S3 blobs holding the sources of
+// applications. Blobs are created by uploads and git imports, and only some of them are
+// deleted again, when stages are dropped. Blobs of failed stagings, and of applications
+// deleted mid-flight, are left behind. These are found by cross-referencing the metadata
+// of the blobs against the existing applications and staging jobs.
+package blobs
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/s3manager"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DefaultRetention is the minimal age of unreferenced blobs before they are collected,
+// unless configured otherwise with `blobs-retention`. It protects sources uploaded and not
+// yet staged, and incomplete uploads which may still be resumed.
+const DefaultRetention = 24 * time.Hour
+
+// Retention returns the minimal age of unreferenced blobs before they are collected, as
+// configured for the server.
+func Retention() time.Duration {
+	retention := viper.GetDuration("blobs-retention")
+	if retention <= 0 {
+		return DefaultRetention
+	}
+	return retention
+}
+
+// references holds what the blobs are checked against
+type references struct {
+	// blobs referenced by applications and staging jobs
+	blobs map[string]struct{}
+	// existing applications
+	apps map[models.AppRef]struct{}
+}
+
+// List returns the blobs of application sources in the storage, with their status. Keys
+// with a prefix, like the `backups/` of services, are not application sources, and are
+// ignored.
+func List(ctx context.Context, cluster *kubernetes.Cluster, manager *s3manager.Manager, retention time.Duration) (models.BlobList, error) {
+	objects, err := manager.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// The references are determined after listing the objects, so that blobs uploaded
+	// and staged in between are either too recent, or referenced.
+	refs, err := findReferences(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := models.BlobList{}
+	for _, object := range objects {
+		if strings.Contains(object.Key, "/") {
+			continue
+		}
+
+		meta, err := manager.Meta(ctx, object.Key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "blob %s", object.Key)
+		}
+
+		blob := models.Blob{
+			ID:           object.Key,
+			Namespace:    meta["Namespace"],
+			App:          meta["App"],
+			Username:     meta["Username"],
+			Size:         object.Size,
+			LastModified: metav1.NewTime(object.LastModified),
+		}
+		blob.Status = status(blob, refs, now, retention)
+
+		result = append(result, blob)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LastModified.Before(&result[j].LastModified)
+	})
+
+	return result, nil
+}
+
+// Collect deletes the blobs which are not in use anymore, and aborts the incomplete
+// uploads older than the retention period. For a dry run nothing is deleted, and the
+// report lists what would be.
+func Collect(ctx context.Context, cluster *kubernetes.Cluster, manager *s3manager.Manager, retention time.Duration, dryRun bool) (models.BlobsGCResponse, error) {
+	report := models.BlobsGCResponse{
+		DryRun:  dryRun,
+		Deleted: models.BlobList{},
+		Aborted: []models.BlobUpload{},
+	}
+
+	blobList, err := List(ctx, cluster, manager, retention)
+	if err != nil {
+		return report, err
+	}
+
+	for _, blob := range blobList {
+		if !blob.Collectable() {
+			continue
+		}
+		if !dryRun {
+			if err := manager.DeleteObject(ctx, blob.ID); err != nil {
+				return report, errors.Wrapf(err, "deleting blob %s", blob.ID)
+			}
+		}
+		report.Deleted = append(report.Deleted, blob)
+		report.Freed += blob.Size
+	}
+
+	uploads, err := manager.MultipartUploads(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	cutoff := time.Now().Add(-retention)
+	for _, upload := range uploads {
+		if strings.Contains(upload.Key, "/") || upload.Initiated.After(cutoff) {
+			continue
+		}
+		if !dryRun {
+			if err := manager.MultipartAbort(ctx, upload.Key, upload.UploadID); err != nil {
+				return report, errors.Wrapf(err, "aborting upload of blob %s", upload.Key)
+			}
+		}
+		report.Aborted = append(report.Aborted, models.BlobUpload{
+			ID:        upload.Key,
+			UploadID:  upload.UploadID,
+			Initiated: metav1.NewTime(upload.Initiated),
+		})
+	}
+
+	return report, nil
+}
+
+// status returns the status of the blob, given the references to blobs, and its age.
+func status(blob models.Blob, refs references, now time.Time, retention time.Duration) string {
+	if _, ok := refs.blobs[blob.ID]; ok {
+		return models.BlobInUse
+	}
+	if blob.LastModified.Add(retention).After(now) {
+		return models.BlobRecent
+	}
+	if _, ok := refs.apps[models.NewAppRef(blob.App, blob.Namespace)]; ok {
+		return models.BlobUnused
+	}
+	return models.BlobOrphaned
+}
+
+// findReferences returns the blobs referenced by the applications and their staging jobs,
+// and the existing applications.
+func findReferences(ctx context.Context, cluster *kubernetes.Cluster) (references, error) {
+	refs := references{
+		blobs: map[string]struct{}{},
+		apps:  map[models.AppRef]struct{}{},
+	}
+
+	client, err := cluster.ClientApp()
+	if err != nil {
+		return refs, err
+	}
+
+	apps, err := client.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return refs, errors.Wrap(err, "listing applications")
+	}
+	for _, app := range apps.Items {
+		refs.apps[models.NewAppRef(app.GetName(), app.GetNamespace())] = struct{}{}
+
+		blobUID, _, err := unstructured.NestedString(app.UnstructuredContent(), "spec", "blobuid")
+		if err != nil {
+			return refs, errors.New("blobuid should be string")
+		}
+		if blobUID != "" {
+			refs.blobs[blobUID] = struct{}{}
+		}
+	}
+
+	jobs, err := cluster.ListJobs(ctx, helmchart.Namespace(), "app.kubernetes.io/component=staging")
+	if err != nil {
+		return refs, errors.Wrap(err, "listing staging jobs")
+	}
+	for _, job := range jobs.Items {
+		if blobUID := job.Labels[models.EpinioStageBlobUIDLabel]; blobUID != "" {
+			refs.blobs[blobUID] = struct{}{}
+		}
+	}
+
+	return refs, nil
+}
@@ -0,0 +1,13 @@
+package blobs
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestBlobs(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Blobs Suite")
+}
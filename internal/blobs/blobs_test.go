@@ -0,0 +1,72 @@
+package blobs
+
+import (
+	"time"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/spf13/viper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Blobs", func() {
+	Describe("status", func() {
+		now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+		retention := 24 * time.Hour
+
+		refs := references{
+			blobs: map[string]struct{}{"staged": {}},
+			apps:  map[models.AppRef]struct{}{models.NewAppRef("app", "workspace"): {}},
+		}
+
+		blob := func(id, app string, age time.Duration) models.Blob {
+			return models.Blob{
+				ID:           id,
+				Namespace:    "workspace",
+				App:          app,
+				LastModified: metav1.NewTime(now.Add(-age)),
+			}
+		}
+
+		It("keeps referenced blobs, whatever their age", func() {
+			Expect(status(blob("staged", "app", 30*24*time.Hour), refs, now, retention)).To(Equal(models.BlobInUse))
+			Expect(status(blob("staged", "gone", 30*24*time.Hour), refs, now, retention)).To(Equal(models.BlobInUse))
+		})
+
+		It("keeps unreferenced blobs within the retention period", func() {
+			Expect(status(blob("uploaded", "app", time.Hour), refs, now, retention)).To(Equal(models.BlobRecent))
+			Expect(status(blob("uploaded", "gone", time.Hour), refs, now, retention)).To(Equal(models.BlobRecent))
+		})
+
+		It("collects old unreferenced blobs of existing applications", func() {
+			unused := blob("failed", "app", 48*time.Hour)
+			unused.Status = status(unused, refs, now, retention)
+			Expect(unused.Status).To(Equal(models.BlobUnused))
+			Expect(unused.Collectable()).To(BeTrue())
+		})
+
+		It("collects old blobs of deleted applications", func() {
+			orphaned := blob("left", "gone", 48*time.Hour)
+			orphaned.Status = status(orphaned, refs, now, retention)
+			Expect(orphaned.Status).To(Equal(models.BlobOrphaned))
+			Expect(orphaned.Collectable()).To(BeTrue())
+		})
+	})
+
+	Describe("Retention", func() {
+		AfterEach(func() {
+			viper.Set("blobs-retention", "")
+		})
+
+		It("defaults to a day", func() {
+			Expect(Retention()).To(Equal(24 * time.Hour))
+		})
+
+		It("uses the configured retention", func() {
+			viper.Set("blobs-retention", "2h")
+			Expect(Retention()).To(Equal(2 * time.Hour))
+		})
+	})
+})
@@ -0,0 +1,169 @@
+// Package builders manages the registry of builder images approved for staging.
+package builders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+)
+
+// ConfigMapName is the name of the config map holding the approved builders, in epinio's
+// namespace. The keys are the names of the builders, the values their JSON encoded
+// details.
+//
+// Note that the registry fails closed: without any builders in it only the system image is
+// accepted. Operators allowing other builder images have to register them.
+const ConfigMapName = "epinio-builders"
+
+// entry is the JSON encoded value of a builder in the config map
+type entry struct {
+	Image       string `json:"image"`
+	Description string `json:"description,omitempty"`
+}
+
+// Validate checks the name and image of the builder
+func Validate(builder models.Builder) error {
+	if errs := validation.IsDNS1123Label(builder.Name); len(errs) > 0 {
+		return fmt.Errorf("builder name '%s' is invalid: %v", builder.Name, errs)
+	}
+	if builder.Image == "" {
+		return errors.New("builder image is missing")
+	}
+	return nil
+}
+
+// List returns the approved builders, sorted by name
+func List(ctx context.Context, cluster *kubernetes.Cluster) (models.BuilderList, error) {
+	configMap, err := cluster.GetConfigMap(ctx, helmchart.Namespace(), ConfigMapName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return models.BuilderList{}, nil
+		}
+		return nil, err
+	}
+
+	result := models.BuilderList{}
+	for name, encoded := range configMap.Data {
+		var details entry
+		if err := json.Unmarshal([]byte(encoded), &details); err != nil {
+			return nil, errors.Wrapf(err, "bad builder '%s'", name)
+		}
+		result = append(result, models.Builder{
+			Name:        name,
+			Image:       details.Image,
+			Description: details.Description,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// Lookup returns the named builder, or nil
+func Lookup(ctx context.Context, cluster *kubernetes.Cluster, name string) (*models.Builder, error) {
+	list, err := List(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	for _, builder := range list {
+		if builder.Name == name {
+			builder := builder
+			return &builder, nil
+		}
+	}
+	return nil, nil
+}
+
+// Set adds the builder to the registry, or replaces the builder of the same name.
+func Set(ctx context.Context, cluster *kubernetes.Cluster, builder models.Builder) error {
+	encoded, err := json.Marshal(entry{Image: builder.Image, Description: builder.Description})
+	if err != nil {
+		return err
+	}
+
+	return update(ctx, cluster, func(data map[string]string) {
+		data[builder.Name] = string(encoded)
+	})
+}
+
+// Delete removes the named builder from the registry. Removing an unknown builder is a
+// no-op.
+func Delete(ctx context.Context, cluster *kubernetes.Cluster, name string) error {
+	return update(ctx, cluster, func(data map[string]string) {
+		delete(data, name)
+	})
+}
+
+// Resolve returns the image to stage with for the requested builder, and whether it is
+// approved. The request is either the name of a builder, or an image. The system image,
+// i.e. the builder configured for the staging scripts, is always approved. Other images
+// are approved only if registered, see ConfigMapName.
+func Resolve(builders models.BuilderList, systemImage, requested string) (string, bool) {
+	for _, builder := range builders {
+		if builder.Name == requested {
+			return builder.Image, true
+		}
+	}
+
+	if requested == systemImage {
+		return requested, true
+	}
+
+	for _, builder := range builders {
+		if builder.Image == requested {
+			return requested, true
+		}
+	}
+
+	return "", false
+}
+
+// update encapsulates the read/modify/write cycle for the registry. The config map
+// holding it is created if necessary.
+func update(ctx context.Context, cluster *kubernetes.Cluster, modify func(map[string]string)) error {
+	client := cluster.Kubectl.CoreV1().ConfigMaps(helmchart.Namespace())
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := client.Get(ctx, ConfigMapName, metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+
+			data := map[string]string{}
+			modify(data)
+
+			_, err = client.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ConfigMapName,
+					Namespace: helmchart.Namespace(),
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "epinio",
+						"epinio.suse.org/area":         "builders",
+					},
+				},
+				Data: data,
+			}, metav1.CreateOptions{})
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		modify(configMap.Data)
+
+		_, err = client.Update(ctx, configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
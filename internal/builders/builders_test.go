@@ -0,0 +1,62 @@
+package builders_test
+
+import (
+	"github.com/epinio/epinio/internal/builders"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Builders", func() {
+	const system = "paketobuildpacks/builder:full"
+
+	registry := models.BuilderList{
+		{Name: "base", Image: "paketobuildpacks/builder:base"},
+		{Name: "tiny", Image: "paketobuildpacks/builder:tiny"},
+	}
+
+	It("resolves builder names to their images", func() {
+		image, ok := builders.Resolve(registry, system, "tiny")
+		Expect(ok).To(BeTrue())
+		Expect(image).To(Equal("paketobuildpacks/builder:tiny"))
+	})
+
+	It("accepts the images of approved builders and the system builder", func() {
+		image, ok := builders.Resolve(registry, system, "paketobuildpacks/builder:base")
+		Expect(ok).To(BeTrue())
+		Expect(image).To(Equal("paketobuildpacks/builder:base"))
+
+		image, ok = builders.Resolve(registry, system, system)
+		Expect(ok).To(BeTrue())
+		Expect(image).To(Equal(system))
+	})
+
+	It("rejects unapproved images", func() {
+		_, ok := builders.Resolve(registry, system, "example.com/evil/builder:latest")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("accepts only the system image while the registry is empty", func() {
+		_, ok := builders.Resolve(models.BuilderList{}, system, "example.com/custom/builder:1")
+		Expect(ok).To(BeFalse())
+
+		image, ok := builders.Resolve(models.BuilderList{}, system, system)
+		Expect(ok).To(BeTrue())
+		Expect(image).To(Equal(system))
+	})
+
+	It("rejects builder names unknown to the registry", func() {
+		_, ok := builders.Resolve(registry, system, "full")
+		Expect(ok).To(BeFalse())
+
+		_, ok = builders.Resolve(models.BuilderList{}, system, "tiny")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("validates builders", func() {
+		Expect(builders.Validate(models.Builder{Name: "tiny", Image: "paketobuildpacks/builder:tiny"})).To(Succeed())
+		Expect(builders.Validate(models.Builder{Name: "Not_Valid", Image: "paketobuildpacks/builder:tiny"})).ToNot(Succeed())
+		Expect(builders.Validate(models.Builder{Name: "tiny"})).ToNot(Succeed())
+	})
+})
@@ -20,8 +20,12 @@ var CmdApp = &cobra.Command{
 
 func init() {
 	CmdAppList.Flags().Bool("all", false, "list all applications")
+	CmdAppList.Flags().StringP("selector", "l", "", "list only applications whose labels match the selector, e.g. team=payments")
+	CmdAppUpdate.Flags().StringSlice("unlabel", []string{}, "labels to remove from the application")
+	CmdAppRestart.Flags().StringP("selector", "l", "", "restart all applications whose labels match the selector, instead of the named one")
 	CmdAppLogs.Flags().Bool("follow", false, "follow the logs of the application")
 	CmdAppLogs.Flags().Bool("staging", false, "show the staging logs of the application")
+	CmdAppSBOM.Flags().String("output", "", "directory to save the SBOM documents in")
 	CmdAppExec.Flags().StringP("instance", "i", "", "The name of the instance to shell to")
 	CmdAppPortForward.Flags().StringSliceVar(&portForwardAddress, "address", []string{"localhost"}, "Addresses to listen on (comma separated). Only accepts IP addresses or localhost as a value. When localhost is supplied, kubectl will try to bind on both 127.0.0.1 and ::1 and will fail if neither of these addresses are available to bind.")
 	CmdAppPortForward.Flags().StringVarP(&portForwardInstance, "instance", "i", "", "The name of the instance to shell to")
@@ -34,6 +38,8 @@ func init() {
 	envOption(CmdAppUpdate)
 	instancesOption(CmdAppCreate)
 	instancesOption(CmdAppUpdate)
+	appMetadataOption(CmdAppCreate)
+	appMetadataOption(CmdAppUpdate)
 
 	CmdAppCreate.Flags().String("app-chart", "", "App chart to use for deployment")
 	CmdAppUpdate.Flags().String("app-chart", "", "App chart to use for deployment")
@@ -49,18 +55,26 @@ func init() {
 	CmdApp.AddCommand(CmdAppManifest)
 	CmdApp.AddCommand(CmdAppShow)
 	CmdApp.AddCommand(CmdAppExport)
+	CmdApp.AddCommand(CmdAppSBOM)
 	CmdApp.AddCommand(CmdAppUpdate)
 	CmdApp.AddCommand(CmdAppDelete)
 	CmdApp.AddCommand(CmdAppPush) // See push.go for implementation
 	CmdApp.AddCommand(CmdAppRestart)
 	CmdApp.AddCommand(CmdAppRestage)
+	CmdApp.AddCommand(CmdAppCache) // See cache.go for implementation
+
+	CmdAppRestage.Flags().Bool("no-cache", false, "clear the build cache of the application before staging")
+
+	CmdAppPromote.Flags().String("to", "", "namespace to promote the application to")
+	promoteOption(CmdAppPromote)
+	CmdApp.AddCommand(CmdAppPromote)
 }
 
 // CmdAppList implements the command: epinio app list
 var CmdAppList = &cobra.Command{
-	Use:   "list [--all]",
+	Use:   "list [--all] [--selector SELECTOR]",
 	Short: "Lists applications",
-	Long:  "Lists applications in the targeted namespace, or all",
+	Long:  "Lists applications in the targeted namespace, or all. With a selector only the applications whose labels match it are listed",
 	Args:  cobra.ExactArgs(0),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
@@ -75,7 +89,12 @@ var CmdAppList = &cobra.Command{
 			return errors.Wrap(err, "error reading option --all")
 		}
 
-		err = client.Apps(all)
+		selector, err := cmd.Flags().GetString("selector")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --selector")
+		}
+
+		err = client.Apps(all, selector)
 		// Note: errors.Wrap (nil, "...") == nil
 		return errors.Wrap(err, "error listing apps")
 	},
@@ -111,6 +130,11 @@ var CmdAppCreate = &cobra.Command{
 			return err
 		}
 
+		m, err = manifest.UpdateMetadata(m, cmd)
+		if err != nil {
+			return errors.Wrap(err, "unable to get labels and annotations")
+		}
+
 		err = client.AppCreate(args[0], m.Configuration)
 		// Note: errors.Wrap (nil, "...") == nil
 		return errors.Wrap(err, "error creating app")
@@ -159,6 +183,37 @@ var CmdAppExport = &cobra.Command{
 	},
 }
 
+// CmdAppSBOM implements the command: epinio apps sbom
+var CmdAppSBOM = &cobra.Command{
+	Use:               "sbom NAME [STAGE_ID]",
+	Short:             "Show the software bill of materials of the named application",
+	Long:              "Show the staging, buildpacks, and software bills of materials of the deployed image of the named application, or of the image of the given staging. Buildpacks export software bills of materials only when staging with platform API 0.8 or higher, the default. Stagings with a lower platform API, see the platformAPI key of the staging configuration, are refused. The bills of materials of recorded stagings stay available after their images are garbage collected",
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: matchingAppsFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		output, err := cmd.Flags().GetString("output")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --output")
+		}
+
+		stageID := ""
+		if len(args) == 2 {
+			stageID = args[1]
+		}
+
+		err = client.AppSBOM(args[0], stageID, output)
+		// Note: errors.Wrap (nil, "...") == nil
+		return errors.Wrap(err, "error showing app sbom")
+	},
+}
+
 // CmdAppLogs implements the command: epinio apps logs
 var CmdAppLogs = &cobra.Command{
 	Use:   "logs NAME",
@@ -282,6 +337,16 @@ var CmdAppUpdate = &cobra.Command{
 			return errors.Wrap(err, "unable to update domains")
 		}
 
+		m, err = manifest.UpdateMetadata(m, cmd)
+		if err != nil {
+			return errors.Wrap(err, "unable to get labels and annotations")
+		}
+
+		m.Configuration.RemoveLabels, err = cmd.Flags().GetStringSlice("unlabel")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --unlabel")
+		}
+
 		err = client.AppUpdate(args[0], m.Configuration)
 		// Note: errors.Wrap (nil, "...") == nil
 		return errors.Wrap(err, "error updating the app")
@@ -311,24 +376,80 @@ var CmdAppManifest = &cobra.Command{
 
 // CmdAppRestart implements the command: epinio app restart
 var CmdAppRestart = &cobra.Command{
-	Use:               "restart NAME",
+	Use:               "restart NAME|--selector SELECTOR",
 	Short:             "Restart the application",
-	Args:              cobra.ExactArgs(1),
+	Long:              "Restart the named application, or all applications whose labels match the selector",
+	Args:              cobra.MaximumNArgs(1),
 	ValidArgsFunction: matchingAppsFinder,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
 
+		selector, err := cmd.Flags().GetString("selector")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --selector")
+		}
+		if (len(args) == 0) == (selector == "") {
+			cmd.SilenceUsage = false
+			return errors.New("specify either the name of an application, or a selector")
+		}
+
 		client, err := usercmd.New()
 		if err != nil {
 			return errors.Wrap(err, "error initializing cli")
 		}
 
+		if selector != "" {
+			err = client.AppsRestart(selector)
+			// Note: errors.Wrap (nil, "...") == nil
+			return errors.Wrap(err, "error restarting apps")
+		}
+
 		err = client.AppRestart(args[0])
 		// Note: errors.Wrap (nil, "...") == nil
 		return errors.Wrap(err, "error restarting app")
 	},
 }
 
+// CmdAppPromote implements the command: epinio app promote
+var CmdAppPromote = &cobra.Command{
+	Use:   "promote NAME --to NAMESPACE",
+	Short: "Promote the application to another namespace",
+	Long: `Copy the application to another namespace, with the configurations and services it uses, and deploy the image it runs there, without staging.
+Configurations and services already present in the other namespace are used as they are.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingAppsFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		target, err := cmd.Flags().GetString("to")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --to")
+		}
+		if target == "" {
+			return errors.New("namespace to promote to not specified, use --to")
+		}
+
+		pattern, overrides, skipServices, err := promoteOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.AppPromote(args[0], models.AppPromoteRequest{
+			Namespace:              target,
+			RoutePattern:           pattern,
+			ConfigurationOverrides: overrides,
+			SkipServices:           skipServices,
+		})
+		// Note: errors.Wrap (nil, "...") == nil
+		return errors.Wrap(err, "error promoting app")
+	},
+}
+
 // CmdAppRestage implements the command: epinio app restage
 var CmdAppRestage = &cobra.Command{
 	Use:               "restage NAME",
@@ -343,7 +464,12 @@ var CmdAppRestage = &cobra.Command{
 			return errors.Wrap(err, "error initializing cli")
 		}
 
-		err = client.AppRestage(args[0])
+		noCache, err := cmd.Flags().GetBool("no-cache")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --no-cache")
+		}
+
+		err = client.AppRestage(args[0], noCache)
 		// Note: errors.Wrap (nil, "...") == nil
 		return errors.Wrap(err, "error restaging app")
 	},
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// CmdBlobs implements the command: epinio blobs
+var CmdBlobs = &cobra.Command{
+	Use:           "blobs",
+	Short:         "Epinio application source blob management",
+	Long:          `Inspect the blobs holding the sources of applications, and collect those not in use anymore. Requires admin rights.`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Args:          cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cmd.Usage(); err != nil {
+			return err
+		}
+		return fmt.Errorf(`Unknown method "%s"`, args[0])
+	},
+}
+
+func init() {
+	CmdBlobsGC.Flags().Bool("dry-run", false, "only show the blobs which would be deleted")
+
+	CmdBlobs.AddCommand(CmdBlobsList)
+	CmdBlobs.AddCommand(CmdBlobsGC)
+}
+
+// CmdBlobsList implements the command: epinio blobs list
+var CmdBlobsList = &cobra.Command{
+	Use:   "list",
+	Short: "List the application source blobs",
+	Long:  "List the blobs holding application sources, with their status for the garbage collection. Requires admin rights.",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.BlobsList()
+		if err != nil {
+			return errors.Wrap(err, "error listing blobs")
+		}
+
+		return nil
+	},
+}
+
+// CmdBlobsGC implements the command: epinio blobs gc
+var CmdBlobsGC = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete the application source blobs not in use anymore",
+	Long:  `Delete the blobs not used by any application or staging, and older than the retention period of the server. Abort incomplete uploads older than that as well. Requires admin rights.`,
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return errors.Wrap(err, "could not read dry-run parameter")
+		}
+
+		err = client.BlobsGC(dryRun)
+		if err != nil {
+			return errors.Wrap(err, "error collecting blobs")
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// CmdBuilder implements the command: epinio builder
+var CmdBuilder = &cobra.Command{
+	Use:           "builder",
+	Short:         "Epinio builder management",
+	Long:          `Manage the builder images approved for staging. Besides the system builder image only approved builders are accepted.`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Args:          cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cmd.Usage(); err != nil {
+			return err
+		}
+		return fmt.Errorf(`Unknown method "%s"`, args[0])
+	},
+}
+
+func init() {
+	CmdBuilderSet.Flags().String("description", "", "description of the builder")
+
+	CmdBuilder.AddCommand(CmdBuilderList)
+	CmdBuilder.AddCommand(CmdBuilderSet)
+	CmdBuilder.AddCommand(CmdBuilderDelete)
+}
+
+// CmdBuilderList implements the command: epinio builder list
+var CmdBuilderList = &cobra.Command{
+	Use:   "list",
+	Short: "List the approved builders",
+	Long:  "List the approved builders",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.BuilderList()
+		if err != nil {
+			return errors.Wrap(err, "error listing builders")
+		}
+
+		return nil
+	},
+}
+
+// CmdBuilderSet implements the command: epinio builder set
+var CmdBuilderSet = &cobra.Command{
+	Use:   "set NAME IMAGE",
+	Short: "Approve the builder IMAGE under NAME",
+	Long:  `Approve the builder IMAGE under NAME, replacing the image of an existing builder NAME. Requires admin rights.`,
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		description, err := cmd.Flags().GetString("description")
+		if err != nil {
+			return errors.Wrap(err, "could not read description parameter")
+		}
+
+		err = client.BuilderSet(args[0], args[1], description)
+		if err != nil {
+			return errors.Wrap(err, "error approving builder")
+		}
+
+		return nil
+	},
+}
+
+// CmdBuilderDelete implements the command: epinio builder delete
+var CmdBuilderDelete = &cobra.Command{
+	Use:   "delete NAME",
+	Short: "Remove the builder NAME from the approved builders",
+	Long:  `Remove the builder NAME from the approved builders. Builders which are the default builder of a namespace cannot be removed. Requires admin rights.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.BuilderDelete(args[0])
+		if err != nil {
+			return errors.Wrap(err, "error removing builder")
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// CmdAppCache implements the command: epinio app cache
+var CmdAppCache = &cobra.Command{
+	Use:   "cache",
+	Short: "Epinio application build cache management",
+	Long:  `Manage the build cache of epinio applications`,
+}
+
+func init() {
+	CmdAppCache.AddCommand(CmdAppCacheShow)
+	CmdAppCache.AddCommand(CmdAppCacheClear)
+}
+
+// CmdAppCacheShow implements the command: epinio app cache show
+var CmdAppCacheShow = &cobra.Command{
+	Use:               "show NAME",
+	Short:             "Show the build cache of the application",
+	Long:              "Show the capacity, storage class, and last use of the build cache of the application",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingAppsFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.AppCacheShow(args[0])
+		// Note: errors.Wrap (nil, "...") == nil
+		return errors.Wrap(err, "error showing app build cache")
+	},
+}
+
+// CmdAppCacheClear implements the command: epinio app cache clear
+var CmdAppCacheClear = &cobra.Command{
+	Use:               "clear NAME",
+	Short:             "Clear the build cache of the application",
+	Long:              "Clear the build cache of the application. The next staging builds from scratch.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingAppsFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.AppCacheClear(args[0])
+		// Note: errors.Wrap (nil, "...") == nil
+		return errors.Wrap(err, "error clearing app build cache")
+	},
+}
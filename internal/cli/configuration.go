@@ -1,17 +1,24 @@
 package cli
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
 )
 
 func init() {
-	CmdConfigurationDelete.Flags().Bool("unbind", false, "Unbind from applications before deleting")
+	CmdConfigurationDelete.Flags().Bool("unbind", false, "Unbind from applications, and drop environment variables referencing it, before deleting")
 	CmdConfiguration.AddCommand(CmdConfigurationShow)
 	CmdConfiguration.AddCommand(CmdConfigurationCreate)
 	CmdConfiguration.AddCommand(CmdConfigurationUpdate)
@@ -19,9 +26,24 @@ func init() {
 	CmdConfiguration.AddCommand(CmdConfigurationBind)
 	CmdConfiguration.AddCommand(CmdConfigurationUnbind)
 	CmdConfiguration.AddCommand(CmdConfigurationList)
+	CmdConfiguration.AddCommand(CmdConfigurationHistory)
+	CmdConfiguration.AddCommand(CmdConfigurationRevert)
+
+	CmdConfigurationRevert.Flags().Bool("restart", false, "Restart the applications bound to the configuration")
 
 	CmdConfigurationList.Flags().Bool("all", false, "list all configurations")
 
+	CmdConfigurationCreate.Flags().StringSlice("from-file", []string{}, "add a key from a file, as `[KEY=]PATH`. The key defaults to the file's name")
+	CmdConfigurationCreate.Flags().StringSlice("from-env-file", []string{}, "add the KEY=VALUE lines of an env file")
+	CmdConfigurationCreate.Flags().StringSlice("from-yaml", []string{}, "add the keys of a yaml file holding a map of scalar values")
+	CmdConfigurationCreate.Flags().String("type", "", "type of the configuration, one of custom (default), tls, docker-registry, basic-auth")
+
+	CmdConfigurationBind.Flags().StringSlice("key", []string{}, "Key of the configuration to bind, as KEY[=NAME]. Default: all keys")
+	CmdConfigurationBind.Flags().Bool("env", false, "Inject the bound keys as environment variables")
+	CmdConfigurationBind.Flags().String("env-prefix", "", "Prefix for the names of the injected environment variables")
+	CmdConfigurationBind.Flags().String("mount-path", "", "Directory to mount the bound keys at. Default: /configurations/NAME")
+	CmdConfigurationBind.Flags().String("mode", "", "Octal permission mode of the mounted files. Default: 0644")
+
 	changeOptions(CmdConfigurationUpdate)
 }
 
@@ -69,9 +91,23 @@ var CmdConfigurationShow = &cobra.Command{
 var CmdConfigurationCreate = &cobra.Command{
 	Use:   "create NAME (KEY VALUE)...",
 	Short: "Create a configuration",
-	Long:  `Create configuration by name and key/value dictionary.`,
+	Long: `Create configuration by name and key/value dictionary.
+
+Keys can further be loaded from files (--from-file), env files (--from-env-file), and yaml
+files (--from-yaml). The content of files loaded with --from-file is kept as is, i.e. may
+be binary.
+
+Configurations of type tls, docker-registry, and basic-auth are checked by the server to
+contain the keys required for the type:
+
+  tls:             tls.crt, tls.key
+  docker-registry: server, username, password, and optional email
+  basic-auth:      username, password`,
 	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) < 3 {
+		if len(args) < 1 {
+			return errors.New("Not enough arguments, expected name")
+		}
+		if len(args) == 1 && !hasDataSources(cmd) {
 			return errors.New("Not enough arguments, expected name, key, and value")
 		}
 		if len(args)%2 == 0 {
@@ -91,6 +127,39 @@ var CmdConfigurationUpdate = &cobra.Command{
 	RunE:  ConfigurationUpdate,
 }
 
+// CmdConfigurationHistory implements the command: epinio configuration history
+var CmdConfigurationHistory = &cobra.Command{
+	Use:   "history NAME",
+	Short: "List the revisions of a configuration",
+	Long:  `List the revisions of the named configuration, newest first, with the keys each changed.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  ConfigurationHistory,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		epinioClient, err := usercmd.New()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		matches := epinioClient.ConfigurationMatching(context.Background(), toComplete)
+
+		return matches, cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+// CmdConfigurationRevert implements the command: epinio configuration revert
+var CmdConfigurationRevert = &cobra.Command{
+	Use:   "revert NAME REVISION",
+	Short: "Revert a configuration to one of its revisions",
+	Long: `Replace the data of the named configuration with the data of the revision.
+The bound applications are restarted only when --restart is specified.`,
+	Args: cobra.ExactArgs(2),
+	RunE: ConfigurationRevert,
+}
+
 // CmdConfigurationDelete implements the command: epinio configuration delete
 var CmdConfigurationDelete = &cobra.Command{
 	Use:   "delete NAME",
@@ -118,9 +187,13 @@ var CmdConfigurationDelete = &cobra.Command{
 var CmdConfigurationBind = &cobra.Command{
 	Use:   "bind NAME APP",
 	Short: "Bind a configuration to an application",
-	Long:  `Bind configuration by name, to named application.`,
-	Args:  cobra.ExactArgs(2),
-	RunE:  ConfigurationBind,
+	Long: `Bind configuration by name, to named application.
+
+By default all keys of the configuration are mounted as files into the directory
+/configurations/NAME. The options allow the selection and renaming of keys, a different
+directory and file mode, or the injection of the keys as environment variables instead.`,
+	Args: cobra.ExactArgs(2),
+	RunE: ConfigurationBind,
 	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) > 1 {
 			return nil, cobra.ShellCompDirectiveNoFileComp
@@ -231,7 +304,68 @@ func ConfigurationCreate(cmd *cobra.Command, args []string) error {
 		return errors.Wrap(err, "error initializing cli")
 	}
 
-	err = client.CreateConfiguration(args[0], args[1:])
+	data := map[string]string{}
+	binaryData := map[string][]byte{}
+
+	envFiles, err := cmd.Flags().GetStringSlice("from-env-file")
+	if err != nil {
+		return errors.Wrap(err, "failed to read option --from-env-file")
+	}
+	for _, path := range envFiles {
+		err := readEnvFile(path, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	yamlFiles, err := cmd.Flags().GetStringSlice("from-yaml")
+	if err != nil {
+		return errors.Wrap(err, "failed to read option --from-yaml")
+	}
+	for _, path := range yamlFiles {
+		err := readYAMLFile(path, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	files, err := cmd.Flags().GetStringSlice("from-file")
+	if err != nil {
+		return errors.Wrap(err, "failed to read option --from-file")
+	}
+	for _, spec := range files {
+		key, path := spec, spec
+		if pieces := strings.SplitN(spec, "=", 2); len(pieces) == 2 {
+			key, path = pieces[0], pieces[1]
+		} else {
+			key = filepath.Base(path)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read file %s", path)
+		}
+		binaryData[key] = content
+	}
+
+	// Explicit key/value arguments take priority over the keys from files.
+	dict := args[1:]
+	for i := 0; i < len(dict); i += 2 {
+		data[dict[i]] = dict[i+1]
+	}
+
+	for key := range binaryData {
+		if _, ok := data[key]; ok {
+			return fmt.Errorf("key %s specified more than once", key)
+		}
+	}
+
+	configurationType, err := cmd.Flags().GetString("type")
+	if err != nil {
+		return errors.Wrap(err, "failed to read option --type")
+	}
+
+	err = client.CreateConfiguration(args[0], data, binaryData, configurationType)
 	if err != nil {
 		return errors.Wrap(err, "error creating configuration")
 	}
@@ -239,6 +373,77 @@ func ConfigurationCreate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// hasDataSources returns true if the command got any of the file-based data options
+func hasDataSources(cmd *cobra.Command) bool {
+	for _, name := range []string{"from-file", "from-env-file", "from-yaml"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// readEnvFile adds the KEY=VALUE lines of the env file to the data. Empty lines and
+// comments are ignored, as are `export` prefixes. Quotes around values are removed.
+func readEnvFile(path string, data map[string]string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read env file %s", path)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		pieces := strings.SplitN(line, "=", 2)
+		if len(pieces) != 2 || strings.TrimSpace(pieces[0]) == "" {
+			return fmt.Errorf("bad line %d in env file %s, expected KEY=VALUE", lineNo, path)
+		}
+
+		value := strings.TrimSpace(pieces[1])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		data[strings.TrimSpace(pieces[0])] = value
+	}
+
+	return errors.Wrapf(scanner.Err(), "failed to read env file %s", path)
+}
+
+// readYAMLFile adds the keys of the yaml file to the data. The file has to contain a map
+// of scalar values.
+func readYAMLFile(path string, data map[string]string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read yaml file %s", path)
+	}
+
+	values := map[string]interface{}{}
+	err = yaml.Unmarshal(content, &values)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse yaml file %s", path)
+	}
+
+	for key, value := range values {
+		switch value.(type) {
+		case map[interface{}]interface{}, []interface{}:
+			return fmt.Errorf("bad key %s in yaml file %s, expected a scalar value", key, path)
+		case nil:
+			data[key] = ""
+		default:
+			data[key] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	return nil
+}
+
 // ConfigurationUpdate is the backend of command: epinio configuration update
 func ConfigurationUpdate(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
@@ -277,6 +482,50 @@ func ConfigurationUpdate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// ConfigurationHistory is the backend of command: epinio configuration history
+func ConfigurationHistory(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	client, err := usercmd.New()
+	if err != nil {
+		return errors.Wrap(err, "error initializing cli")
+	}
+
+	err = client.ConfigurationHistory(args[0])
+	if err != nil {
+		return errors.Wrap(err, "error listing configuration revisions")
+	}
+
+	return nil
+}
+
+// ConfigurationRevert is the backend of command: epinio configuration revert
+func ConfigurationRevert(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	revision, err := strconv.Atoi(args[1])
+	if err != nil {
+		return errors.Wrap(err, "error parsing revision")
+	}
+
+	restart, err := cmd.Flags().GetBool("restart")
+	if err != nil {
+		return errors.Wrap(err, "error reading option --restart")
+	}
+
+	client, err := usercmd.New()
+	if err != nil {
+		return errors.Wrap(err, "error initializing cli")
+	}
+
+	err = client.ConfigurationRevert(args[0], revision, restart)
+	if err != nil {
+		return errors.Wrap(err, "error reverting configuration")
+	}
+
+	return nil
+}
+
 // ConfigurationDelete is the backend of command: epinio configuration delete
 func ConfigurationDelete(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
@@ -308,7 +557,12 @@ func ConfigurationBind(cmd *cobra.Command, args []string) error {
 		return errors.Wrap(err, "error initializing cli")
 	}
 
-	err = client.BindConfiguration(args[0], args[1])
+	options, err := bindOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	err = client.BindConfiguration(args[0], args[1], options)
 	if err != nil {
 		return errors.Wrap(err, "error binding configuration")
 	}
@@ -316,6 +570,58 @@ func ConfigurationBind(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// bindOptions converts the options of the configuration bind command into the options
+// of the binding.
+func bindOptions(cmd *cobra.Command) (models.BindOptions, error) {
+	options := models.BindOptions{}
+
+	keySpecs, err := cmd.Flags().GetStringSlice("key")
+	if err != nil {
+		return options, errors.Wrap(err, "error reading option --key")
+	}
+	for _, spec := range keySpecs {
+		key, as := spec, spec
+		if keyAndName := strings.SplitN(spec, "=", 2); len(keyAndName) == 2 {
+			key, as = keyAndName[0], keyAndName[1]
+		}
+		if key == "" || as == "" {
+			return options, errors.New("Bad --key `" + spec + "`, expected `KEY[=NAME]` as value")
+		}
+		if options.Keys == nil {
+			options.Keys = map[string]string{}
+		}
+		options.Keys[key] = as
+	}
+
+	options.AsEnv, err = cmd.Flags().GetBool("env")
+	if err != nil {
+		return options, errors.Wrap(err, "error reading option --env")
+	}
+	options.EnvPrefix, err = cmd.Flags().GetString("env-prefix")
+	if err != nil {
+		return options, errors.Wrap(err, "error reading option --env-prefix")
+	}
+	options.MountPath, err = cmd.Flags().GetString("mount-path")
+	if err != nil {
+		return options, errors.Wrap(err, "error reading option --mount-path")
+	}
+
+	mode, err := cmd.Flags().GetString("mode")
+	if err != nil {
+		return options, errors.Wrap(err, "error reading option --mode")
+	}
+	if mode != "" {
+		value, err := strconv.ParseInt(mode, 8, 32)
+		if err != nil {
+			return options, errors.New("Bad --mode `" + mode + "`, expected an octal number")
+		}
+		mode32 := int32(value)
+		options.Mode = &mode32
+	}
+
+	return options, options.Validate()
+}
+
 // ConfigurationUnbind is the backend of command: epinio configuration unbind
 func ConfigurationUnbind(cmd *cobra.Command, args []string) error {
 	cmd.SilenceUsage = true
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -16,6 +17,10 @@ var CmdAppEnv = &cobra.Command{
 }
 
 func init() {
+	CmdEnvList.Flags().Bool("reveal", false, "Show the values of variables referencing configuration keys")
+	CmdEnvShow.Flags().Bool("reveal", false, "Show the value of a variable referencing a configuration key")
+	CmdEnvSet.Flags().String("from", "", "Take the value from a configuration key, as `CONFIGURATION/KEY`")
+
 	CmdAppEnv.AddCommand(CmdEnvList)
 	CmdAppEnv.AddCommand(CmdEnvSet)
 	CmdAppEnv.AddCommand(CmdEnvShow)
@@ -37,7 +42,12 @@ var CmdEnvList = &cobra.Command{
 			return errors.Wrap(err, "error initializing cli")
 		}
 
-		err = client.EnvList(cmd.Context(), args[0])
+		reveal, err := cmd.Flags().GetBool("reveal")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --reveal")
+		}
+
+		err = client.EnvList(cmd.Context(), args[0], reveal)
 		if err != nil {
 			return errors.Wrap(err, "error listing app environment")
 		}
@@ -48,10 +58,19 @@ var CmdEnvList = &cobra.Command{
 
 // CmdEnvSet implements the command: epinio app env set
 var CmdEnvSet = &cobra.Command{
-	Use:   "set APPNAME NAME VALUE",
+	Use:   "set APPNAME NAME (VALUE|--from CONFIGURATION/KEY)",
 	Short: "Extend application environment",
-	Long:  "Add or change environment variable of named application",
-	Args:  cobra.ExactArgs(3),
+	Long: `Add or change environment variable of named application.
+
+With --from the variable takes its value from the key of a configuration when the
+application is deployed. The value is then not stored with the variable, and shown
+masked by "env list" and "env show", unless revealed.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("from") {
+			return cobra.ExactArgs(2)(cmd, args)
+		}
+		return cobra.ExactArgs(3)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
 
@@ -61,6 +80,23 @@ var CmdEnvSet = &cobra.Command{
 			return errors.Wrap(err, "error initializing cli")
 		}
 
+		from, err := cmd.Flags().GetString("from")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --from")
+		}
+
+		if from != "" {
+			source, err := models.ParseEnvVariableSource(from)
+			if err != nil {
+				return err
+			}
+			err = client.EnvSetReference(cmd.Context(), args[0], args[1], source)
+			if err != nil {
+				return errors.Wrap(err, "error setting into app environment")
+			}
+			return nil
+		}
+
 		err = client.EnvSet(cmd.Context(), args[0], args[1], args[2])
 		if err != nil {
 			return errors.Wrap(err, "error setting into app environment")
@@ -101,7 +137,12 @@ var CmdEnvShow = &cobra.Command{
 			return errors.Wrap(err, "error initializing cli")
 		}
 
-		err = client.EnvShow(cmd.Context(), args[0], args[1])
+		reveal, err := cmd.Flags().GetBool("reveal")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --reveal")
+		}
+
+		err = client.EnvShow(cmd.Context(), args[0], args[1], reveal)
 		if err != nil {
 			return errors.Wrap(err, "error accessing app environment")
 		}
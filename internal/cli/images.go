@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// CmdImages implements the command: epinio images
+var CmdImages = &cobra.Command{
+	Use:           "images",
+	Short:         "Epinio application image management",
+	Long:          `Inspect the stage images of the applications in the Epinio registry, and delete those expired by the retention policy. Requires admin rights.`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Args:          cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cmd.Usage(); err != nil {
+			return err
+		}
+		return fmt.Errorf(`Unknown method "%s"`, args[0])
+	},
+}
+
+func init() {
+	CmdImagesGC.Flags().Int("keep", -1, "number of stage images to keep per application, defaults to the retention of the server")
+	CmdImagesGC.Flags().Bool("dry-run", false, "only show the images which would be deleted")
+
+	CmdImages.AddCommand(CmdImagesList)
+	CmdImages.AddCommand(CmdImagesGC)
+}
+
+// CmdImagesList implements the command: epinio images list
+var CmdImagesList = &cobra.Command{
+	Use:   "list",
+	Short: "List the application images",
+	Long:  "List the stage images of the applications, with their status for the retention policy. Requires admin rights.",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ImagesList()
+		if err != nil {
+			return errors.Wrap(err, "error listing images")
+		}
+
+		return nil
+	},
+}
+
+// CmdImagesGC implements the command: epinio images gc
+var CmdImagesGC = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete the expired application images",
+	Long:  `Delete the stage images of the applications beyond the last ones kept as rollback targets. Deployed images are never deleted. The registry has to allow deletion. Requires admin rights.`,
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		keep, err := cmd.Flags().GetInt("keep")
+		if err != nil {
+			return errors.Wrap(err, "could not read keep parameter")
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return errors.Wrap(err, "could not read dry-run parameter")
+		}
+
+		err = client.ImagesGC(keep, dryRun)
+		if err != nil {
+			return errors.Wrap(err, "error deleting images")
+		}
+
+		return nil
+	},
+}
@@ -7,12 +7,14 @@ import (
 	"strings"
 
 	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 var (
-	force bool
+	force            bool
+	namespaceRestart bool
 )
 
 // CmdNamespace implements the command: epinio namespace
@@ -36,26 +38,64 @@ func init() {
 
 	flags := CmdNamespaceDelete.Flags()
 	flags.BoolVarP(&force, "force", "f", false, "force namespace deletion")
+	flags.Duration("grace", 0, "scale the applications to zero, and delete the namespace after this grace period, e.g. 24h. The deletion can be undone until then")
+	flags.Bool("preview", false, "only show what the deletion would destroy")
 
 	CmdNamespace.AddCommand(CmdNamespaceCreate)
 	CmdNamespace.AddCommand(CmdNamespaceList)
 	CmdNamespace.AddCommand(CmdNamespaceDelete)
+	CmdNamespace.AddCommand(CmdNamespaceUndelete)
 	CmdNamespace.AddCommand(CmdNamespaceShow)
+
+	quotaOption(CmdNamespaceCreate)
+	quotaOption(CmdNamespaceUpdate)
+	metadataOption(CmdNamespaceCreate)
+	metadataOption(CmdNamespaceUpdate)
+	CmdNamespaceUpdate.Flags().StringSlice("unlabel", []string{}, "remove labels, by key")
+	CmdNamespaceUpdate.Flags().Bool("protected", false, "protect the namespace against deletion, or remove the protection with --protected=false. Admin only")
+	CmdNamespace.AddCommand(CmdNamespaceUpdate)
+
+	CmdNamespaceList.Flags().StringP("selector", "l", "", "list only namespaces whose labels match the selector, e.g. team=payments")
+
+	for _, cmd := range []*cobra.Command{CmdNamespaceEnvSet, CmdNamespaceEnvUnset, CmdNamespaceBind, CmdNamespaceUnbind} {
+		cmd.Flags().BoolVar(&namespaceRestart, "restart", false, "restart the applications of the namespace to pick up the change")
+	}
+
+	CmdNamespaceEnv.AddCommand(CmdNamespaceEnvList)
+	CmdNamespaceEnv.AddCommand(CmdNamespaceEnvSet)
+	CmdNamespaceEnv.AddCommand(CmdNamespaceEnvUnset)
+
+	CmdNamespaceBuilder.AddCommand(CmdNamespaceBuilderSet)
+	CmdNamespaceBuilder.AddCommand(CmdNamespaceBuilderUnset)
+
+	CmdNamespace.AddCommand(CmdNamespaceEnv)
+	CmdNamespace.AddCommand(CmdNamespaceBuilder)
+	CmdNamespace.AddCommand(CmdNamespaceBind)
+	CmdNamespace.AddCommand(CmdNamespaceUnbind)
+
+	promoteOption(CmdNamespaceClone)
+	CmdNamespace.AddCommand(CmdNamespaceClone)
 }
 
 // CmdNamespaces implements the command: epinio namespace list
 var CmdNamespaceList = &cobra.Command{
-	Use:   "list",
+	Use:   "list [--selector SELECTOR]",
 	Short: "Lists all epinio-controlled namespaces",
+	Long:  "Lists all epinio-controlled namespaces, or those whose labels match the selector",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
 
+		selector, err := cmd.Flags().GetString("selector")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --selector")
+		}
+
 		client, err := usercmd.New()
 		if err != nil {
 			return errors.Wrap(err, "error initializing cli")
 		}
 
-		err = client.Namespaces()
+		err = client.Namespaces(selector)
 		if err != nil {
 			return errors.Wrap(err, "error listing epinio-controlled namespaces")
 		}
@@ -77,7 +117,35 @@ var CmdNamespaceCreate = &cobra.Command{
 			return errors.Wrap(err, "error initializing cli")
 		}
 
-		err = client.CreateNamespace(args[0])
+		var quota *models.NamespaceQuota
+		if hasQuotaFlags(cmd) {
+			q, err := quotaFromFlags(cmd, models.NamespaceQuota{})
+			if err != nil {
+				return err
+			}
+			quota = &q
+		}
+
+		labels, err := labelsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		description, err := cmd.Flags().GetString("description")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --description")
+		}
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --owner")
+		}
+
+		err = client.CreateNamespace(models.NamespaceCreateRequest{
+			Name:        args[0],
+			Quota:       quota,
+			Labels:      labels,
+			Description: description,
+			Owner:       owner,
+		})
 		if err != nil {
 			return errors.Wrap(err, "error creating epinio-controlled namespace")
 		}
@@ -97,7 +165,21 @@ var CmdNamespaceDelete = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if !force {
+		preview, err := cmd.Flags().GetBool("preview")
+		if err != nil {
+			return err
+		}
+		if !force && !preview {
+			client, err := usercmd.New()
+			if err != nil {
+				return errors.Wrap(err, "error initializing cli")
+			}
+
+			err = client.NamespaceDeletionPreview(args[0])
+			if err != nil {
+				return errors.Wrap(err, "error previewing namespace deletion")
+			}
+
 			cmd.Printf("You are about to delete namespace %s and everything it includes, i.e. applications, configurations, etc. Are you sure? (y/n): ", args[0])
 			if !askConfirmation(cmd) {
 				return errors.New("Cancelled by user")
@@ -114,7 +196,21 @@ var CmdNamespaceDelete = &cobra.Command{
 			return errors.Wrap(err, "error initializing cli")
 		}
 
-		err = client.DeleteNamespace(args[0])
+		preview, err := cmd.Flags().GetBool("preview")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --preview")
+		}
+		if preview {
+			err = client.NamespaceDeletionPreview(args[0])
+			return errors.Wrap(err, "error previewing namespace deletion")
+		}
+
+		grace, err := cmd.Flags().GetDuration("grace")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --grace")
+		}
+
+		err = client.DeleteNamespace(args[0], grace)
 		if err != nil {
 			return errors.Wrap(err, "error deleting epinio-controlled namespace")
 		}
@@ -123,6 +219,30 @@ var CmdNamespaceDelete = &cobra.Command{
 	},
 }
 
+// CmdNamespaceUndelete implements the command: epinio namespace undelete
+var CmdNamespaceUndelete = &cobra.Command{
+	Use:               "undelete NAME",
+	Short:             "Cancels the pending deletion of an epinio-controlled namespace",
+	Long:              "Cancels the pending deletion of a namespace deleted with a grace period, and scales its applications back up.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.UndeleteNamespace(args[0])
+		if err != nil {
+			return errors.Wrap(err, "error restoring epinio-controlled namespace")
+		}
+
+		return nil
+	},
+}
+
 // CmdNamespaceShow implements the command: epinio namespace show
 var CmdNamespaceShow = &cobra.Command{
 	Use:               "show NAME",
@@ -146,6 +266,444 @@ var CmdNamespaceShow = &cobra.Command{
 	},
 }
 
+// CmdNamespaceUpdate implements the command: epinio namespace update
+var CmdNamespaceUpdate = &cobra.Command{
+	Use:   "update NAME",
+	Short: "Updates the quota and metadata of an epinio-controlled namespace",
+	Long: `Updates the quota, labels, description, owner, and protection of an epinio-controlled namespace.
+Quota limits not specified are kept. A limit of 0, or an empty quantity, removes it. Changing the quota is for admins only.
+An empty description or owner removes it.
+Protected namespaces cannot be deleted. Changing the protection is for admins only.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		if !hasQuotaFlags(cmd) && !hasMetadataFlags(cmd) {
+			return errors.New("no changes specified")
+		}
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		if hasMetadataFlags(cmd) {
+			request, err := metadataUpdateFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			err = client.UpdateNamespace(args[0], request)
+			if err != nil {
+				return errors.Wrap(err, "error updating epinio-controlled namespace")
+			}
+		}
+
+		if !hasQuotaFlags(cmd) {
+			return nil
+		}
+
+		current, err := client.NamespaceQuota(args[0])
+		if err != nil {
+			return errors.Wrap(err, "error reading namespace quota")
+		}
+
+		quota, err := quotaFromFlags(cmd, current)
+		if err != nil {
+			return err
+		}
+
+		err = client.UpdateNamespaceQuota(args[0], quota)
+		if err != nil {
+			return errors.Wrap(err, "error updating epinio-controlled namespace")
+		}
+
+		return nil
+	},
+}
+
+// metadataOption initializes the label, description and owner options for the provided
+// command
+func metadataOption(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringSlice("label", []string{}, "labels of the namespace, as KEY=VALUE")
+	flags.String("description", "", "description of the namespace")
+	flags.String("owner", "", "team owning the namespace")
+}
+
+// hasMetadataFlags returns true if any of the label, description, or owner options was
+// specified
+func hasMetadataFlags(cmd *cobra.Command) bool {
+	for _, name := range []string{"label", "unlabel", "description", "owner", "protected"} {
+		if cmd.Flags().Lookup(name) != nil && cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// labelsFromFlags returns the labels specified by the --label options
+func labelsFromFlags(cmd *cobra.Command) (map[string]string, error) {
+	values, err := cmd.Flags().GetStringSlice("label")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read option --label")
+	}
+
+	labels := map[string]string{}
+	for _, value := range values {
+		key, label, ok := strings.Cut(value, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("bad label '%s', expected KEY=VALUE", value)
+		}
+		labels[key] = label
+	}
+
+	return labels, nil
+}
+
+// metadataUpdateFromFlags returns the metadata changes specified by the options
+func metadataUpdateFromFlags(cmd *cobra.Command) (models.NamespaceUpdateRequest, error) {
+	request := models.NamespaceUpdateRequest{}
+
+	labels, err := labelsFromFlags(cmd)
+	if err != nil {
+		return request, err
+	}
+	request.Labels = labels
+
+	request.RemoveLabels, err = cmd.Flags().GetStringSlice("unlabel")
+	if err != nil {
+		return request, errors.Wrap(err, "could not read option --unlabel")
+	}
+
+	if cmd.Flags().Changed("description") {
+		description, err := cmd.Flags().GetString("description")
+		if err != nil {
+			return request, errors.Wrap(err, "could not read option --description")
+		}
+		request.Description = &description
+	}
+
+	if cmd.Flags().Changed("owner") {
+		owner, err := cmd.Flags().GetString("owner")
+		if err != nil {
+			return request, errors.Wrap(err, "could not read option --owner")
+		}
+		request.Owner = &owner
+	}
+
+	if cmd.Flags().Changed("protected") {
+		protected, err := cmd.Flags().GetBool("protected")
+		if err != nil {
+			return request, errors.Wrap(err, "could not read option --protected")
+		}
+		request.Protected = &protected
+	}
+
+	return request, nil
+}
+
+// quotaCounts maps the options for the quota counts to the fields holding them
+var quotaCounts = map[string]func(*models.NamespaceQuota) *int64{
+	"max-apps":           func(q *models.NamespaceQuota) *int64 { return &q.Apps },
+	"max-instances":      func(q *models.NamespaceQuota) *int64 { return &q.Instances },
+	"max-services":       func(q *models.NamespaceQuota) *int64 { return &q.Services },
+	"max-configurations": func(q *models.NamespaceQuota) *int64 { return &q.Configurations },
+}
+
+// quotaQuantities maps the options for the quota quantities to the fields holding them
+var quotaQuantities = map[string]func(*models.NamespaceQuota) *string{
+	"max-cpu":    func(q *models.NamespaceQuota) *string { return &q.CPU },
+	"max-memory": func(q *models.NamespaceQuota) *string { return &q.Memory },
+}
+
+// quotaOption initializes the quota options for the provided command
+func quotaOption(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.Int64("max-apps", 0, "maximum number of applications (0 = unlimited)")
+	flags.Int64("max-instances", 0, "maximum number of instances over all applications (0 = unlimited)")
+	flags.Int64("max-services", 0, "maximum number of services (0 = unlimited)")
+	flags.Int64("max-configurations", 0, "maximum number of configurations (0 = unlimited)")
+	flags.String("max-cpu", "", "maximum total CPU requested by the pods, e.g. 2 or 500m")
+	flags.String("max-memory", "", "maximum total memory requested by the pods, e.g. 4Gi")
+}
+
+// hasQuotaFlags returns true if any of the quota options was specified
+func hasQuotaFlags(cmd *cobra.Command) bool {
+	for name := range quotaCounts {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	for name := range quotaQuantities {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// quotaFromFlags returns the base quota with the specified quota options applied
+func quotaFromFlags(cmd *cobra.Command, base models.NamespaceQuota) (models.NamespaceQuota, error) {
+	quota := base
+
+	for name, field := range quotaCounts {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+		value, err := cmd.Flags().GetInt64(name)
+		if err != nil {
+			return quota, errors.Wrapf(err, "could not read option --%s", name)
+		}
+		*field(&quota) = value
+	}
+
+	for name, field := range quotaQuantities {
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
+		value, err := cmd.Flags().GetString(name)
+		if err != nil {
+			return quota, errors.Wrapf(err, "could not read option --%s", name)
+		}
+		*field(&quota) = value
+	}
+
+	return quota, nil
+}
+
+// CmdNamespaceEnv implements the command: epinio namespace env
+var CmdNamespaceEnv = &cobra.Command{
+	Use:           "env",
+	Short:         "Namespace default environment",
+	Long:          `Manage the default environment variables of the applications in a namespace. Variables set by an application take precedence.`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Args:          cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cmd.Usage(); err != nil {
+			return err
+		}
+		return fmt.Errorf(`Unknown method "%s"`, args[0])
+	},
+}
+
+// CmdNamespaceEnvList implements the command: epinio namespace env list
+var CmdNamespaceEnvList = &cobra.Command{
+	Use:               "list NAME",
+	Short:             "Lists the default environment variables of the namespace",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.NamespaceEnvList(args[0])
+		if err != nil {
+			return errors.Wrap(err, "error listing namespace default environment")
+		}
+
+		return nil
+	},
+}
+
+// CmdNamespaceEnvSet implements the command: epinio namespace env set
+var CmdNamespaceEnvSet = &cobra.Command{
+	Use:               "set NAME VARIABLE VALUE",
+	Short:             "Extends or modifies the default environment of the namespace",
+	Args:              cobra.ExactArgs(3),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.NamespaceEnvSet(args[0], args[1], args[2], namespaceRestart)
+		if err != nil {
+			return errors.Wrap(err, "error setting namespace default environment variable")
+		}
+
+		return nil
+	},
+}
+
+// CmdNamespaceEnvUnset implements the command: epinio namespace env unset
+var CmdNamespaceEnvUnset = &cobra.Command{
+	Use:               "unset NAME VARIABLE",
+	Short:             "Shrinks the default environment of the namespace",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.NamespaceEnvUnset(args[0], args[1], namespaceRestart)
+		if err != nil {
+			return errors.Wrap(err, "error removing namespace default environment variable")
+		}
+
+		return nil
+	},
+}
+
+// CmdNamespaceBuilder implements the command: epinio namespace builder
+var CmdNamespaceBuilder = &cobra.Command{
+	Use:           "builder",
+	Short:         "Namespace default builder",
+	Long:          `Manage the default builder of the applications in a namespace. Builders chosen by an application take precedence.`,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Args:          cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cmd.Usage(); err != nil {
+			return err
+		}
+		return fmt.Errorf(`Unknown method "%s"`, args[0])
+	},
+}
+
+// CmdNamespaceBuilderSet implements the command: epinio namespace builder set
+var CmdNamespaceBuilderSet = &cobra.Command{
+	Use:               "set NAME BUILDER",
+	Short:             "Sets the default builder of the namespace",
+	Long:              `Sets the default builder of the namespace to the approved BUILDER. Requires admin rights.`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.UpdateNamespaceBuilder(args[0], args[1])
+		if err != nil {
+			return errors.Wrap(err, "error setting namespace default builder")
+		}
+
+		return nil
+	},
+}
+
+// CmdNamespaceBuilderUnset implements the command: epinio namespace builder unset
+var CmdNamespaceBuilderUnset = &cobra.Command{
+	Use:               "unset NAME",
+	Short:             "Removes the default builder of the namespace",
+	Long:              `Removes the default builder of the namespace. Requires admin rights.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.UpdateNamespaceBuilder(args[0], "")
+		if err != nil {
+			return errors.Wrap(err, "error removing namespace default builder")
+		}
+
+		return nil
+	},
+}
+
+// CmdNamespaceBind implements the command: epinio namespace bind
+var CmdNamespaceBind = &cobra.Command{
+	Use:               "bind NAME CONFIGURATION...",
+	Short:             "Binds configurations to all applications of the namespace",
+	Long:              "Binds configurations to all applications of the namespace, present and future.",
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.NamespaceBind(args[0], args[1:], namespaceRestart)
+		if err != nil {
+			return errors.Wrap(err, "error binding configurations to namespace")
+		}
+
+		return nil
+	},
+}
+
+// CmdNamespaceUnbind implements the command: epinio namespace unbind
+var CmdNamespaceUnbind = &cobra.Command{
+	Use:               "unbind NAME CONFIGURATION",
+	Short:             "Unbinds a configuration from all applications of the namespace",
+	Long:              "Unbinds a configuration bound by the namespace. Bindings made to individual applications are not affected.",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.NamespaceUnbind(args[0], args[1], namespaceRestart)
+		if err != nil {
+			return errors.Wrap(err, "error unbinding configuration from namespace")
+		}
+
+		return nil
+	},
+}
+
+// CmdNamespaceClone implements the command: epinio namespace clone
+var CmdNamespaceClone = &cobra.Command{
+	Use:   "clone SOURCE NAME",
+	Short: "Creates an epinio-controlled namespace as copy of another",
+	Long: `Creates an epinio-controlled namespace as copy of another, with its quota, defaults, configurations, services and applications.
+The applications are deployed with the images they run in the source namespace, without staging.`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: matchingNamespaceFinder,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		pattern, overrides, skipServices, err := promoteOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.CloneNamespace(args[0], args[1], models.NamespaceCloneRequest{
+			RoutePattern:           pattern,
+			ConfigurationOverrides: overrides,
+			SkipServices:           skipServices,
+		})
+		if err != nil {
+			return errors.Wrap(err, "error cloning epinio-controlled namespace")
+		}
+
+		return nil
+	},
+}
+
 // askConfirmation is a helper for CmdNamespaceDelete to confirm a deletion request
 func askConfirmation(cmd *cobra.Command) bool {
 	reader := bufio.NewReader(os.Stdin)
@@ -1,10 +1,13 @@
 package cli
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/epinio/epinio/internal/api/v1/application"
+	"github.com/epinio/epinio/internal/api/v1/promote"
 	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -66,3 +69,52 @@ func bindOption(cmd *cobra.Command) {
 func envOption(cmd *cobra.Command) {
 	cmd.Flags().StringSliceP("env", "e", []string{}, "environment variables to be used")
 }
+
+// appMetadataOption initializes the --label and --annotation options for the provided command
+func appMetadataOption(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("label", []string{}, "labels of the application, as KEY=VALUE")
+	cmd.Flags().StringArray("annotation", []string{}, "annotations of the application, as KEY=VALUE. Can be set multiple times. An empty value removes the annotation")
+}
+
+// promoteOption initializes the options of the namespace clone and app promote commands
+func promoteOption(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.String("route-pattern", "",
+		fmt.Sprintf("pattern for the routes of the copied applications, using {host}, {app}, {namespace} and {domain} (default %q)", promote.DefaultRoutePattern))
+	flags.StringArray("override", []string{}, "replace a value of a copied configuration, as CONFIGURATION/KEY=VALUE. Can be set multiple times.")
+	flags.Bool("skip-services", false, "do not copy services. Bindings to their configurations are dropped")
+}
+
+// promoteOptions converts the options of the namespace clone and app promote commands into
+// route pattern, configuration overrides and service handling
+func promoteOptions(cmd *cobra.Command) (string, map[string]map[string]string, bool, error) {
+	flags := cmd.Flags()
+
+	pattern, err := flags.GetString("route-pattern")
+	if err != nil {
+		return "", nil, false, errors.Wrap(err, "could not read option --route-pattern")
+	}
+	skipServices, err := flags.GetBool("skip-services")
+	if err != nil {
+		return "", nil, false, errors.Wrap(err, "could not read option --skip-services")
+	}
+	values, err := flags.GetStringArray("override")
+	if err != nil {
+		return "", nil, false, errors.Wrap(err, "could not read option --override")
+	}
+
+	overrides := map[string]map[string]string{}
+	for _, value := range values {
+		configuration, assignment, ok := strings.Cut(value, "/")
+		key, data, found := strings.Cut(assignment, "=")
+		if !ok || !found || configuration == "" || key == "" {
+			return "", nil, false, fmt.Errorf("bad override '%s', expected CONFIGURATION/KEY=VALUE", value)
+		}
+		if _, ok := overrides[configuration]; !ok {
+			overrides[configuration] = map[string]string{}
+		}
+		overrides[configuration][key] = data
+	}
+
+	return pattern, overrides, skipServices, nil
+}
@@ -19,13 +19,21 @@ func init() {
 	CmdAppPush.Flags().String("container-image-url", "", "Container image url for the app workload image")
 	CmdAppPush.Flags().StringP("name", "n", "", "Application name. (mandatory if no manifest is provided)")
 	CmdAppPush.Flags().StringP("path", "p", "", "Path to application sources.")
-	CmdAppPush.Flags().String("builder-image", "", "Paketo builder image to use for staging")
+	CmdAppPush.Flags().String("builder-image", "", "Paketo builder image to use for staging, or the name of an approved builder, see epinio builder list")
+	CmdAppPush.Flags().String("strategy", "", "Staging strategy, buildpacks (default) or dockerfile")
+	CmdAppPush.Flags().String("dockerfile", "", "Path of the Dockerfile in the sources, for the dockerfile strategy (default \"Dockerfile\")")
+	CmdAppPush.Flags().StringArray("build-arg", []string{}, "Build arg for the dockerfile strategy, as NAME=VALUE")
+	CmdAppPush.Flags().StringArray("build-env", []string{}, "Environment variable of the staging only, as NAME=VALUE")
+	CmdAppPush.Flags().StringSlice("build-secret", []string{}, "Secret environment variable of the staging only, as NAME=CONFIGURATION/KEY")
 	CmdAppPush.Flags().String("app-chart", "", "App chart to use for deployment")
+	CmdAppPush.Flags().StringSlice("exclude", []string{}, "Sources to not upload, in gitignore syntax. Adds to the patterns of the manifest, and of the .epinioignore files")
+	CmdAppPush.Flags().Bool("dry-run-upload", false, "Show the sources which would be uploaded, and the size of their archive, without pushing anything")
 
 	routeOption(CmdAppPush)
 	bindOption(CmdAppPush)
 	envOption(CmdAppPush)
 	instancesOption(CmdAppPush)
+	appMetadataOption(CmdAppPush)
 }
 
 // CmdAppPush implements the command: epinio app push
@@ -78,6 +86,21 @@ var CmdAppPush = &cobra.Command{
 			return err
 		}
 
+		m, err = manifest.UpdateMetadata(m, cmd)
+		if err != nil {
+			return err
+		}
+
+		m, err = manifest.UpdateExcludes(m, cmd)
+		if err != nil {
+			return err
+		}
+
+		m, err = manifest.UpdateBuildEnvironment(m, cmd)
+		if err != nil {
+			return err
+		}
+
 		// Final manifest verify: Name is specified
 
 		if m.Name == "" {
@@ -104,6 +127,16 @@ var CmdAppPush = &cobra.Command{
 			ApplicationManifest: m,
 		}
 
+		dryRun, err := cmd.Flags().GetBool("dry-run-upload")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --dry-run-upload")
+		}
+		if dryRun {
+			err = client.UploadPreview(params)
+			// Note: errors.Wrap (nil, "...") == nil
+			return errors.Wrap(err, "error collecting the application sources")
+		}
+
 		err = client.Push(cmd.Context(), params)
 		if err != nil {
 			return errors.Wrap(err, "error pushing app to server")
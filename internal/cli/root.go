@@ -81,6 +81,9 @@ func init() {
 	rootCmd.AddCommand(CmdServer)
 	rootCmd.AddCommand(cmdVersion)
 	rootCmd.AddCommand(CmdServices)
+	rootCmd.AddCommand(CmdBuilder)
+	rootCmd.AddCommand(CmdBlobs)
+	rootCmd.AddCommand(CmdImages)
 	// Hidden command providing developer tools
 	rootCmd.AddCommand(CmdDebug)
 }
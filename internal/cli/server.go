@@ -14,7 +14,14 @@ import (
 
 	"github.com/epinio/epinio/helpers/termui"
 	"github.com/epinio/epinio/helpers/tracelog"
+	"github.com/epinio/epinio/internal/api/v1/blob"
+	"github.com/epinio/epinio/internal/api/v1/image"
+	"github.com/epinio/epinio/internal/api/v1/namespace"
+	"github.com/epinio/epinio/internal/application"
+	"github.com/epinio/epinio/internal/blobs"
 	"github.com/epinio/epinio/internal/cli/server"
+	"github.com/epinio/epinio/internal/images"
+	"github.com/epinio/epinio/internal/namespaces"
 	"github.com/epinio/epinio/internal/version"
 	"github.com/gin-gonic/gin"
 
@@ -57,6 +64,74 @@ func init() {
 	flags.String("ingress-class-name", "", "(INGRESS_CLASS_NAME) Name of the ingress class to use for apps. Leave empty to add no ingressClassName to the ingress.")
 	viper.BindPFlag("ingress-class-name", flags.Lookup("ingress-class-name"))
 	viper.BindEnv("ingress-class-name", "INGRESS_CLASS_NAME")
+
+	flags.String("namespace-annotations", namespaces.DefaultAnnotations, "(NAMESPACE_ANNOTATIONS) Comma-separated KEY=VALUE annotations for new namespaces, e.g. to choose service mesh injection. A KEY= without value drops that annotation.")
+	viper.BindPFlag("namespace-annotations", flags.Lookup("namespace-annotations"))
+	viper.BindEnv("namespace-annotations", "NAMESPACE_ANNOTATIONS")
+
+	flags.String("staging-cache-size", application.DefaultCacheSize, "(STAGING_CACHE_SIZE) Size of the build cache volume of new applications")
+	viper.BindPFlag("staging-cache-size", flags.Lookup("staging-cache-size"))
+	viper.BindEnv("staging-cache-size", "STAGING_CACHE_SIZE")
+
+	flags.String("staging-cache-storage-class", "", "(STAGING_CACHE_STORAGE_CLASS) Storage class of the build cache volume of new applications. Leave empty to use the default storage class.")
+	viper.BindPFlag("staging-cache-storage-class", flags.Lookup("staging-cache-storage-class"))
+	viper.BindEnv("staging-cache-storage-class", "STAGING_CACHE_STORAGE_CLASS")
+
+	flags.String("staging-cpu-request", "", "(STAGING_CPU_REQUEST) CPU request of the staging jobs. Leave empty for no request.")
+	viper.BindPFlag("staging-cpu-request", flags.Lookup("staging-cpu-request"))
+	viper.BindEnv("staging-cpu-request", "STAGING_CPU_REQUEST")
+
+	flags.String("staging-memory-request", "", "(STAGING_MEMORY_REQUEST) Memory request of the staging jobs. Leave empty for no request.")
+	viper.BindPFlag("staging-memory-request", flags.Lookup("staging-memory-request"))
+	viper.BindEnv("staging-memory-request", "STAGING_MEMORY_REQUEST")
+
+	flags.String("staging-cpu-limit", "", "(STAGING_CPU_LIMIT) CPU limit of the staging jobs, and maximum of their CPU settings per application. Leave empty for no limit.")
+	viper.BindPFlag("staging-cpu-limit", flags.Lookup("staging-cpu-limit"))
+	viper.BindEnv("staging-cpu-limit", "STAGING_CPU_LIMIT")
+
+	flags.String("staging-memory-limit", "", "(STAGING_MEMORY_LIMIT) Memory limit of the staging jobs, and maximum of their memory settings per application. Leave empty for no limit.")
+	viper.BindPFlag("staging-memory-limit", flags.Lookup("staging-memory-limit"))
+	viper.BindEnv("staging-memory-limit", "STAGING_MEMORY_LIMIT")
+
+	flags.String("staging-node-selector", "", "(STAGING_NODE_SELECTOR) Comma-separated KEY=VALUE node labels the staging jobs are scheduled on.")
+	viper.BindPFlag("staging-node-selector", flags.Lookup("staging-node-selector"))
+	viper.BindEnv("staging-node-selector", "STAGING_NODE_SELECTOR")
+
+	flags.String("staging-tolerations", "", "(STAGING_TOLERATIONS) Comma-separated KEY[=VALUE][:EFFECT] taints tolerated by the staging jobs.")
+	viper.BindPFlag("staging-tolerations", flags.Lookup("staging-tolerations"))
+	viper.BindEnv("staging-tolerations", "STAGING_TOLERATIONS")
+
+	flags.String("staging-timeout", "", "(STAGING_TIMEOUT) Maximal run time of the staging jobs, e.g. 30m, also for applications setting their own. Leave empty for no limit.")
+	viper.BindPFlag("staging-timeout", flags.Lookup("staging-timeout"))
+	viper.BindEnv("staging-timeout", "STAGING_TIMEOUT")
+
+	flags.Int32("staging-backoff-limit", 0, "(STAGING_BACKOFF_LIMIT) Number of retries of failed staging jobs")
+	viper.BindPFlag("staging-backoff-limit", flags.Lookup("staging-backoff-limit"))
+	viper.BindEnv("staging-backoff-limit", "STAGING_BACKOFF_LIMIT")
+
+	flags.Duration("blobs-retention", blobs.DefaultRetention, "(BLOBS_RETENTION) Minimal age of unused application source blobs and incomplete uploads before they are collected")
+	viper.BindPFlag("blobs-retention", flags.Lookup("blobs-retention"))
+	viper.BindEnv("blobs-retention", "BLOBS_RETENTION")
+
+	flags.Duration("blobs-gc-interval", time.Hour, "(BLOBS_GC_INTERVAL) Interval of the background collection of unused application source blobs. Zero disables it.")
+	viper.BindPFlag("blobs-gc-interval", flags.Lookup("blobs-gc-interval"))
+	viper.BindEnv("blobs-gc-interval", "BLOBS_GC_INTERVAL")
+
+	flags.Bool("blobs-gc-dry-run", false, "(BLOBS_GC_DRY_RUN) Only log the blobs the background collection would delete")
+	viper.BindPFlag("blobs-gc-dry-run", flags.Lookup("blobs-gc-dry-run"))
+	viper.BindEnv("blobs-gc-dry-run", "BLOBS_GC_DRY_RUN")
+
+	flags.Int("image-retention", images.DefaultRetention, "(IMAGE_RETENTION) Number of stage images kept per application in the registry, in addition to the deployed ones")
+	viper.BindPFlag("image-retention", flags.Lookup("image-retention"))
+	viper.BindEnv("image-retention", "IMAGE_RETENTION")
+
+	flags.Duration("image-gc-interval", 0, "(IMAGE_GC_INTERVAL) Interval of the background deletion of expired stage images. Zero disables it. The registry has to allow deletion.")
+	viper.BindPFlag("image-gc-interval", flags.Lookup("image-gc-interval"))
+	viper.BindEnv("image-gc-interval", "IMAGE_GC_INTERVAL")
+
+	flags.Bool("image-gc-dry-run", false, "(IMAGE_GC_DRY_RUN) Only log the stage images the background deletion would delete")
+	viper.BindPFlag("image-gc-dry-run", flags.Lookup("image-gc-dry-run"))
+	viper.BindEnv("image-gc-dry-run", "IMAGE_GC_DRY_RUN")
 }
 
 // CmdServer implements the command: epinio server
@@ -68,6 +143,17 @@ var CmdServer = &cobra.Command{
 		cmd.SilenceUsage = true
 		logger := tracelog.NewLogger().WithName("EpinioServer")
 
+		if _, _, err := application.CacheSettings(); err != nil {
+			return err
+		}
+		stagingDefaults, err := application.StagingDefaults()
+		if err != nil {
+			return err
+		}
+		if _, err := application.NewStagingJob(stagingDefaults); err != nil {
+			return err
+		}
+
 		handler, err := server.NewHandler(logger)
 		if err != nil {
 			return errors.Wrap(err, "error creating handler")
@@ -79,6 +165,19 @@ var CmdServer = &cobra.Command{
 			return errors.Wrap(err, "error creating listener")
 		}
 
+		// Delete soft-deleted namespaces once their grace period is over
+		go namespace.Reap(context.Background(), logger.WithName("NamespaceReaper"), time.Minute)
+
+		// Delete the blobs of application sources not in use anymore
+		if interval := viper.GetDuration("blobs-gc-interval"); interval > 0 {
+			go blob.Reap(context.Background(), logger.WithName("BlobReaper"), interval)
+		}
+
+		// Delete the stage images expired by the retention policy
+		if interval := viper.GetDuration("image-gc-interval"); interval > 0 {
+			go image.Reap(context.Background(), logger.WithName("ImageReaper"), interval)
+		}
+
 		ui := termui.NewUI()
 		ui.Normal().Msg("Epinio version: " + version.Version)
 		listeningPort := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
@@ -2,8 +2,11 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/epinio/epinio/internal/cli/usercmd"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -20,6 +23,11 @@ var CmdServices = &cobra.Command{
 
 func init() {
 	CmdServiceDelete.Flags().Bool("unbind", false, "Unbind from applications before deleting")
+	CmdServiceBindCreate.Flags().StringSlice("secret", []string{}, "Secret of the service to bind, all keys")
+	CmdServiceBindCreate.Flags().StringSlice("key", []string{}, "Key of a secret to bind, as SECRET:KEY[=NAME]")
+	CmdServiceBindCreate.Flags().Bool("env", false, "Inject the bound keys as environment variables")
+	CmdServiceCreate.Flags().Bool("wait", false, "Wait until the service is deployed and ready")
+	CmdServiceShow.Flags().Bool("secrets", false, "Show the values of the service's configurations")
 	CmdServices.AddCommand(CmdServiceCatalog)
 	CmdServices.AddCommand(CmdServiceCreate)
 	CmdServices.AddCommand(CmdServiceBindCreate)
@@ -27,8 +35,33 @@ func init() {
 	CmdServices.AddCommand(CmdServiceShow)
 	CmdServices.AddCommand(CmdServiceDelete)
 	CmdServices.AddCommand(CmdServiceList)
+	CmdServices.AddCommand(CmdServiceUpdate)
+	CmdServices.AddCommand(CmdServiceUpgrade)
+	CmdServices.AddCommand(CmdServiceBackup)
+	CmdServices.AddCommand(CmdServiceBackups)
+	CmdServices.AddCommand(CmdServiceRestore)
 
 	CmdServiceList.Flags().Bool("all", false, "list all services")
+
+	CmdServiceUpdate.Flags().StringSliceP("set", "s", []string{}, "helm value assignments to add/modify")
+	CmdServiceUpdate.Flags().StringSliceP("remove", "r", []string{}, "helm values to remove")
+
+	CmdServiceCatalog.AddCommand(CmdServiceCatalogAdd)
+	CmdServiceCatalog.AddCommand(CmdServiceCatalogUpdate)
+	CmdServiceCatalog.AddCommand(CmdServiceCatalogRemove)
+
+	for _, cmd := range []*cobra.Command{CmdServiceCatalogAdd, CmdServiceCatalogUpdate} {
+		cmd.Flags().String("chart", "", "name of the helm chart deploying the service")
+		cmd.Flags().String("chart-version", "", "version of the helm chart, defaults to the latest")
+		cmd.Flags().String("app-version", "", "version of the deployed service, defaults to the chart's")
+		cmd.Flags().String("repo", "", "url of the helm repository providing the chart")
+		cmd.Flags().String("description", "", "description of the service")
+		cmd.Flags().String("short-description", "", "short description of the service, for lists")
+		cmd.Flags().String("values", "", "path to a YAML file with the default helm values")
+		cmd.Flags().String("values-schema", "", "path to a JSON schema file the helm values of instances are checked against")
+		cmd.Flags().String("backup-job", "", "path to a YAML file with the pod spec of the job saving the data of an instance")
+		cmd.Flags().String("restore-job", "", "path to a YAML file with the pod spec of the job restoring the data of an instance")
+	}
 }
 
 var CmdServiceCatalog = &cobra.Command{
@@ -70,10 +103,15 @@ var CmdServiceCreate = &cobra.Command{
 			return errors.Wrap(err, "error initializing cli")
 		}
 
+		wait, err := cmd.Flags().GetBool("wait")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --wait")
+		}
+
 		catalogServiceName := args[0]
 		serviceName := args[1]
 
-		err = client.ServiceCreate(catalogServiceName, serviceName)
+		err = client.ServiceCreate(catalogServiceName, serviceName, wait)
 		return errors.Wrap(err, "error creating service")
 	},
 }
@@ -90,9 +128,14 @@ var CmdServiceShow = &cobra.Command{
 			return errors.Wrap(err, "error initializing cli")
 		}
 
+		secrets, err := cmd.Flags().GetBool("secrets")
+		if err != nil {
+			return errors.Wrap(err, "error reading option --secrets")
+		}
+
 		serviceName := args[0]
 
-		err = client.ServiceShow(serviceName)
+		err = client.ServiceShow(serviceName, secrets)
 		return errors.Wrap(err, "error showing service")
 	},
 }
@@ -123,7 +166,13 @@ var CmdServiceDelete = &cobra.Command{
 var CmdServiceBindCreate = &cobra.Command{
 	Use:   "bind SERVICENAME APPNAME",
 	Short: "Bind a service SERVICENAME to an Epinio app APPNAME",
-	Args:  cobra.ExactArgs(2),
+	Long: `Bind a service SERVICENAME to an Epinio app APPNAME.
+
+By default all secrets of the service are bound, with all their keys mounted as files.
+Use --secret to bind only the named secrets, and --key to bind only the named keys of a
+secret, optionally renamed, i.e. --key SECRET:password=DB_PASSWORD. With --env the keys
+are injected as environment variables instead of files.`,
+	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
 
@@ -132,10 +181,15 @@ var CmdServiceBindCreate = &cobra.Command{
 			return errors.Wrap(err, "error initializing cli")
 		}
 
+		secrets, err := bindSelection(cmd)
+		if err != nil {
+			return err
+		}
+
 		serviceName := args[0]
 		appName := args[1]
 
-		err = client.ServiceBind(serviceName, appName)
+		err = client.ServiceBind(serviceName, appName, secrets)
 		return errors.Wrap(err, "error binding service")
 	},
 }
@@ -186,3 +240,293 @@ var CmdServiceList = &cobra.Command{
 		return errors.Wrap(err, "error listing services")
 	},
 }
+
+var CmdServiceUpdate = &cobra.Command{
+	Use:   "update SERVICENAME",
+	Short: "Update the helm values of a service SERVICENAME",
+	Long:  `Update the helm values of the named service through change instructions given by flags. Apps bound to the service are restarted if their configurations change.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		// Process the --remove and --set options into operations (removals, assignments)
+
+		removedKeys, err := cmd.Flags().GetStringSlice("remove")
+		if err != nil {
+			return errors.Wrap(err, "failed to read option --remove")
+		}
+
+		kvAssignments, err := cmd.Flags().GetStringSlice("set")
+		if err != nil {
+			return errors.Wrap(err, "failed to read option --set")
+		}
+
+		assignments := map[string]string{}
+		for _, assignment := range kvAssignments {
+			pieces := strings.SplitN(assignment, "=", 2)
+			if len(pieces) != 2 {
+				return errors.New("Bad --set assignment `" + assignment + "`, expected `name=value` as value")
+			}
+			assignments[pieces[0]] = pieces[1]
+		}
+
+		serviceName := args[0]
+
+		err = client.ServiceUpdate(serviceName, removedKeys, assignments)
+		return errors.Wrap(err, "error updating service")
+	},
+}
+
+var CmdServiceUpgrade = &cobra.Command{
+	Use:   "upgrade SERVICENAME",
+	Short: "Upgrade a service SERVICENAME to the chart version of its catalog service",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		serviceName := args[0]
+
+		err = client.ServiceUpgrade(serviceName)
+		return errors.Wrap(err, "error upgrading service")
+	},
+}
+
+var CmdServiceBackup = &cobra.Command{
+	Use:   "backup SERVICENAME",
+	Short: "Save the data of service SERVICENAME",
+	Long: `Save the data of service SERVICENAME in the Epinio S3 storage, by running the backup job of its catalog service.
+Waits for the job to finish, and prints the id of the new backup, for use with "epinio service restore". The logs of a failed job are shown.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceBackup(args[0])
+		return errors.Wrap(err, "error backing up service")
+	},
+}
+
+var CmdServiceBackups = &cobra.Command{
+	Use:   "backups SERVICENAME",
+	Short: "List the backups of service SERVICENAME",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceBackups(args[0])
+		return errors.Wrap(err, "error listing service backups")
+	},
+}
+
+var CmdServiceRestore = &cobra.Command{
+	Use:   "restore SERVICENAME BACKUP_ID",
+	Short: "Restore the data of service SERVICENAME from backup BACKUP_ID",
+	Long:  `Restore the data of service SERVICENAME from backup BACKUP_ID, by running the restore job of its catalog service. Waits for the job to finish. The logs of a failed job are shown.`,
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceRestore(args[0], args[1])
+		return errors.Wrap(err, "error restoring service")
+	},
+}
+
+var CmdServiceCatalogAdd = &cobra.Command{
+	Use:   "add NAME",
+	Short: "Add a service NAME to the Epinio catalog",
+	Long:  `Add a service NAME to the Epinio catalog. Requires admin rights.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		changes, err := catalogServiceChanges(cmd)
+		if err != nil {
+			return err
+		}
+
+		catalogService := models.CatalogService{
+			Meta: models.MetaLite{Name: args[0]},
+		}
+		set := func(field *string, value *string) {
+			if value != nil {
+				*field = *value
+			}
+		}
+		set(&catalogService.HelmChart, changes.HelmChart)
+		set(&catalogService.ChartVersion, changes.ChartVersion)
+		set(&catalogService.AppVersion, changes.AppVersion)
+		set(&catalogService.HelmRepo.URL, changes.HelmRepoURL)
+		set(&catalogService.Description, changes.Description)
+		set(&catalogService.ShortDescription, changes.ShortDescription)
+		set(&catalogService.Values, changes.Values)
+		set(&catalogService.ValuesSchema, changes.ValuesSchema)
+		set(&catalogService.BackupJob, changes.BackupJob)
+		set(&catalogService.RestoreJob, changes.RestoreJob)
+
+		err = client.ServiceCatalogAdd(catalogService)
+		return errors.Wrap(err, "error adding catalog service")
+	},
+}
+
+var CmdServiceCatalogUpdate = &cobra.Command{
+	Use:   "update NAME",
+	Short: "Update the catalog service NAME",
+	Long:  `Update the catalog service NAME. Only the specified properties are changed. Requires admin rights.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		changes, err := catalogServiceChanges(cmd)
+		if err != nil {
+			return err
+		}
+
+		err = client.ServiceCatalogUpdate(args[0], changes)
+		return errors.Wrap(err, "error updating catalog service")
+	},
+}
+
+var CmdServiceCatalogRemove = &cobra.Command{
+	Use:   "remove NAME",
+	Short: "Remove the service NAME from the Epinio catalog",
+	Long:  `Remove the service NAME from the Epinio catalog. Fails while instances of the service exist. Requires admin rights.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		client, err := usercmd.New()
+		if err != nil {
+			return errors.Wrap(err, "error initializing cli")
+		}
+
+		err = client.ServiceCatalogRemove(args[0])
+		return errors.Wrap(err, "error removing catalog service")
+	},
+}
+
+// catalogServiceChanges collects the catalog service properties specified by the
+// flags of the command. The values, schema, and job options are paths to files whose contents
+// are used.
+func catalogServiceChanges(cmd *cobra.Command) (models.ServiceCatalogUpdateRequest, error) {
+	changes := models.ServiceCatalogUpdateRequest{}
+
+	options := map[string]**string{
+		"chart":             &changes.HelmChart,
+		"chart-version":     &changes.ChartVersion,
+		"app-version":       &changes.AppVersion,
+		"repo":              &changes.HelmRepoURL,
+		"description":       &changes.Description,
+		"short-description": &changes.ShortDescription,
+		"values":            &changes.Values,
+		"values-schema":     &changes.ValuesSchema,
+		"backup-job":        &changes.BackupJob,
+		"restore-job":       &changes.RestoreJob,
+	}
+
+	for option, field := range options {
+		if !cmd.Flags().Changed(option) {
+			continue
+		}
+
+		value, err := cmd.Flags().GetString(option)
+		if err != nil {
+			return changes, errors.Wrap(err, "error reading option --"+option)
+		}
+
+		switch option {
+		case "values", "values-schema", "backup-job", "restore-job":
+			content, err := os.ReadFile(value)
+			if err != nil {
+				return changes, errors.Wrap(err, "error reading file of option --"+option)
+			}
+			value = string(content)
+		}
+
+		*field = &value
+	}
+
+	return changes, nil
+}
+
+// bindSelection converts the --secret, --key, and --env options of the bind command into
+// the selection of service secrets to bind. An empty selection binds everything.
+func bindSelection(cmd *cobra.Command) (map[string]models.BindOptions, error) {
+	secretNames, err := cmd.Flags().GetStringSlice("secret")
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading option --secret")
+	}
+	keySpecs, err := cmd.Flags().GetStringSlice("key")
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading option --key")
+	}
+	asEnv, err := cmd.Flags().GetBool("env")
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading option --env")
+	}
+
+	selection := map[string]models.BindOptions{}
+	for _, secret := range secretNames {
+		selection[secret] = models.BindOptions{AsEnv: asEnv}
+	}
+
+	for _, spec := range keySpecs {
+		secretAndKey := strings.SplitN(spec, ":", 2)
+		if len(secretAndKey) != 2 || secretAndKey[0] == "" || secretAndKey[1] == "" {
+			return nil, errors.New("Bad --key `" + spec + "`, expected `SECRET:KEY[=NAME]` as value")
+		}
+		secret := secretAndKey[0]
+
+		key, as := secretAndKey[1], secretAndKey[1]
+		if keyAndName := strings.SplitN(secretAndKey[1], "=", 2); len(keyAndName) == 2 {
+			key, as = keyAndName[0], keyAndName[1]
+		}
+
+		options := selection[secret]
+		options.AsEnv = asEnv
+		if options.Keys == nil {
+			options.Keys = map[string]string{}
+		}
+		options.Keys[key] = as
+		selection[secret] = options
+	}
+
+	if asEnv && len(selection) == 0 {
+		return nil, errors.New("option --env requires the selection of secrets or keys to bind")
+	}
+
+	return selection, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -68,7 +69,7 @@ func (c *EpinioClient) AppsMatching(prefix string) []string {
 	// Ask for all apps. Filtering is local.
 	// TODO: Create new endpoint (compare `EnvMatch`) and move filtering to the server.
 
-	apps, err := c.API.Apps(c.Settings.Namespace)
+	apps, err := c.API.Apps(c.Settings.Namespace, "")
 	if err != nil {
 		return result
 	}
@@ -87,14 +88,18 @@ func (c *EpinioClient) AppsMatching(prefix string) []string {
 	return result
 }
 
-// Apps gets all Epinio apps in the targeted namespace, or all apps in all namespaces
-func (c *EpinioClient) Apps(all bool) error {
-	log := c.Log.WithName("Apps").WithValues("Namespace", c.Settings.Namespace)
+// Apps gets all Epinio apps in the targeted namespace, or all apps in all namespaces. With
+// a selector only the apps whose labels match it are listed.
+func (c *EpinioClient) Apps(all bool, selector string) error {
+	log := c.Log.WithName("Apps").WithValues("Namespace", c.Settings.Namespace, "Selector", selector)
 	log.Info("start")
 	defer log.Info("return")
 	details := log.V(1) // NOTE: Increment of level, not absolute.
 
 	msg := c.ui.Note()
+	if selector != "" {
+		msg = msg.WithStringValue("Selector", selector)
+	}
 	if all {
 		msg.Msg("Listing all applications")
 	} else {
@@ -113,9 +118,9 @@ func (c *EpinioClient) Apps(all bool) error {
 	var err error
 
 	if all {
-		apps, err = c.API.AllApps()
+		apps, err = c.API.AllApps(selector)
 	} else {
-		apps, err = c.API.Apps(c.Settings.Namespace)
+		apps, err = c.API.Apps(c.Settings.Namespace, selector)
 	}
 	if err != nil {
 		return err
@@ -319,6 +324,37 @@ func (c *EpinioClient) AppRestart(appName string) error {
 	return c.API.AppRestart(c.Settings.Namespace, appName)
 }
 
+// AppsRestart restarts the applications whose labels match the selector, in the targeted
+// namespace
+func (c *EpinioClient) AppsRestart(selector string) error {
+	log := c.Log.WithName("AppsRestart").WithValues("Namespace", c.Settings.Namespace, "Selector", selector)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Selector", selector).
+		Msg("Restarting applications")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	log.V(1).Info("restarting applications")
+
+	result, err := c.API.AppsRestart(c.Settings.Namespace, selector)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().
+		WithStringValue("Restarted", strings.Join(result.Restarted, ", ")).
+		WithStringValue("Skipped, without workload", strings.Join(result.Skipped, ", ")).
+		Msg("Applications restarted")
+
+	return nil
+}
+
 // AppStageID returns the last stage id of the named app, in the targeted namespace
 func (c *EpinioClient) AppStageID(appName string) (string, error) {
 	log := c.Log.WithName("Apps").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
@@ -548,12 +584,20 @@ func (c *EpinioClient) printAppDetails(app models.App) error {
 		WithTableRow("App Chart", app.Configuration.AppChart).
 		WithTableRow("Desired Instances", fmt.Sprintf("%d", *app.Configuration.Instances)).
 		WithTableRow("Bound Configurations", strings.Join(app.Configuration.Configurations, ", ")).
+		WithTableRow("Labels", strings.Join(keyValueList(app.Configuration.Labels), ", ")).
+		WithTableRow("Annotations", strings.Join(keyValueList(app.Configuration.Annotations), ", ")).
 		WithTableRow("Environment", "")
 
-	if len(app.Configuration.Environment) > 0 {
-		for _, ev := range app.Configuration.Environment.List() {
-			msg = msg.WithTableRow("  - "+ev.Name, ev.Value)
+	environment := models.EnvVariableDefinitions{
+		Values:     app.Configuration.Environment,
+		References: app.Configuration.EnvironmentFrom,
+	}
+	for _, ev := range environment.List() {
+		value, from := envDisplay(ev, false)
+		if from != "" {
+			value = fmt.Sprintf("%s (from %s)", value, from)
 		}
+		msg = msg.WithTableRow("  - "+ev.Name, value)
 	}
 
 	msg.Msg("Details:")
@@ -588,8 +632,8 @@ func (c *EpinioClient) printReplicaDetails(app models.App) error {
 	return nil
 }
 
-// AppRestage restage an application
-func (c *EpinioClient) AppRestage(appName string) error {
+// AppRestage restage an application. With noCache the build cache is cleared first.
+func (c *EpinioClient) AppRestage(appName string, noCache bool) error {
 	log := c.Log.WithName("AppRestage").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
 	log.Info("start")
 	defer log.Info("return")
@@ -615,7 +659,7 @@ func (c *EpinioClient) AppRestage(appName string) error {
 		return nil
 	}
 
-	req := models.StageRequest{App: app.Meta}
+	req := models.StageRequest{App: app.Meta, NoCache: noCache}
 	stageResponse, err := c.API.AppStage(req)
 	if err != nil {
 		return err
@@ -628,3 +672,180 @@ func (c *EpinioClient) AppRestage(appName string) error {
 
 	return c.stageLogs(log.V(1), app.Meta, stageID)
 }
+
+// AppCacheShow shows the build cache of the named application
+func (c *EpinioClient) AppCacheShow(appName string) error {
+	log := c.Log.WithName("AppCacheShow").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		Msg("Show application build cache")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	cache, err := c.API.AppCacheShow(c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	storageClass := cache.StorageClass
+	if storageClass == "" {
+		storageClass = "default"
+	}
+	lastUsed := "unknown"
+	if !cache.LastUsed.IsZero() {
+		lastUsed = fmt.Sprintf("%v", cache.LastUsed)
+	}
+
+	c.ui.Success().WithTable("Key", "Value").
+		WithTableRow("Capacity", cache.Capacity).
+		WithTableRow("Storage Class", storageClass).
+		WithTableRow("Created", fmt.Sprintf("%v", cache.CreatedAt)).
+		WithTableRow("Last Used", lastUsed).
+		Msg("Details:")
+
+	return nil
+}
+
+// AppCacheClear removes the build cache of the named application
+func (c *EpinioClient) AppCacheClear(appName string) error {
+	log := c.Log.WithName("AppCacheClear").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		Msg("Clearing application build cache")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	_, err := c.API.AppCacheClear(c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Build cache cleared. The next staging builds from scratch.")
+
+	return nil
+}
+
+// AppPromote copies the named app, in the targeted namespace, to another namespace, and
+// deploys its image there
+func (c *EpinioClient) AppPromote(appName string, request models.AppPromoteRequest) error {
+	log := c.Log.WithName("AppPromote").WithValues("Namespace", c.Settings.Namespace, "Application", appName)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		WithStringValue("Target", request.Namespace).
+		Msg("Promoting application")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	result, err := c.API.AppPromote(request, c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	c.showPromoteResult(result, "Application promoted.")
+
+	return nil
+}
+
+// AppSBOM shows the staging, buildpacks and software bills of materials of the image of
+// the named app, in the targeted namespace. Without stage id it is the deployed image. With
+// a directory the SBOM documents are saved into it.
+func (c *EpinioClient) AppSBOM(appName, stageID, directory string) error {
+	log := c.Log.WithName("AppSBOM").WithValues("Namespace", c.Settings.Namespace, "Application", appName, "Stage", stageID)
+	log.Info("start")
+	defer log.Info("return")
+
+	msg := c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName)
+	if stageID != "" {
+		msg = msg.WithStringValue("Stage", stageID)
+	}
+	msg.Msg("Show application software bill of materials")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	sbom, err := c.API.AppSBOM(c.Settings.Namespace, appName, stageID)
+	if err != nil {
+		return err
+	}
+
+	created := "unknown"
+	if !sbom.Stage.CreatedAt.IsZero() {
+		created = fmt.Sprintf("%v", sbom.Stage.CreatedAt)
+	}
+	gitCommit := sbom.Stage.GitCommit
+	if gitCommit != "" && sbom.Stage.GitURL != "" {
+		gitCommit = fmt.Sprintf("%s @ %s", sbom.Stage.GitURL, gitCommit)
+	}
+
+	c.ui.Success().WithTable("Key", "Value").
+		WithTableRow("Stage", sbom.Stage.ID).
+		WithTableRow("Image", sbom.Image).
+		WithTableRow("Digest", sbom.Digest).
+		WithTableRow("Created", created).
+		WithTableRow("Created By", sbom.Stage.Username).
+		WithTableRow("Strategy", sbom.Stage.Strategy).
+		WithTableRow("Builder", sbom.Stage.BuilderImage).
+		WithTableRow("Source Blob", sbom.Stage.BlobUID).
+		WithTableRow("Git Commit", gitCommit).
+		Msg("Details:")
+
+	if len(sbom.Buildpacks) > 0 {
+		msg := c.ui.Normal().WithTable("Buildpack", "Version", "Homepage")
+		for _, buildpack := range sbom.Buildpacks {
+			msg = msg.WithTableRow(buildpack.ID, buildpack.Version, buildpack.Homepage)
+		}
+		msg.Msg("Buildpacks:")
+	}
+
+	if len(sbom.Documents) == 0 {
+		c.ui.Exclamation().Msg("No SBOM documents in the image")
+		return nil
+	}
+
+	msg = c.ui.Normal().WithTable("Document", "Format", "Size")
+	for _, document := range sbom.Documents {
+		msg = msg.WithTableRow(document.Path, document.Format, byteSize(int64(len(document.Content))))
+	}
+	msg.Msg("SBOM documents:")
+
+	if directory == "" {
+		return nil
+	}
+
+	for _, document := range sbom.Documents {
+		// Cleaning the rooted path keeps the documents inside the directory
+		destination := filepath.Join(directory, filepath.FromSlash(path.Clean("/"+document.Path)))
+
+		if err := os.MkdirAll(filepath.Dir(destination), 0700); err != nil {
+			return errors.Wrapf(err, "failed to create directory for '%s'", destination)
+		}
+		if err := ioutil.WriteFile(destination, document.Content, 0600); err != nil {
+			return errors.Wrapf(err, "failed to save '%s'", destination)
+		}
+	}
+
+	c.ui.Success().WithStringValue("Directory", directory).Msg("SBOM documents saved.")
+
+	return nil
+}
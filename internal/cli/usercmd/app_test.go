@@ -41,8 +41,18 @@ var _ = Describe("Client Apps unit tests", Label("wip"), func() {
 				epinioClient, err := usercmd.NewEpinioClient(&settings.Settings{Namespace: "workspace"}, fake)
 				Expect(err).ToNot(HaveOccurred())
 
-				err = epinioClient.AppRestage("appname")
+				err = epinioClient.AppRestage("appname", false)
 				Expect(err).ToNot(HaveOccurred())
+				Expect(fake.AppStageArgsForCall(0).NoCache).To(BeFalse())
+			})
+
+			It("requests clearing the build cache", func() {
+				epinioClient, err := usercmd.NewEpinioClient(&settings.Settings{Namespace: "workspace"}, fake)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = epinioClient.AppRestage("appname", true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fake.AppStageArgsForCall(0).NoCache).To(BeTrue())
 			})
 		})
 
@@ -66,7 +76,7 @@ var _ = Describe("Client Apps unit tests", Label("wip"), func() {
 				epinioClient, err := usercmd.NewEpinioClient(&settings.Settings{Namespace: "workspace"}, fake)
 				Expect(err).ToNot(HaveOccurred())
 
-				err = epinioClient.AppRestage("appname")
+				err = epinioClient.AppRestage("appname", false)
 				Expect(err).ToNot(HaveOccurred())
 			})
 		})
@@ -0,0 +1,101 @@
+package usercmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// BlobsList lists the blobs holding application sources
+func (c *EpinioClient) BlobsList() error {
+	log := c.Log.WithName("BlobsList")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		Msg("Listing application source blobs")
+
+	blobs, err := c.API.Blobs()
+	if err != nil {
+		return err
+	}
+
+	if len(blobs) == 0 {
+		c.ui.Exclamation().Msg("No blobs found")
+		return nil
+	}
+
+	msg := c.ui.Success().WithTable("ID", "Namespace", "App", "User", "Size", "Last Modified", "Status")
+
+	for _, blob := range blobs {
+		msg = msg.WithTableRow(blob.ID, blob.Namespace, blob.App, blob.Username,
+			byteSize(blob.Size), fmt.Sprintf("%v", blob.LastModified), blob.Status)
+	}
+
+	msg.Msg("Ok")
+	return nil
+}
+
+// BlobsGC deletes the blobs not in use anymore. A dry run only shows what would be deleted.
+func (c *EpinioClient) BlobsGC(dryRun bool) error {
+	log := c.Log.WithName("BlobsGC").WithValues("DryRun", dryRun)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Dry Run", strconv.FormatBool(dryRun)).
+		Msg("Collecting unused application source blobs...")
+
+	report, err := c.API.BlobsGC(models.BlobsGCRequest{DryRun: dryRun})
+	if err != nil {
+		return err
+	}
+
+	if len(report.Deleted) == 0 && len(report.Aborted) == 0 {
+		c.ui.Success().Msg("No unused blobs found.")
+		return nil
+	}
+
+	if len(report.Deleted) > 0 {
+		msg := c.ui.Normal().WithTable("ID", "Namespace", "App", "Size", "Last Modified", "Status")
+		for _, blob := range report.Deleted {
+			msg = msg.WithTableRow(blob.ID, blob.Namespace, blob.App, byteSize(blob.Size),
+				fmt.Sprintf("%v", blob.LastModified), blob.Status)
+		}
+		msg.Msg("Blobs")
+	}
+
+	if len(report.Aborted) > 0 {
+		msg := c.ui.Normal().WithTable("ID", "Initiated")
+		for _, upload := range report.Aborted {
+			msg = msg.WithTableRow(upload.ID, fmt.Sprintf("%v", upload.Initiated))
+		}
+		msg.Msg("Incomplete Uploads")
+	}
+
+	if report.DryRun {
+		c.ui.Success().Msgf("Would delete %d blobs (%s) and abort %d uploads.",
+			len(report.Deleted), byteSize(report.Freed), len(report.Aborted))
+		return nil
+	}
+
+	c.ui.Success().Msgf("Deleted %d blobs (%s) and aborted %d uploads.",
+		len(report.Deleted), byteSize(report.Freed), len(report.Aborted))
+
+	return nil
+}
+
+// byteSize formats the size in bytes with a binary unit
+func byteSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
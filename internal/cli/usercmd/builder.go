@@ -0,0 +1,79 @@
+package usercmd
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// BuilderList lists the approved builders
+func (c *EpinioClient) BuilderList() error {
+	log := c.Log.WithName("BuilderList")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		Msg("Listing approved builders")
+
+	builders, err := c.API.Builders()
+	if err != nil {
+		return err
+	}
+
+	if len(builders) == 0 {
+		c.ui.Exclamation().Msg("No builders are approved, only the system builder image is accepted")
+		return nil
+	}
+
+	msg := c.ui.Success().WithTable("Name", "Image", "Description")
+
+	for _, builder := range builders {
+		msg = msg.WithTableRow(builder.Name, builder.Image, builder.Description)
+	}
+
+	msg.Msg("Ok")
+	return nil
+}
+
+// BuilderSet adds a builder to the approved builders, or replaces it
+func (c *EpinioClient) BuilderSet(name, image, description string) error {
+	log := c.Log.WithName("BuilderSet").WithValues("Name", name)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", name).
+		WithStringValue("Image", image).
+		Msg("Approving builder...")
+
+	_, err := c.API.BuilderSet(models.Builder{
+		Name:        name,
+		Image:       image,
+		Description: description,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Builder approved.")
+
+	return nil
+}
+
+// BuilderDelete removes a builder from the approved builders
+func (c *EpinioClient) BuilderDelete(name string) error {
+	log := c.Log.WithName("BuilderDelete").WithValues("Name", name)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", name).
+		Msg("Removing builder...")
+
+	_, err := c.API.BuilderDelete(name)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Builder removed.")
+
+	return nil
+}
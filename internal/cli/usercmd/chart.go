@@ -3,6 +3,7 @@ package usercmd
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/pkg/errors"
@@ -117,6 +118,7 @@ func (c *EpinioClient) ChartShow(ctx context.Context, name string) error {
 		WithTableRow("Description", chart.Description).
 		WithTableRow("Helm Repository", chart.HelmRepo).
 		WithTableRow("Helm Chart", chart.HelmChart).
+		WithTableRow("Features", strings.Join(chart.Features, ", ")).
 		Msg("Details:")
 
 	return nil
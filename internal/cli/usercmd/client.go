@@ -4,6 +4,8 @@ package usercmd
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 import (
+	"time"
+
 	"github.com/epinio/epinio/helpers/kubernetes/tailer"
 	"github.com/epinio/epinio/helpers/termui"
 	"github.com/epinio/epinio/helpers/tracelog"
@@ -30,13 +32,13 @@ type APIClient interface {
 	AuthToken() (string, error)
 	// app
 	AppCreate(req models.ApplicationCreateRequest, namespace string) (models.Response, error)
-	Apps(namespace string) (models.AppList, error)
-	AllApps() (models.AppList, error)
+	Apps(namespace string, selector string) (models.AppList, error)
+	AllApps(selector string) (models.AppList, error)
 	AppShow(namespace string, appName string) (models.App, error)
 	AppUpdate(req models.ApplicationUpdateRequest, namespace string, appName string) (models.Response, error)
 	AppDelete(namespace string, name string) (models.ApplicationDeleteResponse, error)
 	AppUpload(namespace string, name string, tarball string) (models.UploadResponse, error)
-	AppImportGit(app models.AppRef, gitRef models.GitRef) (*models.ImportGitResponse, error)
+	AppImportGit(app models.AppRef, gitRef models.GitRef, exclude []string) (*models.ImportGitResponse, error)
 	AppStage(req models.StageRequest) (*models.StageResponse, error)
 	AppDeploy(req models.DeployRequest) (*models.DeployResponse, error)
 	AppLogs(namespace, appName, stageID string, follow bool, callback func(tailer.ContainerLogLine)) error
@@ -45,11 +47,16 @@ type APIClient interface {
 	AppExec(namespace string, appName, instance string, tty kubectlterm.TTY) error
 	AppPortForward(namespace string, appName, instance string, opts *epinioapi.PortForwardOpts) error
 	AppRestart(namespace string, appName string) error
+	AppsRestart(namespace string, selector string) (models.AppsRestartResponse, error)
+	AppCacheShow(namespace string, appName string) (models.AppCache, error)
+	AppCacheClear(namespace string, appName string) (models.Response, error)
+	AppPromote(req models.AppPromoteRequest, namespace string, appName string) (models.PromoteResponse, error)
 	AppGetPart(namespace, appName, part, destinationPath string) error
+	AppSBOM(namespace, appName, stageID string) (models.AppSBOM, error)
 	// env
-	EnvList(namespace string, appName string) (models.EnvVariableMap, error)
-	EnvSet(req models.EnvVariableMap, namespace string, appName string) (models.Response, error)
-	EnvShow(namespace string, appName string, envName string) (models.EnvVariable, error)
+	EnvList(namespace string, appName string, reveal bool) (models.EnvVariableDefinitions, error)
+	EnvSet(req models.EnvVariableDefinitions, namespace string, appName string) (models.Response, error)
+	EnvShow(namespace string, appName string, envName string, reveal bool) (models.EnvVariable, error)
 	EnvUnset(namespace string, appName string, envName string) (models.Response, error)
 	EnvMatch(namespace string, appName string, prefix string) (models.EnvMatchResponse, error)
 	// info
@@ -57,9 +64,21 @@ type APIClient interface {
 	// namespaces
 	NamespaceCreate(req models.NamespaceCreateRequest) (models.Response, error)
 	NamespaceDelete(namespace string) (models.Response, error)
+	NamespaceSoftDelete(namespace string, grace time.Duration) (models.Response, error)
+	NamespaceUndelete(namespace string) (models.Response, error)
+	NamespaceDeletionPreview(namespace string) (models.NamespaceDeletionPreview, error)
 	NamespaceShow(namespace string) (models.Namespace, error)
 	NamespacesMatch(prefix string) (models.NamespacesMatchResponse, error)
-	Namespaces() (models.NamespaceList, error)
+	Namespaces(selector string) (models.NamespaceList, error)
+	NamespaceEnvList(namespace string) (models.EnvVariableMap, error)
+	NamespaceEnvSet(req models.NamespaceEnvSetRequest, namespace string) (models.Response, error)
+	NamespaceEnvUnset(namespace, name string, restart bool) (models.Response, error)
+	NamespaceBind(req models.NamespaceBindRequest, namespace string) (models.Response, error)
+	NamespaceUnbind(namespace, configurationName string, restart bool) (models.Response, error)
+	NamespaceQuotaUpdate(quota models.NamespaceQuota, namespace string) (models.Response, error)
+	NamespaceBuilderUpdate(req models.NamespaceBuilderRequest, namespace string) (models.Response, error)
+	NamespaceUpdate(req models.NamespaceUpdateRequest, namespace string) (models.Response, error)
+	NamespaceClone(req models.NamespaceCloneRequest, namespace string) (models.PromoteResponse, error)
 	// configurations
 	Configurations(namespace string) (models.ConfigurationResponseList, error)
 	AllConfigurations() (models.ConfigurationResponseList, error)
@@ -68,11 +87,16 @@ type APIClient interface {
 	ConfigurationDelete(req models.ConfigurationDeleteRequest, namespace string, name string, f epinioapi.ErrorFunc) (models.ConfigurationDeleteResponse, error)
 	ConfigurationCreate(req models.ConfigurationCreateRequest, namespace string) (models.Response, error)
 	ConfigurationUpdate(req models.ConfigurationUpdateRequest, namespace, name string) (models.Response, error)
+	ConfigurationHistory(namespace, name string) (models.ConfigurationHistoryResponse, error)
+	ConfigurationRevert(req models.ConfigurationRevertRequest, namespace, name string, revision int) (models.Response, error)
 	ConfigurationShow(namespace string, name string) (models.ConfigurationResponse, error)
 	ConfigurationApps(namespace string) (models.ConfigurationAppsResponse, error)
 	// services
 	ServiceCatalog() (*models.ServiceCatalogResponse, error)
 	ServiceCatalogShow(serviceName string) (*models.ServiceCatalogShowResponse, error)
+	ServiceCatalogCreate(req *models.CatalogService) error
+	ServiceCatalogUpdate(req *models.ServiceCatalogUpdateRequest, serviceName string) error
+	ServiceCatalogDelete(serviceName string) error
 
 	AllServices() (*models.ServiceListResponse, error)
 	ServiceShow(req *models.ServiceShowRequest, namespace string) (*models.ServiceShowResponse, error)
@@ -81,11 +105,30 @@ type APIClient interface {
 	ServiceUnbind(req *models.ServiceUnbindRequest, namespace, name string) error
 	ServiceDelete(req models.ServiceDeleteRequest, namespace string, name string, f epinioapi.ErrorFunc) (models.ServiceDeleteResponse, error)
 	ServiceList(namespace string) (*models.ServiceListResponse, error)
+	ServiceUpdate(req *models.ServiceUpdateRequest, namespace, name string) error
+	ServiceUpgrade(namespace, name string) (*models.ServiceUpgradeResponse, error)
+	ServiceBackup(namespace, name string) (*models.ServiceJob, error)
+	ServiceBackups(namespace, name string) (models.ServiceBackupList, error)
+	ServiceRestore(namespace, name, backupID string) (*models.ServiceJob, error)
+	ServiceJob(namespace, name, job string) (*models.ServiceJob, error)
 
 	// application charts
 	ChartList() ([]models.AppChart, error)
 	ChartShow(name string) (models.AppChart, error)
 	ChartMatch(prefix string) (models.ChartMatchResponse, error)
+
+	// builders
+	Builders() (models.BuilderList, error)
+	BuilderSet(builder models.Builder) (models.Response, error)
+	BuilderDelete(name string) (models.Response, error)
+
+	// blobs
+	Blobs() (models.BlobList, error)
+	BlobsGC(req models.BlobsGCRequest) (models.BlobsGCResponse, error)
+
+	// images
+	Images() (models.AppImageList, error)
+	ImagesGC(req models.ImagesGCRequest) (models.ImagesGCResponse, error)
 }
 
 func New() (*EpinioClient, error) {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 
 	apierrors "github.com/epinio/epinio/pkg/api/core/v1/errors"
@@ -114,18 +115,43 @@ func (c *EpinioClient) ConfigurationMatching(ctx context.Context, prefix string)
 }
 
 // BindConfiguration attaches a configuration specified by name to the named application,
-// both in the targeted namespace.
-func (c *EpinioClient) BindConfiguration(configurationName, appName string) error {
+// both in the targeted namespace. The options specify how the application sees the
+// configuration.
+func (c *EpinioClient) BindConfiguration(configurationName, appName string, options models.BindOptions) error {
 	log := c.Log.WithName("Bind Configuration To Application").
 		WithValues("Name", configurationName, "Application", appName, "Namespace", c.Settings.Namespace)
 	log.Info("start")
 	defer log.Info("return")
 
-	c.ui.Note().
+	msg := c.ui.Note().
 		WithStringValue("Configuration", configurationName).
 		WithStringValue("Application", appName).
-		WithStringValue("Namespace", c.Settings.Namespace).
-		Msg("Bind Configuration")
+		WithStringValue("Namespace", c.Settings.Namespace)
+	if options.AsEnv {
+		msg = msg.WithStringValue("Injection", "env").
+			WithStringValue("Prefix", options.EnvPrefix)
+	} else {
+		if options.MountPath != "" {
+			msg = msg.WithStringValue("Mount Path", options.MountPath)
+		}
+		if options.Mode != nil {
+			msg = msg.WithStringValue("Mode", fmt.Sprintf("%#o", *options.Mode))
+		}
+	}
+	if len(options.Keys) > 0 {
+		msg = msg.WithTable("Key", "As")
+
+		keys := []string{}
+		for key := range options.Keys {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			msg = msg.WithTableRow(key, options.Keys[key])
+		}
+	}
+	msg.Msg("Bind Configuration")
 
 	if err := c.TargetOk(); err != nil {
 		return err
@@ -134,6 +160,11 @@ func (c *EpinioClient) BindConfiguration(configurationName, appName string) erro
 	request := models.BindRequest{
 		Names: []string{configurationName},
 	}
+	if !options.IsDefault() {
+		request.Options = map[string]models.BindOptions{
+			configurationName: options,
+		}
+	}
 
 	br, err := c.API.ConfigurationBindingCreate(request, c.Settings.Namespace, appName)
 	if err != nil {
@@ -307,25 +338,128 @@ func (c *EpinioClient) UpdateConfiguration(name string, removedKeys []string, as
 	return nil
 }
 
-// CreateConfiguration creates a configuration specified by name and key/value dictionary
+// ConfigurationHistory lists the revisions of a configuration
+func (c *EpinioClient) ConfigurationHistory(name string) error {
+	log := c.Log.WithName("Configuration History").
+		WithValues("Name", name, "Namespace", c.Settings.Namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", name).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		Msg("Listing configuration revisions...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	history, err := c.API.ConfigurationHistory(c.Settings.Namespace, name)
+	if err != nil {
+		return err
+	}
+
+	if len(history) == 0 {
+		c.ui.Normal().Msg("No revisions found")
+		return nil
+	}
+
+	msg := c.ui.Success().WithTable("Revision", "Created", "User", "Added", "Modified", "Removed", "Note")
+	for _, revision := range history {
+		number := strconv.Itoa(revision.Revision)
+		if revision.Current {
+			number += " (current)"
+		}
+
+		note := ""
+		if revision.RevertedFrom > 0 {
+			note = fmt.Sprintf("reverted to revision %d", revision.RevertedFrom)
+		}
+
+		msg = msg.WithTableRow(
+			number,
+			revision.CreatedAt.String(),
+			revision.Username,
+			strings.Join(revision.Added, ", "),
+			strings.Join(revision.Modified, ", "),
+			strings.Join(revision.Removed, ", "),
+			note,
+		)
+	}
+	msg.Msg("Details:")
+
+	return nil
+}
+
+// ConfigurationRevert reverts a configuration to one of its revisions, and optionally
+// restarts the applications bound to it
+func (c *EpinioClient) ConfigurationRevert(name string, revision int, restart bool) error {
+	log := c.Log.WithName("Revert Configuration").
+		WithValues("Name", name, "Namespace", c.Settings.Namespace, "Revision", revision)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", name).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Revision", strconv.Itoa(revision)).
+		Msg("Reverting Configuration...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	request := models.ConfigurationRevertRequest{
+		Restart: restart,
+	}
+
+	_, err := c.API.ConfigurationRevert(request, c.Settings.Namespace, name, revision)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().
+		WithStringValue("Name", name).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Revision", strconv.Itoa(revision)).
+		Msg("Configuration Reverted.")
+
+	return nil
+}
+
+// CreateConfiguration creates a configuration specified by name, type, and key/value
+// dictionaries. The binary data holds the contents of files, shown by size only.
 // TODO: Allow underscores in configuration names (right now they fail because of kubernetes naming rules for secrets)
-func (c *EpinioClient) CreateConfiguration(name string, dict []string) error {
+func (c *EpinioClient) CreateConfiguration(name string, data map[string]string, binaryData map[string][]byte, configurationType string) error {
 	log := c.Log.WithName("Create Configuration").
 		WithValues("Name", name, "Namespace", c.Settings.Namespace)
 	log.Info("start")
 	defer log.Info("return")
 
-	data := make(map[string]string)
 	msg := c.ui.Note().
 		WithStringValue("Name", name).
-		WithStringValue("Namespace", c.Settings.Namespace).
-		WithTable("Parameter", "Value", "Access Path")
-	for i := 0; i < len(dict); i += 2 {
-		key := dict[i]
-		value := dict[i+1]
+		WithStringValue("Namespace", c.Settings.Namespace)
+	if configurationType != "" {
+		msg = msg.WithStringValue("Type", configurationType)
+	}
+	msg = msg.WithTable("Parameter", "Value", "Access Path")
+
+	keys := make([]string, 0, len(data)+len(binaryData))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	for key := range binaryData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, ok := data[key]
+		if !ok {
+			value = binaryValue(binaryData[key])
+		}
 		path := fmt.Sprintf("/configurations/%s/%s", name, key)
 		msg = msg.WithTableRow(key, value, path)
-		data[key] = value
 	}
 	msg.Msg("Create Configuration")
 
@@ -334,8 +468,10 @@ func (c *EpinioClient) CreateConfiguration(name string, dict []string) error {
 	}
 
 	request := models.ConfigurationCreateRequest{
-		Name: name,
-		Data: data,
+		Name:       name,
+		Data:       data,
+		BinaryData: binaryData,
+		Type:       configurationType,
 	}
 
 	_, err := c.API.ConfigurationCreate(request, c.Settings.Namespace)
@@ -376,11 +512,19 @@ func (c *EpinioClient) ConfigurationDetails(name string) error {
 	configurationDetails := resp.Configuration.Details
 	boundApps := resp.Configuration.BoundApps
 
+	for k, v := range resp.Configuration.BinaryDetails {
+		if configurationDetails == nil {
+			configurationDetails = map[string]string{}
+		}
+		configurationDetails[k] = binaryValue(v)
+	}
+
 	sort.Strings(boundApps)
 
 	c.ui.Note().
 		WithStringValue("Created", fmt.Sprintf("%v", resp.Meta.CreatedAt)).
 		WithStringValue("User", resp.Configuration.Username).
+		WithStringValue("Type", resp.Configuration.Type).
 		WithStringValue("Used-By", strings.Join(boundApps, ", ")).
 		Msg("")
 
@@ -408,3 +552,8 @@ func (c *EpinioClient) ConfigurationDetails(name string) error {
 		Msg("Beware, the shown access paths are only available in the application's container")
 	return nil
 }
+
+// binaryValue returns the text shown for a value which is not text.
+func binaryValue(value []byte) string {
+	return fmt.Sprintf("<binary, %d bytes>", len(value))
+}
@@ -6,9 +6,14 @@ import (
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 )
 
+// maskedValue is shown in place of the values of variables referencing configuration
+// keys, unless revealed.
+const maskedValue = "********"
+
 // EnvList displays a table of all environment variables and their
-// values for the named application.
-func (c *EpinioClient) EnvList(ctx context.Context, appName string) error {
+// values for the named application. The values of variables
+// referencing configuration keys are masked, unless revealed.
+func (c *EpinioClient) EnvList(ctx context.Context, appName string, reveal bool) error {
 	log := c.Log.WithName("EnvList")
 	log.Info("start")
 	defer log.Info("return")
@@ -22,15 +27,16 @@ func (c *EpinioClient) EnvList(ctx context.Context, appName string) error {
 		return err
 	}
 
-	eVariables, err := c.API.EnvList(c.Settings.Namespace, appName)
+	eVariables, err := c.API.EnvList(c.Settings.Namespace, appName, reveal)
 	if err != nil {
 		return err
 	}
 
-	msg := c.ui.Success().WithTable("Variable", "Value")
+	msg := c.ui.Success().WithTable("Variable", "Value", "From")
 
 	for _, ev := range eVariables.List() {
-		msg = msg.WithTableRow(ev.Name, ev.Value)
+		value, from := envDisplay(ev, reveal)
+		msg = msg.WithTableRow(ev.Name, value, from)
 	}
 
 	msg.Msg("Ok")
@@ -55,8 +61,41 @@ func (c *EpinioClient) EnvSet(ctx context.Context, appName, envName, envValue st
 		return err
 	}
 
-	request := models.EnvVariableMap{}
-	request[envName] = envValue
+	request := models.EnvVariableDefinitions{
+		Values: models.EnvVariableMap{envName: envValue},
+	}
+
+	_, err := c.API.EnvSet(request, c.Settings.Namespace, appName)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("OK")
+	return nil
+}
+
+// EnvSetReference adds or modifies the specified environment variable in the named
+// application, to take its value from the given configuration key. A workload is
+// restarted.
+func (c *EpinioClient) EnvSetReference(ctx context.Context, appName, envName string, source models.EnvVariableSource) error {
+	log := c.Log.WithName("Env")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Application", appName).
+		WithStringValue("Variable", envName).
+		WithStringValue("From", source.String()).
+		Msg("Extend or modify application environment")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	request := models.EnvVariableDefinitions{
+		References: models.EnvVariableRefMap{envName: source},
+	}
 
 	_, err := c.API.EnvSet(request, c.Settings.Namespace, appName)
 	if err != nil {
@@ -68,8 +107,9 @@ func (c *EpinioClient) EnvSet(ctx context.Context, appName, envName, envValue st
 }
 
 // EnvShow shows the value of the specified environment variable in
-// the named application.
-func (c *EpinioClient) EnvShow(ctx context.Context, appName, envName string) error {
+// the named application. The value of a variable referencing a
+// configuration key is masked, unless revealed.
+func (c *EpinioClient) EnvShow(ctx context.Context, appName, envName string, reveal bool) error {
 	log := c.Log.WithName("Env")
 	log.Info("start")
 	defer log.Info("return")
@@ -84,14 +124,17 @@ func (c *EpinioClient) EnvShow(ctx context.Context, appName, envName string) err
 		return err
 	}
 
-	eVariable, err := c.API.EnvShow(c.Settings.Namespace, appName, envName)
+	eVariable, err := c.API.EnvShow(c.Settings.Namespace, appName, envName, reveal)
 	if err != nil {
 		return err
 	}
 
-	c.ui.Success().
-		WithStringValue("Value", eVariable.Value).
-		Msg("OK")
+	value, from := envDisplay(eVariable, reveal)
+	msg := c.ui.Success().WithStringValue("Value", value)
+	if from != "" {
+		msg = msg.WithStringValue("From", from)
+	}
+	msg.Msg("OK")
 
 	return nil
 }
@@ -138,3 +181,15 @@ func (c *EpinioClient) EnvMatching(ctx context.Context, appName, prefix string)
 
 	return resp.Names
 }
+
+// envDisplay returns the value to show for the variable, and the configuration key it
+// references, if any.
+func envDisplay(ev models.EnvVariable, reveal bool) (string, string) {
+	if ev.ValueFrom == nil {
+		return ev.Value, ""
+	}
+	if reveal {
+		return ev.Value, ev.ValueFrom.String()
+	}
+	return maskedValue, ev.ValueFrom.String()
+}
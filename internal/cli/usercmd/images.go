@@ -0,0 +1,82 @@
+package usercmd
+
+import (
+	"strconv"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// ImagesList lists the stage images of the applications
+func (c *EpinioClient) ImagesList() error {
+	log := c.Log.WithName("ImagesList")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		Msg("Listing application images")
+
+	images, err := c.API.Images()
+	if err != nil {
+		return err
+	}
+
+	if len(images) == 0 {
+		c.ui.Exclamation().Msg("No images found")
+		return nil
+	}
+
+	msg := c.ui.Success().WithTable("Namespace", "App", "Tag", "Digest", "Status")
+
+	for _, image := range images {
+		msg = msg.WithTableRow(image.Namespace, image.App, image.Tag, image.Digest, image.Status)
+	}
+
+	msg.Msg("Ok")
+	return nil
+}
+
+// ImagesGC deletes the expired stage images of the applications. A negative number of
+// images to keep uses the retention of the server. A dry run only shows what would be
+// deleted.
+func (c *EpinioClient) ImagesGC(keep int, dryRun bool) error {
+	log := c.Log.WithName("ImagesGC").WithValues("Keep", keep, "DryRun", dryRun)
+	log.Info("start")
+	defer log.Info("return")
+
+	req := models.ImagesGCRequest{DryRun: dryRun}
+
+	msg := c.ui.Note()
+	if keep >= 0 {
+		req.Keep = &keep
+		msg = msg.WithStringValue("Keep", strconv.Itoa(keep))
+	}
+	msg.WithStringValue("Dry Run", strconv.FormatBool(dryRun)).
+		Msg("Deleting expired application images...")
+
+	report, err := c.API.ImagesGC(req)
+	if err != nil {
+		return err
+	}
+
+	if len(report.Deleted) == 0 {
+		c.ui.Success().Msg("No expired images found.")
+		return nil
+	}
+
+	table := c.ui.Normal().WithTable("Namespace", "App", "Tag", "Digest")
+	for _, image := range report.Deleted {
+		table = table.WithTableRow(image.Namespace, image.App, image.Tag, image.Digest)
+	}
+	table.Msg("Images")
+
+	if report.DryRun {
+		c.ui.Success().Msgf("Would delete %d images, keeping %d per application.",
+			len(report.Deleted), report.Keep)
+		return nil
+	}
+
+	c.ui.Success().Msgf("Deleted %d images, keeping %d per application.",
+		len(report.Deleted), report.Keep)
+
+	return nil
+}
@@ -3,7 +3,9 @@ package usercmd
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 
@@ -11,22 +13,22 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
-// CreateNamespace creates a namespace
-func (c *EpinioClient) CreateNamespace(namespace string) error {
-	log := c.Log.WithName("CreateNamespace").WithValues("Namespace", namespace)
+// CreateNamespace creates a namespace, with the quota and metadata of the request
+func (c *EpinioClient) CreateNamespace(request models.NamespaceCreateRequest) error {
+	log := c.Log.WithName("CreateNamespace").WithValues("Namespace", request.Name)
 	log.Info("start")
 	defer log.Info("return")
 
 	c.ui.Note().
-		WithStringValue("Name", namespace).
+		WithStringValue("Name", request.Name).
 		Msg("Creating namespace...")
 
-	errorMsgs := validation.IsDNS1123Subdomain(namespace)
+	errorMsgs := validation.IsDNS1123Subdomain(request.Name)
 	if len(errorMsgs) > 0 {
 		return fmt.Errorf("%s: %s", "namespace name incorrect", strings.Join(errorMsgs, "\n"))
 	}
 
-	_, err := c.API.NamespaceCreate(models.NamespaceCreateRequest{Name: namespace})
+	_, err := c.API.NamespaceCreate(request)
 	if err != nil {
 		return err
 	}
@@ -55,8 +57,10 @@ func (c *EpinioClient) NamespacesMatching(prefix string) []string {
 	return result
 }
 
-func (c *EpinioClient) Namespaces() error {
-	log := c.Log.WithName("Namespaces")
+// Namespaces lists the namespaces, restricted to those whose labels match the selector,
+// if any
+func (c *EpinioClient) Namespaces(selector string) error {
+	log := c.Log.WithName("Namespaces").WithValues("Selector", selector)
 	log.Info("start")
 	defer log.Info("return")
 	details := log.V(1) // NOTE: Increment of level, not absolute.
@@ -65,13 +69,13 @@ func (c *EpinioClient) Namespaces() error {
 
 	details.Info("list namespaces")
 
-	namespaces, err := c.API.Namespaces()
+	namespaces, err := c.API.Namespaces(selector)
 	if err != nil {
 		return err
 	}
 
 	sort.Sort(namespaces)
-	msg := c.ui.Success().WithTable("Name", "Created", "Applications", "Configurations")
+	msg := c.ui.Success().WithTable("Name", "Created", "Applications", "Configurations", "Owner", "Labels")
 
 	for _, namespace := range namespaces {
 		sort.Strings(namespace.Apps)
@@ -80,7 +84,9 @@ func (c *EpinioClient) Namespaces() error {
 			namespace.Meta.Name,
 			fmt.Sprintf("%v", namespace.Meta.CreatedAt),
 			strings.Join(namespace.Apps, ", "),
-			strings.Join(namespace.Configurations, ", "))
+			strings.Join(namespace.Configurations, ", "),
+			namespace.Owner,
+			strings.Join(keyValueList(namespace.Labels), ", "))
 	}
 
 	msg.Msg("Epinio Namespaces:")
@@ -127,8 +133,8 @@ func (c *EpinioClient) TargetOk() error {
 }
 
 // DeleteNamespace deletes a Namespace
-func (c *EpinioClient) DeleteNamespace(namespace string) error {
-	log := c.Log.WithName("DeleteNamespace").WithValues("Namespace", namespace)
+func (c *EpinioClient) DeleteNamespace(namespace string, grace time.Duration) error {
+	log := c.Log.WithName("DeleteNamespace").WithValues("Namespace", namespace, "Grace", grace)
 	log.Info("start")
 	defer log.Info("return")
 
@@ -136,6 +142,19 @@ func (c *EpinioClient) DeleteNamespace(namespace string) error {
 		WithStringValue("Name", namespace).
 		Msg("Deleting namespace...")
 
+	if grace > 0 {
+		_, err := c.API.NamespaceSoftDelete(namespace, grace)
+		if err != nil {
+			return err
+		}
+
+		c.ui.Success().
+			WithStringValue("Deleted after", grace.String()).
+			Msg("Namespace scheduled for deletion. Its applications are scaled to zero. Undo with `epinio namespace undelete " + namespace + "`.")
+
+		return nil
+	}
+
 	_, err := c.API.NamespaceDelete(namespace)
 	if err != nil {
 		return err
@@ -146,6 +165,54 @@ func (c *EpinioClient) DeleteNamespace(namespace string) error {
 	return nil
 }
 
+// UndeleteNamespace cancels the pending deletion of a soft-deleted namespace
+func (c *EpinioClient) UndeleteNamespace(namespace string) error {
+	log := c.Log.WithName("UndeleteNamespace").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", namespace).
+		Msg("Restoring namespace...")
+
+	_, err := c.API.NamespaceUndelete(namespace)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Namespace restored.")
+
+	return nil
+}
+
+// NamespaceDeletionPreview shows what the deletion of a namespace would destroy
+func (c *EpinioClient) NamespaceDeletionPreview(namespace string) error {
+	log := c.Log.WithName("NamespaceDeletionPreview").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	preview, err := c.API.NamespaceDeletionPreview(namespace)
+	if err != nil {
+		return err
+	}
+
+	if preview.Protected {
+		c.ui.Exclamation().Msg("Namespace '" + namespace + "' is protected against deletion.")
+	}
+
+	c.ui.Note().
+		WithTable("Resource", "Destroyed").
+		WithTableRow("Applications", strings.Join(preview.Apps, "\n")).
+		WithTableRow("Routes", strings.Join(preview.Routes, "\n")).
+		WithTableRow("Services", strings.Join(preview.Services, "\n")).
+		WithTableRow("Configurations", strings.Join(preview.Configurations, "\n")).
+		WithTableRow("Volumes", strings.Join(preview.Volumes, "\n")).
+		WithTableRow("Blobs", strings.Join(preview.Blobs, "\n")).
+		Msg("Deleting namespace " + namespace + " destroys:")
+
+	return nil
+}
+
 // ShowNamepsace shows a Namespace
 func (c *EpinioClient) ShowNamespace(namespace string) error {
 	log := c.Log.WithName("ShowNamespace").WithValues("Namespace", namespace)
@@ -165,14 +232,319 @@ func (c *EpinioClient) ShowNamespace(namespace string) error {
 
 	sort.Strings(space.Apps)
 	sort.Strings(space.Configurations)
+	sort.Strings(space.DefaultConfigurations)
+
+	defaultEnvironment := []string{}
+	for _, ev := range space.DefaultEnvironment.List() {
+		defaultEnvironment = append(defaultEnvironment, ev.Name+"="+ev.Value)
+	}
 
 	msg = msg.
 		WithTableRow("Name", space.Meta.Name).
 		WithTableRow("Created", fmt.Sprintf("%v", space.Meta.CreatedAt)).
+		WithTableRow("Description", space.Description).
+		WithTableRow("Owner", space.Owner).
+		WithTableRow("Labels", strings.Join(keyValueList(space.Labels), "\n")).
 		WithTableRow("Applications", strings.Join(space.Apps, "\n")).
-		WithTableRow("Configurations", strings.Join(space.Configurations, "\n"))
+		WithTableRow("Configurations", strings.Join(space.Configurations, "\n")).
+		WithTableRow("Default Environment", strings.Join(defaultEnvironment, "\n")).
+		WithTableRow("Default Bindings", strings.Join(space.DefaultConfigurations, "\n")).
+		WithTableRow("Default Builder", space.DefaultBuilder).
+		WithTableRow("Protected", strconv.FormatBool(space.Protected))
+
+	if space.DeleteAfter != nil {
+		msg = msg.WithTableRow("Deletion Scheduled", fmt.Sprintf("%v", space.DeleteAfter))
+	}
+
+	if space.Quota != nil && space.Usage != nil {
+		quota, usage := *space.Quota, *space.Usage
+		msg = msg.
+			WithTableRow("Quota Apps", quotaCount(usage.Apps, quota.Apps)).
+			WithTableRow("Quota Instances", quotaCount(usage.Instances, quota.Instances)).
+			WithTableRow("Quota CPU", quotaQuantity(usage.CPU, quota.CPU)).
+			WithTableRow("Quota Memory", quotaQuantity(usage.Memory, quota.Memory)).
+			WithTableRow("Quota Services", quotaCount(usage.Services, quota.Services)).
+			WithTableRow("Quota Configurations", quotaCount(usage.Configurations, quota.Configurations))
+	}
 
 	msg.Msg("Details:")
 
 	return nil
 }
+
+// NamespaceEnvList displays a table of the default environment variables of the apps in
+// the namespace
+func (c *EpinioClient) NamespaceEnvList(namespace string) error {
+	log := c.Log.WithName("NamespaceEnvList").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		Msg("Show Namespace Default Environment")
+
+	environment, err := c.API.NamespaceEnvList(namespace)
+	if err != nil {
+		return err
+	}
+
+	msg := c.ui.Success().WithTable("Variable", "Value")
+
+	for _, ev := range environment.List() {
+		msg = msg.WithTableRow(ev.Name, ev.Value)
+	}
+
+	msg.Msg("Ok")
+	return nil
+}
+
+// NamespaceEnvSet adds or modifies the specified default environment variable of the apps
+// in the namespace. With restart set the active apps are restarted to pick it up.
+func (c *EpinioClient) NamespaceEnvSet(namespace, envName, envValue string, restart bool) error {
+	log := c.Log.WithName("NamespaceEnvSet").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		WithStringValue("Variable", envName).
+		WithStringValue("Value", envValue).
+		Msg("Extend or modify namespace default environment")
+
+	request := models.NamespaceEnvSetRequest{
+		Environment: models.EnvVariableMap{envName: envValue},
+		Restart:     restart,
+	}
+
+	_, err := c.API.NamespaceEnvSet(request, namespace)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("OK")
+	return nil
+}
+
+// NamespaceEnvUnset removes the specified default environment variable of the apps in the
+// namespace. With restart set the active apps are restarted to drop it.
+func (c *EpinioClient) NamespaceEnvUnset(namespace, envName string, restart bool) error {
+	log := c.Log.WithName("NamespaceEnvUnset").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		WithStringValue("Variable", envName).
+		Msg("Remove from namespace default environment")
+
+	_, err := c.API.NamespaceEnvUnset(namespace, envName, restart)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("OK")
+	return nil
+}
+
+// NamespaceBind binds the named configurations to all apps in the namespace, present and
+// future. With restart set the active apps are restarted to pick them up.
+func (c *EpinioClient) NamespaceBind(namespace string, configurationNames []string, restart bool) error {
+	log := c.Log.WithName("NamespaceBind").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		WithStringValue("Configurations", strings.Join(configurationNames, ", ")).
+		Msg("Bind configurations to all applications of the namespace")
+
+	request := models.NamespaceBindRequest{
+		Names:   configurationNames,
+		Restart: restart,
+	}
+
+	_, err := c.API.NamespaceBind(request, namespace)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Configurations bound.")
+	return nil
+}
+
+// NamespaceUnbind removes the named configuration from the configurations bound to all
+// apps in the namespace. With restart set the active apps are restarted to drop it.
+func (c *EpinioClient) NamespaceUnbind(namespace, configurationName string, restart bool) error {
+	log := c.Log.WithName("NamespaceUnbind").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Namespace", namespace).
+		WithStringValue("Configuration", configurationName).
+		Msg("Unbind configuration from all applications of the namespace")
+
+	_, err := c.API.NamespaceUnbind(namespace, configurationName, restart)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Configuration unbound.")
+	return nil
+}
+
+// NamespaceQuota returns the quota of the namespace. A namespace without quota returns an
+// empty quota.
+func (c *EpinioClient) NamespaceQuota(namespace string) (models.NamespaceQuota, error) {
+	log := c.Log.WithName("NamespaceQuota").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	space, err := c.API.NamespaceShow(namespace)
+	if err != nil {
+		return models.NamespaceQuota{}, err
+	}
+	if space.Quota == nil {
+		return models.NamespaceQuota{}, nil
+	}
+
+	return *space.Quota, nil
+}
+
+// UpdateNamespaceQuota replaces the quota of the namespace
+func (c *EpinioClient) UpdateNamespaceQuota(namespace string, quota models.NamespaceQuota) error {
+	log := c.Log.WithName("UpdateNamespaceQuota").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", namespace).
+		Msg("Updating namespace quota...")
+
+	_, err := c.API.NamespaceQuotaUpdate(quota, namespace)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Namespace updated.")
+
+	return nil
+}
+
+// UpdateNamespaceBuilder sets the default builder of a namespace
+func (c *EpinioClient) UpdateNamespaceBuilder(namespace, builder string) error {
+	log := c.Log.WithName("UpdateNamespaceBuilder").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", namespace).
+		WithStringValue("Builder", builder).
+		Msg("Setting the default builder of the namespace...")
+
+	_, err := c.API.NamespaceBuilderUpdate(models.NamespaceBuilderRequest{Builder: builder}, namespace)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Namespace updated.")
+
+	return nil
+}
+
+// UpdateNamespace changes the labels, description and owner of a namespace
+func (c *EpinioClient) UpdateNamespace(namespace string, request models.NamespaceUpdateRequest) error {
+	log := c.Log.WithName("UpdateNamespace").WithValues("Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", namespace).
+		Msg("Updating namespace...")
+
+	_, err := c.API.NamespaceUpdate(request, namespace)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Success().Msg("Namespace updated.")
+
+	return nil
+}
+
+// keyValueList formats labels or annotations as sorted list of KEY=VALUE
+func keyValueList(labels map[string]string) []string {
+	result := []string{}
+	for key, value := range labels {
+		result = append(result, key+"="+value)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// quotaCount formats the usage of a counted resource against its limit
+func quotaCount(used, limit int64) string {
+	if limit == 0 {
+		return fmt.Sprintf("%d / unlimited", used)
+	}
+	return fmt.Sprintf("%d / %d", used, limit)
+}
+
+// quotaQuantity formats the usage of a resource quantity against its limit
+func quotaQuantity(used, limit string) string {
+	if limit == "" {
+		return "unlimited"
+	}
+	if used == "" {
+		used = "0"
+	}
+	return fmt.Sprintf("%s / %s", used, limit)
+}
+
+// CloneNamespace creates a new namespace as copy of the source namespace
+func (c *EpinioClient) CloneNamespace(source, namespace string, request models.NamespaceCloneRequest) error {
+	log := c.Log.WithName("CloneNamespace").WithValues("Source", source, "Namespace", namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Source", source).
+		WithStringValue("Name", namespace).
+		Msg("Cloning namespace...")
+
+	errorMsgs := validation.IsDNS1123Subdomain(namespace)
+	if len(errorMsgs) > 0 {
+		return fmt.Errorf("%s: %s", "namespace name incorrect", strings.Join(errorMsgs, "\n"))
+	}
+
+	request.Name = namespace
+	result, err := c.API.NamespaceClone(request, source)
+	if err != nil {
+		return err
+	}
+
+	c.showPromoteResult(result, "Namespace cloned.")
+
+	return nil
+}
+
+// showPromoteResult prints what was copied by a namespace clone or app promotion
+func (c *EpinioClient) showPromoteResult(result models.PromoteResponse, message string) {
+	msg := c.ui.Success().WithTable("Application", "Routes", "Pending Bindings")
+	for _, app := range result.Apps {
+		msg = msg.WithTableRow(app,
+			strings.Join(result.Routes[app], ", "),
+			strings.Join(result.PendingBindings[app], ", "))
+	}
+	msg.Msg(message)
+
+	if len(result.Configurations) > 0 {
+		c.ui.Note().Msg("Configurations copied: " + strings.Join(result.Configurations, ", "))
+	}
+	if len(result.Services) > 0 {
+		c.ui.Note().Msg("Services copied: " + strings.Join(result.Services, ", "))
+	}
+	if len(result.PendingBindings) > 0 {
+		c.ui.Exclamation().Msg("Bind the pending configurations with `epinio configuration bind` when the copied services are deployed.")
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/epinio/epinio/helpers"
+	"github.com/epinio/epinio/helpers/bytes"
 	"github.com/epinio/epinio/helpers/kubernetes/tailer"
 	"github.com/epinio/epinio/internal/cli/logprinter"
 	"github.com/epinio/epinio/internal/duration"
@@ -61,8 +62,16 @@ func (c *EpinioClient) Push(ctx context.Context, params PushParams) error { // n
 		WithStringValue("Source Origin", source).
 		WithStringValue("AppChart", params.Configuration.AppChart).
 		WithStringValue("Target Namespace", appRef.Namespace)
-	for _, ev := range params.Configuration.Environment.List() {
-		msg = msg.WithStringValue(fmt.Sprintf("Environment '%s'", ev.Name), ev.Value)
+	environment := models.EnvVariableDefinitions{
+		Values:     params.Configuration.Environment,
+		References: params.Configuration.EnvironmentFrom,
+	}
+	for _, ev := range environment.List() {
+		value := ev.Value
+		if ev.ValueFrom != nil {
+			value = "from " + ev.ValueFrom.String()
+		}
+		msg = msg.WithStringValue(fmt.Sprintf("Environment '%s'", ev.Name), value)
 	}
 	// TODO ? Make this a table for nicer alignment
 
@@ -75,6 +84,52 @@ func (c *EpinioClient) Push(ctx context.Context, params PushParams) error { // n
 		params.Staging.Builder != "" {
 		msg = msg.WithStringValue("Builder", params.Staging.Builder)
 	}
+	if params.Origin.Kind != models.OriginContainer &&
+		params.Staging.Strategy != "" {
+		msg = msg.WithStringValue("Strategy", params.Staging.Strategy)
+	}
+	if params.Staging.Dockerfile != "" {
+		msg = msg.WithStringValue("Dockerfile", params.Staging.Dockerfile)
+	}
+	if len(params.Staging.BuildArgs) > 0 {
+		msg = msg.WithStringValue("Build Args", strings.Join(keyValueList(params.Staging.BuildArgs), ", "))
+	}
+	buildEnvironment := models.EnvVariableDefinitions{
+		Values:     params.Staging.Environment,
+		References: params.Staging.Secrets,
+	}
+	for _, ev := range buildEnvironment.List() {
+		value := ev.Value
+		if ev.ValueFrom != nil {
+			value = "secret from " + ev.ValueFrom.String()
+		}
+		msg = msg.WithStringValue(fmt.Sprintf("Build Environment '%s'", ev.Name), value)
+	}
+	if params.Origin.Kind != models.OriginContainer && !params.Staging.Job.IsEmpty() {
+		job := params.Staging.Job
+		if job.CPURequest != "" || job.CPULimit != "" {
+			msg = msg.WithStringValue("Staging CPU", fmt.Sprintf("%s / %s", orDefault(job.CPURequest), orDefault(job.CPULimit)))
+		}
+		if job.MemoryRequest != "" || job.MemoryLimit != "" {
+			msg = msg.WithStringValue("Staging Memory", fmt.Sprintf("%s / %s", orDefault(job.MemoryRequest), orDefault(job.MemoryLimit)))
+		}
+		if len(job.NodeSelector) > 0 {
+			msg = msg.WithStringValue("Staging Node Selector", strings.Join(keyValueList(job.NodeSelector), ", "))
+		}
+		if len(job.Tolerations) > 0 {
+			msg = msg.WithStringValue("Staging Tolerations", strings.Join(job.Tolerations, ", "))
+		}
+		if job.Timeout != "" {
+			msg = msg.WithStringValue("Staging Timeout", job.Timeout)
+		}
+		if job.BackoffLimit != nil {
+			msg = msg.WithStringValue("Staging Retries", fmt.Sprintf("%d", *job.BackoffLimit))
+		}
+	}
+	if (params.Origin.Kind == models.OriginPath || params.Origin.Kind == models.OriginGit) &&
+		len(params.Staging.Exclude) > 0 {
+		msg = msg.WithStringValue("Excludes", strings.Join(params.Staging.Exclude, ", "))
+	}
 
 	if params.Configuration.Instances != nil {
 		msg = msg.WithStringValue("Instances",
@@ -142,7 +197,7 @@ func (c *EpinioClient) Push(ctx context.Context, params PushParams) error { // n
 	case models.OriginPath:
 		c.ui.Normal().Msg("Collecting the application sources ...")
 
-		tmpDir, tarball, err := helpers.Tar(source)
+		tmpDir, tarball, err := helpers.Tar(source, params.Staging.Exclude)
 		defer func() {
 			if tmpDir != "" {
 				_ = os.RemoveAll(tmpDir)
@@ -161,6 +216,10 @@ func (c *EpinioClient) Push(ctx context.Context, params PushParams) error { // n
 		}
 		log.V(3).Info("upload response", "response", upload)
 
+		if upload.Existing {
+			c.ui.Normal().Msg("Application code is unchanged, skipped the upload.")
+		}
+
 		blobUID = upload.BlobUID
 
 	case models.OriginGit:
@@ -171,7 +230,7 @@ func (c *EpinioClient) Push(ctx context.Context, params PushParams) error { // n
 			return errors.New("git origin is nil")
 		}
 
-		response, err := c.API.AppImportGit(appRef, *gitOrigin)
+		response, err := c.API.AppImportGit(appRef, *gitOrigin, params.Staging.Exclude)
 		if err != nil {
 			return errors.Wrap(err, "importing git remote")
 		}
@@ -192,6 +251,14 @@ func (c *EpinioClient) Push(ctx context.Context, params PushParams) error { // n
 			App:          appRef,
 			BlobUID:      blobUID,
 			BuilderImage: params.Staging.Builder,
+			Strategy:     params.Staging.Strategy,
+			Dockerfile:   params.Staging.Dockerfile,
+			BuildArgs:    params.Staging.BuildArgs,
+
+			BuildEnvironment: params.Staging.Environment,
+			BuildSecrets:     params.Staging.Secrets,
+
+			Job: params.Staging.Job,
 		}
 		details.Info("staging code", "Blob", blobUID)
 		stageResponse, err = c.API.AppStage(req)
@@ -292,3 +359,62 @@ func (c *EpinioClient) stageLogs(logger logr.Logger, appRef models.AppRef, stage
 
 	return err
 }
+
+// UploadPreview shows the application sources a push would upload, and the size of the
+// archive holding them, without uploading anything.
+func (c *EpinioClient) UploadPreview(params PushParams) error {
+	source := params.Origin.String()
+	log := c.Log.WithName("UploadPreview").WithValues("Sources", source)
+	log.Info("start")
+	defer log.Info("return")
+
+	if params.Origin.Kind != models.OriginPath {
+		return errors.New("only sources from a local path are uploaded")
+	}
+
+	c.ui.Note().
+		WithStringValue("Sources", source).
+		WithStringValue("Excludes", strings.Join(params.Staging.Exclude, ", ")).
+		Msg("Collecting the application sources ...")
+
+	sources, err := helpers.Sources(source, params.Staging.Exclude)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, tarball, err := helpers.Tar(source, params.Staging.Exclude)
+	defer func() {
+		if tmpDir != "" {
+			_ = os.RemoveAll(tmpDir)
+		}
+	}()
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(tarball)
+	if err != nil {
+		return err
+	}
+
+	msg := c.ui.Success().WithTable("Source")
+	for _, path := range sources {
+		msg = msg.WithTableRow(path)
+	}
+	msg.Msg("Sources to upload:")
+
+	c.ui.Success().
+		WithStringValue("Sources", strconv.Itoa(len(sources))).
+		WithStringValue("Archive Size", bytes.ByteCountIEC(info.Size())).
+		Msg("Nothing was uploaded.")
+
+	return nil
+}
+
+// orDefault returns the value, or a marker for the server default if the value is empty
+func orDefault(value string) string {
+	if value == "" {
+		return "default"
+	}
+	return value
+}
@@ -5,13 +5,20 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/epinio/epinio/internal/duration"
 	apierrors "github.com/epinio/epinio/pkg/api/core/v1/errors"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
+// serviceStatusInterval is the time between checks of the status of a service waited for
+const serviceStatusInterval = 3 * time.Second
+
 // ServiceCatalog lists available services
 func (c *EpinioClient) ServiceCatalog() error {
 	log := c.Log.WithName("ServiceCatalog")
@@ -64,13 +71,87 @@ func (c *EpinioClient) ServiceCatalogShow(serviceName string) error {
 		WithTableRow("Version", service.AppVersion).
 		WithTableRow("Short Description", service.ShortDescription).
 		WithTableRow("Description", service.Description).
+		WithTableRow("Chart", service.HelmChart).
+		WithTableRow("Chart Version", service.ChartVersion).
+		WithTableRow("Helm Repository", service.HelmRepo.URL).
+		WithTableRow("Backup", strconv.FormatBool(service.BackupJob != "")).
+		WithTableRow("Restore", strconv.FormatBool(service.RestoreJob != "")).
 		Msg("Epinio Service:")
 
 	return nil
 }
 
-// ServiceCreate creates a service
-func (c *EpinioClient) ServiceCreate(catalogServiceName, serviceName string) error {
+// ServiceCatalogAdd adds a service to the catalog
+func (c *EpinioClient) ServiceCatalogAdd(catalogService models.CatalogService) error {
+	log := c.Log.WithName("ServiceCatalogAdd")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Service", catalogService.Meta.Name).
+		WithStringValue("Chart", catalogService.HelmChart).
+		WithStringValue("Helm Repository", catalogService.HelmRepo.URL).
+		Msg("Adding service to the catalog...")
+
+	err := c.API.ServiceCatalogCreate(&catalogService)
+	if err != nil {
+		return errors.Wrap(err, "service catalog add failed")
+	}
+
+	c.ui.Success().
+		WithStringValue("Service", catalogService.Meta.Name).
+		Msg("Catalog service added.")
+
+	return nil
+}
+
+// ServiceCatalogUpdate modifies a service of the catalog
+func (c *EpinioClient) ServiceCatalogUpdate(serviceName string, request models.ServiceCatalogUpdateRequest) error {
+	log := c.Log.WithName("ServiceCatalogUpdate")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Service", serviceName).
+		Msg("Updating catalog service...")
+
+	err := c.API.ServiceCatalogUpdate(&request, serviceName)
+	if err != nil {
+		return errors.Wrap(err, "service catalog update failed")
+	}
+
+	c.ui.Success().
+		WithStringValue("Service", serviceName).
+		Msg("Catalog service changes saved.")
+
+	return nil
+}
+
+// ServiceCatalogRemove removes a service from the catalog
+func (c *EpinioClient) ServiceCatalogRemove(serviceName string) error {
+	log := c.Log.WithName("ServiceCatalogRemove")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Service", serviceName).
+		Msg("Removing service from the catalog...")
+
+	err := c.API.ServiceCatalogDelete(serviceName)
+	if err != nil {
+		return errors.Wrap(err, "service catalog remove failed")
+	}
+
+	c.ui.Success().
+		WithStringValue("Service", serviceName).
+		Msg("Catalog service removed.")
+
+	return nil
+}
+
+// ServiceCreate creates a service. When waiting is requested it returns only after the
+// service is deployed and all its pods are ready, or has failed.
+func (c *EpinioClient) ServiceCreate(catalogServiceName, serviceName string, wait bool) error {
 	log := c.Log.WithName("ServiceCreate")
 	log.Info("start")
 	defer log.Info("return")
@@ -86,12 +167,108 @@ func (c *EpinioClient) ServiceCreate(catalogServiceName, serviceName string) err
 	}
 
 	err := c.API.ServiceCreate(request, c.Settings.Namespace)
-	// Note: errors.Wrap (nil, "...") == nil
-	return errors.Wrap(err, "service create failed")
+	if err != nil || !wait {
+		// Note: errors.Wrap (nil, "...") == nil
+		return errors.Wrap(err, "service create failed")
+	}
+
+	c.ui.Note().
+		WithStringValue("Service", serviceName).
+		Msg("Waiting for the service to become ready...")
+
+	return errors.Wrap(c.waitForService(serviceName), "service create failed")
+}
+
+// waitForService polls the status of the named service until it is deployed with all its
+// pods ready, or has failed, or the wait timed out.
+func (c *EpinioClient) waitForService(serviceName string) error {
+	var service *models.Service
+
+	err := wait.PollImmediate(serviceStatusInterval, duration.ToServiceReady(), func() (bool, error) {
+		resp, err := c.API.ServiceShow(&models.ServiceShowRequest{Name: serviceName}, c.Settings.Namespace)
+		if err != nil {
+			return false, err
+		}
+		if resp.Service == nil {
+			return false, errors.New("Service not found")
+		}
+
+		service = resp.Service
+		return service.Status != models.ServiceStatusNotReady, nil
+	})
+	if err != nil {
+		if err == wait.ErrWaitTimeout && service != nil {
+			return fmt.Errorf("service not ready in time: %s", statusSummary(*service))
+		}
+		return err
+	}
+
+	if service.Status != models.ServiceStatusDeployed {
+		return fmt.Errorf("service %s: %s", service.Status, statusSummary(*service))
+	}
+
+	c.ui.Success().
+		WithStringValue("Service", serviceName).
+		WithStringValue("Status", service.Status.String()).
+		Msg("Service ready.")
+
+	return nil
 }
 
-// ServiceShow describes a service instance
-func (c *EpinioClient) ServiceShow(serviceName string) error {
+// waitForServiceJob waits for the backup or restore job of the service to finish, and
+// returns its final status. The logs of a failed job are shown.
+func (c *EpinioClient) waitForServiceJob(serviceName string, job *models.ServiceJob) (*models.ServiceJob, error) {
+	s := c.ui.Progressf("Waiting for the %s job %s", job.Kind, job.Name)
+	defer s.Stop()
+
+	err := wait.PollImmediate(serviceStatusInterval, duration.ToServiceBackup()+time.Minute, func() (bool, error) {
+		resp, err := c.API.ServiceJob(c.Settings.Namespace, serviceName, job.Name)
+		if err != nil {
+			return false, err
+		}
+
+		job = resp
+		return job.Status != models.ServiceJobRunning, nil
+	})
+	if err != nil {
+		if err == wait.ErrWaitTimeout {
+			return nil, fmt.Errorf("%s job %s not done in time", job.Kind, job.Name)
+		}
+		return nil, err
+	}
+	s.Stop()
+
+	if job.Status != models.ServiceJobSucceeded {
+		if job.Logs != "" {
+			c.ui.Normal().Msg(job.Logs)
+		}
+		return nil, fmt.Errorf("%s job %s failed: %s", job.Kind, job.Name, job.Message)
+	}
+
+	return job, nil
+}
+
+// statusSummary condenses the status details of a service into a single line
+func statusSummary(service models.Service) string {
+	details := service.Details
+
+	summary := fmt.Sprintf("pods ready %d/%d", details.PodsReady, details.PodsTotal)
+	if details.HelmStatus != "" {
+		summary = fmt.Sprintf("release %s, %s", details.HelmStatus, summary)
+	}
+	if details.JobStatus != "" {
+		summary = fmt.Sprintf("job %s, %s", details.JobStatus, summary)
+	}
+	if details.LastError != "" {
+		summary = fmt.Sprintf("%s, last error: %s", summary, details.LastError)
+	}
+
+	return summary
+}
+
+// ServiceShow describes a service instance. The values of the service's configurations
+// are shown only on request.
+func (c *EpinioClient) ServiceShow(serviceName string, secrets bool) error {
 	log := c.Log.WithName("ServiceShow")
 	log.Info("start")
 	defer log.Info("return")
@@ -99,7 +276,8 @@ func (c *EpinioClient) ServiceShow(serviceName string) error {
 	c.ui.Note().Msg("Showing Service...")
 
 	request := &models.ServiceShowRequest{
-		Name: serviceName,
+		Name:    serviceName,
+		Secrets: secrets,
 	}
 
 	resp, err := c.API.ServiceShow(request, c.Settings.Namespace)
@@ -115,9 +293,240 @@ func (c *EpinioClient) ServiceShow(serviceName string) error {
 		WithTableRow("Name", resp.Service.Meta.Name).
 		WithTableRow("Created", fmt.Sprintf("%v", resp.Service.Meta.CreatedAt)).
 		WithTableRow("Catalog Service", resp.Service.CatalogService).
+		WithTableRow("Chart Version", resp.Service.ChartVersion).
 		WithTableRow("Status", resp.Service.Status.String()).
+		WithTableRow("Helm Release", resp.Service.Details.HelmStatus).
+		WithTableRow("Helm Job", string(resp.Service.Details.JobStatus)).
+		WithTableRow("Pods Ready", fmt.Sprintf("%d/%d", resp.Service.Details.PodsReady, resp.Service.Details.PodsTotal)).
+		WithTableRow("Last Error", resp.Service.Details.LastError).
 		Msg("Details:")
 
+	if len(resp.Service.Settings) > 0 {
+		keys := []string{}
+		for key := range resp.Service.Settings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		msg := c.ui.Normal().WithTable("Value", "Setting")
+		for _, key := range keys {
+			msg = msg.WithTableRow(key, resp.Service.Settings[key])
+		}
+		msg.Msg("Settings:")
+	}
+
+	if len(resp.Configurations) > 0 {
+		msg := c.ui.Normal()
+		if secrets {
+			msg = msg.WithTable("Configuration", "Key", "Value")
+		} else {
+			msg = msg.WithTable("Configuration", "Key")
+		}
+
+		for _, configuration := range resp.Configurations {
+			for _, key := range configuration.Keys {
+				if secrets {
+					msg = msg.WithTableRow(configuration.Name, key, configuration.Values[key])
+				} else {
+					msg = msg.WithTableRow(configuration.Name, key)
+				}
+			}
+		}
+		msg.Msg("Configurations:")
+	}
+
+	return nil
+}
+
+// ServiceUpdate changes the helm values of a service
+func (c *EpinioClient) ServiceUpdate(name string, removedKeys []string, assignments map[string]string) error {
+	log := c.Log.WithName("ServiceUpdate").
+		WithValues("Name", name, "Namespace", c.Settings.Namespace)
+	log.Info("start")
+	defer log.Info("return")
+
+	msg := c.ui.Note().
+		WithStringValue("Name", name).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithTable("Value", "Op", "Setting")
+
+	for _, removed := range removedKeys {
+		msg = msg.WithTableRow(removed, "remove", "")
+	}
+
+	changed := []string{}
+	for key := range assignments {
+		changed = append(changed, key)
+	}
+	sort.Strings(changed)
+
+	for _, key := range changed {
+		msg = msg.WithTableRow(key, "add/change", assignments[key])
+	}
+	msg.Msg("Updating Service...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	request := &models.ServiceUpdateRequest{
+		Remove: removedKeys,
+		Set:    assignments,
+	}
+
+	err := c.API.ServiceUpdate(request, c.Settings.Namespace, name)
+	if err != nil {
+		return errors.Wrap(err, "service update failed")
+	}
+
+	c.ui.Success().
+		WithStringValue("Name", name).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		Msg("Service Changes Saved.")
+
+	return nil
+}
+
+// ServiceUpgrade upgrades a service to the chart version of its catalog service
+func (c *EpinioClient) ServiceUpgrade(name string) error {
+	log := c.Log.WithName("ServiceUpgrade")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", name).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		Msg("Upgrading Service...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	resp, err := c.API.ServiceUpgrade(c.Settings.Namespace, name)
+	if err != nil {
+		return errors.Wrap(err, "service upgrade failed")
+	}
+
+	if resp.OldChartVersion == resp.NewChartVersion {
+		c.ui.Success().
+			WithStringValue("Name", name).
+			WithStringValue("Chart Version", resp.NewChartVersion).
+			Msg("Service is up to date.")
+		return nil
+	}
+
+	c.ui.Success().
+		WithStringValue("Name", name).
+		WithStringValue("Old Chart Version", resp.OldChartVersion).
+		WithStringValue("New Chart Version", resp.NewChartVersion).
+		Msg("Service Upgraded.")
+
+	return nil
+}
+
+// ServiceBackup saves the data of a service in the Epinio S3 storage
+func (c *EpinioClient) ServiceBackup(name string) error {
+	log := c.Log.WithName("ServiceBackup")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", name).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		Msg("Backing up Service...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	job, err := c.API.ServiceBackup(c.Settings.Namespace, name)
+	if err != nil {
+		return errors.Wrap(err, "service backup failed")
+	}
+
+	job, err = c.waitForServiceJob(name, job)
+	if err != nil {
+		return errors.Wrap(err, "service backup failed")
+	}
+
+	c.ui.Success().
+		WithStringValue("Name", name).
+		WithStringValue("Backup", job.Backup.ID).
+		WithStringValue("Size", strconv.FormatInt(job.Backup.Size, 10)).
+		Msg("Service Backed Up.")
+
+	return nil
+}
+
+// ServiceBackups lists the backups of a service
+func (c *EpinioClient) ServiceBackups(name string) error {
+	log := c.Log.WithName("ServiceBackups")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", name).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		Msg("Listing Service Backups...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	backups, err := c.API.ServiceBackups(c.Settings.Namespace, name)
+	if err != nil {
+		return errors.Wrap(err, "service backup list failed")
+	}
+
+	if len(backups) == 0 {
+		c.ui.Normal().Msg("No backups found")
+		return nil
+	}
+
+	msg := c.ui.Success().WithTable("Backup", "Created", "Size")
+	for _, backup := range backups {
+		msg = msg.WithTableRow(
+			backup.ID,
+			backup.CreatedAt.String(),
+			strconv.FormatInt(backup.Size, 10),
+		)
+	}
+	msg.Msg("Details:")
+
+	return nil
+}
+
+// ServiceRestore restores the data of a service from one of its backups
+func (c *EpinioClient) ServiceRestore(name, backupID string) error {
+	log := c.Log.WithName("ServiceRestore")
+	log.Info("start")
+	defer log.Info("return")
+
+	c.ui.Note().
+		WithStringValue("Name", name).
+		WithStringValue("Namespace", c.Settings.Namespace).
+		WithStringValue("Backup", backupID).
+		Msg("Restoring Service...")
+
+	if err := c.TargetOk(); err != nil {
+		return err
+	}
+
+	job, err := c.API.ServiceRestore(c.Settings.Namespace, name, backupID)
+	if err != nil {
+		return errors.Wrap(err, "service restore failed")
+	}
+
+	_, err = c.waitForServiceJob(name, job)
+	if err != nil {
+		return errors.Wrap(err, "service restore failed")
+	}
+
+	c.ui.Success().
+		WithStringValue("Name", name).
+		WithStringValue("Backup", backupID).
+		Msg("Service Restored.")
+
 	return nil
 }
 
@@ -190,15 +599,49 @@ func (c *EpinioClient) ServiceDelete(name string, unbind bool) error {
 }
 
 // ServiceBind binds a service to an application
-func (c *EpinioClient) ServiceBind(name, appName string) error {
+func (c *EpinioClient) ServiceBind(name, appName string, secrets map[string]models.BindOptions) error {
 	log := c.Log.WithName("ServiceBind")
 	log.Info("start")
 	defer log.Info("return")
 
-	c.ui.Note().Msg("Binding Service...")
+	msg := c.ui.Note()
+	if len(secrets) > 0 {
+		msg = msg.WithTable("Secret", "Key", "As", "Injection")
+
+		names := []string{}
+		for secret := range secrets {
+			names = append(names, secret)
+		}
+		sort.Strings(names)
+
+		for _, secret := range names {
+			options := secrets[secret]
+			injection := "file"
+			if options.AsEnv {
+				injection = "env"
+			}
+
+			if len(options.Keys) == 0 {
+				msg = msg.WithTableRow(secret, "*", "*", injection)
+				continue
+			}
+
+			keys := []string{}
+			for key := range options.Keys {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				msg = msg.WithTableRow(secret, key, options.Keys[key], injection)
+			}
+		}
+	}
+	msg.Msg("Binding Service...")
 
 	request := &models.ServiceBindRequest{
 		AppName: appName,
+		Secrets: secrets,
 	}
 
 	err := c.API.ServiceBind(request, c.Settings.Namespace, name)
@@ -2,8 +2,8 @@
 package usercmdfakes
 
 import (
-	"net/http"
 	"sync"
+	"time"
 
 	"github.com/epinio/epinio/helpers/kubernetes/tailer"
 	"github.com/epinio/epinio/internal/cli/usercmd"
@@ -13,9 +13,10 @@ import (
 )
 
 type FakeAPIClient struct {
-	AllAppsStub        func() (models.AppList, error)
+	AllAppsStub        func(string) (models.AppList, error)
 	allAppsMutex       sync.RWMutex
 	allAppsArgsForCall []struct {
+		arg1 string
 	}
 	allAppsReturns struct {
 		result1 models.AppList
@@ -49,6 +50,34 @@ type FakeAPIClient struct {
 		result1 *models.ServiceListResponse
 		result2 error
 	}
+	AppCacheClearStub        func(string, string) (models.Response, error)
+	appCacheClearMutex       sync.RWMutex
+	appCacheClearArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appCacheClearReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	appCacheClearReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	AppCacheShowStub        func(string, string) (models.AppCache, error)
+	appCacheShowMutex       sync.RWMutex
+	appCacheShowArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appCacheShowReturns struct {
+		result1 models.AppCache
+		result2 error
+	}
+	appCacheShowReturnsOnCall map[int]struct {
+		result1 models.AppCache
+		result2 error
+	}
 	AppCreateStub        func(models.ApplicationCreateRequest, string) (models.Response, error)
 	appCreateMutex       sync.RWMutex
 	appCreateArgsForCall []struct {
@@ -118,11 +147,12 @@ type FakeAPIClient struct {
 	appGetPartReturnsOnCall map[int]struct {
 		result1 error
 	}
-	AppImportGitStub        func(models.AppRef, models.GitRef) (*models.ImportGitResponse, error)
+	AppImportGitStub        func(models.AppRef, models.GitRef, []string) (*models.ImportGitResponse, error)
 	appImportGitMutex       sync.RWMutex
 	appImportGitArgsForCall []struct {
 		arg1 models.AppRef
 		arg2 models.GitRef
+		arg3 []string
 	}
 	appImportGitReturns struct {
 		result1 *models.ImportGitResponse
@@ -161,6 +191,21 @@ type FakeAPIClient struct {
 	appPortForwardReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AppPromoteStub        func(models.AppPromoteRequest, string, string) (models.PromoteResponse, error)
+	appPromoteMutex       sync.RWMutex
+	appPromoteArgsForCall []struct {
+		arg1 models.AppPromoteRequest
+		arg2 string
+		arg3 string
+	}
+	appPromoteReturns struct {
+		result1 models.PromoteResponse
+		result2 error
+	}
+	appPromoteReturnsOnCall map[int]struct {
+		result1 models.PromoteResponse
+		result2 error
+	}
 	AppRestartStub        func(string, string) error
 	appRestartMutex       sync.RWMutex
 	appRestartArgsForCall []struct {
@@ -186,6 +231,21 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
+	AppSBOMStub        func(string, string, string) (models.AppSBOM, error)
+	appSBOMMutex       sync.RWMutex
+	appSBOMArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	appSBOMReturns struct {
+		result1 models.AppSBOM
+		result2 error
+	}
+	appSBOMReturnsOnCall map[int]struct {
+		result1 models.AppSBOM
+		result2 error
+	}
 	AppShowStub        func(string, string) (models.App, error)
 	appShowMutex       sync.RWMutex
 	appShowArgsForCall []struct {
@@ -243,10 +303,11 @@ type FakeAPIClient struct {
 		result1 models.UploadResponse
 		result2 error
 	}
-	AppsStub        func(string) (models.AppList, error)
+	AppsStub        func(string, string) (models.AppList, error)
 	appsMutex       sync.RWMutex
 	appsArgsForCall []struct {
 		arg1 string
+		arg2 string
 	}
 	appsReturns struct {
 		result1 models.AppList
@@ -256,6 +317,20 @@ type FakeAPIClient struct {
 		result1 models.AppList
 		result2 error
 	}
+	AppsRestartStub        func(string, string) (models.AppsRestartResponse, error)
+	appsRestartMutex       sync.RWMutex
+	appsRestartArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	appsRestartReturns struct {
+		result1 models.AppsRestartResponse
+		result2 error
+	}
+	appsRestartReturnsOnCall map[int]struct {
+		result1 models.AppsRestartResponse
+		result2 error
+	}
 	AuthTokenStub        func() (string, error)
 	authTokenMutex       sync.RWMutex
 	authTokenArgsForCall []struct {
@@ -268,6 +343,69 @@ type FakeAPIClient struct {
 		result1 string
 		result2 error
 	}
+	BlobsStub        func() (models.BlobList, error)
+	blobsMutex       sync.RWMutex
+	blobsArgsForCall []struct {
+	}
+	blobsReturns struct {
+		result1 models.BlobList
+		result2 error
+	}
+	blobsReturnsOnCall map[int]struct {
+		result1 models.BlobList
+		result2 error
+	}
+	BlobsGCStub        func(models.BlobsGCRequest) (models.BlobsGCResponse, error)
+	blobsGCMutex       sync.RWMutex
+	blobsGCArgsForCall []struct {
+		arg1 models.BlobsGCRequest
+	}
+	blobsGCReturns struct {
+		result1 models.BlobsGCResponse
+		result2 error
+	}
+	blobsGCReturnsOnCall map[int]struct {
+		result1 models.BlobsGCResponse
+		result2 error
+	}
+	BuilderDeleteStub        func(string) (models.Response, error)
+	builderDeleteMutex       sync.RWMutex
+	builderDeleteArgsForCall []struct {
+		arg1 string
+	}
+	builderDeleteReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	builderDeleteReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	BuilderSetStub        func(models.Builder) (models.Response, error)
+	builderSetMutex       sync.RWMutex
+	builderSetArgsForCall []struct {
+		arg1 models.Builder
+	}
+	builderSetReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	builderSetReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	BuildersStub        func() (models.BuilderList, error)
+	buildersMutex       sync.RWMutex
+	buildersArgsForCall []struct {
+	}
+	buildersReturns struct {
+		result1 models.BuilderList
+		result2 error
+	}
+	buildersReturnsOnCall map[int]struct {
+		result1 models.BuilderList
+		result2 error
+	}
 	ChartListStub        func() ([]models.AppChart, error)
 	chartListMutex       sync.RWMutex
 	chartListArgsForCall []struct {
@@ -363,13 +501,13 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
-	ConfigurationDeleteStub        func(models.ConfigurationDeleteRequest, string, string, func(response *http.Response, bodyBytes []byte, err error) error) (models.ConfigurationDeleteResponse, error)
+	ConfigurationDeleteStub        func(models.ConfigurationDeleteRequest, string, string, client.ErrorFunc) (models.ConfigurationDeleteResponse, error)
 	configurationDeleteMutex       sync.RWMutex
 	configurationDeleteArgsForCall []struct {
 		arg1 models.ConfigurationDeleteRequest
 		arg2 string
 		arg3 string
-		arg4 func(response *http.Response, bodyBytes []byte, err error) error
+		arg4 client.ErrorFunc
 	}
 	configurationDeleteReturns struct {
 		result1 models.ConfigurationDeleteResponse
@@ -379,6 +517,36 @@ type FakeAPIClient struct {
 		result1 models.ConfigurationDeleteResponse
 		result2 error
 	}
+	ConfigurationHistoryStub        func(string, string) (models.ConfigurationHistoryResponse, error)
+	configurationHistoryMutex       sync.RWMutex
+	configurationHistoryArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	configurationHistoryReturns struct {
+		result1 models.ConfigurationHistoryResponse
+		result2 error
+	}
+	configurationHistoryReturnsOnCall map[int]struct {
+		result1 models.ConfigurationHistoryResponse
+		result2 error
+	}
+	ConfigurationRevertStub        func(models.ConfigurationRevertRequest, string, string, int) (models.Response, error)
+	configurationRevertMutex       sync.RWMutex
+	configurationRevertArgsForCall []struct {
+		arg1 models.ConfigurationRevertRequest
+		arg2 string
+		arg3 string
+		arg4 int
+	}
+	configurationRevertReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	configurationRevertReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
 	ConfigurationShowStub        func(string, string) (models.ConfigurationResponse, error)
 	configurationShowMutex       sync.RWMutex
 	configurationShowArgsForCall []struct {
@@ -421,18 +589,19 @@ type FakeAPIClient struct {
 		result1 models.ConfigurationResponseList
 		result2 error
 	}
-	EnvListStub        func(string, string) (models.EnvVariableMap, error)
+	EnvListStub        func(string, string, bool) (models.EnvVariableDefinitions, error)
 	envListMutex       sync.RWMutex
 	envListArgsForCall []struct {
 		arg1 string
 		arg2 string
+		arg3 bool
 	}
 	envListReturns struct {
-		result1 models.EnvVariableMap
+		result1 models.EnvVariableDefinitions
 		result2 error
 	}
 	envListReturnsOnCall map[int]struct {
-		result1 models.EnvVariableMap
+		result1 models.EnvVariableDefinitions
 		result2 error
 	}
 	EnvMatchStub        func(string, string, string) (models.EnvMatchResponse, error)
@@ -450,10 +619,10 @@ type FakeAPIClient struct {
 		result1 models.EnvMatchResponse
 		result2 error
 	}
-	EnvSetStub        func(models.EnvVariableMap, string, string) (models.Response, error)
+	EnvSetStub        func(models.EnvVariableDefinitions, string, string) (models.Response, error)
 	envSetMutex       sync.RWMutex
 	envSetArgsForCall []struct {
-		arg1 models.EnvVariableMap
+		arg1 models.EnvVariableDefinitions
 		arg2 string
 		arg3 string
 	}
@@ -465,12 +634,13 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
-	EnvShowStub        func(string, string, string) (models.EnvVariable, error)
+	EnvShowStub        func(string, string, string, bool) (models.EnvVariable, error)
 	envShowMutex       sync.RWMutex
 	envShowArgsForCall []struct {
 		arg1 string
 		arg2 string
 		arg3 string
+		arg4 bool
 	}
 	envShowReturns struct {
 		result1 models.EnvVariable
@@ -495,6 +665,31 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
+	ImagesStub        func() (models.AppImageList, error)
+	imagesMutex       sync.RWMutex
+	imagesArgsForCall []struct {
+	}
+	imagesReturns struct {
+		result1 models.AppImageList
+		result2 error
+	}
+	imagesReturnsOnCall map[int]struct {
+		result1 models.AppImageList
+		result2 error
+	}
+	ImagesGCStub        func(models.ImagesGCRequest) (models.ImagesGCResponse, error)
+	imagesGCMutex       sync.RWMutex
+	imagesGCArgsForCall []struct {
+		arg1 models.ImagesGCRequest
+	}
+	imagesGCReturns struct {
+		result1 models.ImagesGCResponse
+		result2 error
+	}
+	imagesGCReturnsOnCall map[int]struct {
+		result1 models.ImagesGCResponse
+		result2 error
+	}
 	InfoStub        func() (models.InfoResponse, error)
 	infoMutex       sync.RWMutex
 	infoArgsForCall []struct {
@@ -507,6 +702,48 @@ type FakeAPIClient struct {
 		result1 models.InfoResponse
 		result2 error
 	}
+	NamespaceBindStub        func(models.NamespaceBindRequest, string) (models.Response, error)
+	namespaceBindMutex       sync.RWMutex
+	namespaceBindArgsForCall []struct {
+		arg1 models.NamespaceBindRequest
+		arg2 string
+	}
+	namespaceBindReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceBindReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespaceBuilderUpdateStub        func(models.NamespaceBuilderRequest, string) (models.Response, error)
+	namespaceBuilderUpdateMutex       sync.RWMutex
+	namespaceBuilderUpdateArgsForCall []struct {
+		arg1 models.NamespaceBuilderRequest
+		arg2 string
+	}
+	namespaceBuilderUpdateReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceBuilderUpdateReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespaceCloneStub        func(models.NamespaceCloneRequest, string) (models.PromoteResponse, error)
+	namespaceCloneMutex       sync.RWMutex
+	namespaceCloneArgsForCall []struct {
+		arg1 models.NamespaceCloneRequest
+		arg2 string
+	}
+	namespaceCloneReturns struct {
+		result1 models.PromoteResponse
+		result2 error
+	}
+	namespaceCloneReturnsOnCall map[int]struct {
+		result1 models.PromoteResponse
+		result2 error
+	}
 	NamespaceCreateStub        func(models.NamespaceCreateRequest) (models.Response, error)
 	namespaceCreateMutex       sync.RWMutex
 	namespaceCreateArgsForCall []struct {
@@ -533,6 +770,75 @@ type FakeAPIClient struct {
 		result1 models.Response
 		result2 error
 	}
+	NamespaceDeletionPreviewStub        func(string) (models.NamespaceDeletionPreview, error)
+	namespaceDeletionPreviewMutex       sync.RWMutex
+	namespaceDeletionPreviewArgsForCall []struct {
+		arg1 string
+	}
+	namespaceDeletionPreviewReturns struct {
+		result1 models.NamespaceDeletionPreview
+		result2 error
+	}
+	namespaceDeletionPreviewReturnsOnCall map[int]struct {
+		result1 models.NamespaceDeletionPreview
+		result2 error
+	}
+	NamespaceEnvListStub        func(string) (models.EnvVariableMap, error)
+	namespaceEnvListMutex       sync.RWMutex
+	namespaceEnvListArgsForCall []struct {
+		arg1 string
+	}
+	namespaceEnvListReturns struct {
+		result1 models.EnvVariableMap
+		result2 error
+	}
+	namespaceEnvListReturnsOnCall map[int]struct {
+		result1 models.EnvVariableMap
+		result2 error
+	}
+	NamespaceEnvSetStub        func(models.NamespaceEnvSetRequest, string) (models.Response, error)
+	namespaceEnvSetMutex       sync.RWMutex
+	namespaceEnvSetArgsForCall []struct {
+		arg1 models.NamespaceEnvSetRequest
+		arg2 string
+	}
+	namespaceEnvSetReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceEnvSetReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespaceEnvUnsetStub        func(string, string, bool) (models.Response, error)
+	namespaceEnvUnsetMutex       sync.RWMutex
+	namespaceEnvUnsetArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}
+	namespaceEnvUnsetReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceEnvUnsetReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespaceQuotaUpdateStub        func(models.NamespaceQuota, string) (models.Response, error)
+	namespaceQuotaUpdateMutex       sync.RWMutex
+	namespaceQuotaUpdateArgsForCall []struct {
+		arg1 models.NamespaceQuota
+		arg2 string
+	}
+	namespaceQuotaUpdateReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceQuotaUpdateReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
 	NamespaceShowStub        func(string) (models.Namespace, error)
 	namespaceShowMutex       sync.RWMutex
 	namespaceShowArgsForCall []struct {
@@ -546,9 +852,66 @@ type FakeAPIClient struct {
 		result1 models.Namespace
 		result2 error
 	}
-	NamespacesStub        func() (models.NamespaceList, error)
+	NamespaceSoftDeleteStub        func(string, time.Duration) (models.Response, error)
+	namespaceSoftDeleteMutex       sync.RWMutex
+	namespaceSoftDeleteArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+	}
+	namespaceSoftDeleteReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceSoftDeleteReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespaceUnbindStub        func(string, string, bool) (models.Response, error)
+	namespaceUnbindMutex       sync.RWMutex
+	namespaceUnbindArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}
+	namespaceUnbindReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceUnbindReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespaceUndeleteStub        func(string) (models.Response, error)
+	namespaceUndeleteMutex       sync.RWMutex
+	namespaceUndeleteArgsForCall []struct {
+		arg1 string
+	}
+	namespaceUndeleteReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceUndeleteReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespaceUpdateStub        func(models.NamespaceUpdateRequest, string) (models.Response, error)
+	namespaceUpdateMutex       sync.RWMutex
+	namespaceUpdateArgsForCall []struct {
+		arg1 models.NamespaceUpdateRequest
+		arg2 string
+	}
+	namespaceUpdateReturns struct {
+		result1 models.Response
+		result2 error
+	}
+	namespaceUpdateReturnsOnCall map[int]struct {
+		result1 models.Response
+		result2 error
+	}
+	NamespacesStub        func(string) (models.NamespaceList, error)
 	namespacesMutex       sync.RWMutex
 	namespacesArgsForCall []struct {
+		arg1 string
 	}
 	namespacesReturns struct {
 		result1 models.NamespaceList
@@ -571,6 +934,34 @@ type FakeAPIClient struct {
 		result1 models.NamespacesMatchResponse
 		result2 error
 	}
+	ServiceBackupStub        func(string, string) (*models.ServiceJob, error)
+	serviceBackupMutex       sync.RWMutex
+	serviceBackupArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	serviceBackupReturns struct {
+		result1 *models.ServiceJob
+		result2 error
+	}
+	serviceBackupReturnsOnCall map[int]struct {
+		result1 *models.ServiceJob
+		result2 error
+	}
+	ServiceBackupsStub        func(string, string) (models.ServiceBackupList, error)
+	serviceBackupsMutex       sync.RWMutex
+	serviceBackupsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	serviceBackupsReturns struct {
+		result1 models.ServiceBackupList
+		result2 error
+	}
+	serviceBackupsReturnsOnCall map[int]struct {
+		result1 models.ServiceBackupList
+		result2 error
+	}
 	ServiceBindStub        func(*models.ServiceBindRequest, string, string) error
 	serviceBindMutex       sync.RWMutex
 	serviceBindArgsForCall []struct {
@@ -596,6 +987,28 @@ type FakeAPIClient struct {
 		result1 *models.ServiceCatalogResponse
 		result2 error
 	}
+	ServiceCatalogCreateStub        func(*models.CatalogService) error
+	serviceCatalogCreateMutex       sync.RWMutex
+	serviceCatalogCreateArgsForCall []struct {
+		arg1 *models.CatalogService
+	}
+	serviceCatalogCreateReturns struct {
+		result1 error
+	}
+	serviceCatalogCreateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ServiceCatalogDeleteStub        func(string) error
+	serviceCatalogDeleteMutex       sync.RWMutex
+	serviceCatalogDeleteArgsForCall []struct {
+		arg1 string
+	}
+	serviceCatalogDeleteReturns struct {
+		result1 error
+	}
+	serviceCatalogDeleteReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ServiceCatalogShowStub        func(string) (*models.ServiceCatalogShowResponse, error)
 	serviceCatalogShowMutex       sync.RWMutex
 	serviceCatalogShowArgsForCall []struct {
@@ -609,6 +1022,18 @@ type FakeAPIClient struct {
 		result1 *models.ServiceCatalogShowResponse
 		result2 error
 	}
+	ServiceCatalogUpdateStub        func(*models.ServiceCatalogUpdateRequest, string) error
+	serviceCatalogUpdateMutex       sync.RWMutex
+	serviceCatalogUpdateArgsForCall []struct {
+		arg1 *models.ServiceCatalogUpdateRequest
+		arg2 string
+	}
+	serviceCatalogUpdateReturns struct {
+		result1 error
+	}
+	serviceCatalogUpdateReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ServiceCreateStub        func(*models.ServiceCreateRequest, string) error
 	serviceCreateMutex       sync.RWMutex
 	serviceCreateArgsForCall []struct {
@@ -621,13 +1046,13 @@ type FakeAPIClient struct {
 	serviceCreateReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ServiceDeleteStub        func(models.ServiceDeleteRequest, string, string, func(response *http.Response, bodyBytes []byte, err error) error) (models.ServiceDeleteResponse, error)
+	ServiceDeleteStub        func(models.ServiceDeleteRequest, string, string, client.ErrorFunc) (models.ServiceDeleteResponse, error)
 	serviceDeleteMutex       sync.RWMutex
 	serviceDeleteArgsForCall []struct {
 		arg1 models.ServiceDeleteRequest
 		arg2 string
 		arg3 string
-		arg4 func(response *http.Response, bodyBytes []byte, err error) error
+		arg4 client.ErrorFunc
 	}
 	serviceDeleteReturns struct {
 		result1 models.ServiceDeleteResponse
@@ -637,6 +1062,21 @@ type FakeAPIClient struct {
 		result1 models.ServiceDeleteResponse
 		result2 error
 	}
+	ServiceJobStub        func(string, string, string) (*models.ServiceJob, error)
+	serviceJobMutex       sync.RWMutex
+	serviceJobArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	serviceJobReturns struct {
+		result1 *models.ServiceJob
+		result2 error
+	}
+	serviceJobReturnsOnCall map[int]struct {
+		result1 *models.ServiceJob
+		result2 error
+	}
 	ServiceListStub        func(string) (*models.ServiceListResponse, error)
 	serviceListMutex       sync.RWMutex
 	serviceListArgsForCall []struct {
@@ -650,6 +1090,21 @@ type FakeAPIClient struct {
 		result1 *models.ServiceListResponse
 		result2 error
 	}
+	ServiceRestoreStub        func(string, string, string) (*models.ServiceJob, error)
+	serviceRestoreMutex       sync.RWMutex
+	serviceRestoreArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	serviceRestoreReturns struct {
+		result1 *models.ServiceJob
+		result2 error
+	}
+	serviceRestoreReturnsOnCall map[int]struct {
+		result1 *models.ServiceJob
+		result2 error
+	}
 	ServiceShowStub        func(*models.ServiceShowRequest, string) (*models.ServiceShowResponse, error)
 	serviceShowMutex       sync.RWMutex
 	serviceShowArgsForCall []struct {
@@ -677,6 +1132,33 @@ type FakeAPIClient struct {
 	serviceUnbindReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ServiceUpdateStub        func(*models.ServiceUpdateRequest, string, string) error
+	serviceUpdateMutex       sync.RWMutex
+	serviceUpdateArgsForCall []struct {
+		arg1 *models.ServiceUpdateRequest
+		arg2 string
+		arg3 string
+	}
+	serviceUpdateReturns struct {
+		result1 error
+	}
+	serviceUpdateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ServiceUpgradeStub        func(string, string) (*models.ServiceUpgradeResponse, error)
+	serviceUpgradeMutex       sync.RWMutex
+	serviceUpgradeArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	serviceUpgradeReturns struct {
+		result1 *models.ServiceUpgradeResponse
+		result2 error
+	}
+	serviceUpgradeReturnsOnCall map[int]struct {
+		result1 *models.ServiceUpgradeResponse
+		result2 error
+	}
 	StagingCompleteStub        func(string, string) (models.Response, error)
 	stagingCompleteMutex       sync.RWMutex
 	stagingCompleteArgsForCall []struct {
@@ -695,17 +1177,18 @@ type FakeAPIClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeAPIClient) AllApps() (models.AppList, error) {
+func (fake *FakeAPIClient) AllApps(arg1 string) (models.AppList, error) {
 	fake.allAppsMutex.Lock()
 	ret, specificReturn := fake.allAppsReturnsOnCall[len(fake.allAppsArgsForCall)]
 	fake.allAppsArgsForCall = append(fake.allAppsArgsForCall, struct {
-	}{})
+		arg1 string
+	}{arg1})
 	stub := fake.AllAppsStub
 	fakeReturns := fake.allAppsReturns
-	fake.recordInvocation("AllApps", []interface{}{})
+	fake.recordInvocation("AllApps", []interface{}{arg1})
 	fake.allAppsMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -719,12 +1202,19 @@ func (fake *FakeAPIClient) AllAppsCallCount() int {
 	return len(fake.allAppsArgsForCall)
 }
 
-func (fake *FakeAPIClient) AllAppsCalls(stub func() (models.AppList, error)) {
+func (fake *FakeAPIClient) AllAppsCalls(stub func(string) (models.AppList, error)) {
 	fake.allAppsMutex.Lock()
 	defer fake.allAppsMutex.Unlock()
 	fake.AllAppsStub = stub
 }
 
+func (fake *FakeAPIClient) AllAppsArgsForCall(i int) string {
+	fake.allAppsMutex.RLock()
+	defer fake.allAppsMutex.RUnlock()
+	argsForCall := fake.allAppsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeAPIClient) AllAppsReturns(result1 models.AppList, result2 error) {
 	fake.allAppsMutex.Lock()
 	defer fake.allAppsMutex.Unlock()
@@ -863,6 +1353,136 @@ func (fake *FakeAPIClient) AllServicesReturnsOnCall(i int, result1 *models.Servi
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) AppCacheClear(arg1 string, arg2 string) (models.Response, error) {
+	fake.appCacheClearMutex.Lock()
+	ret, specificReturn := fake.appCacheClearReturnsOnCall[len(fake.appCacheClearArgsForCall)]
+	fake.appCacheClearArgsForCall = append(fake.appCacheClearArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppCacheClearStub
+	fakeReturns := fake.appCacheClearReturns
+	fake.recordInvocation("AppCacheClear", []interface{}{arg1, arg2})
+	fake.appCacheClearMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppCacheClearCallCount() int {
+	fake.appCacheClearMutex.RLock()
+	defer fake.appCacheClearMutex.RUnlock()
+	return len(fake.appCacheClearArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppCacheClearCalls(stub func(string, string) (models.Response, error)) {
+	fake.appCacheClearMutex.Lock()
+	defer fake.appCacheClearMutex.Unlock()
+	fake.AppCacheClearStub = stub
+}
+
+func (fake *FakeAPIClient) AppCacheClearArgsForCall(i int) (string, string) {
+	fake.appCacheClearMutex.RLock()
+	defer fake.appCacheClearMutex.RUnlock()
+	argsForCall := fake.appCacheClearArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) AppCacheClearReturns(result1 models.Response, result2 error) {
+	fake.appCacheClearMutex.Lock()
+	defer fake.appCacheClearMutex.Unlock()
+	fake.AppCacheClearStub = nil
+	fake.appCacheClearReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppCacheClearReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.appCacheClearMutex.Lock()
+	defer fake.appCacheClearMutex.Unlock()
+	fake.AppCacheClearStub = nil
+	if fake.appCacheClearReturnsOnCall == nil {
+		fake.appCacheClearReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.appCacheClearReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppCacheShow(arg1 string, arg2 string) (models.AppCache, error) {
+	fake.appCacheShowMutex.Lock()
+	ret, specificReturn := fake.appCacheShowReturnsOnCall[len(fake.appCacheShowArgsForCall)]
+	fake.appCacheShowArgsForCall = append(fake.appCacheShowArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppCacheShowStub
+	fakeReturns := fake.appCacheShowReturns
+	fake.recordInvocation("AppCacheShow", []interface{}{arg1, arg2})
+	fake.appCacheShowMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppCacheShowCallCount() int {
+	fake.appCacheShowMutex.RLock()
+	defer fake.appCacheShowMutex.RUnlock()
+	return len(fake.appCacheShowArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppCacheShowCalls(stub func(string, string) (models.AppCache, error)) {
+	fake.appCacheShowMutex.Lock()
+	defer fake.appCacheShowMutex.Unlock()
+	fake.AppCacheShowStub = stub
+}
+
+func (fake *FakeAPIClient) AppCacheShowArgsForCall(i int) (string, string) {
+	fake.appCacheShowMutex.RLock()
+	defer fake.appCacheShowMutex.RUnlock()
+	argsForCall := fake.appCacheShowArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) AppCacheShowReturns(result1 models.AppCache, result2 error) {
+	fake.appCacheShowMutex.Lock()
+	defer fake.appCacheShowMutex.Unlock()
+	fake.AppCacheShowStub = nil
+	fake.appCacheShowReturns = struct {
+		result1 models.AppCache
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppCacheShowReturnsOnCall(i int, result1 models.AppCache, result2 error) {
+	fake.appCacheShowMutex.Lock()
+	defer fake.appCacheShowMutex.Unlock()
+	fake.AppCacheShowStub = nil
+	if fake.appCacheShowReturnsOnCall == nil {
+		fake.appCacheShowReturnsOnCall = make(map[int]struct {
+			result1 models.AppCache
+			result2 error
+		})
+	}
+	fake.appCacheShowReturnsOnCall[i] = struct {
+		result1 models.AppCache
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) AppCreate(arg1 models.ApplicationCreateRequest, arg2 string) (models.Response, error) {
 	fake.appCreateMutex.Lock()
 	ret, specificReturn := fake.appCreateReturnsOnCall[len(fake.appCreateArgsForCall)]
@@ -1185,19 +1805,25 @@ func (fake *FakeAPIClient) AppGetPartReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeAPIClient) AppImportGit(arg1 models.AppRef, arg2 models.GitRef) (*models.ImportGitResponse, error) {
+func (fake *FakeAPIClient) AppImportGit(arg1 models.AppRef, arg2 models.GitRef, arg3 []string) (*models.ImportGitResponse, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
 	fake.appImportGitMutex.Lock()
 	ret, specificReturn := fake.appImportGitReturnsOnCall[len(fake.appImportGitArgsForCall)]
 	fake.appImportGitArgsForCall = append(fake.appImportGitArgsForCall, struct {
 		arg1 models.AppRef
 		arg2 models.GitRef
-	}{arg1, arg2})
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
 	stub := fake.AppImportGitStub
 	fakeReturns := fake.appImportGitReturns
-	fake.recordInvocation("AppImportGit", []interface{}{arg1, arg2})
+	fake.recordInvocation("AppImportGit", []interface{}{arg1, arg2, arg3Copy})
 	fake.appImportGitMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1211,17 +1837,17 @@ func (fake *FakeAPIClient) AppImportGitCallCount() int {
 	return len(fake.appImportGitArgsForCall)
 }
 
-func (fake *FakeAPIClient) AppImportGitCalls(stub func(models.AppRef, models.GitRef) (*models.ImportGitResponse, error)) {
+func (fake *FakeAPIClient) AppImportGitCalls(stub func(models.AppRef, models.GitRef, []string) (*models.ImportGitResponse, error)) {
 	fake.appImportGitMutex.Lock()
 	defer fake.appImportGitMutex.Unlock()
 	fake.AppImportGitStub = stub
 }
 
-func (fake *FakeAPIClient) AppImportGitArgsForCall(i int) (models.AppRef, models.GitRef) {
+func (fake *FakeAPIClient) AppImportGitArgsForCall(i int) (models.AppRef, models.GitRef, []string) {
 	fake.appImportGitMutex.RLock()
 	defer fake.appImportGitMutex.RUnlock()
 	argsForCall := fake.appImportGitArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeAPIClient) AppImportGitReturns(result1 *models.ImportGitResponse, result2 error) {
@@ -1379,6 +2005,72 @@ func (fake *FakeAPIClient) AppPortForwardReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeAPIClient) AppPromote(arg1 models.AppPromoteRequest, arg2 string, arg3 string) (models.PromoteResponse, error) {
+	fake.appPromoteMutex.Lock()
+	ret, specificReturn := fake.appPromoteReturnsOnCall[len(fake.appPromoteArgsForCall)]
+	fake.appPromoteArgsForCall = append(fake.appPromoteArgsForCall, struct {
+		arg1 models.AppPromoteRequest
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.AppPromoteStub
+	fakeReturns := fake.appPromoteReturns
+	fake.recordInvocation("AppPromote", []interface{}{arg1, arg2, arg3})
+	fake.appPromoteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppPromoteCallCount() int {
+	fake.appPromoteMutex.RLock()
+	defer fake.appPromoteMutex.RUnlock()
+	return len(fake.appPromoteArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppPromoteCalls(stub func(models.AppPromoteRequest, string, string) (models.PromoteResponse, error)) {
+	fake.appPromoteMutex.Lock()
+	defer fake.appPromoteMutex.Unlock()
+	fake.AppPromoteStub = stub
+}
+
+func (fake *FakeAPIClient) AppPromoteArgsForCall(i int) (models.AppPromoteRequest, string, string) {
+	fake.appPromoteMutex.RLock()
+	defer fake.appPromoteMutex.RUnlock()
+	argsForCall := fake.appPromoteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) AppPromoteReturns(result1 models.PromoteResponse, result2 error) {
+	fake.appPromoteMutex.Lock()
+	defer fake.appPromoteMutex.Unlock()
+	fake.AppPromoteStub = nil
+	fake.appPromoteReturns = struct {
+		result1 models.PromoteResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppPromoteReturnsOnCall(i int, result1 models.PromoteResponse, result2 error) {
+	fake.appPromoteMutex.Lock()
+	defer fake.appPromoteMutex.Unlock()
+	fake.AppPromoteStub = nil
+	if fake.appPromoteReturnsOnCall == nil {
+		fake.appPromoteReturnsOnCall = make(map[int]struct {
+			result1 models.PromoteResponse
+			result2 error
+		})
+	}
+	fake.appPromoteReturnsOnCall[i] = struct {
+		result1 models.PromoteResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) AppRestart(arg1 string, arg2 string) error {
 	fake.appRestartMutex.Lock()
 	ret, specificReturn := fake.appRestartReturnsOnCall[len(fake.appRestartArgsForCall)]
@@ -1505,6 +2197,72 @@ func (fake *FakeAPIClient) AppRunningReturnsOnCall(i int, result1 models.Respons
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) AppSBOM(arg1 string, arg2 string, arg3 string) (models.AppSBOM, error) {
+	fake.appSBOMMutex.Lock()
+	ret, specificReturn := fake.appSBOMReturnsOnCall[len(fake.appSBOMArgsForCall)]
+	fake.appSBOMArgsForCall = append(fake.appSBOMArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.AppSBOMStub
+	fakeReturns := fake.appSBOMReturns
+	fake.recordInvocation("AppSBOM", []interface{}{arg1, arg2, arg3})
+	fake.appSBOMMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppSBOMCallCount() int {
+	fake.appSBOMMutex.RLock()
+	defer fake.appSBOMMutex.RUnlock()
+	return len(fake.appSBOMArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppSBOMCalls(stub func(string, string, string) (models.AppSBOM, error)) {
+	fake.appSBOMMutex.Lock()
+	defer fake.appSBOMMutex.Unlock()
+	fake.AppSBOMStub = stub
+}
+
+func (fake *FakeAPIClient) AppSBOMArgsForCall(i int) (string, string, string) {
+	fake.appSBOMMutex.RLock()
+	defer fake.appSBOMMutex.RUnlock()
+	argsForCall := fake.appSBOMArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) AppSBOMReturns(result1 models.AppSBOM, result2 error) {
+	fake.appSBOMMutex.Lock()
+	defer fake.appSBOMMutex.Unlock()
+	fake.AppSBOMStub = nil
+	fake.appSBOMReturns = struct {
+		result1 models.AppSBOM
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppSBOMReturnsOnCall(i int, result1 models.AppSBOM, result2 error) {
+	fake.appSBOMMutex.Lock()
+	defer fake.appSBOMMutex.Unlock()
+	fake.AppSBOMStub = nil
+	if fake.appSBOMReturnsOnCall == nil {
+		fake.appSBOMReturnsOnCall = make(map[int]struct {
+			result1 models.AppSBOM
+			result2 error
+		})
+	}
+	fake.appSBOMReturnsOnCall[i] = struct {
+		result1 models.AppSBOM
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) AppShow(arg1 string, arg2 string) (models.App, error) {
 	fake.appShowMutex.Lock()
 	ret, specificReturn := fake.appShowReturnsOnCall[len(fake.appShowArgsForCall)]
@@ -1766,18 +2524,19 @@ func (fake *FakeAPIClient) AppUploadReturnsOnCall(i int, result1 models.UploadRe
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) Apps(arg1 string) (models.AppList, error) {
+func (fake *FakeAPIClient) Apps(arg1 string, arg2 string) (models.AppList, error) {
 	fake.appsMutex.Lock()
 	ret, specificReturn := fake.appsReturnsOnCall[len(fake.appsArgsForCall)]
 	fake.appsArgsForCall = append(fake.appsArgsForCall, struct {
 		arg1 string
-	}{arg1})
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.AppsStub
 	fakeReturns := fake.appsReturns
-	fake.recordInvocation("Apps", []interface{}{arg1})
+	fake.recordInvocation("Apps", []interface{}{arg1, arg2})
 	fake.appsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1791,17 +2550,17 @@ func (fake *FakeAPIClient) AppsCallCount() int {
 	return len(fake.appsArgsForCall)
 }
 
-func (fake *FakeAPIClient) AppsCalls(stub func(string) (models.AppList, error)) {
+func (fake *FakeAPIClient) AppsCalls(stub func(string, string) (models.AppList, error)) {
 	fake.appsMutex.Lock()
 	defer fake.appsMutex.Unlock()
 	fake.AppsStub = stub
 }
 
-func (fake *FakeAPIClient) AppsArgsForCall(i int) string {
+func (fake *FakeAPIClient) AppsArgsForCall(i int) (string, string) {
 	fake.appsMutex.RLock()
 	defer fake.appsMutex.RUnlock()
 	argsForCall := fake.appsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeAPIClient) AppsReturns(result1 models.AppList, result2 error) {
@@ -1830,6 +2589,71 @@ func (fake *FakeAPIClient) AppsReturnsOnCall(i int, result1 models.AppList, resu
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) AppsRestart(arg1 string, arg2 string) (models.AppsRestartResponse, error) {
+	fake.appsRestartMutex.Lock()
+	ret, specificReturn := fake.appsRestartReturnsOnCall[len(fake.appsRestartArgsForCall)]
+	fake.appsRestartArgsForCall = append(fake.appsRestartArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AppsRestartStub
+	fakeReturns := fake.appsRestartReturns
+	fake.recordInvocation("AppsRestart", []interface{}{arg1, arg2})
+	fake.appsRestartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) AppsRestartCallCount() int {
+	fake.appsRestartMutex.RLock()
+	defer fake.appsRestartMutex.RUnlock()
+	return len(fake.appsRestartArgsForCall)
+}
+
+func (fake *FakeAPIClient) AppsRestartCalls(stub func(string, string) (models.AppsRestartResponse, error)) {
+	fake.appsRestartMutex.Lock()
+	defer fake.appsRestartMutex.Unlock()
+	fake.AppsRestartStub = stub
+}
+
+func (fake *FakeAPIClient) AppsRestartArgsForCall(i int) (string, string) {
+	fake.appsRestartMutex.RLock()
+	defer fake.appsRestartMutex.RUnlock()
+	argsForCall := fake.appsRestartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) AppsRestartReturns(result1 models.AppsRestartResponse, result2 error) {
+	fake.appsRestartMutex.Lock()
+	defer fake.appsRestartMutex.Unlock()
+	fake.AppsRestartStub = nil
+	fake.appsRestartReturns = struct {
+		result1 models.AppsRestartResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) AppsRestartReturnsOnCall(i int, result1 models.AppsRestartResponse, result2 error) {
+	fake.appsRestartMutex.Lock()
+	defer fake.appsRestartMutex.Unlock()
+	fake.AppsRestartStub = nil
+	if fake.appsRestartReturnsOnCall == nil {
+		fake.appsRestartReturnsOnCall = make(map[int]struct {
+			result1 models.AppsRestartResponse
+			result2 error
+		})
+	}
+	fake.appsRestartReturnsOnCall[i] = struct {
+		result1 models.AppsRestartResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) AuthToken() (string, error) {
 	fake.authTokenMutex.Lock()
 	ret, specificReturn := fake.authTokenReturnsOnCall[len(fake.authTokenArgsForCall)]
@@ -1886,15 +2710,15 @@ func (fake *FakeAPIClient) AuthTokenReturnsOnCall(i int, result1 string, result2
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ChartList() ([]models.AppChart, error) {
-	fake.chartListMutex.Lock()
-	ret, specificReturn := fake.chartListReturnsOnCall[len(fake.chartListArgsForCall)]
-	fake.chartListArgsForCall = append(fake.chartListArgsForCall, struct {
+func (fake *FakeAPIClient) Blobs() (models.BlobList, error) {
+	fake.blobsMutex.Lock()
+	ret, specificReturn := fake.blobsReturnsOnCall[len(fake.blobsArgsForCall)]
+	fake.blobsArgsForCall = append(fake.blobsArgsForCall, struct {
 	}{})
-	stub := fake.ChartListStub
-	fakeReturns := fake.chartListReturns
-	fake.recordInvocation("ChartList", []interface{}{})
-	fake.chartListMutex.Unlock()
+	stub := fake.BlobsStub
+	fakeReturns := fake.blobsReturns
+	fake.recordInvocation("Blobs", []interface{}{})
+	fake.blobsMutex.Unlock()
 	if stub != nil {
 		return stub()
 	}
@@ -1904,54 +2728,54 @@ func (fake *FakeAPIClient) ChartList() ([]models.AppChart, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) ChartListCallCount() int {
-	fake.chartListMutex.RLock()
-	defer fake.chartListMutex.RUnlock()
-	return len(fake.chartListArgsForCall)
+func (fake *FakeAPIClient) BlobsCallCount() int {
+	fake.blobsMutex.RLock()
+	defer fake.blobsMutex.RUnlock()
+	return len(fake.blobsArgsForCall)
 }
 
-func (fake *FakeAPIClient) ChartListCalls(stub func() ([]models.AppChart, error)) {
-	fake.chartListMutex.Lock()
-	defer fake.chartListMutex.Unlock()
-	fake.ChartListStub = stub
+func (fake *FakeAPIClient) BlobsCalls(stub func() (models.BlobList, error)) {
+	fake.blobsMutex.Lock()
+	defer fake.blobsMutex.Unlock()
+	fake.BlobsStub = stub
 }
 
-func (fake *FakeAPIClient) ChartListReturns(result1 []models.AppChart, result2 error) {
-	fake.chartListMutex.Lock()
-	defer fake.chartListMutex.Unlock()
-	fake.ChartListStub = nil
-	fake.chartListReturns = struct {
-		result1 []models.AppChart
+func (fake *FakeAPIClient) BlobsReturns(result1 models.BlobList, result2 error) {
+	fake.blobsMutex.Lock()
+	defer fake.blobsMutex.Unlock()
+	fake.BlobsStub = nil
+	fake.blobsReturns = struct {
+		result1 models.BlobList
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ChartListReturnsOnCall(i int, result1 []models.AppChart, result2 error) {
-	fake.chartListMutex.Lock()
-	defer fake.chartListMutex.Unlock()
-	fake.ChartListStub = nil
-	if fake.chartListReturnsOnCall == nil {
-		fake.chartListReturnsOnCall = make(map[int]struct {
-			result1 []models.AppChart
+func (fake *FakeAPIClient) BlobsReturnsOnCall(i int, result1 models.BlobList, result2 error) {
+	fake.blobsMutex.Lock()
+	defer fake.blobsMutex.Unlock()
+	fake.BlobsStub = nil
+	if fake.blobsReturnsOnCall == nil {
+		fake.blobsReturnsOnCall = make(map[int]struct {
+			result1 models.BlobList
 			result2 error
 		})
 	}
-	fake.chartListReturnsOnCall[i] = struct {
-		result1 []models.AppChart
+	fake.blobsReturnsOnCall[i] = struct {
+		result1 models.BlobList
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ChartMatch(arg1 string) (models.ChartMatchResponse, error) {
-	fake.chartMatchMutex.Lock()
-	ret, specificReturn := fake.chartMatchReturnsOnCall[len(fake.chartMatchArgsForCall)]
-	fake.chartMatchArgsForCall = append(fake.chartMatchArgsForCall, struct {
-		arg1 string
+func (fake *FakeAPIClient) BlobsGC(arg1 models.BlobsGCRequest) (models.BlobsGCResponse, error) {
+	fake.blobsGCMutex.Lock()
+	ret, specificReturn := fake.blobsGCReturnsOnCall[len(fake.blobsGCArgsForCall)]
+	fake.blobsGCArgsForCall = append(fake.blobsGCArgsForCall, struct {
+		arg1 models.BlobsGCRequest
 	}{arg1})
-	stub := fake.ChartMatchStub
-	fakeReturns := fake.chartMatchReturns
-	fake.recordInvocation("ChartMatch", []interface{}{arg1})
-	fake.chartMatchMutex.Unlock()
+	stub := fake.BlobsGCStub
+	fakeReturns := fake.blobsGCReturns
+	fake.recordInvocation("BlobsGC", []interface{}{arg1})
+	fake.blobsGCMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -1961,61 +2785,61 @@ func (fake *FakeAPIClient) ChartMatch(arg1 string) (models.ChartMatchResponse, e
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) ChartMatchCallCount() int {
-	fake.chartMatchMutex.RLock()
-	defer fake.chartMatchMutex.RUnlock()
-	return len(fake.chartMatchArgsForCall)
+func (fake *FakeAPIClient) BlobsGCCallCount() int {
+	fake.blobsGCMutex.RLock()
+	defer fake.blobsGCMutex.RUnlock()
+	return len(fake.blobsGCArgsForCall)
 }
 
-func (fake *FakeAPIClient) ChartMatchCalls(stub func(string) (models.ChartMatchResponse, error)) {
-	fake.chartMatchMutex.Lock()
-	defer fake.chartMatchMutex.Unlock()
-	fake.ChartMatchStub = stub
+func (fake *FakeAPIClient) BlobsGCCalls(stub func(models.BlobsGCRequest) (models.BlobsGCResponse, error)) {
+	fake.blobsGCMutex.Lock()
+	defer fake.blobsGCMutex.Unlock()
+	fake.BlobsGCStub = stub
 }
 
-func (fake *FakeAPIClient) ChartMatchArgsForCall(i int) string {
-	fake.chartMatchMutex.RLock()
-	defer fake.chartMatchMutex.RUnlock()
-	argsForCall := fake.chartMatchArgsForCall[i]
+func (fake *FakeAPIClient) BlobsGCArgsForCall(i int) models.BlobsGCRequest {
+	fake.blobsGCMutex.RLock()
+	defer fake.blobsGCMutex.RUnlock()
+	argsForCall := fake.blobsGCArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakeAPIClient) ChartMatchReturns(result1 models.ChartMatchResponse, result2 error) {
-	fake.chartMatchMutex.Lock()
-	defer fake.chartMatchMutex.Unlock()
-	fake.ChartMatchStub = nil
-	fake.chartMatchReturns = struct {
-		result1 models.ChartMatchResponse
+func (fake *FakeAPIClient) BlobsGCReturns(result1 models.BlobsGCResponse, result2 error) {
+	fake.blobsGCMutex.Lock()
+	defer fake.blobsGCMutex.Unlock()
+	fake.BlobsGCStub = nil
+	fake.blobsGCReturns = struct {
+		result1 models.BlobsGCResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ChartMatchReturnsOnCall(i int, result1 models.ChartMatchResponse, result2 error) {
-	fake.chartMatchMutex.Lock()
-	defer fake.chartMatchMutex.Unlock()
-	fake.ChartMatchStub = nil
-	if fake.chartMatchReturnsOnCall == nil {
-		fake.chartMatchReturnsOnCall = make(map[int]struct {
-			result1 models.ChartMatchResponse
+func (fake *FakeAPIClient) BlobsGCReturnsOnCall(i int, result1 models.BlobsGCResponse, result2 error) {
+	fake.blobsGCMutex.Lock()
+	defer fake.blobsGCMutex.Unlock()
+	fake.BlobsGCStub = nil
+	if fake.blobsGCReturnsOnCall == nil {
+		fake.blobsGCReturnsOnCall = make(map[int]struct {
+			result1 models.BlobsGCResponse
 			result2 error
 		})
 	}
-	fake.chartMatchReturnsOnCall[i] = struct {
-		result1 models.ChartMatchResponse
+	fake.blobsGCReturnsOnCall[i] = struct {
+		result1 models.BlobsGCResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ChartShow(arg1 string) (models.AppChart, error) {
-	fake.chartShowMutex.Lock()
-	ret, specificReturn := fake.chartShowReturnsOnCall[len(fake.chartShowArgsForCall)]
-	fake.chartShowArgsForCall = append(fake.chartShowArgsForCall, struct {
+func (fake *FakeAPIClient) BuilderDelete(arg1 string) (models.Response, error) {
+	fake.builderDeleteMutex.Lock()
+	ret, specificReturn := fake.builderDeleteReturnsOnCall[len(fake.builderDeleteArgsForCall)]
+	fake.builderDeleteArgsForCall = append(fake.builderDeleteArgsForCall, struct {
 		arg1 string
 	}{arg1})
-	stub := fake.ChartShowStub
-	fakeReturns := fake.chartShowReturns
-	fake.recordInvocation("ChartShow", []interface{}{arg1})
-	fake.chartShowMutex.Unlock()
+	stub := fake.BuilderDeleteStub
+	fakeReturns := fake.builderDeleteReturns
+	fake.recordInvocation("BuilderDelete", []interface{}{arg1})
+	fake.builderDeleteMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -2025,13 +2849,317 @@ func (fake *FakeAPIClient) ChartShow(arg1 string) (models.AppChart, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) ChartShowCallCount() int {
-	fake.chartShowMutex.RLock()
-	defer fake.chartShowMutex.RUnlock()
-	return len(fake.chartShowArgsForCall)
+func (fake *FakeAPIClient) BuilderDeleteCallCount() int {
+	fake.builderDeleteMutex.RLock()
+	defer fake.builderDeleteMutex.RUnlock()
+	return len(fake.builderDeleteArgsForCall)
 }
 
-func (fake *FakeAPIClient) ChartShowCalls(stub func(string) (models.AppChart, error)) {
+func (fake *FakeAPIClient) BuilderDeleteCalls(stub func(string) (models.Response, error)) {
+	fake.builderDeleteMutex.Lock()
+	defer fake.builderDeleteMutex.Unlock()
+	fake.BuilderDeleteStub = stub
+}
+
+func (fake *FakeAPIClient) BuilderDeleteArgsForCall(i int) string {
+	fake.builderDeleteMutex.RLock()
+	defer fake.builderDeleteMutex.RUnlock()
+	argsForCall := fake.builderDeleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) BuilderDeleteReturns(result1 models.Response, result2 error) {
+	fake.builderDeleteMutex.Lock()
+	defer fake.builderDeleteMutex.Unlock()
+	fake.BuilderDeleteStub = nil
+	fake.builderDeleteReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) BuilderDeleteReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.builderDeleteMutex.Lock()
+	defer fake.builderDeleteMutex.Unlock()
+	fake.BuilderDeleteStub = nil
+	if fake.builderDeleteReturnsOnCall == nil {
+		fake.builderDeleteReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.builderDeleteReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) BuilderSet(arg1 models.Builder) (models.Response, error) {
+	fake.builderSetMutex.Lock()
+	ret, specificReturn := fake.builderSetReturnsOnCall[len(fake.builderSetArgsForCall)]
+	fake.builderSetArgsForCall = append(fake.builderSetArgsForCall, struct {
+		arg1 models.Builder
+	}{arg1})
+	stub := fake.BuilderSetStub
+	fakeReturns := fake.builderSetReturns
+	fake.recordInvocation("BuilderSet", []interface{}{arg1})
+	fake.builderSetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) BuilderSetCallCount() int {
+	fake.builderSetMutex.RLock()
+	defer fake.builderSetMutex.RUnlock()
+	return len(fake.builderSetArgsForCall)
+}
+
+func (fake *FakeAPIClient) BuilderSetCalls(stub func(models.Builder) (models.Response, error)) {
+	fake.builderSetMutex.Lock()
+	defer fake.builderSetMutex.Unlock()
+	fake.BuilderSetStub = stub
+}
+
+func (fake *FakeAPIClient) BuilderSetArgsForCall(i int) models.Builder {
+	fake.builderSetMutex.RLock()
+	defer fake.builderSetMutex.RUnlock()
+	argsForCall := fake.builderSetArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) BuilderSetReturns(result1 models.Response, result2 error) {
+	fake.builderSetMutex.Lock()
+	defer fake.builderSetMutex.Unlock()
+	fake.BuilderSetStub = nil
+	fake.builderSetReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) BuilderSetReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.builderSetMutex.Lock()
+	defer fake.builderSetMutex.Unlock()
+	fake.BuilderSetStub = nil
+	if fake.builderSetReturnsOnCall == nil {
+		fake.builderSetReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.builderSetReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) Builders() (models.BuilderList, error) {
+	fake.buildersMutex.Lock()
+	ret, specificReturn := fake.buildersReturnsOnCall[len(fake.buildersArgsForCall)]
+	fake.buildersArgsForCall = append(fake.buildersArgsForCall, struct {
+	}{})
+	stub := fake.BuildersStub
+	fakeReturns := fake.buildersReturns
+	fake.recordInvocation("Builders", []interface{}{})
+	fake.buildersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) BuildersCallCount() int {
+	fake.buildersMutex.RLock()
+	defer fake.buildersMutex.RUnlock()
+	return len(fake.buildersArgsForCall)
+}
+
+func (fake *FakeAPIClient) BuildersCalls(stub func() (models.BuilderList, error)) {
+	fake.buildersMutex.Lock()
+	defer fake.buildersMutex.Unlock()
+	fake.BuildersStub = stub
+}
+
+func (fake *FakeAPIClient) BuildersReturns(result1 models.BuilderList, result2 error) {
+	fake.buildersMutex.Lock()
+	defer fake.buildersMutex.Unlock()
+	fake.BuildersStub = nil
+	fake.buildersReturns = struct {
+		result1 models.BuilderList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) BuildersReturnsOnCall(i int, result1 models.BuilderList, result2 error) {
+	fake.buildersMutex.Lock()
+	defer fake.buildersMutex.Unlock()
+	fake.BuildersStub = nil
+	if fake.buildersReturnsOnCall == nil {
+		fake.buildersReturnsOnCall = make(map[int]struct {
+			result1 models.BuilderList
+			result2 error
+		})
+	}
+	fake.buildersReturnsOnCall[i] = struct {
+		result1 models.BuilderList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ChartList() ([]models.AppChart, error) {
+	fake.chartListMutex.Lock()
+	ret, specificReturn := fake.chartListReturnsOnCall[len(fake.chartListArgsForCall)]
+	fake.chartListArgsForCall = append(fake.chartListArgsForCall, struct {
+	}{})
+	stub := fake.ChartListStub
+	fakeReturns := fake.chartListReturns
+	fake.recordInvocation("ChartList", []interface{}{})
+	fake.chartListMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ChartListCallCount() int {
+	fake.chartListMutex.RLock()
+	defer fake.chartListMutex.RUnlock()
+	return len(fake.chartListArgsForCall)
+}
+
+func (fake *FakeAPIClient) ChartListCalls(stub func() ([]models.AppChart, error)) {
+	fake.chartListMutex.Lock()
+	defer fake.chartListMutex.Unlock()
+	fake.ChartListStub = stub
+}
+
+func (fake *FakeAPIClient) ChartListReturns(result1 []models.AppChart, result2 error) {
+	fake.chartListMutex.Lock()
+	defer fake.chartListMutex.Unlock()
+	fake.ChartListStub = nil
+	fake.chartListReturns = struct {
+		result1 []models.AppChart
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ChartListReturnsOnCall(i int, result1 []models.AppChart, result2 error) {
+	fake.chartListMutex.Lock()
+	defer fake.chartListMutex.Unlock()
+	fake.ChartListStub = nil
+	if fake.chartListReturnsOnCall == nil {
+		fake.chartListReturnsOnCall = make(map[int]struct {
+			result1 []models.AppChart
+			result2 error
+		})
+	}
+	fake.chartListReturnsOnCall[i] = struct {
+		result1 []models.AppChart
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ChartMatch(arg1 string) (models.ChartMatchResponse, error) {
+	fake.chartMatchMutex.Lock()
+	ret, specificReturn := fake.chartMatchReturnsOnCall[len(fake.chartMatchArgsForCall)]
+	fake.chartMatchArgsForCall = append(fake.chartMatchArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ChartMatchStub
+	fakeReturns := fake.chartMatchReturns
+	fake.recordInvocation("ChartMatch", []interface{}{arg1})
+	fake.chartMatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ChartMatchCallCount() int {
+	fake.chartMatchMutex.RLock()
+	defer fake.chartMatchMutex.RUnlock()
+	return len(fake.chartMatchArgsForCall)
+}
+
+func (fake *FakeAPIClient) ChartMatchCalls(stub func(string) (models.ChartMatchResponse, error)) {
+	fake.chartMatchMutex.Lock()
+	defer fake.chartMatchMutex.Unlock()
+	fake.ChartMatchStub = stub
+}
+
+func (fake *FakeAPIClient) ChartMatchArgsForCall(i int) string {
+	fake.chartMatchMutex.RLock()
+	defer fake.chartMatchMutex.RUnlock()
+	argsForCall := fake.chartMatchArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) ChartMatchReturns(result1 models.ChartMatchResponse, result2 error) {
+	fake.chartMatchMutex.Lock()
+	defer fake.chartMatchMutex.Unlock()
+	fake.ChartMatchStub = nil
+	fake.chartMatchReturns = struct {
+		result1 models.ChartMatchResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ChartMatchReturnsOnCall(i int, result1 models.ChartMatchResponse, result2 error) {
+	fake.chartMatchMutex.Lock()
+	defer fake.chartMatchMutex.Unlock()
+	fake.ChartMatchStub = nil
+	if fake.chartMatchReturnsOnCall == nil {
+		fake.chartMatchReturnsOnCall = make(map[int]struct {
+			result1 models.ChartMatchResponse
+			result2 error
+		})
+	}
+	fake.chartMatchReturnsOnCall[i] = struct {
+		result1 models.ChartMatchResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ChartShow(arg1 string) (models.AppChart, error) {
+	fake.chartShowMutex.Lock()
+	ret, specificReturn := fake.chartShowReturnsOnCall[len(fake.chartShowArgsForCall)]
+	fake.chartShowArgsForCall = append(fake.chartShowArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ChartShowStub
+	fakeReturns := fake.chartShowReturns
+	fake.recordInvocation("ChartShow", []interface{}{arg1})
+	fake.chartShowMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ChartShowCallCount() int {
+	fake.chartShowMutex.RLock()
+	defer fake.chartShowMutex.RUnlock()
+	return len(fake.chartShowArgsForCall)
+}
+
+func (fake *FakeAPIClient) ChartShowCalls(stub func(string) (models.AppChart, error)) {
 	fake.chartShowMutex.Lock()
 	defer fake.chartShowMutex.Unlock()
 	fake.ChartShowStub = stub
@@ -2331,14 +3459,14 @@ func (fake *FakeAPIClient) ConfigurationCreateReturnsOnCall(i int, result1 model
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ConfigurationDelete(arg1 models.ConfigurationDeleteRequest, arg2 string, arg3 string, arg4 func(response *http.Response, bodyBytes []byte, err error) error) (models.ConfigurationDeleteResponse, error) {
+func (fake *FakeAPIClient) ConfigurationDelete(arg1 models.ConfigurationDeleteRequest, arg2 string, arg3 string, arg4 client.ErrorFunc) (models.ConfigurationDeleteResponse, error) {
 	fake.configurationDeleteMutex.Lock()
 	ret, specificReturn := fake.configurationDeleteReturnsOnCall[len(fake.configurationDeleteArgsForCall)]
 	fake.configurationDeleteArgsForCall = append(fake.configurationDeleteArgsForCall, struct {
 		arg1 models.ConfigurationDeleteRequest
 		arg2 string
 		arg3 string
-		arg4 func(response *http.Response, bodyBytes []byte, err error) error
+		arg4 client.ErrorFunc
 	}{arg1, arg2, arg3, arg4})
 	stub := fake.ConfigurationDeleteStub
 	fakeReturns := fake.configurationDeleteReturns
@@ -2359,13 +3487,13 @@ func (fake *FakeAPIClient) ConfigurationDeleteCallCount() int {
 	return len(fake.configurationDeleteArgsForCall)
 }
 
-func (fake *FakeAPIClient) ConfigurationDeleteCalls(stub func(models.ConfigurationDeleteRequest, string, string, func(response *http.Response, bodyBytes []byte, err error) error) (models.ConfigurationDeleteResponse, error)) {
+func (fake *FakeAPIClient) ConfigurationDeleteCalls(stub func(models.ConfigurationDeleteRequest, string, string, client.ErrorFunc) (models.ConfigurationDeleteResponse, error)) {
 	fake.configurationDeleteMutex.Lock()
 	defer fake.configurationDeleteMutex.Unlock()
 	fake.ConfigurationDeleteStub = stub
 }
 
-func (fake *FakeAPIClient) ConfigurationDeleteArgsForCall(i int) (models.ConfigurationDeleteRequest, string, string, func(response *http.Response, bodyBytes []byte, err error) error) {
+func (fake *FakeAPIClient) ConfigurationDeleteArgsForCall(i int) (models.ConfigurationDeleteRequest, string, string, client.ErrorFunc) {
 	fake.configurationDeleteMutex.RLock()
 	defer fake.configurationDeleteMutex.RUnlock()
 	argsForCall := fake.configurationDeleteArgsForCall[i]
@@ -2398,17 +3526,17 @@ func (fake *FakeAPIClient) ConfigurationDeleteReturnsOnCall(i int, result1 model
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ConfigurationShow(arg1 string, arg2 string) (models.ConfigurationResponse, error) {
-	fake.configurationShowMutex.Lock()
-	ret, specificReturn := fake.configurationShowReturnsOnCall[len(fake.configurationShowArgsForCall)]
-	fake.configurationShowArgsForCall = append(fake.configurationShowArgsForCall, struct {
+func (fake *FakeAPIClient) ConfigurationHistory(arg1 string, arg2 string) (models.ConfigurationHistoryResponse, error) {
+	fake.configurationHistoryMutex.Lock()
+	ret, specificReturn := fake.configurationHistoryReturnsOnCall[len(fake.configurationHistoryArgsForCall)]
+	fake.configurationHistoryArgsForCall = append(fake.configurationHistoryArgsForCall, struct {
 		arg1 string
 		arg2 string
 	}{arg1, arg2})
-	stub := fake.ConfigurationShowStub
-	fakeReturns := fake.configurationShowReturns
-	fake.recordInvocation("ConfigurationShow", []interface{}{arg1, arg2})
-	fake.configurationShowMutex.Unlock()
+	stub := fake.ConfigurationHistoryStub
+	fakeReturns := fake.configurationHistoryReturns
+	fake.recordInvocation("ConfigurationHistory", []interface{}{arg1, arg2})
+	fake.configurationHistoryMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -2418,47 +3546,179 @@ func (fake *FakeAPIClient) ConfigurationShow(arg1 string, arg2 string) (models.C
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) ConfigurationShowCallCount() int {
-	fake.configurationShowMutex.RLock()
-	defer fake.configurationShowMutex.RUnlock()
-	return len(fake.configurationShowArgsForCall)
+func (fake *FakeAPIClient) ConfigurationHistoryCallCount() int {
+	fake.configurationHistoryMutex.RLock()
+	defer fake.configurationHistoryMutex.RUnlock()
+	return len(fake.configurationHistoryArgsForCall)
 }
 
-func (fake *FakeAPIClient) ConfigurationShowCalls(stub func(string, string) (models.ConfigurationResponse, error)) {
-	fake.configurationShowMutex.Lock()
-	defer fake.configurationShowMutex.Unlock()
-	fake.ConfigurationShowStub = stub
+func (fake *FakeAPIClient) ConfigurationHistoryCalls(stub func(string, string) (models.ConfigurationHistoryResponse, error)) {
+	fake.configurationHistoryMutex.Lock()
+	defer fake.configurationHistoryMutex.Unlock()
+	fake.ConfigurationHistoryStub = stub
 }
 
-func (fake *FakeAPIClient) ConfigurationShowArgsForCall(i int) (string, string) {
-	fake.configurationShowMutex.RLock()
-	defer fake.configurationShowMutex.RUnlock()
-	argsForCall := fake.configurationShowArgsForCall[i]
+func (fake *FakeAPIClient) ConfigurationHistoryArgsForCall(i int) (string, string) {
+	fake.configurationHistoryMutex.RLock()
+	defer fake.configurationHistoryMutex.RUnlock()
+	argsForCall := fake.configurationHistoryArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) ConfigurationShowReturns(result1 models.ConfigurationResponse, result2 error) {
-	fake.configurationShowMutex.Lock()
-	defer fake.configurationShowMutex.Unlock()
-	fake.ConfigurationShowStub = nil
-	fake.configurationShowReturns = struct {
-		result1 models.ConfigurationResponse
+func (fake *FakeAPIClient) ConfigurationHistoryReturns(result1 models.ConfigurationHistoryResponse, result2 error) {
+	fake.configurationHistoryMutex.Lock()
+	defer fake.configurationHistoryMutex.Unlock()
+	fake.ConfigurationHistoryStub = nil
+	fake.configurationHistoryReturns = struct {
+		result1 models.ConfigurationHistoryResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) ConfigurationShowReturnsOnCall(i int, result1 models.ConfigurationResponse, result2 error) {
-	fake.configurationShowMutex.Lock()
-	defer fake.configurationShowMutex.Unlock()
-	fake.ConfigurationShowStub = nil
-	if fake.configurationShowReturnsOnCall == nil {
-		fake.configurationShowReturnsOnCall = make(map[int]struct {
-			result1 models.ConfigurationResponse
+func (fake *FakeAPIClient) ConfigurationHistoryReturnsOnCall(i int, result1 models.ConfigurationHistoryResponse, result2 error) {
+	fake.configurationHistoryMutex.Lock()
+	defer fake.configurationHistoryMutex.Unlock()
+	fake.ConfigurationHistoryStub = nil
+	if fake.configurationHistoryReturnsOnCall == nil {
+		fake.configurationHistoryReturnsOnCall = make(map[int]struct {
+			result1 models.ConfigurationHistoryResponse
 			result2 error
 		})
 	}
-	fake.configurationShowReturnsOnCall[i] = struct {
-		result1 models.ConfigurationResponse
+	fake.configurationHistoryReturnsOnCall[i] = struct {
+		result1 models.ConfigurationHistoryResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ConfigurationRevert(arg1 models.ConfigurationRevertRequest, arg2 string, arg3 string, arg4 int) (models.Response, error) {
+	fake.configurationRevertMutex.Lock()
+	ret, specificReturn := fake.configurationRevertReturnsOnCall[len(fake.configurationRevertArgsForCall)]
+	fake.configurationRevertArgsForCall = append(fake.configurationRevertArgsForCall, struct {
+		arg1 models.ConfigurationRevertRequest
+		arg2 string
+		arg3 string
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ConfigurationRevertStub
+	fakeReturns := fake.configurationRevertReturns
+	fake.recordInvocation("ConfigurationRevert", []interface{}{arg1, arg2, arg3, arg4})
+	fake.configurationRevertMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ConfigurationRevertCallCount() int {
+	fake.configurationRevertMutex.RLock()
+	defer fake.configurationRevertMutex.RUnlock()
+	return len(fake.configurationRevertArgsForCall)
+}
+
+func (fake *FakeAPIClient) ConfigurationRevertCalls(stub func(models.ConfigurationRevertRequest, string, string, int) (models.Response, error)) {
+	fake.configurationRevertMutex.Lock()
+	defer fake.configurationRevertMutex.Unlock()
+	fake.ConfigurationRevertStub = stub
+}
+
+func (fake *FakeAPIClient) ConfigurationRevertArgsForCall(i int) (models.ConfigurationRevertRequest, string, string, int) {
+	fake.configurationRevertMutex.RLock()
+	defer fake.configurationRevertMutex.RUnlock()
+	argsForCall := fake.configurationRevertArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeAPIClient) ConfigurationRevertReturns(result1 models.Response, result2 error) {
+	fake.configurationRevertMutex.Lock()
+	defer fake.configurationRevertMutex.Unlock()
+	fake.ConfigurationRevertStub = nil
+	fake.configurationRevertReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ConfigurationRevertReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.configurationRevertMutex.Lock()
+	defer fake.configurationRevertMutex.Unlock()
+	fake.ConfigurationRevertStub = nil
+	if fake.configurationRevertReturnsOnCall == nil {
+		fake.configurationRevertReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.configurationRevertReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ConfigurationShow(arg1 string, arg2 string) (models.ConfigurationResponse, error) {
+	fake.configurationShowMutex.Lock()
+	ret, specificReturn := fake.configurationShowReturnsOnCall[len(fake.configurationShowArgsForCall)]
+	fake.configurationShowArgsForCall = append(fake.configurationShowArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ConfigurationShowStub
+	fakeReturns := fake.configurationShowReturns
+	fake.recordInvocation("ConfigurationShow", []interface{}{arg1, arg2})
+	fake.configurationShowMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ConfigurationShowCallCount() int {
+	fake.configurationShowMutex.RLock()
+	defer fake.configurationShowMutex.RUnlock()
+	return len(fake.configurationShowArgsForCall)
+}
+
+func (fake *FakeAPIClient) ConfigurationShowCalls(stub func(string, string) (models.ConfigurationResponse, error)) {
+	fake.configurationShowMutex.Lock()
+	defer fake.configurationShowMutex.Unlock()
+	fake.ConfigurationShowStub = stub
+}
+
+func (fake *FakeAPIClient) ConfigurationShowArgsForCall(i int) (string, string) {
+	fake.configurationShowMutex.RLock()
+	defer fake.configurationShowMutex.RUnlock()
+	argsForCall := fake.configurationShowArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) ConfigurationShowReturns(result1 models.ConfigurationResponse, result2 error) {
+	fake.configurationShowMutex.Lock()
+	defer fake.configurationShowMutex.Unlock()
+	fake.ConfigurationShowStub = nil
+	fake.configurationShowReturns = struct {
+		result1 models.ConfigurationResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ConfigurationShowReturnsOnCall(i int, result1 models.ConfigurationResponse, result2 error) {
+	fake.configurationShowMutex.Lock()
+	defer fake.configurationShowMutex.Unlock()
+	fake.ConfigurationShowStub = nil
+	if fake.configurationShowReturnsOnCall == nil {
+		fake.configurationShowReturnsOnCall = make(map[int]struct {
+			result1 models.ConfigurationResponse
+			result2 error
+		})
+	}
+	fake.configurationShowReturnsOnCall[i] = struct {
+		result1 models.ConfigurationResponse
 		result2 error
 	}{result1, result2}
 }
@@ -2593,19 +3853,20 @@ func (fake *FakeAPIClient) ConfigurationsReturnsOnCall(i int, result1 models.Con
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) EnvList(arg1 string, arg2 string) (models.EnvVariableMap, error) {
+func (fake *FakeAPIClient) EnvList(arg1 string, arg2 string, arg3 bool) (models.EnvVariableDefinitions, error) {
 	fake.envListMutex.Lock()
 	ret, specificReturn := fake.envListReturnsOnCall[len(fake.envListArgsForCall)]
 	fake.envListArgsForCall = append(fake.envListArgsForCall, struct {
 		arg1 string
 		arg2 string
-	}{arg1, arg2})
+		arg3 bool
+	}{arg1, arg2, arg3})
 	stub := fake.EnvListStub
 	fakeReturns := fake.envListReturns
-	fake.recordInvocation("EnvList", []interface{}{arg1, arg2})
+	fake.recordInvocation("EnvList", []interface{}{arg1, arg2, arg3})
 	fake.envListMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2619,41 +3880,41 @@ func (fake *FakeAPIClient) EnvListCallCount() int {
 	return len(fake.envListArgsForCall)
 }
 
-func (fake *FakeAPIClient) EnvListCalls(stub func(string, string) (models.EnvVariableMap, error)) {
+func (fake *FakeAPIClient) EnvListCalls(stub func(string, string, bool) (models.EnvVariableDefinitions, error)) {
 	fake.envListMutex.Lock()
 	defer fake.envListMutex.Unlock()
 	fake.EnvListStub = stub
 }
 
-func (fake *FakeAPIClient) EnvListArgsForCall(i int) (string, string) {
+func (fake *FakeAPIClient) EnvListArgsForCall(i int) (string, string, bool) {
 	fake.envListMutex.RLock()
 	defer fake.envListMutex.RUnlock()
 	argsForCall := fake.envListArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeAPIClient) EnvListReturns(result1 models.EnvVariableMap, result2 error) {
+func (fake *FakeAPIClient) EnvListReturns(result1 models.EnvVariableDefinitions, result2 error) {
 	fake.envListMutex.Lock()
 	defer fake.envListMutex.Unlock()
 	fake.EnvListStub = nil
 	fake.envListReturns = struct {
-		result1 models.EnvVariableMap
+		result1 models.EnvVariableDefinitions
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) EnvListReturnsOnCall(i int, result1 models.EnvVariableMap, result2 error) {
+func (fake *FakeAPIClient) EnvListReturnsOnCall(i int, result1 models.EnvVariableDefinitions, result2 error) {
 	fake.envListMutex.Lock()
 	defer fake.envListMutex.Unlock()
 	fake.EnvListStub = nil
 	if fake.envListReturnsOnCall == nil {
 		fake.envListReturnsOnCall = make(map[int]struct {
-			result1 models.EnvVariableMap
+			result1 models.EnvVariableDefinitions
 			result2 error
 		})
 	}
 	fake.envListReturnsOnCall[i] = struct {
-		result1 models.EnvVariableMap
+		result1 models.EnvVariableDefinitions
 		result2 error
 	}{result1, result2}
 }
@@ -2724,11 +3985,11 @@ func (fake *FakeAPIClient) EnvMatchReturnsOnCall(i int, result1 models.EnvMatchR
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) EnvSet(arg1 models.EnvVariableMap, arg2 string, arg3 string) (models.Response, error) {
+func (fake *FakeAPIClient) EnvSet(arg1 models.EnvVariableDefinitions, arg2 string, arg3 string) (models.Response, error) {
 	fake.envSetMutex.Lock()
 	ret, specificReturn := fake.envSetReturnsOnCall[len(fake.envSetArgsForCall)]
 	fake.envSetArgsForCall = append(fake.envSetArgsForCall, struct {
-		arg1 models.EnvVariableMap
+		arg1 models.EnvVariableDefinitions
 		arg2 string
 		arg3 string
 	}{arg1, arg2, arg3})
@@ -2751,13 +4012,13 @@ func (fake *FakeAPIClient) EnvSetCallCount() int {
 	return len(fake.envSetArgsForCall)
 }
 
-func (fake *FakeAPIClient) EnvSetCalls(stub func(models.EnvVariableMap, string, string) (models.Response, error)) {
+func (fake *FakeAPIClient) EnvSetCalls(stub func(models.EnvVariableDefinitions, string, string) (models.Response, error)) {
 	fake.envSetMutex.Lock()
 	defer fake.envSetMutex.Unlock()
 	fake.EnvSetStub = stub
 }
 
-func (fake *FakeAPIClient) EnvSetArgsForCall(i int) (models.EnvVariableMap, string, string) {
+func (fake *FakeAPIClient) EnvSetArgsForCall(i int) (models.EnvVariableDefinitions, string, string) {
 	fake.envSetMutex.RLock()
 	defer fake.envSetMutex.RUnlock()
 	argsForCall := fake.envSetArgsForCall[i]
@@ -2790,20 +4051,21 @@ func (fake *FakeAPIClient) EnvSetReturnsOnCall(i int, result1 models.Response, r
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) EnvShow(arg1 string, arg2 string, arg3 string) (models.EnvVariable, error) {
+func (fake *FakeAPIClient) EnvShow(arg1 string, arg2 string, arg3 string, arg4 bool) (models.EnvVariable, error) {
 	fake.envShowMutex.Lock()
 	ret, specificReturn := fake.envShowReturnsOnCall[len(fake.envShowArgsForCall)]
 	fake.envShowArgsForCall = append(fake.envShowArgsForCall, struct {
 		arg1 string
 		arg2 string
 		arg3 string
-	}{arg1, arg2, arg3})
+		arg4 bool
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.EnvShowStub
 	fakeReturns := fake.envShowReturns
-	fake.recordInvocation("EnvShow", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("EnvShow", []interface{}{arg1, arg2, arg3, arg4})
 	fake.envShowMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2817,17 +4079,17 @@ func (fake *FakeAPIClient) EnvShowCallCount() int {
 	return len(fake.envShowArgsForCall)
 }
 
-func (fake *FakeAPIClient) EnvShowCalls(stub func(string, string, string) (models.EnvVariable, error)) {
+func (fake *FakeAPIClient) EnvShowCalls(stub func(string, string, string, bool) (models.EnvVariable, error)) {
 	fake.envShowMutex.Lock()
 	defer fake.envShowMutex.Unlock()
 	fake.EnvShowStub = stub
 }
 
-func (fake *FakeAPIClient) EnvShowArgsForCall(i int) (string, string, string) {
+func (fake *FakeAPIClient) EnvShowArgsForCall(i int) (string, string, string, bool) {
 	fake.envShowMutex.RLock()
 	defer fake.envShowMutex.RUnlock()
 	argsForCall := fake.envShowArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeAPIClient) EnvShowReturns(result1 models.EnvVariable, result2 error) {
@@ -2922,6 +4184,126 @@ func (fake *FakeAPIClient) EnvUnsetReturnsOnCall(i int, result1 models.Response,
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) Images() (models.AppImageList, error) {
+	fake.imagesMutex.Lock()
+	ret, specificReturn := fake.imagesReturnsOnCall[len(fake.imagesArgsForCall)]
+	fake.imagesArgsForCall = append(fake.imagesArgsForCall, struct {
+	}{})
+	stub := fake.ImagesStub
+	fakeReturns := fake.imagesReturns
+	fake.recordInvocation("Images", []interface{}{})
+	fake.imagesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ImagesCallCount() int {
+	fake.imagesMutex.RLock()
+	defer fake.imagesMutex.RUnlock()
+	return len(fake.imagesArgsForCall)
+}
+
+func (fake *FakeAPIClient) ImagesCalls(stub func() (models.AppImageList, error)) {
+	fake.imagesMutex.Lock()
+	defer fake.imagesMutex.Unlock()
+	fake.ImagesStub = stub
+}
+
+func (fake *FakeAPIClient) ImagesReturns(result1 models.AppImageList, result2 error) {
+	fake.imagesMutex.Lock()
+	defer fake.imagesMutex.Unlock()
+	fake.ImagesStub = nil
+	fake.imagesReturns = struct {
+		result1 models.AppImageList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ImagesReturnsOnCall(i int, result1 models.AppImageList, result2 error) {
+	fake.imagesMutex.Lock()
+	defer fake.imagesMutex.Unlock()
+	fake.ImagesStub = nil
+	if fake.imagesReturnsOnCall == nil {
+		fake.imagesReturnsOnCall = make(map[int]struct {
+			result1 models.AppImageList
+			result2 error
+		})
+	}
+	fake.imagesReturnsOnCall[i] = struct {
+		result1 models.AppImageList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ImagesGC(arg1 models.ImagesGCRequest) (models.ImagesGCResponse, error) {
+	fake.imagesGCMutex.Lock()
+	ret, specificReturn := fake.imagesGCReturnsOnCall[len(fake.imagesGCArgsForCall)]
+	fake.imagesGCArgsForCall = append(fake.imagesGCArgsForCall, struct {
+		arg1 models.ImagesGCRequest
+	}{arg1})
+	stub := fake.ImagesGCStub
+	fakeReturns := fake.imagesGCReturns
+	fake.recordInvocation("ImagesGC", []interface{}{arg1})
+	fake.imagesGCMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ImagesGCCallCount() int {
+	fake.imagesGCMutex.RLock()
+	defer fake.imagesGCMutex.RUnlock()
+	return len(fake.imagesGCArgsForCall)
+}
+
+func (fake *FakeAPIClient) ImagesGCCalls(stub func(models.ImagesGCRequest) (models.ImagesGCResponse, error)) {
+	fake.imagesGCMutex.Lock()
+	defer fake.imagesGCMutex.Unlock()
+	fake.ImagesGCStub = stub
+}
+
+func (fake *FakeAPIClient) ImagesGCArgsForCall(i int) models.ImagesGCRequest {
+	fake.imagesGCMutex.RLock()
+	defer fake.imagesGCMutex.RUnlock()
+	argsForCall := fake.imagesGCArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) ImagesGCReturns(result1 models.ImagesGCResponse, result2 error) {
+	fake.imagesGCMutex.Lock()
+	defer fake.imagesGCMutex.Unlock()
+	fake.ImagesGCStub = nil
+	fake.imagesGCReturns = struct {
+		result1 models.ImagesGCResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ImagesGCReturnsOnCall(i int, result1 models.ImagesGCResponse, result2 error) {
+	fake.imagesGCMutex.Lock()
+	defer fake.imagesGCMutex.Unlock()
+	fake.ImagesGCStub = nil
+	if fake.imagesGCReturnsOnCall == nil {
+		fake.imagesGCReturnsOnCall = make(map[int]struct {
+			result1 models.ImagesGCResponse
+			result2 error
+		})
+	}
+	fake.imagesGCReturnsOnCall[i] = struct {
+		result1 models.ImagesGCResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) Info() (models.InfoResponse, error) {
 	fake.infoMutex.Lock()
 	ret, specificReturn := fake.infoReturnsOnCall[len(fake.infoArgsForCall)]
@@ -2978,18 +4360,19 @@ func (fake *FakeAPIClient) InfoReturnsOnCall(i int, result1 models.InfoResponse,
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespaceCreate(arg1 models.NamespaceCreateRequest) (models.Response, error) {
-	fake.namespaceCreateMutex.Lock()
-	ret, specificReturn := fake.namespaceCreateReturnsOnCall[len(fake.namespaceCreateArgsForCall)]
-	fake.namespaceCreateArgsForCall = append(fake.namespaceCreateArgsForCall, struct {
-		arg1 models.NamespaceCreateRequest
-	}{arg1})
-	stub := fake.NamespaceCreateStub
-	fakeReturns := fake.namespaceCreateReturns
-	fake.recordInvocation("NamespaceCreate", []interface{}{arg1})
-	fake.namespaceCreateMutex.Unlock()
+func (fake *FakeAPIClient) NamespaceBind(arg1 models.NamespaceBindRequest, arg2 string) (models.Response, error) {
+	fake.namespaceBindMutex.Lock()
+	ret, specificReturn := fake.namespaceBindReturnsOnCall[len(fake.namespaceBindArgsForCall)]
+	fake.namespaceBindArgsForCall = append(fake.namespaceBindArgsForCall, struct {
+		arg1 models.NamespaceBindRequest
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NamespaceBindStub
+	fakeReturns := fake.namespaceBindReturns
+	fake.recordInvocation("NamespaceBind", []interface{}{arg1, arg2})
+	fake.namespaceBindMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2997,63 +4380,64 @@ func (fake *FakeAPIClient) NamespaceCreate(arg1 models.NamespaceCreateRequest) (
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) NamespaceCreateCallCount() int {
-	fake.namespaceCreateMutex.RLock()
-	defer fake.namespaceCreateMutex.RUnlock()
-	return len(fake.namespaceCreateArgsForCall)
+func (fake *FakeAPIClient) NamespaceBindCallCount() int {
+	fake.namespaceBindMutex.RLock()
+	defer fake.namespaceBindMutex.RUnlock()
+	return len(fake.namespaceBindArgsForCall)
 }
 
-func (fake *FakeAPIClient) NamespaceCreateCalls(stub func(models.NamespaceCreateRequest) (models.Response, error)) {
-	fake.namespaceCreateMutex.Lock()
-	defer fake.namespaceCreateMutex.Unlock()
-	fake.NamespaceCreateStub = stub
+func (fake *FakeAPIClient) NamespaceBindCalls(stub func(models.NamespaceBindRequest, string) (models.Response, error)) {
+	fake.namespaceBindMutex.Lock()
+	defer fake.namespaceBindMutex.Unlock()
+	fake.NamespaceBindStub = stub
 }
 
-func (fake *FakeAPIClient) NamespaceCreateArgsForCall(i int) models.NamespaceCreateRequest {
-	fake.namespaceCreateMutex.RLock()
-	defer fake.namespaceCreateMutex.RUnlock()
-	argsForCall := fake.namespaceCreateArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeAPIClient) NamespaceBindArgsForCall(i int) (models.NamespaceBindRequest, string) {
+	fake.namespaceBindMutex.RLock()
+	defer fake.namespaceBindMutex.RUnlock()
+	argsForCall := fake.namespaceBindArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) NamespaceCreateReturns(result1 models.Response, result2 error) {
-	fake.namespaceCreateMutex.Lock()
-	defer fake.namespaceCreateMutex.Unlock()
-	fake.NamespaceCreateStub = nil
-	fake.namespaceCreateReturns = struct {
+func (fake *FakeAPIClient) NamespaceBindReturns(result1 models.Response, result2 error) {
+	fake.namespaceBindMutex.Lock()
+	defer fake.namespaceBindMutex.Unlock()
+	fake.NamespaceBindStub = nil
+	fake.namespaceBindReturns = struct {
 		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespaceCreateReturnsOnCall(i int, result1 models.Response, result2 error) {
-	fake.namespaceCreateMutex.Lock()
-	defer fake.namespaceCreateMutex.Unlock()
-	fake.NamespaceCreateStub = nil
-	if fake.namespaceCreateReturnsOnCall == nil {
-		fake.namespaceCreateReturnsOnCall = make(map[int]struct {
+func (fake *FakeAPIClient) NamespaceBindReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceBindMutex.Lock()
+	defer fake.namespaceBindMutex.Unlock()
+	fake.NamespaceBindStub = nil
+	if fake.namespaceBindReturnsOnCall == nil {
+		fake.namespaceBindReturnsOnCall = make(map[int]struct {
 			result1 models.Response
 			result2 error
 		})
 	}
-	fake.namespaceCreateReturnsOnCall[i] = struct {
+	fake.namespaceBindReturnsOnCall[i] = struct {
 		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespaceDelete(arg1 string) (models.Response, error) {
-	fake.namespaceDeleteMutex.Lock()
-	ret, specificReturn := fake.namespaceDeleteReturnsOnCall[len(fake.namespaceDeleteArgsForCall)]
-	fake.namespaceDeleteArgsForCall = append(fake.namespaceDeleteArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	stub := fake.NamespaceDeleteStub
-	fakeReturns := fake.namespaceDeleteReturns
-	fake.recordInvocation("NamespaceDelete", []interface{}{arg1})
-	fake.namespaceDeleteMutex.Unlock()
+func (fake *FakeAPIClient) NamespaceBuilderUpdate(arg1 models.NamespaceBuilderRequest, arg2 string) (models.Response, error) {
+	fake.namespaceBuilderUpdateMutex.Lock()
+	ret, specificReturn := fake.namespaceBuilderUpdateReturnsOnCall[len(fake.namespaceBuilderUpdateArgsForCall)]
+	fake.namespaceBuilderUpdateArgsForCall = append(fake.namespaceBuilderUpdateArgsForCall, struct {
+		arg1 models.NamespaceBuilderRequest
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NamespaceBuilderUpdateStub
+	fakeReturns := fake.namespaceBuilderUpdateReturns
+	fake.recordInvocation("NamespaceBuilderUpdate", []interface{}{arg1, arg2})
+	fake.namespaceBuilderUpdateMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -3061,60 +4445,577 @@ func (fake *FakeAPIClient) NamespaceDelete(arg1 string) (models.Response, error)
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteCallCount() int {
-	fake.namespaceDeleteMutex.RLock()
-	defer fake.namespaceDeleteMutex.RUnlock()
-	return len(fake.namespaceDeleteArgsForCall)
+func (fake *FakeAPIClient) NamespaceBuilderUpdateCallCount() int {
+	fake.namespaceBuilderUpdateMutex.RLock()
+	defer fake.namespaceBuilderUpdateMutex.RUnlock()
+	return len(fake.namespaceBuilderUpdateArgsForCall)
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteCalls(stub func(string) (models.Response, error)) {
-	fake.namespaceDeleteMutex.Lock()
-	defer fake.namespaceDeleteMutex.Unlock()
-	fake.NamespaceDeleteStub = stub
+func (fake *FakeAPIClient) NamespaceBuilderUpdateCalls(stub func(models.NamespaceBuilderRequest, string) (models.Response, error)) {
+	fake.namespaceBuilderUpdateMutex.Lock()
+	defer fake.namespaceBuilderUpdateMutex.Unlock()
+	fake.NamespaceBuilderUpdateStub = stub
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteArgsForCall(i int) string {
-	fake.namespaceDeleteMutex.RLock()
-	defer fake.namespaceDeleteMutex.RUnlock()
-	argsForCall := fake.namespaceDeleteArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeAPIClient) NamespaceBuilderUpdateArgsForCall(i int) (models.NamespaceBuilderRequest, string) {
+	fake.namespaceBuilderUpdateMutex.RLock()
+	defer fake.namespaceBuilderUpdateMutex.RUnlock()
+	argsForCall := fake.namespaceBuilderUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteReturns(result1 models.Response, result2 error) {
-	fake.namespaceDeleteMutex.Lock()
-	defer fake.namespaceDeleteMutex.Unlock()
-	fake.NamespaceDeleteStub = nil
-	fake.namespaceDeleteReturns = struct {
+func (fake *FakeAPIClient) NamespaceBuilderUpdateReturns(result1 models.Response, result2 error) {
+	fake.namespaceBuilderUpdateMutex.Lock()
+	defer fake.namespaceBuilderUpdateMutex.Unlock()
+	fake.NamespaceBuilderUpdateStub = nil
+	fake.namespaceBuilderUpdateReturns = struct {
 		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespaceDeleteReturnsOnCall(i int, result1 models.Response, result2 error) {
-	fake.namespaceDeleteMutex.Lock()
-	defer fake.namespaceDeleteMutex.Unlock()
-	fake.NamespaceDeleteStub = nil
-	if fake.namespaceDeleteReturnsOnCall == nil {
-		fake.namespaceDeleteReturnsOnCall = make(map[int]struct {
+func (fake *FakeAPIClient) NamespaceBuilderUpdateReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceBuilderUpdateMutex.Lock()
+	defer fake.namespaceBuilderUpdateMutex.Unlock()
+	fake.NamespaceBuilderUpdateStub = nil
+	if fake.namespaceBuilderUpdateReturnsOnCall == nil {
+		fake.namespaceBuilderUpdateReturnsOnCall = make(map[int]struct {
 			result1 models.Response
 			result2 error
 		})
 	}
-	fake.namespaceDeleteReturnsOnCall[i] = struct {
+	fake.namespaceBuilderUpdateReturnsOnCall[i] = struct {
 		result1 models.Response
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespaceShow(arg1 string) (models.Namespace, error) {
-	fake.namespaceShowMutex.Lock()
-	ret, specificReturn := fake.namespaceShowReturnsOnCall[len(fake.namespaceShowArgsForCall)]
-	fake.namespaceShowArgsForCall = append(fake.namespaceShowArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	stub := fake.NamespaceShowStub
-	fakeReturns := fake.namespaceShowReturns
-	fake.recordInvocation("NamespaceShow", []interface{}{arg1})
+func (fake *FakeAPIClient) NamespaceClone(arg1 models.NamespaceCloneRequest, arg2 string) (models.PromoteResponse, error) {
+	fake.namespaceCloneMutex.Lock()
+	ret, specificReturn := fake.namespaceCloneReturnsOnCall[len(fake.namespaceCloneArgsForCall)]
+	fake.namespaceCloneArgsForCall = append(fake.namespaceCloneArgsForCall, struct {
+		arg1 models.NamespaceCloneRequest
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NamespaceCloneStub
+	fakeReturns := fake.namespaceCloneReturns
+	fake.recordInvocation("NamespaceClone", []interface{}{arg1, arg2})
+	fake.namespaceCloneMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceCloneCallCount() int {
+	fake.namespaceCloneMutex.RLock()
+	defer fake.namespaceCloneMutex.RUnlock()
+	return len(fake.namespaceCloneArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceCloneCalls(stub func(models.NamespaceCloneRequest, string) (models.PromoteResponse, error)) {
+	fake.namespaceCloneMutex.Lock()
+	defer fake.namespaceCloneMutex.Unlock()
+	fake.NamespaceCloneStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceCloneArgsForCall(i int) (models.NamespaceCloneRequest, string) {
+	fake.namespaceCloneMutex.RLock()
+	defer fake.namespaceCloneMutex.RUnlock()
+	argsForCall := fake.namespaceCloneArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) NamespaceCloneReturns(result1 models.PromoteResponse, result2 error) {
+	fake.namespaceCloneMutex.Lock()
+	defer fake.namespaceCloneMutex.Unlock()
+	fake.NamespaceCloneStub = nil
+	fake.namespaceCloneReturns = struct {
+		result1 models.PromoteResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceCloneReturnsOnCall(i int, result1 models.PromoteResponse, result2 error) {
+	fake.namespaceCloneMutex.Lock()
+	defer fake.namespaceCloneMutex.Unlock()
+	fake.NamespaceCloneStub = nil
+	if fake.namespaceCloneReturnsOnCall == nil {
+		fake.namespaceCloneReturnsOnCall = make(map[int]struct {
+			result1 models.PromoteResponse
+			result2 error
+		})
+	}
+	fake.namespaceCloneReturnsOnCall[i] = struct {
+		result1 models.PromoteResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceCreate(arg1 models.NamespaceCreateRequest) (models.Response, error) {
+	fake.namespaceCreateMutex.Lock()
+	ret, specificReturn := fake.namespaceCreateReturnsOnCall[len(fake.namespaceCreateArgsForCall)]
+	fake.namespaceCreateArgsForCall = append(fake.namespaceCreateArgsForCall, struct {
+		arg1 models.NamespaceCreateRequest
+	}{arg1})
+	stub := fake.NamespaceCreateStub
+	fakeReturns := fake.namespaceCreateReturns
+	fake.recordInvocation("NamespaceCreate", []interface{}{arg1})
+	fake.namespaceCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceCreateCallCount() int {
+	fake.namespaceCreateMutex.RLock()
+	defer fake.namespaceCreateMutex.RUnlock()
+	return len(fake.namespaceCreateArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceCreateCalls(stub func(models.NamespaceCreateRequest) (models.Response, error)) {
+	fake.namespaceCreateMutex.Lock()
+	defer fake.namespaceCreateMutex.Unlock()
+	fake.NamespaceCreateStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceCreateArgsForCall(i int) models.NamespaceCreateRequest {
+	fake.namespaceCreateMutex.RLock()
+	defer fake.namespaceCreateMutex.RUnlock()
+	argsForCall := fake.namespaceCreateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) NamespaceCreateReturns(result1 models.Response, result2 error) {
+	fake.namespaceCreateMutex.Lock()
+	defer fake.namespaceCreateMutex.Unlock()
+	fake.NamespaceCreateStub = nil
+	fake.namespaceCreateReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceCreateReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceCreateMutex.Lock()
+	defer fake.namespaceCreateMutex.Unlock()
+	fake.NamespaceCreateStub = nil
+	if fake.namespaceCreateReturnsOnCall == nil {
+		fake.namespaceCreateReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceCreateReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceDelete(arg1 string) (models.Response, error) {
+	fake.namespaceDeleteMutex.Lock()
+	ret, specificReturn := fake.namespaceDeleteReturnsOnCall[len(fake.namespaceDeleteArgsForCall)]
+	fake.namespaceDeleteArgsForCall = append(fake.namespaceDeleteArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.NamespaceDeleteStub
+	fakeReturns := fake.namespaceDeleteReturns
+	fake.recordInvocation("NamespaceDelete", []interface{}{arg1})
+	fake.namespaceDeleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteCallCount() int {
+	fake.namespaceDeleteMutex.RLock()
+	defer fake.namespaceDeleteMutex.RUnlock()
+	return len(fake.namespaceDeleteArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteCalls(stub func(string) (models.Response, error)) {
+	fake.namespaceDeleteMutex.Lock()
+	defer fake.namespaceDeleteMutex.Unlock()
+	fake.NamespaceDeleteStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteArgsForCall(i int) string {
+	fake.namespaceDeleteMutex.RLock()
+	defer fake.namespaceDeleteMutex.RUnlock()
+	argsForCall := fake.namespaceDeleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteReturns(result1 models.Response, result2 error) {
+	fake.namespaceDeleteMutex.Lock()
+	defer fake.namespaceDeleteMutex.Unlock()
+	fake.NamespaceDeleteStub = nil
+	fake.namespaceDeleteReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceDeleteReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceDeleteMutex.Lock()
+	defer fake.namespaceDeleteMutex.Unlock()
+	fake.NamespaceDeleteStub = nil
+	if fake.namespaceDeleteReturnsOnCall == nil {
+		fake.namespaceDeleteReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceDeleteReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceDeletionPreview(arg1 string) (models.NamespaceDeletionPreview, error) {
+	fake.namespaceDeletionPreviewMutex.Lock()
+	ret, specificReturn := fake.namespaceDeletionPreviewReturnsOnCall[len(fake.namespaceDeletionPreviewArgsForCall)]
+	fake.namespaceDeletionPreviewArgsForCall = append(fake.namespaceDeletionPreviewArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.NamespaceDeletionPreviewStub
+	fakeReturns := fake.namespaceDeletionPreviewReturns
+	fake.recordInvocation("NamespaceDeletionPreview", []interface{}{arg1})
+	fake.namespaceDeletionPreviewMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceDeletionPreviewCallCount() int {
+	fake.namespaceDeletionPreviewMutex.RLock()
+	defer fake.namespaceDeletionPreviewMutex.RUnlock()
+	return len(fake.namespaceDeletionPreviewArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceDeletionPreviewCalls(stub func(string) (models.NamespaceDeletionPreview, error)) {
+	fake.namespaceDeletionPreviewMutex.Lock()
+	defer fake.namespaceDeletionPreviewMutex.Unlock()
+	fake.NamespaceDeletionPreviewStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceDeletionPreviewArgsForCall(i int) string {
+	fake.namespaceDeletionPreviewMutex.RLock()
+	defer fake.namespaceDeletionPreviewMutex.RUnlock()
+	argsForCall := fake.namespaceDeletionPreviewArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) NamespaceDeletionPreviewReturns(result1 models.NamespaceDeletionPreview, result2 error) {
+	fake.namespaceDeletionPreviewMutex.Lock()
+	defer fake.namespaceDeletionPreviewMutex.Unlock()
+	fake.NamespaceDeletionPreviewStub = nil
+	fake.namespaceDeletionPreviewReturns = struct {
+		result1 models.NamespaceDeletionPreview
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceDeletionPreviewReturnsOnCall(i int, result1 models.NamespaceDeletionPreview, result2 error) {
+	fake.namespaceDeletionPreviewMutex.Lock()
+	defer fake.namespaceDeletionPreviewMutex.Unlock()
+	fake.NamespaceDeletionPreviewStub = nil
+	if fake.namespaceDeletionPreviewReturnsOnCall == nil {
+		fake.namespaceDeletionPreviewReturnsOnCall = make(map[int]struct {
+			result1 models.NamespaceDeletionPreview
+			result2 error
+		})
+	}
+	fake.namespaceDeletionPreviewReturnsOnCall[i] = struct {
+		result1 models.NamespaceDeletionPreview
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceEnvList(arg1 string) (models.EnvVariableMap, error) {
+	fake.namespaceEnvListMutex.Lock()
+	ret, specificReturn := fake.namespaceEnvListReturnsOnCall[len(fake.namespaceEnvListArgsForCall)]
+	fake.namespaceEnvListArgsForCall = append(fake.namespaceEnvListArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.NamespaceEnvListStub
+	fakeReturns := fake.namespaceEnvListReturns
+	fake.recordInvocation("NamespaceEnvList", []interface{}{arg1})
+	fake.namespaceEnvListMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceEnvListCallCount() int {
+	fake.namespaceEnvListMutex.RLock()
+	defer fake.namespaceEnvListMutex.RUnlock()
+	return len(fake.namespaceEnvListArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceEnvListCalls(stub func(string) (models.EnvVariableMap, error)) {
+	fake.namespaceEnvListMutex.Lock()
+	defer fake.namespaceEnvListMutex.Unlock()
+	fake.NamespaceEnvListStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceEnvListArgsForCall(i int) string {
+	fake.namespaceEnvListMutex.RLock()
+	defer fake.namespaceEnvListMutex.RUnlock()
+	argsForCall := fake.namespaceEnvListArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) NamespaceEnvListReturns(result1 models.EnvVariableMap, result2 error) {
+	fake.namespaceEnvListMutex.Lock()
+	defer fake.namespaceEnvListMutex.Unlock()
+	fake.NamespaceEnvListStub = nil
+	fake.namespaceEnvListReturns = struct {
+		result1 models.EnvVariableMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceEnvListReturnsOnCall(i int, result1 models.EnvVariableMap, result2 error) {
+	fake.namespaceEnvListMutex.Lock()
+	defer fake.namespaceEnvListMutex.Unlock()
+	fake.NamespaceEnvListStub = nil
+	if fake.namespaceEnvListReturnsOnCall == nil {
+		fake.namespaceEnvListReturnsOnCall = make(map[int]struct {
+			result1 models.EnvVariableMap
+			result2 error
+		})
+	}
+	fake.namespaceEnvListReturnsOnCall[i] = struct {
+		result1 models.EnvVariableMap
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceEnvSet(arg1 models.NamespaceEnvSetRequest, arg2 string) (models.Response, error) {
+	fake.namespaceEnvSetMutex.Lock()
+	ret, specificReturn := fake.namespaceEnvSetReturnsOnCall[len(fake.namespaceEnvSetArgsForCall)]
+	fake.namespaceEnvSetArgsForCall = append(fake.namespaceEnvSetArgsForCall, struct {
+		arg1 models.NamespaceEnvSetRequest
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NamespaceEnvSetStub
+	fakeReturns := fake.namespaceEnvSetReturns
+	fake.recordInvocation("NamespaceEnvSet", []interface{}{arg1, arg2})
+	fake.namespaceEnvSetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceEnvSetCallCount() int {
+	fake.namespaceEnvSetMutex.RLock()
+	defer fake.namespaceEnvSetMutex.RUnlock()
+	return len(fake.namespaceEnvSetArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceEnvSetCalls(stub func(models.NamespaceEnvSetRequest, string) (models.Response, error)) {
+	fake.namespaceEnvSetMutex.Lock()
+	defer fake.namespaceEnvSetMutex.Unlock()
+	fake.NamespaceEnvSetStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceEnvSetArgsForCall(i int) (models.NamespaceEnvSetRequest, string) {
+	fake.namespaceEnvSetMutex.RLock()
+	defer fake.namespaceEnvSetMutex.RUnlock()
+	argsForCall := fake.namespaceEnvSetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) NamespaceEnvSetReturns(result1 models.Response, result2 error) {
+	fake.namespaceEnvSetMutex.Lock()
+	defer fake.namespaceEnvSetMutex.Unlock()
+	fake.NamespaceEnvSetStub = nil
+	fake.namespaceEnvSetReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceEnvSetReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceEnvSetMutex.Lock()
+	defer fake.namespaceEnvSetMutex.Unlock()
+	fake.NamespaceEnvSetStub = nil
+	if fake.namespaceEnvSetReturnsOnCall == nil {
+		fake.namespaceEnvSetReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceEnvSetReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceEnvUnset(arg1 string, arg2 string, arg3 bool) (models.Response, error) {
+	fake.namespaceEnvUnsetMutex.Lock()
+	ret, specificReturn := fake.namespaceEnvUnsetReturnsOnCall[len(fake.namespaceEnvUnsetArgsForCall)]
+	fake.namespaceEnvUnsetArgsForCall = append(fake.namespaceEnvUnsetArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.NamespaceEnvUnsetStub
+	fakeReturns := fake.namespaceEnvUnsetReturns
+	fake.recordInvocation("NamespaceEnvUnset", []interface{}{arg1, arg2, arg3})
+	fake.namespaceEnvUnsetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceEnvUnsetCallCount() int {
+	fake.namespaceEnvUnsetMutex.RLock()
+	defer fake.namespaceEnvUnsetMutex.RUnlock()
+	return len(fake.namespaceEnvUnsetArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceEnvUnsetCalls(stub func(string, string, bool) (models.Response, error)) {
+	fake.namespaceEnvUnsetMutex.Lock()
+	defer fake.namespaceEnvUnsetMutex.Unlock()
+	fake.NamespaceEnvUnsetStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceEnvUnsetArgsForCall(i int) (string, string, bool) {
+	fake.namespaceEnvUnsetMutex.RLock()
+	defer fake.namespaceEnvUnsetMutex.RUnlock()
+	argsForCall := fake.namespaceEnvUnsetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) NamespaceEnvUnsetReturns(result1 models.Response, result2 error) {
+	fake.namespaceEnvUnsetMutex.Lock()
+	defer fake.namespaceEnvUnsetMutex.Unlock()
+	fake.NamespaceEnvUnsetStub = nil
+	fake.namespaceEnvUnsetReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceEnvUnsetReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceEnvUnsetMutex.Lock()
+	defer fake.namespaceEnvUnsetMutex.Unlock()
+	fake.NamespaceEnvUnsetStub = nil
+	if fake.namespaceEnvUnsetReturnsOnCall == nil {
+		fake.namespaceEnvUnsetReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceEnvUnsetReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceQuotaUpdate(arg1 models.NamespaceQuota, arg2 string) (models.Response, error) {
+	fake.namespaceQuotaUpdateMutex.Lock()
+	ret, specificReturn := fake.namespaceQuotaUpdateReturnsOnCall[len(fake.namespaceQuotaUpdateArgsForCall)]
+	fake.namespaceQuotaUpdateArgsForCall = append(fake.namespaceQuotaUpdateArgsForCall, struct {
+		arg1 models.NamespaceQuota
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NamespaceQuotaUpdateStub
+	fakeReturns := fake.namespaceQuotaUpdateReturns
+	fake.recordInvocation("NamespaceQuotaUpdate", []interface{}{arg1, arg2})
+	fake.namespaceQuotaUpdateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceQuotaUpdateCallCount() int {
+	fake.namespaceQuotaUpdateMutex.RLock()
+	defer fake.namespaceQuotaUpdateMutex.RUnlock()
+	return len(fake.namespaceQuotaUpdateArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceQuotaUpdateCalls(stub func(models.NamespaceQuota, string) (models.Response, error)) {
+	fake.namespaceQuotaUpdateMutex.Lock()
+	defer fake.namespaceQuotaUpdateMutex.Unlock()
+	fake.NamespaceQuotaUpdateStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceQuotaUpdateArgsForCall(i int) (models.NamespaceQuota, string) {
+	fake.namespaceQuotaUpdateMutex.RLock()
+	defer fake.namespaceQuotaUpdateMutex.RUnlock()
+	argsForCall := fake.namespaceQuotaUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) NamespaceQuotaUpdateReturns(result1 models.Response, result2 error) {
+	fake.namespaceQuotaUpdateMutex.Lock()
+	defer fake.namespaceQuotaUpdateMutex.Unlock()
+	fake.NamespaceQuotaUpdateStub = nil
+	fake.namespaceQuotaUpdateReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceQuotaUpdateReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceQuotaUpdateMutex.Lock()
+	defer fake.namespaceQuotaUpdateMutex.Unlock()
+	fake.NamespaceQuotaUpdateStub = nil
+	if fake.namespaceQuotaUpdateReturnsOnCall == nil {
+		fake.namespaceQuotaUpdateReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceQuotaUpdateReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceShow(arg1 string) (models.Namespace, error) {
+	fake.namespaceShowMutex.Lock()
+	ret, specificReturn := fake.namespaceShowReturnsOnCall[len(fake.namespaceShowArgsForCall)]
+	fake.namespaceShowArgsForCall = append(fake.namespaceShowArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.NamespaceShowStub
+	fakeReturns := fake.namespaceShowReturns
+	fake.recordInvocation("NamespaceShow", []interface{}{arg1})
 	fake.namespaceShowMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
@@ -3170,17 +5071,278 @@ func (fake *FakeAPIClient) NamespaceShowReturnsOnCall(i int, result1 models.Name
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) Namespaces() (models.NamespaceList, error) {
+func (fake *FakeAPIClient) NamespaceSoftDelete(arg1 string, arg2 time.Duration) (models.Response, error) {
+	fake.namespaceSoftDeleteMutex.Lock()
+	ret, specificReturn := fake.namespaceSoftDeleteReturnsOnCall[len(fake.namespaceSoftDeleteArgsForCall)]
+	fake.namespaceSoftDeleteArgsForCall = append(fake.namespaceSoftDeleteArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+	}{arg1, arg2})
+	stub := fake.NamespaceSoftDeleteStub
+	fakeReturns := fake.namespaceSoftDeleteReturns
+	fake.recordInvocation("NamespaceSoftDelete", []interface{}{arg1, arg2})
+	fake.namespaceSoftDeleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceSoftDeleteCallCount() int {
+	fake.namespaceSoftDeleteMutex.RLock()
+	defer fake.namespaceSoftDeleteMutex.RUnlock()
+	return len(fake.namespaceSoftDeleteArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceSoftDeleteCalls(stub func(string, time.Duration) (models.Response, error)) {
+	fake.namespaceSoftDeleteMutex.Lock()
+	defer fake.namespaceSoftDeleteMutex.Unlock()
+	fake.NamespaceSoftDeleteStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceSoftDeleteArgsForCall(i int) (string, time.Duration) {
+	fake.namespaceSoftDeleteMutex.RLock()
+	defer fake.namespaceSoftDeleteMutex.RUnlock()
+	argsForCall := fake.namespaceSoftDeleteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) NamespaceSoftDeleteReturns(result1 models.Response, result2 error) {
+	fake.namespaceSoftDeleteMutex.Lock()
+	defer fake.namespaceSoftDeleteMutex.Unlock()
+	fake.NamespaceSoftDeleteStub = nil
+	fake.namespaceSoftDeleteReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceSoftDeleteReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceSoftDeleteMutex.Lock()
+	defer fake.namespaceSoftDeleteMutex.Unlock()
+	fake.NamespaceSoftDeleteStub = nil
+	if fake.namespaceSoftDeleteReturnsOnCall == nil {
+		fake.namespaceSoftDeleteReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceSoftDeleteReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceUnbind(arg1 string, arg2 string, arg3 bool) (models.Response, error) {
+	fake.namespaceUnbindMutex.Lock()
+	ret, specificReturn := fake.namespaceUnbindReturnsOnCall[len(fake.namespaceUnbindArgsForCall)]
+	fake.namespaceUnbindArgsForCall = append(fake.namespaceUnbindArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.NamespaceUnbindStub
+	fakeReturns := fake.namespaceUnbindReturns
+	fake.recordInvocation("NamespaceUnbind", []interface{}{arg1, arg2, arg3})
+	fake.namespaceUnbindMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceUnbindCallCount() int {
+	fake.namespaceUnbindMutex.RLock()
+	defer fake.namespaceUnbindMutex.RUnlock()
+	return len(fake.namespaceUnbindArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceUnbindCalls(stub func(string, string, bool) (models.Response, error)) {
+	fake.namespaceUnbindMutex.Lock()
+	defer fake.namespaceUnbindMutex.Unlock()
+	fake.NamespaceUnbindStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceUnbindArgsForCall(i int) (string, string, bool) {
+	fake.namespaceUnbindMutex.RLock()
+	defer fake.namespaceUnbindMutex.RUnlock()
+	argsForCall := fake.namespaceUnbindArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) NamespaceUnbindReturns(result1 models.Response, result2 error) {
+	fake.namespaceUnbindMutex.Lock()
+	defer fake.namespaceUnbindMutex.Unlock()
+	fake.NamespaceUnbindStub = nil
+	fake.namespaceUnbindReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceUnbindReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceUnbindMutex.Lock()
+	defer fake.namespaceUnbindMutex.Unlock()
+	fake.NamespaceUnbindStub = nil
+	if fake.namespaceUnbindReturnsOnCall == nil {
+		fake.namespaceUnbindReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceUnbindReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceUndelete(arg1 string) (models.Response, error) {
+	fake.namespaceUndeleteMutex.Lock()
+	ret, specificReturn := fake.namespaceUndeleteReturnsOnCall[len(fake.namespaceUndeleteArgsForCall)]
+	fake.namespaceUndeleteArgsForCall = append(fake.namespaceUndeleteArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.NamespaceUndeleteStub
+	fakeReturns := fake.namespaceUndeleteReturns
+	fake.recordInvocation("NamespaceUndelete", []interface{}{arg1})
+	fake.namespaceUndeleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceUndeleteCallCount() int {
+	fake.namespaceUndeleteMutex.RLock()
+	defer fake.namespaceUndeleteMutex.RUnlock()
+	return len(fake.namespaceUndeleteArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceUndeleteCalls(stub func(string) (models.Response, error)) {
+	fake.namespaceUndeleteMutex.Lock()
+	defer fake.namespaceUndeleteMutex.Unlock()
+	fake.NamespaceUndeleteStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceUndeleteArgsForCall(i int) string {
+	fake.namespaceUndeleteMutex.RLock()
+	defer fake.namespaceUndeleteMutex.RUnlock()
+	argsForCall := fake.namespaceUndeleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) NamespaceUndeleteReturns(result1 models.Response, result2 error) {
+	fake.namespaceUndeleteMutex.Lock()
+	defer fake.namespaceUndeleteMutex.Unlock()
+	fake.NamespaceUndeleteStub = nil
+	fake.namespaceUndeleteReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceUndeleteReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceUndeleteMutex.Lock()
+	defer fake.namespaceUndeleteMutex.Unlock()
+	fake.NamespaceUndeleteStub = nil
+	if fake.namespaceUndeleteReturnsOnCall == nil {
+		fake.namespaceUndeleteReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceUndeleteReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceUpdate(arg1 models.NamespaceUpdateRequest, arg2 string) (models.Response, error) {
+	fake.namespaceUpdateMutex.Lock()
+	ret, specificReturn := fake.namespaceUpdateReturnsOnCall[len(fake.namespaceUpdateArgsForCall)]
+	fake.namespaceUpdateArgsForCall = append(fake.namespaceUpdateArgsForCall, struct {
+		arg1 models.NamespaceUpdateRequest
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NamespaceUpdateStub
+	fakeReturns := fake.namespaceUpdateReturns
+	fake.recordInvocation("NamespaceUpdate", []interface{}{arg1, arg2})
+	fake.namespaceUpdateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespaceUpdateCallCount() int {
+	fake.namespaceUpdateMutex.RLock()
+	defer fake.namespaceUpdateMutex.RUnlock()
+	return len(fake.namespaceUpdateArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespaceUpdateCalls(stub func(models.NamespaceUpdateRequest, string) (models.Response, error)) {
+	fake.namespaceUpdateMutex.Lock()
+	defer fake.namespaceUpdateMutex.Unlock()
+	fake.NamespaceUpdateStub = stub
+}
+
+func (fake *FakeAPIClient) NamespaceUpdateArgsForCall(i int) (models.NamespaceUpdateRequest, string) {
+	fake.namespaceUpdateMutex.RLock()
+	defer fake.namespaceUpdateMutex.RUnlock()
+	argsForCall := fake.namespaceUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) NamespaceUpdateReturns(result1 models.Response, result2 error) {
+	fake.namespaceUpdateMutex.Lock()
+	defer fake.namespaceUpdateMutex.Unlock()
+	fake.NamespaceUpdateStub = nil
+	fake.namespaceUpdateReturns = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespaceUpdateReturnsOnCall(i int, result1 models.Response, result2 error) {
+	fake.namespaceUpdateMutex.Lock()
+	defer fake.namespaceUpdateMutex.Unlock()
+	fake.NamespaceUpdateStub = nil
+	if fake.namespaceUpdateReturnsOnCall == nil {
+		fake.namespaceUpdateReturnsOnCall = make(map[int]struct {
+			result1 models.Response
+			result2 error
+		})
+	}
+	fake.namespaceUpdateReturnsOnCall[i] = struct {
+		result1 models.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) Namespaces(arg1 string) (models.NamespaceList, error) {
 	fake.namespacesMutex.Lock()
 	ret, specificReturn := fake.namespacesReturnsOnCall[len(fake.namespacesArgsForCall)]
 	fake.namespacesArgsForCall = append(fake.namespacesArgsForCall, struct {
-	}{})
+		arg1 string
+	}{arg1})
 	stub := fake.NamespacesStub
 	fakeReturns := fake.namespacesReturns
-	fake.recordInvocation("Namespaces", []interface{}{})
+	fake.recordInvocation("Namespaces", []interface{}{arg1})
 	fake.namespacesMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -3194,12 +5356,19 @@ func (fake *FakeAPIClient) NamespacesCallCount() int {
 	return len(fake.namespacesArgsForCall)
 }
 
-func (fake *FakeAPIClient) NamespacesCalls(stub func() (models.NamespaceList, error)) {
+func (fake *FakeAPIClient) NamespacesCalls(stub func(string) (models.NamespaceList, error)) {
 	fake.namespacesMutex.Lock()
 	defer fake.namespacesMutex.Unlock()
 	fake.NamespacesStub = stub
 }
 
+func (fake *FakeAPIClient) NamespacesArgsForCall(i int) string {
+	fake.namespacesMutex.RLock()
+	defer fake.namespacesMutex.RUnlock()
+	argsForCall := fake.namespacesArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeAPIClient) NamespacesReturns(result1 models.NamespaceList, result2 error) {
 	fake.namespacesMutex.Lock()
 	defer fake.namespacesMutex.Unlock()
@@ -3220,24 +5389,154 @@ func (fake *FakeAPIClient) NamespacesReturnsOnCall(i int, result1 models.Namespa
 			result2 error
 		})
 	}
-	fake.namespacesReturnsOnCall[i] = struct {
-		result1 models.NamespaceList
+	fake.namespacesReturnsOnCall[i] = struct {
+		result1 models.NamespaceList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespacesMatch(arg1 string) (models.NamespacesMatchResponse, error) {
+	fake.namespacesMatchMutex.Lock()
+	ret, specificReturn := fake.namespacesMatchReturnsOnCall[len(fake.namespacesMatchArgsForCall)]
+	fake.namespacesMatchArgsForCall = append(fake.namespacesMatchArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.NamespacesMatchStub
+	fakeReturns := fake.namespacesMatchReturns
+	fake.recordInvocation("NamespacesMatch", []interface{}{arg1})
+	fake.namespacesMatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) NamespacesMatchCallCount() int {
+	fake.namespacesMatchMutex.RLock()
+	defer fake.namespacesMatchMutex.RUnlock()
+	return len(fake.namespacesMatchArgsForCall)
+}
+
+func (fake *FakeAPIClient) NamespacesMatchCalls(stub func(string) (models.NamespacesMatchResponse, error)) {
+	fake.namespacesMatchMutex.Lock()
+	defer fake.namespacesMatchMutex.Unlock()
+	fake.NamespacesMatchStub = stub
+}
+
+func (fake *FakeAPIClient) NamespacesMatchArgsForCall(i int) string {
+	fake.namespacesMatchMutex.RLock()
+	defer fake.namespacesMatchMutex.RUnlock()
+	argsForCall := fake.namespacesMatchArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) NamespacesMatchReturns(result1 models.NamespacesMatchResponse, result2 error) {
+	fake.namespacesMatchMutex.Lock()
+	defer fake.namespacesMatchMutex.Unlock()
+	fake.NamespacesMatchStub = nil
+	fake.namespacesMatchReturns = struct {
+		result1 models.NamespacesMatchResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) NamespacesMatchReturnsOnCall(i int, result1 models.NamespacesMatchResponse, result2 error) {
+	fake.namespacesMatchMutex.Lock()
+	defer fake.namespacesMatchMutex.Unlock()
+	fake.NamespacesMatchStub = nil
+	if fake.namespacesMatchReturnsOnCall == nil {
+		fake.namespacesMatchReturnsOnCall = make(map[int]struct {
+			result1 models.NamespacesMatchResponse
+			result2 error
+		})
+	}
+	fake.namespacesMatchReturnsOnCall[i] = struct {
+		result1 models.NamespacesMatchResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceBackup(arg1 string, arg2 string) (*models.ServiceJob, error) {
+	fake.serviceBackupMutex.Lock()
+	ret, specificReturn := fake.serviceBackupReturnsOnCall[len(fake.serviceBackupArgsForCall)]
+	fake.serviceBackupArgsForCall = append(fake.serviceBackupArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ServiceBackupStub
+	fakeReturns := fake.serviceBackupReturns
+	fake.recordInvocation("ServiceBackup", []interface{}{arg1, arg2})
+	fake.serviceBackupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ServiceBackupCallCount() int {
+	fake.serviceBackupMutex.RLock()
+	defer fake.serviceBackupMutex.RUnlock()
+	return len(fake.serviceBackupArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceBackupCalls(stub func(string, string) (*models.ServiceJob, error)) {
+	fake.serviceBackupMutex.Lock()
+	defer fake.serviceBackupMutex.Unlock()
+	fake.ServiceBackupStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceBackupArgsForCall(i int) (string, string) {
+	fake.serviceBackupMutex.RLock()
+	defer fake.serviceBackupMutex.RUnlock()
+	argsForCall := fake.serviceBackupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) ServiceBackupReturns(result1 *models.ServiceJob, result2 error) {
+	fake.serviceBackupMutex.Lock()
+	defer fake.serviceBackupMutex.Unlock()
+	fake.ServiceBackupStub = nil
+	fake.serviceBackupReturns = struct {
+		result1 *models.ServiceJob
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceBackupReturnsOnCall(i int, result1 *models.ServiceJob, result2 error) {
+	fake.serviceBackupMutex.Lock()
+	defer fake.serviceBackupMutex.Unlock()
+	fake.ServiceBackupStub = nil
+	if fake.serviceBackupReturnsOnCall == nil {
+		fake.serviceBackupReturnsOnCall = make(map[int]struct {
+			result1 *models.ServiceJob
+			result2 error
+		})
+	}
+	fake.serviceBackupReturnsOnCall[i] = struct {
+		result1 *models.ServiceJob
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespacesMatch(arg1 string) (models.NamespacesMatchResponse, error) {
-	fake.namespacesMatchMutex.Lock()
-	ret, specificReturn := fake.namespacesMatchReturnsOnCall[len(fake.namespacesMatchArgsForCall)]
-	fake.namespacesMatchArgsForCall = append(fake.namespacesMatchArgsForCall, struct {
+func (fake *FakeAPIClient) ServiceBackups(arg1 string, arg2 string) (models.ServiceBackupList, error) {
+	fake.serviceBackupsMutex.Lock()
+	ret, specificReturn := fake.serviceBackupsReturnsOnCall[len(fake.serviceBackupsArgsForCall)]
+	fake.serviceBackupsArgsForCall = append(fake.serviceBackupsArgsForCall, struct {
 		arg1 string
-	}{arg1})
-	stub := fake.NamespacesMatchStub
-	fakeReturns := fake.namespacesMatchReturns
-	fake.recordInvocation("NamespacesMatch", []interface{}{arg1})
-	fake.namespacesMatchMutex.Unlock()
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ServiceBackupsStub
+	fakeReturns := fake.serviceBackupsReturns
+	fake.recordInvocation("ServiceBackups", []interface{}{arg1, arg2})
+	fake.serviceBackupsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -3245,47 +5544,47 @@ func (fake *FakeAPIClient) NamespacesMatch(arg1 string) (models.NamespacesMatchR
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeAPIClient) NamespacesMatchCallCount() int {
-	fake.namespacesMatchMutex.RLock()
-	defer fake.namespacesMatchMutex.RUnlock()
-	return len(fake.namespacesMatchArgsForCall)
+func (fake *FakeAPIClient) ServiceBackupsCallCount() int {
+	fake.serviceBackupsMutex.RLock()
+	defer fake.serviceBackupsMutex.RUnlock()
+	return len(fake.serviceBackupsArgsForCall)
 }
 
-func (fake *FakeAPIClient) NamespacesMatchCalls(stub func(string) (models.NamespacesMatchResponse, error)) {
-	fake.namespacesMatchMutex.Lock()
-	defer fake.namespacesMatchMutex.Unlock()
-	fake.NamespacesMatchStub = stub
+func (fake *FakeAPIClient) ServiceBackupsCalls(stub func(string, string) (models.ServiceBackupList, error)) {
+	fake.serviceBackupsMutex.Lock()
+	defer fake.serviceBackupsMutex.Unlock()
+	fake.ServiceBackupsStub = stub
 }
 
-func (fake *FakeAPIClient) NamespacesMatchArgsForCall(i int) string {
-	fake.namespacesMatchMutex.RLock()
-	defer fake.namespacesMatchMutex.RUnlock()
-	argsForCall := fake.namespacesMatchArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeAPIClient) ServiceBackupsArgsForCall(i int) (string, string) {
+	fake.serviceBackupsMutex.RLock()
+	defer fake.serviceBackupsMutex.RUnlock()
+	argsForCall := fake.serviceBackupsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeAPIClient) NamespacesMatchReturns(result1 models.NamespacesMatchResponse, result2 error) {
-	fake.namespacesMatchMutex.Lock()
-	defer fake.namespacesMatchMutex.Unlock()
-	fake.NamespacesMatchStub = nil
-	fake.namespacesMatchReturns = struct {
-		result1 models.NamespacesMatchResponse
+func (fake *FakeAPIClient) ServiceBackupsReturns(result1 models.ServiceBackupList, result2 error) {
+	fake.serviceBackupsMutex.Lock()
+	defer fake.serviceBackupsMutex.Unlock()
+	fake.ServiceBackupsStub = nil
+	fake.serviceBackupsReturns = struct {
+		result1 models.ServiceBackupList
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeAPIClient) NamespacesMatchReturnsOnCall(i int, result1 models.NamespacesMatchResponse, result2 error) {
-	fake.namespacesMatchMutex.Lock()
-	defer fake.namespacesMatchMutex.Unlock()
-	fake.NamespacesMatchStub = nil
-	if fake.namespacesMatchReturnsOnCall == nil {
-		fake.namespacesMatchReturnsOnCall = make(map[int]struct {
-			result1 models.NamespacesMatchResponse
+func (fake *FakeAPIClient) ServiceBackupsReturnsOnCall(i int, result1 models.ServiceBackupList, result2 error) {
+	fake.serviceBackupsMutex.Lock()
+	defer fake.serviceBackupsMutex.Unlock()
+	fake.ServiceBackupsStub = nil
+	if fake.serviceBackupsReturnsOnCall == nil {
+		fake.serviceBackupsReturnsOnCall = make(map[int]struct {
+			result1 models.ServiceBackupList
 			result2 error
 		})
 	}
-	fake.namespacesMatchReturnsOnCall[i] = struct {
-		result1 models.NamespacesMatchResponse
+	fake.serviceBackupsReturnsOnCall[i] = struct {
+		result1 models.ServiceBackupList
 		result2 error
 	}{result1, result2}
 }
@@ -3409,6 +5708,128 @@ func (fake *FakeAPIClient) ServiceCatalogReturnsOnCall(i int, result1 *models.Se
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) ServiceCatalogCreate(arg1 *models.CatalogService) error {
+	fake.serviceCatalogCreateMutex.Lock()
+	ret, specificReturn := fake.serviceCatalogCreateReturnsOnCall[len(fake.serviceCatalogCreateArgsForCall)]
+	fake.serviceCatalogCreateArgsForCall = append(fake.serviceCatalogCreateArgsForCall, struct {
+		arg1 *models.CatalogService
+	}{arg1})
+	stub := fake.ServiceCatalogCreateStub
+	fakeReturns := fake.serviceCatalogCreateReturns
+	fake.recordInvocation("ServiceCatalogCreate", []interface{}{arg1})
+	fake.serviceCatalogCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) ServiceCatalogCreateCallCount() int {
+	fake.serviceCatalogCreateMutex.RLock()
+	defer fake.serviceCatalogCreateMutex.RUnlock()
+	return len(fake.serviceCatalogCreateArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceCatalogCreateCalls(stub func(*models.CatalogService) error) {
+	fake.serviceCatalogCreateMutex.Lock()
+	defer fake.serviceCatalogCreateMutex.Unlock()
+	fake.ServiceCatalogCreateStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceCatalogCreateArgsForCall(i int) *models.CatalogService {
+	fake.serviceCatalogCreateMutex.RLock()
+	defer fake.serviceCatalogCreateMutex.RUnlock()
+	argsForCall := fake.serviceCatalogCreateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) ServiceCatalogCreateReturns(result1 error) {
+	fake.serviceCatalogCreateMutex.Lock()
+	defer fake.serviceCatalogCreateMutex.Unlock()
+	fake.ServiceCatalogCreateStub = nil
+	fake.serviceCatalogCreateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceCatalogCreateReturnsOnCall(i int, result1 error) {
+	fake.serviceCatalogCreateMutex.Lock()
+	defer fake.serviceCatalogCreateMutex.Unlock()
+	fake.ServiceCatalogCreateStub = nil
+	if fake.serviceCatalogCreateReturnsOnCall == nil {
+		fake.serviceCatalogCreateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.serviceCatalogCreateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceCatalogDelete(arg1 string) error {
+	fake.serviceCatalogDeleteMutex.Lock()
+	ret, specificReturn := fake.serviceCatalogDeleteReturnsOnCall[len(fake.serviceCatalogDeleteArgsForCall)]
+	fake.serviceCatalogDeleteArgsForCall = append(fake.serviceCatalogDeleteArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ServiceCatalogDeleteStub
+	fakeReturns := fake.serviceCatalogDeleteReturns
+	fake.recordInvocation("ServiceCatalogDelete", []interface{}{arg1})
+	fake.serviceCatalogDeleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) ServiceCatalogDeleteCallCount() int {
+	fake.serviceCatalogDeleteMutex.RLock()
+	defer fake.serviceCatalogDeleteMutex.RUnlock()
+	return len(fake.serviceCatalogDeleteArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceCatalogDeleteCalls(stub func(string) error) {
+	fake.serviceCatalogDeleteMutex.Lock()
+	defer fake.serviceCatalogDeleteMutex.Unlock()
+	fake.ServiceCatalogDeleteStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceCatalogDeleteArgsForCall(i int) string {
+	fake.serviceCatalogDeleteMutex.RLock()
+	defer fake.serviceCatalogDeleteMutex.RUnlock()
+	argsForCall := fake.serviceCatalogDeleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAPIClient) ServiceCatalogDeleteReturns(result1 error) {
+	fake.serviceCatalogDeleteMutex.Lock()
+	defer fake.serviceCatalogDeleteMutex.Unlock()
+	fake.ServiceCatalogDeleteStub = nil
+	fake.serviceCatalogDeleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceCatalogDeleteReturnsOnCall(i int, result1 error) {
+	fake.serviceCatalogDeleteMutex.Lock()
+	defer fake.serviceCatalogDeleteMutex.Unlock()
+	fake.ServiceCatalogDeleteStub = nil
+	if fake.serviceCatalogDeleteReturnsOnCall == nil {
+		fake.serviceCatalogDeleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.serviceCatalogDeleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeAPIClient) ServiceCatalogShow(arg1 string) (*models.ServiceCatalogShowResponse, error) {
 	fake.serviceCatalogShowMutex.Lock()
 	ret, specificReturn := fake.serviceCatalogShowReturnsOnCall[len(fake.serviceCatalogShowArgsForCall)]
@@ -3473,6 +5894,68 @@ func (fake *FakeAPIClient) ServiceCatalogShowReturnsOnCall(i int, result1 *model
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) ServiceCatalogUpdate(arg1 *models.ServiceCatalogUpdateRequest, arg2 string) error {
+	fake.serviceCatalogUpdateMutex.Lock()
+	ret, specificReturn := fake.serviceCatalogUpdateReturnsOnCall[len(fake.serviceCatalogUpdateArgsForCall)]
+	fake.serviceCatalogUpdateArgsForCall = append(fake.serviceCatalogUpdateArgsForCall, struct {
+		arg1 *models.ServiceCatalogUpdateRequest
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ServiceCatalogUpdateStub
+	fakeReturns := fake.serviceCatalogUpdateReturns
+	fake.recordInvocation("ServiceCatalogUpdate", []interface{}{arg1, arg2})
+	fake.serviceCatalogUpdateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) ServiceCatalogUpdateCallCount() int {
+	fake.serviceCatalogUpdateMutex.RLock()
+	defer fake.serviceCatalogUpdateMutex.RUnlock()
+	return len(fake.serviceCatalogUpdateArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceCatalogUpdateCalls(stub func(*models.ServiceCatalogUpdateRequest, string) error) {
+	fake.serviceCatalogUpdateMutex.Lock()
+	defer fake.serviceCatalogUpdateMutex.Unlock()
+	fake.ServiceCatalogUpdateStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceCatalogUpdateArgsForCall(i int) (*models.ServiceCatalogUpdateRequest, string) {
+	fake.serviceCatalogUpdateMutex.RLock()
+	defer fake.serviceCatalogUpdateMutex.RUnlock()
+	argsForCall := fake.serviceCatalogUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) ServiceCatalogUpdateReturns(result1 error) {
+	fake.serviceCatalogUpdateMutex.Lock()
+	defer fake.serviceCatalogUpdateMutex.Unlock()
+	fake.ServiceCatalogUpdateStub = nil
+	fake.serviceCatalogUpdateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceCatalogUpdateReturnsOnCall(i int, result1 error) {
+	fake.serviceCatalogUpdateMutex.Lock()
+	defer fake.serviceCatalogUpdateMutex.Unlock()
+	fake.ServiceCatalogUpdateStub = nil
+	if fake.serviceCatalogUpdateReturnsOnCall == nil {
+		fake.serviceCatalogUpdateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.serviceCatalogUpdateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeAPIClient) ServiceCreate(arg1 *models.ServiceCreateRequest, arg2 string) error {
 	fake.serviceCreateMutex.Lock()
 	ret, specificReturn := fake.serviceCreateReturnsOnCall[len(fake.serviceCreateArgsForCall)]
@@ -3535,14 +6018,14 @@ func (fake *FakeAPIClient) ServiceCreateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeAPIClient) ServiceDelete(arg1 models.ServiceDeleteRequest, arg2 string, arg3 string, arg4 func(response *http.Response, bodyBytes []byte, err error) error) (models.ServiceDeleteResponse, error) {
+func (fake *FakeAPIClient) ServiceDelete(arg1 models.ServiceDeleteRequest, arg2 string, arg3 string, arg4 client.ErrorFunc) (models.ServiceDeleteResponse, error) {
 	fake.serviceDeleteMutex.Lock()
 	ret, specificReturn := fake.serviceDeleteReturnsOnCall[len(fake.serviceDeleteArgsForCall)]
 	fake.serviceDeleteArgsForCall = append(fake.serviceDeleteArgsForCall, struct {
 		arg1 models.ServiceDeleteRequest
 		arg2 string
 		arg3 string
-		arg4 func(response *http.Response, bodyBytes []byte, err error) error
+		arg4 client.ErrorFunc
 	}{arg1, arg2, arg3, arg4})
 	stub := fake.ServiceDeleteStub
 	fakeReturns := fake.serviceDeleteReturns
@@ -3563,13 +6046,13 @@ func (fake *FakeAPIClient) ServiceDeleteCallCount() int {
 	return len(fake.serviceDeleteArgsForCall)
 }
 
-func (fake *FakeAPIClient) ServiceDeleteCalls(stub func(models.ServiceDeleteRequest, string, string, func(response *http.Response, bodyBytes []byte, err error) error) (models.ServiceDeleteResponse, error)) {
+func (fake *FakeAPIClient) ServiceDeleteCalls(stub func(models.ServiceDeleteRequest, string, string, client.ErrorFunc) (models.ServiceDeleteResponse, error)) {
 	fake.serviceDeleteMutex.Lock()
 	defer fake.serviceDeleteMutex.Unlock()
 	fake.ServiceDeleteStub = stub
 }
 
-func (fake *FakeAPIClient) ServiceDeleteArgsForCall(i int) (models.ServiceDeleteRequest, string, string, func(response *http.Response, bodyBytes []byte, err error) error) {
+func (fake *FakeAPIClient) ServiceDeleteArgsForCall(i int) (models.ServiceDeleteRequest, string, string, client.ErrorFunc) {
 	fake.serviceDeleteMutex.RLock()
 	defer fake.serviceDeleteMutex.RUnlock()
 	argsForCall := fake.serviceDeleteArgsForCall[i]
@@ -3602,6 +6085,72 @@ func (fake *FakeAPIClient) ServiceDeleteReturnsOnCall(i int, result1 models.Serv
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) ServiceJob(arg1 string, arg2 string, arg3 string) (*models.ServiceJob, error) {
+	fake.serviceJobMutex.Lock()
+	ret, specificReturn := fake.serviceJobReturnsOnCall[len(fake.serviceJobArgsForCall)]
+	fake.serviceJobArgsForCall = append(fake.serviceJobArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ServiceJobStub
+	fakeReturns := fake.serviceJobReturns
+	fake.recordInvocation("ServiceJob", []interface{}{arg1, arg2, arg3})
+	fake.serviceJobMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ServiceJobCallCount() int {
+	fake.serviceJobMutex.RLock()
+	defer fake.serviceJobMutex.RUnlock()
+	return len(fake.serviceJobArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceJobCalls(stub func(string, string, string) (*models.ServiceJob, error)) {
+	fake.serviceJobMutex.Lock()
+	defer fake.serviceJobMutex.Unlock()
+	fake.ServiceJobStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceJobArgsForCall(i int) (string, string, string) {
+	fake.serviceJobMutex.RLock()
+	defer fake.serviceJobMutex.RUnlock()
+	argsForCall := fake.serviceJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) ServiceJobReturns(result1 *models.ServiceJob, result2 error) {
+	fake.serviceJobMutex.Lock()
+	defer fake.serviceJobMutex.Unlock()
+	fake.ServiceJobStub = nil
+	fake.serviceJobReturns = struct {
+		result1 *models.ServiceJob
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceJobReturnsOnCall(i int, result1 *models.ServiceJob, result2 error) {
+	fake.serviceJobMutex.Lock()
+	defer fake.serviceJobMutex.Unlock()
+	fake.ServiceJobStub = nil
+	if fake.serviceJobReturnsOnCall == nil {
+		fake.serviceJobReturnsOnCall = make(map[int]struct {
+			result1 *models.ServiceJob
+			result2 error
+		})
+	}
+	fake.serviceJobReturnsOnCall[i] = struct {
+		result1 *models.ServiceJob
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) ServiceList(arg1 string) (*models.ServiceListResponse, error) {
 	fake.serviceListMutex.Lock()
 	ret, specificReturn := fake.serviceListReturnsOnCall[len(fake.serviceListArgsForCall)]
@@ -3666,6 +6215,72 @@ func (fake *FakeAPIClient) ServiceListReturnsOnCall(i int, result1 *models.Servi
 	}{result1, result2}
 }
 
+func (fake *FakeAPIClient) ServiceRestore(arg1 string, arg2 string, arg3 string) (*models.ServiceJob, error) {
+	fake.serviceRestoreMutex.Lock()
+	ret, specificReturn := fake.serviceRestoreReturnsOnCall[len(fake.serviceRestoreArgsForCall)]
+	fake.serviceRestoreArgsForCall = append(fake.serviceRestoreArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ServiceRestoreStub
+	fakeReturns := fake.serviceRestoreReturns
+	fake.recordInvocation("ServiceRestore", []interface{}{arg1, arg2, arg3})
+	fake.serviceRestoreMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ServiceRestoreCallCount() int {
+	fake.serviceRestoreMutex.RLock()
+	defer fake.serviceRestoreMutex.RUnlock()
+	return len(fake.serviceRestoreArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceRestoreCalls(stub func(string, string, string) (*models.ServiceJob, error)) {
+	fake.serviceRestoreMutex.Lock()
+	defer fake.serviceRestoreMutex.Unlock()
+	fake.ServiceRestoreStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceRestoreArgsForCall(i int) (string, string, string) {
+	fake.serviceRestoreMutex.RLock()
+	defer fake.serviceRestoreMutex.RUnlock()
+	argsForCall := fake.serviceRestoreArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) ServiceRestoreReturns(result1 *models.ServiceJob, result2 error) {
+	fake.serviceRestoreMutex.Lock()
+	defer fake.serviceRestoreMutex.Unlock()
+	fake.ServiceRestoreStub = nil
+	fake.serviceRestoreReturns = struct {
+		result1 *models.ServiceJob
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceRestoreReturnsOnCall(i int, result1 *models.ServiceJob, result2 error) {
+	fake.serviceRestoreMutex.Lock()
+	defer fake.serviceRestoreMutex.Unlock()
+	fake.ServiceRestoreStub = nil
+	if fake.serviceRestoreReturnsOnCall == nil {
+		fake.serviceRestoreReturnsOnCall = make(map[int]struct {
+			result1 *models.ServiceJob
+			result2 error
+		})
+	}
+	fake.serviceRestoreReturnsOnCall[i] = struct {
+		result1 *models.ServiceJob
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) ServiceShow(arg1 *models.ServiceShowRequest, arg2 string) (*models.ServiceShowResponse, error) {
 	fake.serviceShowMutex.Lock()
 	ret, specificReturn := fake.serviceShowReturnsOnCall[len(fake.serviceShowArgsForCall)]
@@ -3794,6 +6409,134 @@ func (fake *FakeAPIClient) ServiceUnbindReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeAPIClient) ServiceUpdate(arg1 *models.ServiceUpdateRequest, arg2 string, arg3 string) error {
+	fake.serviceUpdateMutex.Lock()
+	ret, specificReturn := fake.serviceUpdateReturnsOnCall[len(fake.serviceUpdateArgsForCall)]
+	fake.serviceUpdateArgsForCall = append(fake.serviceUpdateArgsForCall, struct {
+		arg1 *models.ServiceUpdateRequest
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ServiceUpdateStub
+	fakeReturns := fake.serviceUpdateReturns
+	fake.recordInvocation("ServiceUpdate", []interface{}{arg1, arg2, arg3})
+	fake.serviceUpdateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAPIClient) ServiceUpdateCallCount() int {
+	fake.serviceUpdateMutex.RLock()
+	defer fake.serviceUpdateMutex.RUnlock()
+	return len(fake.serviceUpdateArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceUpdateCalls(stub func(*models.ServiceUpdateRequest, string, string) error) {
+	fake.serviceUpdateMutex.Lock()
+	defer fake.serviceUpdateMutex.Unlock()
+	fake.ServiceUpdateStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceUpdateArgsForCall(i int) (*models.ServiceUpdateRequest, string, string) {
+	fake.serviceUpdateMutex.RLock()
+	defer fake.serviceUpdateMutex.RUnlock()
+	argsForCall := fake.serviceUpdateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAPIClient) ServiceUpdateReturns(result1 error) {
+	fake.serviceUpdateMutex.Lock()
+	defer fake.serviceUpdateMutex.Unlock()
+	fake.ServiceUpdateStub = nil
+	fake.serviceUpdateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceUpdateReturnsOnCall(i int, result1 error) {
+	fake.serviceUpdateMutex.Lock()
+	defer fake.serviceUpdateMutex.Unlock()
+	fake.ServiceUpdateStub = nil
+	if fake.serviceUpdateReturnsOnCall == nil {
+		fake.serviceUpdateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.serviceUpdateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAPIClient) ServiceUpgrade(arg1 string, arg2 string) (*models.ServiceUpgradeResponse, error) {
+	fake.serviceUpgradeMutex.Lock()
+	ret, specificReturn := fake.serviceUpgradeReturnsOnCall[len(fake.serviceUpgradeArgsForCall)]
+	fake.serviceUpgradeArgsForCall = append(fake.serviceUpgradeArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ServiceUpgradeStub
+	fakeReturns := fake.serviceUpgradeReturns
+	fake.recordInvocation("ServiceUpgrade", []interface{}{arg1, arg2})
+	fake.serviceUpgradeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAPIClient) ServiceUpgradeCallCount() int {
+	fake.serviceUpgradeMutex.RLock()
+	defer fake.serviceUpgradeMutex.RUnlock()
+	return len(fake.serviceUpgradeArgsForCall)
+}
+
+func (fake *FakeAPIClient) ServiceUpgradeCalls(stub func(string, string) (*models.ServiceUpgradeResponse, error)) {
+	fake.serviceUpgradeMutex.Lock()
+	defer fake.serviceUpgradeMutex.Unlock()
+	fake.ServiceUpgradeStub = stub
+}
+
+func (fake *FakeAPIClient) ServiceUpgradeArgsForCall(i int) (string, string) {
+	fake.serviceUpgradeMutex.RLock()
+	defer fake.serviceUpgradeMutex.RUnlock()
+	argsForCall := fake.serviceUpgradeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAPIClient) ServiceUpgradeReturns(result1 *models.ServiceUpgradeResponse, result2 error) {
+	fake.serviceUpgradeMutex.Lock()
+	defer fake.serviceUpgradeMutex.Unlock()
+	fake.ServiceUpgradeStub = nil
+	fake.serviceUpgradeReturns = struct {
+		result1 *models.ServiceUpgradeResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAPIClient) ServiceUpgradeReturnsOnCall(i int, result1 *models.ServiceUpgradeResponse, result2 error) {
+	fake.serviceUpgradeMutex.Lock()
+	defer fake.serviceUpgradeMutex.Unlock()
+	fake.ServiceUpgradeStub = nil
+	if fake.serviceUpgradeReturnsOnCall == nil {
+		fake.serviceUpgradeReturnsOnCall = make(map[int]struct {
+			result1 *models.ServiceUpgradeResponse
+			result2 error
+		})
+	}
+	fake.serviceUpgradeReturnsOnCall[i] = struct {
+		result1 *models.ServiceUpgradeResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeAPIClient) StagingComplete(arg1 string, arg2 string) (models.Response, error) {
 	fake.stagingCompleteMutex.Lock()
 	ret, specificReturn := fake.stagingCompleteReturnsOnCall[len(fake.stagingCompleteArgsForCall)]
@@ -3868,6 +6611,10 @@ func (fake *FakeAPIClient) Invocations() map[string][][]interface{} {
 	defer fake.allConfigurationsMutex.RUnlock()
 	fake.allServicesMutex.RLock()
 	defer fake.allServicesMutex.RUnlock()
+	fake.appCacheClearMutex.RLock()
+	defer fake.appCacheClearMutex.RUnlock()
+	fake.appCacheShowMutex.RLock()
+	defer fake.appCacheShowMutex.RUnlock()
 	fake.appCreateMutex.RLock()
 	defer fake.appCreateMutex.RUnlock()
 	fake.appDeleteMutex.RLock()
@@ -3884,10 +6631,14 @@ func (fake *FakeAPIClient) Invocations() map[string][][]interface{} {
 	defer fake.appLogsMutex.RUnlock()
 	fake.appPortForwardMutex.RLock()
 	defer fake.appPortForwardMutex.RUnlock()
+	fake.appPromoteMutex.RLock()
+	defer fake.appPromoteMutex.RUnlock()
 	fake.appRestartMutex.RLock()
 	defer fake.appRestartMutex.RUnlock()
 	fake.appRunningMutex.RLock()
 	defer fake.appRunningMutex.RUnlock()
+	fake.appSBOMMutex.RLock()
+	defer fake.appSBOMMutex.RUnlock()
 	fake.appShowMutex.RLock()
 	defer fake.appShowMutex.RUnlock()
 	fake.appStageMutex.RLock()
@@ -3898,8 +6649,20 @@ func (fake *FakeAPIClient) Invocations() map[string][][]interface{} {
 	defer fake.appUploadMutex.RUnlock()
 	fake.appsMutex.RLock()
 	defer fake.appsMutex.RUnlock()
+	fake.appsRestartMutex.RLock()
+	defer fake.appsRestartMutex.RUnlock()
 	fake.authTokenMutex.RLock()
 	defer fake.authTokenMutex.RUnlock()
+	fake.blobsMutex.RLock()
+	defer fake.blobsMutex.RUnlock()
+	fake.blobsGCMutex.RLock()
+	defer fake.blobsGCMutex.RUnlock()
+	fake.builderDeleteMutex.RLock()
+	defer fake.builderDeleteMutex.RUnlock()
+	fake.builderSetMutex.RLock()
+	defer fake.builderSetMutex.RUnlock()
+	fake.buildersMutex.RLock()
+	defer fake.buildersMutex.RUnlock()
 	fake.chartListMutex.RLock()
 	defer fake.chartListMutex.RUnlock()
 	fake.chartMatchMutex.RLock()
@@ -3916,6 +6679,10 @@ func (fake *FakeAPIClient) Invocations() map[string][][]interface{} {
 	defer fake.configurationCreateMutex.RUnlock()
 	fake.configurationDeleteMutex.RLock()
 	defer fake.configurationDeleteMutex.RUnlock()
+	fake.configurationHistoryMutex.RLock()
+	defer fake.configurationHistoryMutex.RUnlock()
+	fake.configurationRevertMutex.RLock()
+	defer fake.configurationRevertMutex.RUnlock()
 	fake.configurationShowMutex.RLock()
 	defer fake.configurationShowMutex.RUnlock()
 	fake.configurationUpdateMutex.RLock()
@@ -3932,34 +6699,80 @@ func (fake *FakeAPIClient) Invocations() map[string][][]interface{} {
 	defer fake.envShowMutex.RUnlock()
 	fake.envUnsetMutex.RLock()
 	defer fake.envUnsetMutex.RUnlock()
+	fake.imagesMutex.RLock()
+	defer fake.imagesMutex.RUnlock()
+	fake.imagesGCMutex.RLock()
+	defer fake.imagesGCMutex.RUnlock()
 	fake.infoMutex.RLock()
 	defer fake.infoMutex.RUnlock()
+	fake.namespaceBindMutex.RLock()
+	defer fake.namespaceBindMutex.RUnlock()
+	fake.namespaceBuilderUpdateMutex.RLock()
+	defer fake.namespaceBuilderUpdateMutex.RUnlock()
+	fake.namespaceCloneMutex.RLock()
+	defer fake.namespaceCloneMutex.RUnlock()
 	fake.namespaceCreateMutex.RLock()
 	defer fake.namespaceCreateMutex.RUnlock()
 	fake.namespaceDeleteMutex.RLock()
 	defer fake.namespaceDeleteMutex.RUnlock()
+	fake.namespaceDeletionPreviewMutex.RLock()
+	defer fake.namespaceDeletionPreviewMutex.RUnlock()
+	fake.namespaceEnvListMutex.RLock()
+	defer fake.namespaceEnvListMutex.RUnlock()
+	fake.namespaceEnvSetMutex.RLock()
+	defer fake.namespaceEnvSetMutex.RUnlock()
+	fake.namespaceEnvUnsetMutex.RLock()
+	defer fake.namespaceEnvUnsetMutex.RUnlock()
+	fake.namespaceQuotaUpdateMutex.RLock()
+	defer fake.namespaceQuotaUpdateMutex.RUnlock()
 	fake.namespaceShowMutex.RLock()
 	defer fake.namespaceShowMutex.RUnlock()
+	fake.namespaceSoftDeleteMutex.RLock()
+	defer fake.namespaceSoftDeleteMutex.RUnlock()
+	fake.namespaceUnbindMutex.RLock()
+	defer fake.namespaceUnbindMutex.RUnlock()
+	fake.namespaceUndeleteMutex.RLock()
+	defer fake.namespaceUndeleteMutex.RUnlock()
+	fake.namespaceUpdateMutex.RLock()
+	defer fake.namespaceUpdateMutex.RUnlock()
 	fake.namespacesMutex.RLock()
 	defer fake.namespacesMutex.RUnlock()
 	fake.namespacesMatchMutex.RLock()
 	defer fake.namespacesMatchMutex.RUnlock()
+	fake.serviceBackupMutex.RLock()
+	defer fake.serviceBackupMutex.RUnlock()
+	fake.serviceBackupsMutex.RLock()
+	defer fake.serviceBackupsMutex.RUnlock()
 	fake.serviceBindMutex.RLock()
 	defer fake.serviceBindMutex.RUnlock()
 	fake.serviceCatalogMutex.RLock()
 	defer fake.serviceCatalogMutex.RUnlock()
+	fake.serviceCatalogCreateMutex.RLock()
+	defer fake.serviceCatalogCreateMutex.RUnlock()
+	fake.serviceCatalogDeleteMutex.RLock()
+	defer fake.serviceCatalogDeleteMutex.RUnlock()
 	fake.serviceCatalogShowMutex.RLock()
 	defer fake.serviceCatalogShowMutex.RUnlock()
+	fake.serviceCatalogUpdateMutex.RLock()
+	defer fake.serviceCatalogUpdateMutex.RUnlock()
 	fake.serviceCreateMutex.RLock()
 	defer fake.serviceCreateMutex.RUnlock()
 	fake.serviceDeleteMutex.RLock()
 	defer fake.serviceDeleteMutex.RUnlock()
+	fake.serviceJobMutex.RLock()
+	defer fake.serviceJobMutex.RUnlock()
 	fake.serviceListMutex.RLock()
 	defer fake.serviceListMutex.RUnlock()
+	fake.serviceRestoreMutex.RLock()
+	defer fake.serviceRestoreMutex.RUnlock()
 	fake.serviceShowMutex.RLock()
 	defer fake.serviceShowMutex.RUnlock()
 	fake.serviceUnbindMutex.RLock()
 	defer fake.serviceUnbindMutex.RUnlock()
+	fake.serviceUpdateMutex.RLock()
+	defer fake.serviceUpdateMutex.RUnlock()
+	fake.serviceUpgradeMutex.RLock()
+	defer fake.serviceUpgradeMutex.RUnlock()
 	fake.stagingCompleteMutex.RLock()
 	defer fake.stagingCompleteMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
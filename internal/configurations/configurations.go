@@ -11,7 +11,7 @@ package configurations
 import (
 	"context"
 	"errors"
-	"reflect"
+	"unicode/utf8"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	epinioerrors "github.com/epinio/epinio/internal/errors"
@@ -38,6 +38,7 @@ type Configuration struct {
 	Name       string
 	Namespace  string
 	Username   string
+	Type       string
 	CreatedAt  metav1.Time
 	kubeClient *kubernetes.Cluster
 }
@@ -56,6 +57,7 @@ func Lookup(ctx context.Context, kubeClient *kubernetes.Cluster, namespace, conf
 		return nil, err
 	}
 	c.Username = s.ObjectMeta.Labels["app.kubernetes.io/created-by"]
+	c.Type = s.ObjectMeta.Labels[ConfigurationTypeLabelKey]
 	c.CreatedAt = s.ObjectMeta.CreationTimestamp
 
 	return c, nil
@@ -100,6 +102,7 @@ func List(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (C
 			Name:       name,
 			Namespace:  namespace,
 			Username:   username,
+			Type:       s.ObjectMeta.Labels[ConfigurationTypeLabelKey],
 			kubeClient: cluster,
 		})
 	}
@@ -108,25 +111,19 @@ func List(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (C
 }
 
 // CreateConfiguration creates a new  configuration instance from namespace,
-// name, and a map of parameters.
-func CreateConfiguration(ctx context.Context, cluster *kubernetes.Cluster, name, namespace, username string,
-	data map[string]string) (*Configuration, error) {
+// name, type, and a map of parameters. The data is expected to be valid for the type, see
+// ValidateType.
+func CreateConfiguration(ctx context.Context, cluster *kubernetes.Cluster, name, namespace, username, configurationType string,
+	sdata map[string][]byte) (*Configuration, error) {
 
 	_, err := cluster.GetSecret(ctx, namespace, name)
 	if err == nil {
 		return nil, errors.New("a secret for this configuration already exists")
 	}
 
-	// Convert from `string -> string` to the `string -> []byte` expected
-	// by kube.
-	sdata := make(map[string][]byte)
-	for k, v := range data {
-		sdata[k] = []byte(v)
-	}
-
 	labels := map[string]string{
 		ConfigurationLabelKey:          "true",
-		ConfigurationTypeLabelKey:      "custom",
+		ConfigurationTypeLabelKey:      configurationType,
 		"app.kubernetes.io/created-by": username,
 		"app.kubernetes.io/name":       "epinio",
 		// "app.kubernetes.io/version":     cmd.Version
@@ -139,37 +136,81 @@ func CreateConfiguration(ctx context.Context, cluster *kubernetes.Cluster, name,
 		return nil, err
 	}
 
-	return &Configuration{
+	configuration := &Configuration{
 		Name:       name,
 		Namespace:  namespace,
+		Username:   username,
+		Type:       configurationType,
 		kubeClient: cluster,
-	}, nil
+	}
+
+	err = recordRevision(ctx, cluster, configuration, username, nil, sdata, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return configuration, nil
 }
 
 // UpdateConfiguration modifies an existing configuration as per the instructions and writes
-// the result back to the resource.
-func UpdateConfiguration(ctx context.Context, cluster *kubernetes.Cluster, configuration *Configuration, changes models.ConfigurationUpdateRequest) error {
-	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+// the result back to the resource. The change is recorded as a new revision. The result
+// has to satisfy the type of the configuration, see ValidateType.
+func UpdateConfiguration(ctx context.Context, cluster *kubernetes.Cluster, configuration *Configuration, username string, changes models.ConfigurationUpdateRequest) error {
+	var oldData, newData map[string][]byte
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		secret, err := configuration.GetSecret(ctx)
 		if err != nil {
 			return err
 		}
 
+		oldData = map[string][]byte{}
+		for key, value := range secret.Data {
+			oldData[key] = value
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
 		for _, remove := range changes.Remove {
 			delete(secret.Data, remove)
 		}
 		for key, value := range changes.Set {
 			secret.Data[key] = []byte(value)
 		}
+		secret.Data, err = validateData(configuration, secret.Data)
+		if err != nil {
+			return err
+		}
+		newData = secret.Data
 
 		_, err = cluster.Kubectl.CoreV1().Secrets(configuration.Namespace).Update(
 			ctx, secret, metav1.UpdateOptions{})
 		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	if sameData(oldData, newData) {
+		return nil
+	}
+
+	return recordRevision(ctx, cluster, configuration, username, oldData, newData, 0)
 }
 
-// ReplaceConfiguration replaces an existing configuration
-func ReplaceConfiguration(ctx context.Context, cluster *kubernetes.Cluster, configuration *Configuration, data map[string]string) (bool, error) {
+// ReplaceConfiguration replaces an existing configuration. The change is recorded as a new
+// revision. The result is false when nothing changed. The new data has to satisfy the type
+// of the configuration, see ValidateType.
+func ReplaceConfiguration(ctx context.Context, cluster *kubernetes.Cluster, configuration *Configuration, username string, data map[string]string) (bool, error) {
+	return replaceConfiguration(ctx, cluster, configuration, username, data, 0)
+}
+
+// replaceConfiguration implements ReplaceConfiguration and Revert. The revertedFrom
+// argument is the revision the data was taken from, if any.
+func replaceConfiguration(ctx context.Context, cluster *kubernetes.Cluster, configuration *Configuration,
+	username string, data map[string]string, revertedFrom int) (bool, error) {
+
 	secret, err := configuration.GetSecret(ctx)
 	if err != nil {
 		return false, err
@@ -181,7 +222,11 @@ func ReplaceConfiguration(ctx context.Context, cluster *kubernetes.Cluster, conf
 	for k, v := range data {
 		secret.Data[k] = []byte(v)
 	}
-	if reflect.DeepEqual(oldData, secret.Data) {
+	secret.Data, err = validateData(configuration, secret.Data)
+	if err != nil {
+		return false, err
+	}
+	if sameData(oldData, secret.Data) {
 		return false, nil
 	}
 
@@ -194,6 +239,11 @@ func ReplaceConfiguration(ctx context.Context, cluster *kubernetes.Cluster, conf
 		return false, err
 	}
 
+	err = recordRevision(ctx, cluster, configuration, username, oldData, secret.Data, revertedFrom)
+	if err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
@@ -225,7 +275,7 @@ func ForService(ctx context.Context, kubeClient *kubernetes.Cluster, namespace,
 		"app.kubernetes.io/managed-by": "Helm",
 		"app.kubernetes.io/instance":   names.ServiceHelmChartName(name, namespace),
 		ConfigurationLabelKey:          "true",
-		ConfigurationTypeLabelKey:      "service",
+		ConfigurationTypeLabelKey:      models.ConfigurationTypeService,
 	}).AsSelector()
 
 	listOptions := metav1.ListOptions{
@@ -241,9 +291,10 @@ func ForService(ctx context.Context, kubeClient *kubernetes.Cluster, namespace,
 	return secretList.Items, nil
 }
 
-// LabelServiceSecrets will look for the Opaque secrets released with a service, looking for the
-// app.kubernetes.io/instance label, then it will add the Configuration labels to "create" the configurations
-func LabelServiceSecrets(ctx context.Context, kubeClient *kubernetes.Cluster, namespace, name string) ([]v1.Secret, error) {
+// ServiceSecrets returns the Opaque secrets released with a service, looking for the
+// app.kubernetes.io/instance label. Contrary to ForService this includes the secrets not
+// turned into configurations yet.
+func ServiceSecrets(ctx context.Context, kubeClient *kubernetes.Cluster, namespace, name string) ([]v1.Secret, error) {
 	secretSelector := labels.Set(map[string]string{
 		"app.kubernetes.io/managed-by": "Helm",
 		"app.kubernetes.io/instance":   names.ServiceHelmChartName(name, namespace),
@@ -260,41 +311,62 @@ func LabelServiceSecrets(ctx context.Context, kubeClient *kubernetes.Cluster, na
 		return nil, err
 	}
 
-	for _, secret := range secretList.Items {
+	return secretList.Items, nil
+}
+
+// IsConfiguration returns true if the secret carries the Configuration labels already.
+func IsConfiguration(secret v1.Secret) bool {
+	return secret.GetLabels()[ConfigurationLabelKey] == "true"
+}
+
+// LabelServiceSecrets adds the Configuration labels to the secrets of a service, see
+// ServiceSecrets, to "create" the configurations
+func LabelServiceSecrets(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string, secrets []v1.Secret) error {
+	for _, secret := range secrets {
 		sec := secret
 
 		// set labels without override the old ones
 		sec.GetLabels()[ConfigurationLabelKey] = "true"
-		sec.GetLabels()[ConfigurationTypeLabelKey] = "service"
+		sec.GetLabels()[ConfigurationTypeLabelKey] = models.ConfigurationTypeService
 
-		_, err = kubeClient.Kubectl.CoreV1().Secrets(namespace).Update(ctx, &sec, metav1.UpdateOptions{})
+		_, err := kubeClient.Kubectl.CoreV1().Secrets(namespace).Update(ctx, &sec, metav1.UpdateOptions{})
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return secretList.Items, nil
+	return nil
 }
 
 // Delete destroys the configuration instance, i.e. its underlying secret
 // holding the instance's parameters
 func (s *Configuration) Delete(ctx context.Context) error {
+	err := deleteRevisions(ctx, s.kubeClient, s)
+	if err != nil {
+		return err
+	}
+
 	return s.kubeClient.DeleteSecret(ctx, s.Namespace, s.Name)
 }
 
 // Details returns the configuration instance's configuration.
-// I.e. the parameter data.
-func (s *Configuration) Details(ctx context.Context) (map[string]string, error) {
+// I.e. the parameter data. Values which are not text are returned separately.
+func (s *Configuration) Details(ctx context.Context) (map[string]string, map[string][]byte, error) {
 	secret, err := s.GetSecret(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	details := map[string]string{}
+	binaryDetails := map[string][]byte{}
 
 	for k, v := range secret.Data {
-		details[k] = string(v)
+		if utf8.Valid(v) {
+			details[k] = string(v)
+		} else {
+			binaryDetails[k] = v
+		}
 	}
 
-	return details, nil
+	return details, binaryDetails, nil
 }
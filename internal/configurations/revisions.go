@@ -0,0 +1,393 @@
+package configurations
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// RevisionOfLabelKey names the configuration a revision secret belongs to
+	RevisionOfLabelKey = "epinio.suse.org/configuration-revision-of"
+	// RevisionLabelKey holds the number of a revision
+	RevisionLabelKey = "epinio.suse.org/configuration-revision"
+
+	revisionAddedAnnotation    = "epinio.suse.org/revision-added"
+	revisionModifiedAnnotation = "epinio.suse.org/revision-modified"
+	revisionRemovedAnnotation  = "epinio.suse.org/revision-removed"
+	revisionRevertAnnotation   = "epinio.suse.org/revision-reverted-from"
+
+	// revisionKeySecretName is the secret in the epinio namespace holding the key the
+	// values of all revisions are encrypted with. It is created on first use.
+	revisionKeySecretName = "epinio-configuration-revisions-key" // nolint:gosec // Not credentials
+
+	// MaxRevisions is the number of revisions kept per configuration. Older
+	// revisions are removed when new ones are made.
+	MaxRevisions = 20
+)
+
+// Changes compares the old and new data of a configuration and returns the keys which were
+// added, modified, and removed, each sorted by name.
+func Changes(old, new map[string][]byte) ([]string, []string, []string) {
+	added := []string{}
+	modified := []string{}
+	removed := []string{}
+
+	for key, value := range new {
+		oldValue, ok := old[key]
+		if !ok {
+			added = append(added, key)
+		} else if string(oldValue) != string(value) {
+			modified = append(modified, key)
+		}
+	}
+	for key := range old {
+		if _, ok := new[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(modified)
+	sort.Strings(removed)
+
+	return added, modified, removed
+}
+
+// History returns the revisions of the configuration, newest first. A configuration which
+// was not changed since revisions were introduced has no history.
+func History(ctx context.Context, cluster *kubernetes.Cluster, configuration *Configuration) (models.ConfigurationHistoryResponse, error) {
+	secrets, err := revisionSecrets(ctx, cluster, configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	current := 0
+	if len(secrets) > 0 {
+		current = revisionNumber(secrets[0])
+	}
+
+	result := models.ConfigurationHistoryResponse{}
+	for _, secret := range secrets {
+		result = append(result, revisionOf(secret, current))
+	}
+
+	return result, nil
+}
+
+// Revert replaces the data of the configuration with the data of the specified revision,
+// recording this as a new revision. The result is false when nothing changed.
+func Revert(ctx context.Context, cluster *kubernetes.Cluster, configuration *Configuration, username string, revision int) (bool, error) {
+	secrets, err := revisionSecrets(ctx, cluster, configuration)
+	if err != nil {
+		return false, err
+	}
+
+	var target *v1.Secret
+	for i := range secrets {
+		if revisionNumber(secrets[i]) == revision {
+			target = &secrets[i]
+			break
+		}
+	}
+	if target == nil {
+		return false, RevisionNotFoundError{Configuration: configuration.Name, Revision: revision}
+	}
+
+	key, err := revisionKey(ctx, cluster)
+	if err != nil {
+		return false, err
+	}
+
+	data := map[string]string{}
+	for name, sealed := range target.Data {
+		value, err := unseal(key, sealed)
+		if err != nil {
+			return false, errors.Wrapf(err, "decrypting key %s of revision %d", name, revision)
+		}
+		data[name] = string(value)
+	}
+
+	return replaceConfiguration(ctx, cluster, configuration, username, data, revision)
+}
+
+// RevisionNotFoundError is returned by Revert for unknown revisions.
+type RevisionNotFoundError struct {
+	Configuration string
+	Revision      int
+}
+
+func (e RevisionNotFoundError) Error() string {
+	return fmt.Sprintf("configuration %s has no revision %d", e.Configuration, e.Revision)
+}
+
+// recordRevision stores the new data of the configuration as a new revision, with the
+// changes relative to the old data. A configuration without revisions first gets the old
+// data recorded as its initial revision, so that the change can be reverted. The
+// revertedFrom argument is the revision the new data was taken from, if any.
+func recordRevision(ctx context.Context, cluster *kubernetes.Cluster, configuration *Configuration,
+	username string, old, new map[string][]byte, revertedFrom int) error {
+
+	secrets, err := revisionSecrets(ctx, cluster, configuration)
+	if err != nil {
+		return err
+	}
+
+	key, err := revisionKey(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	next := 1
+	if len(secrets) > 0 {
+		next = revisionNumber(secrets[0]) + 1
+	} else if old != nil {
+		err := createRevision(ctx, cluster, key, configuration, configuration.Username, next, nil, old, 0)
+		if err != nil {
+			return err
+		}
+		next++
+	}
+
+	err = createRevision(ctx, cluster, key, configuration, username, next, old, new, revertedFrom)
+	if err != nil {
+		return err
+	}
+
+	// Prune the oldest revisions beyond the limit.
+	secrets, err = revisionSecrets(ctx, cluster, configuration)
+	if err != nil {
+		return err
+	}
+	for i := MaxRevisions; i < len(secrets); i++ {
+		err := cluster.DeleteSecret(ctx, configuration.Namespace, secrets[i].Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createRevision saves the new data of the configuration as the specified revision.
+func createRevision(ctx context.Context, cluster *kubernetes.Cluster, key []byte, configuration *Configuration,
+	username string, revision int, old, new map[string][]byte, revertedFrom int) error {
+
+	sealed := map[string][]byte{}
+	for name, value := range new {
+		s, err := seal(key, value)
+		if err != nil {
+			return errors.Wrapf(err, "encrypting key %s", name)
+		}
+		sealed[name] = s
+	}
+
+	added, modified, removed := Changes(old, new)
+
+	annotations := map[string]string{
+		revisionAddedAnnotation:    strings.Join(added, ","),
+		revisionModifiedAnnotation: strings.Join(modified, ","),
+		revisionRemovedAnnotation:  strings.Join(removed, ","),
+	}
+	if revertedFrom > 0 {
+		annotations[revisionRevertAnnotation] = strconv.Itoa(revertedFrom)
+	}
+
+	immutable := true
+	_, err := cluster.Kubectl.CoreV1().Secrets(configuration.Namespace).Create(ctx, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: names.GenerateResourceName("cr", configuration.Name, strconv.Itoa(revision)),
+			Labels: map[string]string{
+				RevisionOfLabelKey:             revisionOfValue(configuration.Name),
+				RevisionLabelKey:               strconv.Itoa(revision),
+				"app.kubernetes.io/created-by": username,
+				"app.kubernetes.io/name":       "epinio",
+			},
+			Annotations: annotations,
+		},
+		Data:      sealed,
+		Immutable: &immutable,
+		Type:      v1.SecretTypeOpaque,
+	}, metav1.CreateOptions{})
+
+	return errors.Wrapf(err, "saving revision %d of configuration %s", revision, configuration.Name)
+}
+
+// revisionSecrets returns the secrets holding the revisions of the configuration, newest first.
+func revisionSecrets(ctx context.Context, cluster *kubernetes.Cluster, configuration *Configuration) ([]v1.Secret, error) {
+	selector := labels.Set(map[string]string{
+		RevisionOfLabelKey: revisionOfValue(configuration.Name),
+	}).AsSelector()
+
+	secrets, err := cluster.Kubectl.CoreV1().Secrets(configuration.Namespace).List(ctx,
+		metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing configuration revisions")
+	}
+
+	result := secrets.Items
+	sort.Slice(result, func(i, j int) bool {
+		return revisionNumber(result[i]) > revisionNumber(result[j])
+	})
+
+	return result, nil
+}
+
+// deleteRevisions removes all revisions of the configuration
+func deleteRevisions(ctx context.Context, cluster *kubernetes.Cluster, configuration *Configuration) error {
+	secrets, err := revisionSecrets(ctx, cluster, configuration)
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secrets {
+		err := cluster.DeleteSecret(ctx, configuration.Namespace, secret.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// revisionOfValue returns the value of the RevisionOfLabelKey label for the named
+// configuration. Names too long for a label value are replaced by a hash.
+func revisionOfValue(name string) string {
+	if len(name) <= validation.LabelValueMaxLength {
+		return name
+	}
+	return names.GenerateResourceName(name)
+}
+
+func revisionNumber(secret v1.Secret) int {
+	number, err := strconv.Atoi(secret.Labels[RevisionLabelKey])
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// revisionOf converts a revision secret into its API description. The values are not
+// part of it.
+func revisionOf(secret v1.Secret, current int) models.ConfigurationRevision {
+	split := func(annotation string) []string {
+		value := secret.Annotations[annotation]
+		if value == "" {
+			return nil
+		}
+		return strings.Split(value, ",")
+	}
+
+	revertedFrom, _ := strconv.Atoi(secret.Annotations[revisionRevertAnnotation])
+
+	return models.ConfigurationRevision{
+		Revision:     revisionNumber(secret),
+		Username:     secret.Labels["app.kubernetes.io/created-by"],
+		CreatedAt:    secret.CreationTimestamp,
+		Current:      revisionNumber(secret) == current,
+		Added:        split(revisionAddedAnnotation),
+		Modified:     split(revisionModifiedAnnotation),
+		Removed:      split(revisionRemovedAnnotation),
+		RevertedFrom: revertedFrom,
+	}
+}
+
+// revisionKey returns the key the values of revisions are encrypted with, creating it if
+// it does not exist yet.
+func revisionKey(ctx context.Context, cluster *kubernetes.Cluster) ([]byte, error) {
+	secrets := cluster.Kubectl.CoreV1().Secrets(helmchart.Namespace())
+
+	secret, err := secrets.Get(ctx, revisionKeySecretName, metav1.GetOptions{})
+	if err == nil {
+		return secret.Data["key"], nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, errors.Wrap(err, "reading the revision key")
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Wrap(err, "generating the revision key")
+	}
+
+	_, err = secrets.Create(ctx, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: revisionKeySecretName,
+		},
+		Data: map[string][]byte{"key": key},
+		Type: v1.SecretTypeOpaque,
+	}, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		// Lost a race with a concurrent change. Use the winner's key.
+		return revisionKey(ctx, cluster)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "saving the revision key")
+	}
+
+	return key, nil
+}
+
+// seal encrypts the value with AES-GCM. The result is the nonce followed by the ciphertext.
+func seal(key, value []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, value, nil), nil
+}
+
+// unseal decrypts a value encrypted by seal.
+func unseal(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("encrypted value too short")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sameData compares configuration data, treating nil and empty as equal.
+func sameData(old, new map[string][]byte) bool {
+	if len(old) == 0 && len(new) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(old, new)
+}
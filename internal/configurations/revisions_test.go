@@ -0,0 +1,81 @@
+package configurations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Configuration revisions", func() {
+	Describe("Changes", func() {
+		It("reports added, modified, and removed keys", func() {
+			added, modified, removed := Changes(
+				map[string][]byte{"user": []byte("admin"), "password": []byte("old"), "host": []byte("db")},
+				map[string][]byte{"user": []byte("admin"), "password": []byte("new"), "port": []byte("5432")},
+			)
+
+			Expect(added).To(Equal([]string{"port"}))
+			Expect(modified).To(Equal([]string{"password"}))
+			Expect(removed).To(Equal([]string{"host"}))
+		})
+
+		It("reports all keys of new data as added", func() {
+			added, modified, removed := Changes(nil, map[string][]byte{"b": nil, "a": nil})
+
+			Expect(added).To(Equal([]string{"a", "b"}))
+			Expect(modified).To(BeEmpty())
+			Expect(removed).To(BeEmpty())
+		})
+	})
+
+	Describe("value encryption", func() {
+		key := []byte("0123456789abcdef0123456789abcdef")
+
+		It("round-trips values", func() {
+			sealed, err := seal(key, []byte("secret"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(sealed)).ToNot(ContainSubstring("secret"))
+
+			value, err := unseal(key, sealed)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(value)).To(Equal("secret"))
+		})
+
+		It("rejects values sealed with a different key", func() {
+			sealed, err := seal(key, []byte("secret"))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = unseal([]byte("fedcba9876543210fedcba9876543210"), sealed)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("revisionOf", func() {
+		It("describes a revision secret", func() {
+			secret := v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						RevisionLabelKey:               "3",
+						"app.kubernetes.io/created-by": "admin",
+					},
+					Annotations: map[string]string{
+						revisionAddedAnnotation:    "",
+						revisionModifiedAnnotation: "password,user",
+						revisionRevertAnnotation:   "1",
+					},
+				},
+			}
+
+			revision := revisionOf(secret, 3)
+			Expect(revision.Revision).To(Equal(3))
+			Expect(revision.Current).To(BeTrue())
+			Expect(revision.Username).To(Equal("admin"))
+			Expect(revision.Added).To(BeEmpty())
+			Expect(revision.Modified).To(Equal([]string{"password", "user"}))
+			Expect(revision.RevertedFrom).To(Equal(1))
+
+			Expect(revisionOf(secret, 4).Current).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,129 @@
+package configurations
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+)
+
+// typeKeys lists the keys required by the typed configurations.
+var typeKeys = map[string][]string{
+	models.ConfigurationTypeCustom:         {},
+	models.ConfigurationTypeTLS:            {"tls.crt", "tls.key"},
+	models.ConfigurationTypeDockerRegistry: {"server", "username", "password"},
+	models.ConfigurationTypeBasicAuth:      {"username", "password"},
+}
+
+// ValidTypes returns the names of the configuration types users can create, sorted.
+func ValidTypes() []string {
+	result := []string{}
+	for name := range typeKeys {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ValidateType checks the data of a configuration against the requirements of its type,
+// and returns the data to store. For most types this is the data as is. Docker registry
+// configurations additionally get a `.dockerconfigjson` key, in the format expected by
+// image pull secrets.
+func ValidateType(configurationType string, data map[string][]byte) (map[string][]byte, error) {
+	required, ok := typeKeys[configurationType]
+	if !ok {
+		return nil, fmt.Errorf("unknown configuration type '%s', expected one of %s",
+			configurationType, strings.Join(ValidTypes(), ", "))
+	}
+
+	missing := []string{}
+	for _, key := range required {
+		if len(data[key]) == 0 {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("configuration of type '%s' is missing keys: %s",
+			configurationType, strings.Join(missing, ", "))
+	}
+
+	switch configurationType {
+	case models.ConfigurationTypeTLS:
+		if _, err := tls.X509KeyPair(data["tls.crt"], data["tls.key"]); err != nil {
+			return nil, errors.Wrap(err, "invalid tls keypair")
+		}
+	case models.ConfigurationTypeDockerRegistry:
+		dockerConfig, err := dockerConfigJSON(data)
+		if err != nil {
+			return nil, err
+		}
+
+		result := map[string][]byte{}
+		for key, value := range data {
+			result[key] = value
+		}
+		result[".dockerconfigjson"] = dockerConfig
+		return result, nil
+	}
+
+	return data, nil
+}
+
+// validateData is the helper for the functions writing the data of a configuration. It
+// applies ValidateType for the type of the configuration, wrapping problems into a
+// TypeError. Configurations without type, i.e. created before types existed, are custom.
+// Service configurations are managed by their service, and are not checked.
+func validateData(configuration *Configuration, data map[string][]byte) (map[string][]byte, error) {
+	configurationType := configuration.Type
+	switch configurationType {
+	case models.ConfigurationTypeService:
+		return data, nil
+	case "":
+		configurationType = models.ConfigurationTypeCustom
+	}
+
+	result, err := ValidateType(configurationType, data)
+	if err != nil {
+		return nil, TypeError{Configuration: configuration.Name, Err: err}
+	}
+
+	return result, nil
+}
+
+// TypeError is returned by the functions writing the data of a configuration, when the
+// data does not satisfy the requirements of the configuration's type.
+type TypeError struct {
+	Configuration string
+	Err           error
+}
+
+func (e TypeError) Error() string {
+	return fmt.Sprintf("configuration %s: %s", e.Configuration, e.Err.Error())
+}
+
+// dockerConfigJSON generates the `.dockerconfigjson` for the registry credentials.
+func dockerConfigJSON(data map[string][]byte) ([]byte, error) {
+	username := string(data["username"])
+	password := string(data["password"])
+
+	entry := map[string]string{
+		"username": username,
+		"password": password,
+		"auth":     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+	}
+	if email, ok := data["email"]; ok {
+		entry["email"] = string(email)
+	}
+
+	result, err := json.Marshal(map[string]interface{}{
+		"auths": map[string]interface{}{
+			string(data["server"]): entry,
+		},
+	})
+	return result, errors.Wrap(err, "encoding docker config")
+}
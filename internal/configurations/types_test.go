@@ -0,0 +1,134 @@
+package configurations
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Configuration types", func() {
+	It("accepts any data for custom configurations", func() {
+		data := map[string][]byte{"anything": {0xff, 0x00}}
+
+		result, err := ValidateType(models.ConfigurationTypeCustom, data)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(data))
+	})
+
+	It("rejects unknown types", func() {
+		_, err := ValidateType("bogus", map[string][]byte{"a": []byte("b")})
+		Expect(err).To(MatchError(ContainSubstring("unknown configuration type 'bogus'")))
+	})
+
+	It("reports the missing keys", func() {
+		_, err := ValidateType(models.ConfigurationTypeBasicAuth, map[string][]byte{"username": []byte("admin")})
+		Expect(err).To(MatchError("configuration of type 'basic-auth' is missing keys: password"))
+	})
+
+	It("rejects tls configurations with an invalid keypair", func() {
+		_, err := ValidateType(models.ConfigurationTypeTLS, map[string][]byte{
+			"tls.crt": []byte("not a certificate"),
+			"tls.key": []byte("not a key"),
+		})
+		Expect(err).To(MatchError(ContainSubstring("invalid tls keypair")))
+	})
+
+	It("accepts tls configurations with a valid keypair", func() {
+		cert, key := selfSignedKeyPair()
+
+		_, err := ValidateType(models.ConfigurationTypeTLS, map[string][]byte{
+			"tls.crt": cert,
+			"tls.key": key,
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("adds the docker config to docker-registry configurations", func() {
+		result, err := ValidateType(models.ConfigurationTypeDockerRegistry, map[string][]byte{
+			"server":   []byte("registry.example.com"),
+			"username": []byte("user"),
+			"password": []byte("pass"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveKey("server"))
+
+		var config map[string]map[string]map[string]string
+		err = json.Unmarshal(result[".dockerconfigjson"], &config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config["auths"]["registry.example.com"]).To(Equal(map[string]string{
+			"username": "user",
+			"password": "pass",
+			"auth":     "dXNlcjpwYXNz",
+		}))
+	})
+
+	Describe("validateData", func() {
+		It("regenerates the docker config of changed docker-registry configurations", func() {
+			configuration := &Configuration{Name: "pull", Type: models.ConfigurationTypeDockerRegistry}
+			result, err := validateData(configuration, map[string][]byte{
+				"server":            []byte("registry.example.com"),
+				"username":          []byte("other"),
+				"password":          []byte("pass"),
+				".dockerconfigjson": []byte(`{"auths":{"registry.example.com":{"username":"user"}}}`),
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			var config map[string]map[string]map[string]string
+			err = json.Unmarshal(result[".dockerconfigjson"], &config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config["auths"]["registry.example.com"]).To(HaveKeyWithValue("username", "other"))
+		})
+
+		It("reports type violations as TypeError", func() {
+			certificate, _ := selfSignedKeyPair()
+			configuration := &Configuration{Name: "cert", Type: models.ConfigurationTypeTLS}
+			_, err := validateData(configuration, map[string][]byte{"tls.crt": certificate})
+			Expect(err).To(BeAssignableToTypeOf(TypeError{}))
+			Expect(err).To(MatchError(ContainSubstring("missing keys: tls.key")))
+		})
+
+		It("treats configurations without type as custom", func() {
+			data := map[string][]byte{"anything": []byte("value")}
+			result, err := validateData(&Configuration{Name: "old"}, data)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(data))
+		})
+
+		It("does not check service configurations", func() {
+			data := map[string][]byte{"anything": []byte("value")}
+			result, err := validateData(&Configuration{Name: "svc", Type: models.ConfigurationTypeService}, data)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(data))
+		})
+	})
+})
+
+func selfSignedKeyPair() ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "epinio.test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	Expect(err).ToNot(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
@@ -15,6 +15,9 @@ const (
 	configurationSecret = 5 * time.Minute
 	appBuilt            = 10 * time.Minute
 	secretCopied        = 5 * time.Minute
+	serviceReady        = 10 * time.Minute
+	serviceBackup       = 30 * time.Minute
+	cacheDeletion       = 1 * time.Minute
 
 	// Fixed. __Not__ affected by the multiplier.
 	userAbort  = 5 * time.Second
@@ -65,6 +68,24 @@ func ToConfigurationSecret() time.Duration {
 	return Multiplier() * configurationSecret
 }
 
+// ToServiceReady returns the duration to wait for the helm release of a service instance
+// to become ready, after creation or a change.
+func ToServiceReady() time.Duration {
+	return Multiplier() * serviceReady
+}
+
+// ToServiceBackup returns the duration to wait for the job saving or restoring the data
+// of a service instance to complete.
+func ToServiceBackup() time.Duration {
+	return Multiplier() * serviceBackup
+}
+
+// ToCacheDeletion returns the duration to wait for a cleared build cache to be gone,
+// before staging with a new one.
+func ToCacheDeletion() time.Duration {
+	return Multiplier() * cacheDeletion
+}
+
 //
 // The following durations are not affected by the timeout multiplier.
 //
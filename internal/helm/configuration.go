@@ -0,0 +1,103 @@
+package helm
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// configurationRenderer is a helm post renderer placing the volumes, mounts, and
+// environment variables of the configurations bound with binding options into the pod
+// spec of the deployments of a release. The mounts and variables go into every container.
+// It makes these options independent of the app chart rendering the
+// `configurationVolumes`, `configurationMounts`, and `configurationEnv` values. Entries of
+// the chart with the same name are replaced.
+type configurationRenderer struct {
+	volumes []corev1.Volume
+	mounts  []corev1.VolumeMount
+	env     []corev1.EnvVar
+}
+
+// Run implements the postrender.PostRenderer interface.
+func (r configurationRenderer) Run(rendered *bytes.Buffer) (*bytes.Buffer, error) {
+	if len(r.volumes) == 0 && len(r.mounts) == 0 && len(r.env) == 0 {
+		return rendered, nil
+	}
+
+	return renderDeployments(rendered, r.decorate)
+}
+
+// decorate adds the configuration volumes, mounts, and variables to the pod spec of the
+// deployment.
+func (r configurationRenderer) decorate(resource *unstructured.Unstructured) error {
+	path := []string{"spec", "template", "spec"}
+
+	content, _, err := unstructured.NestedMap(resource.Object, path...)
+	if err != nil {
+		return errors.Wrapf(err, "reading pod spec of deployment %s", resource.GetName())
+	}
+	spec := corev1.PodSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, &spec); err != nil {
+		return errors.Wrapf(err, "decoding pod spec of deployment %s", resource.GetName())
+	}
+
+	for _, volume := range r.volumes {
+		spec.Volumes = withVolume(spec.Volumes, volume)
+	}
+	for i := range spec.Containers {
+		container := &spec.Containers[i]
+		for _, mount := range r.mounts {
+			container.VolumeMounts = withMount(container.VolumeMounts, mount)
+		}
+		for _, env := range r.env {
+			container.Env = withEnv(container.Env, env)
+		}
+	}
+
+	content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+	if err != nil {
+		return errors.Wrapf(err, "encoding pod spec of deployment %s", resource.GetName())
+	}
+	if err := unstructured.SetNestedMap(resource.Object, content, path...); err != nil {
+		return errors.Wrapf(err, "setting pod spec of deployment %s", resource.GetName())
+	}
+
+	return nil
+}
+
+// withVolume returns the volumes with the volume added, replacing a volume of the same name.
+func withVolume(volumes []corev1.Volume, volume corev1.Volume) []corev1.Volume {
+	for i := range volumes {
+		if volumes[i].Name == volume.Name {
+			volumes[i] = volume
+			return volumes
+		}
+	}
+	return append(volumes, volume)
+}
+
+// withMount returns the mounts with the mount added, replacing a mount of the same path.
+func withMount(mounts []corev1.VolumeMount, mount corev1.VolumeMount) []corev1.VolumeMount {
+	for i := range mounts {
+		if mounts[i].MountPath == mount.MountPath {
+			mounts[i] = mount
+			return mounts
+		}
+	}
+	return append(mounts, mount)
+}
+
+// withEnv returns the variables with the variable added, replacing a variable of the same
+// name.
+func withEnv(env []corev1.EnvVar, variable corev1.EnvVar) []corev1.EnvVar {
+	for i := range env {
+		if env[i].Name == variable.Name {
+			env[i] = variable
+			return env
+		}
+	}
+	return append(env, variable)
+}
@@ -0,0 +1,103 @@
+package helm
+
+import (
+	"bytes"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("configurationRenderer", func() {
+	manifests := `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+        env:
+        - name: DB_PASSWORD
+          value: plain
+        - name: PORT
+          value: "8080"
+      volumes:
+      - name: other
+        secret:
+          secretName: other
+`
+
+	podSpec := func(rendered *bytes.Buffer) corev1.PodSpec {
+		documents := bytes.Split(rendered.Bytes(), []byte("---\n"))
+		Expect(documents).To(HaveLen(3))
+
+		object := map[string]interface{}{}
+		Expect(yaml.Unmarshal(documents[2], &object)).To(Succeed())
+		content, found, err := unstructured.NestedMap(object, "spec", "template", "spec")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		spec := corev1.PodSpec{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(content, &spec)).To(Succeed())
+		return spec
+	}
+
+	It("places the volumes, mounts, and variables into the pod spec", func() {
+		renderer := configurationRenderer{
+			volumes: []corev1.Volume{{
+				Name: "db",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: "db"},
+				},
+			}},
+			mounts: []corev1.VolumeMount{{
+				Name:      "db",
+				ReadOnly:  true,
+				MountPath: "/etc/db",
+			}},
+			env: []corev1.EnvVar{{
+				Name: "DB_PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "db"},
+						Key:                  "password",
+					},
+				},
+			}},
+		}
+
+		rendered, err := renderer.Run(bytes.NewBufferString(manifests))
+		Expect(err).ToNot(HaveOccurred())
+
+		spec := podSpec(rendered)
+		Expect(spec.Volumes).To(HaveLen(2))
+		Expect(spec.Volumes[0].Name).To(Equal("other"))
+		Expect(spec.Volumes[1]).To(Equal(renderer.volumes[0]))
+
+		Expect(spec.Containers).To(HaveLen(1))
+		Expect(spec.Containers[0].VolumeMounts).To(Equal(renderer.mounts))
+		Expect(spec.Containers[0].Env).To(Equal([]corev1.EnvVar{
+			renderer.env[0],
+			{Name: "PORT", Value: "8080"},
+		}))
+	})
+
+	It("leaves the manifests alone without configurations", func() {
+		rendered, err := configurationRenderer{}.Run(bytes.NewBufferString(manifests))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rendered.String()).To(Equal(manifests))
+	})
+})
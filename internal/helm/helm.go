@@ -5,7 +5,9 @@ package helm
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -20,8 +22,11 @@ import (
 	hc "github.com/mittwald/go-helm-client"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v2"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/getter"
 	helmrelease "helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/repo"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/rest"
 )
 
@@ -38,6 +43,15 @@ type ChartParameters struct {
 	Configurations []string              // Bound Configurations (list of names)
 	Routes         []string              // Desired application routes
 	Start          *int64                // Nano-epoch of deployment. Optional. Used to force a restart, even when nothing else has changed.
+	Labels         map[string]string     // User labels for the deployment and pods
+	Annotations    map[string]string     // User annotations for the deployment and pods
+
+	// Bound configurations with binding options, as the kube structures to place into the
+	// application's pod spec. Charts declaring the configuration values feature render
+	// these as given. For all other charts they are placed by a post renderer.
+	ConfigurationVolumes []corev1.Volume      // Volumes of configurations mounted as files
+	ConfigurationMounts  []corev1.VolumeMount // Mounts for these volumes
+	ConfigurationEnv     []corev1.EnvVar      // Configuration keys injected as environment variables
 }
 
 func Values(cluster *kubernetes.Cluster, logger logr.Logger, app models.AppRef) ([]byte, error) {
@@ -108,6 +122,28 @@ func Deploy(logger logr.Logger, parameters ChartParameters) error {
 		routesYaml = fmt.Sprintf(`[%s]`, strings.Join(rs, `,`))
 	}
 
+	configurationVolumes, err := json.Marshal(parameters.ConfigurationVolumes)
+	if err != nil {
+		return errors.Wrap(err, "encoding configuration volumes")
+	}
+	configurationMounts, err := json.Marshal(parameters.ConfigurationMounts)
+	if err != nil {
+		return errors.Wrap(err, "encoding configuration mounts")
+	}
+	configurationEnv, err := json.Marshal(parameters.ConfigurationEnv)
+	if err != nil {
+		return errors.Wrap(err, "encoding configuration environment")
+	}
+
+	labels, err := json.Marshal(nameValues(parameters.Labels))
+	if err != nil {
+		return errors.Wrap(err, "encoding labels")
+	}
+	annotations, err := json.Marshal(nameValues(parameters.Annotations))
+	if err != nil {
+		return errors.Wrap(err, "encoding annotations")
+	}
+
 	ingress := "~"
 	name := viper.GetString("ingress-class-name")
 	if name != "" {
@@ -128,6 +164,11 @@ epinio:
   replicaCount: %[1]d
   routes: %[7]s
   configurations: %[5]s
+  configurationVolumes: %[12]s
+  configurationMounts: %[13]s
+  configurationEnv: %[14]s
+  labels: %[15]s
+  annotations: %[16]s
   stageID: "%[2]s"
   tlsIssuer: "%[11]s"
   username: "%[4]s"
@@ -143,6 +184,11 @@ epinio:
 		parameters.Name,
 		ingress,
 		viper.GetString("tls-issuer"),
+		configurationVolumes,
+		configurationMounts,
+		configurationEnv,
+		labels,
+		annotations,
 	)
 
 	logger.Info("app helm setup", "parameters", yamlParameters)
@@ -174,16 +220,32 @@ epinio:
 		helmChart = fmt.Sprintf("%s/%s", name, helmChart)
 	}
 
+	renderers := postRenderers{metadataRenderer{
+		labels:      parameters.Labels,
+		annotations: parameters.Annotations,
+	}}
+
+	// Charts not rendering the configuration values themselves get them placed into the
+	// pod spec of their deployment.
+	if !appChart.Supports(models.AppChartFeatureConfigurationValues) {
+		renderers = append(renderers, configurationRenderer{
+			volumes: parameters.ConfigurationVolumes,
+			mounts:  parameters.ConfigurationMounts,
+			env:     parameters.ConfigurationEnv,
+		})
+	}
+
 	chartSpec := hc.ChartSpec{
-		ReleaseName: names.ReleaseName(parameters.Name),
-		ChartName:   helmChart,
-		Version:     helmVersion,
-		Namespace:   parameters.Namespace,
-		Wait:        true,
-		Atomic:      true,
-		ValuesYaml:  yamlParameters,
-		Timeout:     duration.ToDeployment(),
-		ReuseValues: true,
+		ReleaseName:  names.ReleaseName(parameters.Name),
+		ChartName:    helmChart,
+		Version:      helmVersion,
+		Namespace:    parameters.Namespace,
+		Wait:         true,
+		Atomic:       true,
+		ValuesYaml:   yamlParameters,
+		Timeout:      duration.ToDeployment(),
+		ReuseValues:  true,
+		PostRenderer: renderers,
 	}
 
 	if _, err := client.InstallOrUpgradeChart(context.Background(), &chartSpec); err != nil {
@@ -193,22 +255,89 @@ epinio:
 	return nil
 }
 
+// nameValue is the representation of labels and annotations in the chart values. A list
+// of these is used instead of a map because helm merges maps with the values of the
+// previous release, keeping removed keys.
+type nameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// nameValues converts the map into a list of nameValue, sorted by name
+func nameValues(values map[string]string) []nameValue {
+	result := []nameValue{}
+	for name, value := range values {
+		result = append(result, nameValue{Name: name, Value: value})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
 func Status(ctx context.Context, logger logr.Logger, cluster *kubernetes.Cluster, namespace, releaseName string) (helmrelease.Status, error) {
-	client, err := GetHelmClient(cluster.RestConfig, logger, namespace)
+	r, err := Release(ctx, logger, cluster, namespace, releaseName)
 	if err != nil {
 		return "", err
 	}
 
+	return r.Info.Status, nil
+}
+
+// Release returns the current revision of the named release in the namespace. It is an
+// error for the release to not carry status information.
+func Release(ctx context.Context, logger logr.Logger, cluster *kubernetes.Cluster, namespace, releaseName string) (*helmrelease.Release, error) {
+	client, err := GetHelmClient(cluster.RestConfig, logger, namespace)
+	if err != nil {
+		return nil, err
+	}
+
 	var r *helmrelease.Release
 	if r, err = client.GetRelease(releaseName); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if r.Info == nil {
-		return "", errors.New("no status available")
+		return nil, errors.New("no status available")
 	}
 
-	return r.Info.Status, nil
+	return r, nil
+}
+
+// ResolveChart verifies that the named chart can be found in the helm repository at the
+// given url, in the specified version. An empty version resolves to the latest version
+// of the chart. It returns the entry of the chart in the index of the repository.
+func ResolveChart(repoURL, chartName, chartVersion string) (*repo.ChartVersion, error) {
+	entry := repo.Entry{
+		Name: names.GenerateResourceName("hr-" + base64.StdEncoding.EncodeToString([]byte(repoURL))),
+		URL:  repoURL,
+	}
+
+	r, err := repo.NewChartRepository(&entry, getter.All(cli.New()))
+	if err != nil {
+		return nil, err
+	}
+	r.CachePath = "/tmp/.helmcache" // See GetHelmClient
+
+	indexFile, err := r.DownloadIndexFile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "looks like %q is not a valid chart repository or cannot be reached", repoURL)
+	}
+
+	index, err := repo.LoadIndexFile(indexFile)
+	if err != nil {
+		return nil, err
+	}
+
+	chart, err := index.Get(chartName, chartVersion)
+	if err != nil {
+		if chartVersion == "" {
+			return nil, errors.Errorf("chart %q not found in %s repository", chartName, repoURL)
+		}
+		return nil, errors.Errorf("chart %q version %q not found in %s repository", chartName, chartVersion, repoURL)
+	}
+
+	return chart, nil
 }
 
 func GetHelmClient(restConfig *rest.Config, logger logr.Logger, namespace string) (hc.Client, error) {
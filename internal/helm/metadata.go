@@ -0,0 +1,61 @@
+package helm
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// metadataRenderer is a helm post renderer adding the user labels and annotations of an
+// application to the deployments of its release, and to their pod templates. It makes the
+// metadata independent of the app chart rendering the `labels` and `annotations` values.
+// Labels and annotations set by the chart itself take precedence.
+type metadataRenderer struct {
+	labels      map[string]string
+	annotations map[string]string
+}
+
+// Run implements the postrender.PostRenderer interface.
+func (r metadataRenderer) Run(rendered *bytes.Buffer) (*bytes.Buffer, error) {
+	if len(r.labels) == 0 && len(r.annotations) == 0 {
+		return rendered, nil
+	}
+
+	return renderDeployments(rendered, r.decorate)
+}
+
+// decorate adds the user metadata to the deployment, and to its pod template.
+func (r metadataRenderer) decorate(resource *unstructured.Unstructured) error {
+	resource.SetLabels(merged(resource.GetLabels(), r.labels))
+	resource.SetAnnotations(merged(resource.GetAnnotations(), r.annotations))
+
+	for field, values := range map[string]map[string]string{
+		"labels":      r.labels,
+		"annotations": r.annotations,
+	} {
+		path := []string{"spec", "template", "metadata", field}
+		current, _, err := unstructured.NestedStringMap(resource.Object, path...)
+		if err != nil {
+			return errors.Wrapf(err, "reading pod template %s of deployment %s", field, resource.GetName())
+		}
+		if err := unstructured.SetNestedStringMap(resource.Object, merged(current, values), path...); err != nil {
+			return errors.Wrapf(err, "setting pod template %s of deployment %s", field, resource.GetName())
+		}
+	}
+
+	return nil
+}
+
+// merged returns the current map extended by the additional keys. Keys already present in
+// the current map keep their value.
+func merged(current, additional map[string]string) map[string]string {
+	result := map[string]string{}
+	for key, value := range additional {
+		result[key] = value
+	}
+	for key, value := range current {
+		result[key] = value
+	}
+	return result
+}
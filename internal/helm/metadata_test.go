@@ -0,0 +1,98 @@
+package helm
+
+import (
+	"bytes"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("metadataRenderer", func() {
+	manifests := `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    app.kubernetes.io/name: app
+spec:
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: app
+    spec:
+      containers: []
+`
+
+	decode := func(rendered *bytes.Buffer) []unstructured.Unstructured {
+		result := []unstructured.Unstructured{}
+		for _, document := range bytes.Split(rendered.Bytes(), []byte("---\n")) {
+			if len(bytes.TrimSpace(document)) == 0 {
+				continue
+			}
+			object := map[string]interface{}{}
+			Expect(yaml.Unmarshal(document, &object)).To(Succeed())
+			result = append(result, unstructured.Unstructured{Object: object})
+		}
+		return result
+	}
+
+	It("adds the metadata to the deployment and its pod template", func() {
+		renderer := metadataRenderer{
+			labels:      map[string]string{"team": "payments"},
+			annotations: map[string]string{"owner": "alice"},
+		}
+
+		rendered, err := renderer.Run(bytes.NewBufferString(manifests))
+		Expect(err).ToNot(HaveOccurred())
+
+		resources := decode(rendered)
+		Expect(resources).To(HaveLen(2))
+
+		Expect(resources[0].GetKind()).To(Equal("Service"))
+		Expect(resources[0].GetLabels()).To(BeEmpty())
+
+		deployment := resources[1]
+		Expect(deployment.GetLabels()).To(Equal(map[string]string{
+			"app.kubernetes.io/name": "app",
+			"team":                   "payments",
+		}))
+		Expect(deployment.GetAnnotations()).To(Equal(map[string]string{"owner": "alice"}))
+
+		podLabels, _, err := unstructured.NestedStringMap(deployment.Object, "spec", "template", "metadata", "labels")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(podLabels).To(Equal(map[string]string{
+			"app.kubernetes.io/name": "app",
+			"team":                   "payments",
+		}))
+		podAnnotations, _, err := unstructured.NestedStringMap(deployment.Object, "spec", "template", "metadata", "annotations")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(podAnnotations).To(Equal(map[string]string{"owner": "alice"}))
+	})
+
+	It("keeps the labels set by the chart", func() {
+		renderer := metadataRenderer{
+			labels: map[string]string{"app.kubernetes.io/name": "other"},
+		}
+
+		rendered, err := renderer.Run(bytes.NewBufferString(manifests))
+		Expect(err).ToNot(HaveOccurred())
+
+		deployment := decode(rendered)[1]
+		Expect(deployment.GetLabels()).To(HaveKeyWithValue("app.kubernetes.io/name", "app"))
+	})
+
+	It("leaves the manifests alone without metadata", func() {
+		rendered, err := metadataRenderer{}.Run(bytes.NewBufferString(manifests))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rendered.String()).To(Equal(manifests))
+	})
+})
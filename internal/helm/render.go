@@ -0,0 +1,77 @@
+package helm
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/postrender"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// postRenderers is a helm post renderer running the contained post renderers in order,
+// each on the result of the previous one.
+type postRenderers []postrender.PostRenderer
+
+// Run implements the postrender.PostRenderer interface.
+func (p postRenderers) Run(rendered *bytes.Buffer) (*bytes.Buffer, error) {
+	for _, renderer := range p {
+		var err error
+		rendered, err = renderer.Run(rendered)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rendered, nil
+}
+
+// renderDeployments returns the rendered manifests with the deployments among them
+// modified by the decorate function. Other documents are returned unchanged.
+func renderDeployments(rendered *bytes.Buffer, decorate func(*unstructured.Unstructured) error) (*bytes.Buffer, error) {
+	documents := releaseutil.SplitManifests(rendered.String())
+	keys := []string{}
+	for key := range documents {
+		keys = append(keys, key)
+	}
+	sort.Sort(releaseutil.BySplitManifestsOrder(keys))
+
+	result := &bytes.Buffer{}
+	for _, key := range keys {
+		document, err := renderDeployment(documents[key], decorate)
+		if err != nil {
+			return nil, err
+		}
+		result.WriteString("---\n")
+		result.WriteString(document)
+		result.WriteString("\n")
+	}
+
+	return result, nil
+}
+
+// renderDeployment returns the document modified by the decorate function, if it is a
+// deployment. Other documents are returned unchanged.
+func renderDeployment(document string, decorate func(*unstructured.Unstructured) error) (string, error) {
+	object := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(document), &object); err != nil {
+		return "", errors.Wrap(err, "decoding rendered manifest")
+	}
+
+	resource := unstructured.Unstructured{Object: object}
+	if resource.GetKind() != "Deployment" {
+		return document, nil
+	}
+
+	if err := decorate(&resource); err != nil {
+		return "", err
+	}
+
+	encoded, err := yaml.Marshal(resource.Object)
+	if err != nil {
+		return "", errors.Wrap(err, "encoding rendered manifest")
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,369 @@
+// Package images implements the retention policy of the application images in the Epinio
+// registry. Every staging pushes an image tagged with its stage id. The last stagings of an
+// application are kept as rollback targets, older images are deleted. Images deployed by
+// any application, and those of running stagings, are never deleted.
+package images
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/registry"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// StageHistoryAnnotationKey is the annotation of the application resource holding the
+	// ids of its stagings, oldest first, as JSON. It orders the images of the application.
+	StageHistoryAnnotationKey = "epinio.suse.org/stage-history"
+	// DefaultRetention is the number of stage images kept per application, unless
+	// configured otherwise with `image-retention`.
+	DefaultRetention = 5
+	// stageHistoryLimit is the maximal number of stagings recorded per application
+	stageHistoryLimit = 100
+)
+
+// Retention returns the number of stage images kept per application, as configured for the
+// server. The deployed images are kept in addition.
+func Retention() int {
+	retention := viper.GetInt("image-retention")
+	if retention < 0 {
+		return 0
+	}
+	return retention
+}
+
+// StageHistory returns the ids of the stagings of the application, oldest first.
+func StageHistory(app *unstructured.Unstructured) ([]string, error) {
+	history := []string{}
+
+	encoded, ok := app.GetAnnotations()[StageHistoryAnnotationKey]
+	if !ok {
+		return history, nil
+	}
+	if err := json.Unmarshal([]byte(encoded), &history); err != nil {
+		return history, errors.Wrap(err, "bad stage history of the application")
+	}
+
+	return history, nil
+}
+
+// RecordStage adds the staging to the history of the application resource. Only the newest
+// stagings are recorded. The caller has to save the resource.
+func RecordStage(app *unstructured.Unstructured, stageID string) error {
+	history, err := StageHistory(app)
+	if err != nil {
+		return err
+	}
+
+	history = append(history, stageID)
+	if len(history) > stageHistoryLimit {
+		history = history[len(history)-stageHistoryLimit:]
+	}
+
+	// Cannot fail for a list of strings
+	encoded, _ := json.Marshal(history)
+
+	annotations := app.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[StageHistoryAnnotationKey] = string(encoded)
+	app.SetAnnotations(annotations)
+
+	return nil
+}
+
+// NewClient returns a client for the Epinio registry, trusting the certificate of
+// `registry-certificate-secret`, if configured.
+func NewClient(ctx context.Context, cluster *kubernetes.Cluster) (*registry.Client, error) {
+	details, err := registry.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), registry.CredentialsSecretName)
+	if err != nil {
+		return nil, err
+	}
+
+	var ca []byte
+	if secretName := viper.GetString("registry-certificate-secret"); secretName != "" {
+		secret, err := cluster.GetSecret(ctx, helmchart.Namespace(), secretName)
+		if err != nil {
+			return nil, err
+		}
+		ca = secret.Data["ca.crt"]
+		if len(ca) == 0 {
+			ca = secret.Data["tls.crt"]
+		}
+	}
+
+	return registry.NewClient(details, ca)
+}
+
+// repositories holds the images of the applications, and the images in use
+type repositories struct {
+	// namespace of the applications in the registry
+	registryNamespace string
+	// stage history of the applications, by repository
+	history map[string][]string
+	// applications, by repository
+	apps map[string]models.AppRef
+	// deployed tags, by repository
+	deployed map[string]map[string]struct{}
+	// tags of running or current stagings, by repository
+	staging map[string]map[string]struct{}
+}
+
+// Repository returns the repository of the images of the application, in the registry
+// namespace.
+func Repository(registryNamespace string, appRef models.AppRef) string {
+	return path.Join(registryNamespace, appRef.Namespace+"-"+appRef.Name)
+}
+
+// List returns the stage images of the applications, with their status for the retention
+// policy keeping the given number of images per application.
+func List(ctx context.Context, cluster *kubernetes.Cluster, client *registry.Client, keep int) (models.AppImageList, error) {
+	repos, err := findRepositories(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.AppImageList{}
+	for repository, appRef := range repos.apps {
+		images, err := listRepository(ctx, client, repos, repository, appRef, keep)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, images...)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].App < result[j].App
+	})
+
+	return result, nil
+}
+
+// Collect deletes the expired stage images of the applications, keeping the given number
+// of images per application. For a dry run nothing is deleted, and the report lists what
+// would be.
+func Collect(ctx context.Context, cluster *kubernetes.Cluster, client *registry.Client, keep int, dryRun bool) (models.ImagesGCResponse, error) {
+	report := models.ImagesGCResponse{
+		DryRun:  dryRun,
+		Keep:    keep,
+		Deleted: models.AppImageList{},
+	}
+
+	imageList, err := List(ctx, cluster, client, keep)
+	if err != nil {
+		return report, err
+	}
+
+	report.Deleted, err = deleteExpired(ctx, cluster, client, imageList, dryRun, true)
+	return report, err
+}
+
+// Cleanup deletes the images of the deleted application, except those deployed by other
+// applications, e.g. clones of it.
+func Cleanup(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) error {
+	client, err := NewClient(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	repos, err := findRepositories(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	repository := Repository(repos.registryNamespace, appRef)
+	images, err := listRepository(ctx, client, repos, repository, appRef, 0)
+	if err != nil {
+		return err
+	}
+
+	_, err = deleteExpired(ctx, cluster, client, images, false, false)
+	if err != nil {
+		return err
+	}
+
+	return DeleteSBOMs(ctx, cluster, appRef)
+}
+
+// deleteExpired deletes the expired images, and returns them. With record set the SBOM of
+// each image is recorded before it is deleted, keeping it available.
+func deleteExpired(ctx context.Context, cluster *kubernetes.Cluster, client *registry.Client, imageList models.AppImageList, dryRun, record bool) (models.AppImageList, error) {
+	deleted := models.AppImageList{}
+
+	for _, image := range imageList {
+		if image.Status != models.ImageExpired {
+			continue
+		}
+		if !dryRun {
+			if record {
+				appRef := models.NewAppRef(image.App, image.Namespace)
+				if err := RecordSBOM(ctx, cluster, client, appRef, image.Tag); err != nil {
+					return deleted, err
+				}
+			}
+			if err := client.DeleteManifest(ctx, image.Repository, image.Digest); err != nil {
+				return deleted, err
+			}
+		}
+		deleted = append(deleted, image)
+	}
+
+	return deleted, nil
+}
+
+// listRepository returns the stage images in the repository of the application, with
+// their status.
+func listRepository(ctx context.Context, client *registry.Client, repos repositories, repository string, appRef models.AppRef, keep int) (models.AppImageList, error) {
+	tags, err := client.Tags(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := classify(tags, repos.history[repository], repos.deployed[repository],
+		repos.staging[repository], keep)
+
+	result := models.AppImageList{}
+	keptDigests := map[string]struct{}{}
+	for _, tag := range tags {
+		digest, err := client.Digest(ctx, repository, tag)
+		if err != nil {
+			return nil, err
+		}
+		if statuses[tag] != models.ImageExpired {
+			keptDigests[digest] = struct{}{}
+		}
+		result = append(result, models.AppImage{
+			Namespace:  appRef.Namespace,
+			App:        appRef.Name,
+			Repository: repository,
+			Tag:        tag,
+			Digest:     digest,
+			Status:     statuses[tag],
+		})
+	}
+
+	// Deleting a manifest deletes all of its tags. Expired tags sharing the manifest of
+	// a kept tag have to be kept as well.
+	for i, image := range result {
+		if _, ok := keptDigests[image.Digest]; ok && image.Status == models.ImageExpired {
+			result[i].Status = models.ImageRetained
+		}
+	}
+
+	return result, nil
+}
+
+// classify returns the status of the tags of a repository. The deployed and staging tags
+// are kept, as are the tags of the last stagings in the history, up to the given number.
+// Tags not in the history are older than all recorded stagings.
+func classify(tags, history []string, deployed, staging map[string]struct{}, keep int) map[string]string {
+	exists := map[string]struct{}{}
+	for _, tag := range tags {
+		exists[tag] = struct{}{}
+	}
+
+	retained := map[string]struct{}{}
+	for i := len(history) - 1; i >= 0 && len(retained) < keep; i-- {
+		if _, ok := exists[history[i]]; ok {
+			retained[history[i]] = struct{}{}
+		}
+	}
+
+	result := map[string]string{}
+	for _, tag := range tags {
+		if _, ok := deployed[tag]; ok {
+			result[tag] = models.ImageDeployed
+		} else if _, ok := staging[tag]; ok {
+			result[tag] = models.ImageStaging
+		} else if _, ok := retained[tag]; ok {
+			result[tag] = models.ImageRetained
+		} else {
+			result[tag] = models.ImageExpired
+		}
+	}
+
+	return result
+}
+
+// findRepositories returns the repositories of the existing applications, with their
+// stage histories and the tags in use.
+func findRepositories(ctx context.Context, cluster *kubernetes.Cluster) (repositories, error) {
+	repos := repositories{
+		history:  map[string][]string{},
+		apps:     map[string]models.AppRef{},
+		deployed: map[string]map[string]struct{}{},
+		staging:  map[string]map[string]struct{}{},
+	}
+
+	details, err := registry.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), registry.CredentialsSecretName)
+	if err != nil {
+		return repos, err
+	}
+	repos.registryNamespace = details.Namespace
+
+	mark := func(tags map[string]map[string]struct{}, repository, tag string) {
+		if tags[repository] == nil {
+			tags[repository] = map[string]struct{}{}
+		}
+		tags[repository][tag] = struct{}{}
+	}
+
+	appClient, err := cluster.ClientApp()
+	if err != nil {
+		return repos, err
+	}
+
+	apps, err := appClient.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return repos, errors.Wrap(err, "listing applications")
+	}
+	for i, app := range apps.Items {
+		appRef := models.NewAppRef(app.GetName(), app.GetNamespace())
+		repository := Repository(repos.registryNamespace, appRef)
+
+		repos.apps[repository] = appRef
+		repos.history[repository], err = StageHistory(&apps.Items[i])
+		if err != nil {
+			return repos, errors.Wrapf(err, "application %s", appRef)
+		}
+
+		imageURL, _, err := unstructured.NestedString(app.UnstructuredContent(), "spec", "imageurl")
+		if err != nil {
+			return repos, errors.New("imageurl should be string")
+		}
+		if imageURL == "" {
+			continue
+		}
+		// The images deployed by an application may be in the repository of another,
+		// e.g. for clones.
+		imageRepository, tag, err := registry.ImageRepositoryTag(imageURL)
+		if err != nil {
+			return repos, errors.Wrapf(err, "image of application %s", appRef)
+		}
+		mark(repos.deployed, imageRepository, tag)
+	}
+
+	jobs, err := cluster.ListJobs(ctx, helmchart.Namespace(), "app.kubernetes.io/component=staging")
+	if err != nil {
+		return repos, errors.Wrap(err, "listing staging jobs")
+	}
+	for _, job := range jobs.Items {
+		appRef := models.NewAppRef(job.Labels["app.kubernetes.io/name"], job.Labels["app.kubernetes.io/part-of"])
+		mark(repos.staging, Repository(repos.registryNamespace, appRef), job.Labels[models.EpinioStageIDLabel])
+	}
+
+	return repos, nil
+}
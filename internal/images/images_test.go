@@ -0,0 +1,112 @@
+package images
+
+import (
+	"fmt"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Images", func() {
+	Describe("classify", func() {
+		set := func(tags ...string) map[string]struct{} {
+			result := map[string]struct{}{}
+			for _, tag := range tags {
+				result[tag] = struct{}{}
+			}
+			return result
+		}
+
+		It("keeps the last stage images, and expires the older ones", func() {
+			statuses := classify([]string{"s1", "s2", "s3", "s4"}, []string{"s1", "s2", "s3", "s4"},
+				set(), set(), 2)
+			Expect(statuses).To(Equal(map[string]string{
+				"s1": models.ImageExpired,
+				"s2": models.ImageExpired,
+				"s3": models.ImageRetained,
+				"s4": models.ImageRetained,
+			}))
+		})
+
+		It("never expires deployed and staging images", func() {
+			statuses := classify([]string{"s1", "s2", "s3", "s4"}, []string{"s1", "s2", "s3", "s4"},
+				set("s1"), set("s4"), 1)
+			Expect(statuses).To(Equal(map[string]string{
+				"s1": models.ImageDeployed,
+				"s2": models.ImageExpired,
+				"s3": models.ImageExpired,
+				"s4": models.ImageStaging,
+			}))
+		})
+
+		It("counts only stagings which pushed an image", func() {
+			statuses := classify([]string{"s1", "s2"}, []string{"s1", "s2", "failed"},
+				set(), set(), 2)
+			Expect(statuses).To(Equal(map[string]string{
+				"s1": models.ImageRetained,
+				"s2": models.ImageRetained,
+			}))
+		})
+
+		It("considers images missing from the history as the oldest", func() {
+			statuses := classify([]string{"old", "s1"}, []string{"s1"},
+				set(), set(), 2)
+			Expect(statuses).To(Equal(map[string]string{
+				"old": models.ImageExpired,
+				"s1":  models.ImageRetained,
+			}))
+		})
+	})
+
+	Describe("RecordStage", func() {
+		It("records the stagings, oldest first", func() {
+			app := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			Expect(RecordStage(app, "s1")).To(Succeed())
+			Expect(RecordStage(app, "s2")).To(Succeed())
+
+			history, err := StageHistory(app)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(Equal([]string{"s1", "s2"}))
+		})
+
+		It("records only the newest stagings", func() {
+			app := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			for i := 0; i < stageHistoryLimit+2; i++ {
+				Expect(RecordStage(app, fmt.Sprintf("s%d", i))).To(Succeed())
+			}
+
+			history, err := StageHistory(app)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(HaveLen(stageHistoryLimit))
+			Expect(history[0]).To(Equal("s2"))
+		})
+	})
+
+	Describe("Repository", func() {
+		It("places the images of the application in the registry namespace", func() {
+			appRef := models.NewAppRef("app", "workspace")
+			Expect(Repository("apps", appRef)).To(Equal("apps/workspace-app"))
+			Expect(Repository("", appRef)).To(Equal("workspace-app"))
+		})
+	})
+
+	Describe("Retention", func() {
+		AfterEach(func() {
+			viper.Set("image-retention", DefaultRetention)
+		})
+
+		It("uses the configured retention", func() {
+			viper.Set("image-retention", 2)
+			Expect(Retention()).To(Equal(2))
+		})
+
+		It("treats negative retentions as keeping none", func() {
+			viper.Set("image-retention", -1)
+			Expect(Retention()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,291 @@
+package images
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/epinio/epinio/internal/registry"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// buildMetadataLabel is the label of buildpack images describing the buildpacks
+	buildMetadataLabel = "io.buildpacks.build.metadata"
+	// lifecycleMetadataLabel is the label of buildpack images describing their layers,
+	// including the SBOM layer
+	lifecycleMetadataLabel = "io.buildpacks.lifecycle.metadata"
+	// sbomDocumentLimit is the maximal size of an SBOM document read from an image
+	sbomDocumentLimit = 64 << 20
+	// SBOMPlatformAPI is the lowest buildpack platform API under which buildpacks export
+	// their SBOMs into the image
+	SBOMPlatformAPI = "0.8"
+)
+
+// ExportsSBOM returns true if buildpacks staging under the platform API export their
+// SBOMs into the image. An unknown platform API is assumed to do so.
+func ExportsSBOM(platformAPI string) bool {
+	if platformAPI == "" {
+		return true
+	}
+
+	version, ok := platformVersion(platformAPI)
+	if !ok {
+		return true
+	}
+	minimum, _ := platformVersion(SBOMPlatformAPI)
+
+	return version[0] > minimum[0] || (version[0] == minimum[0] && version[1] >= minimum[1])
+}
+
+// platformVersion returns the major and minor version of the platform API, and whether it
+// is well-formed.
+func platformVersion(platformAPI string) ([2]int, bool) {
+	version := [2]int{}
+
+	parts := strings.Split(platformAPI, ".")
+	if len(parts) != 2 {
+		return version, false
+	}
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return version, false
+		}
+		version[i] = number
+	}
+
+	return version, true
+}
+
+// manifest holds the parts of an image manifest, or image index, needed to locate the
+// config and layers of an image
+type manifest struct {
+	Config    descriptor   `json:"config"`
+	Layers    []descriptor `json:"layers"`
+	Manifests []descriptor `json:"manifests"`
+}
+
+// descriptor references a blob, or manifest, of a repository
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+}
+
+// imageConfig holds the parts of an image config needed to read the buildpack metadata
+type imageConfig struct {
+	Config struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"config"`
+	RootFS struct {
+		DiffIDs []string `json:"diff_ids"`
+	} `json:"rootfs"`
+}
+
+// SBOM returns the digest of the image with the tag of the repository, the buildpacks
+// used to build it, and the software bills of materials the buildpacks exported into its
+// SBOM layer. Images not built by buildpacks have neither.
+func SBOM(ctx context.Context, client *registry.Client, repository, tag string) (string, []models.Buildpack, []models.SBOMDocument, error) {
+	content, _, err := client.Manifest(ctx, repository, tag)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	sum := sha256.Sum256(content)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+
+	image := manifest{}
+	if err := json.Unmarshal(content, &image); err != nil {
+		return "", nil, nil, errors.Wrapf(err, "decoding the manifest of %s:%s", repository, tag)
+	}
+
+	// Buildpacks build images for a single platform. An index is resolved to its first
+	// image.
+	if len(image.Manifests) > 0 {
+		content, _, err = client.Manifest(ctx, repository, image.Manifests[0].Digest)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		image = manifest{}
+		if err := json.Unmarshal(content, &image); err != nil {
+			return "", nil, nil, errors.Wrapf(err, "decoding the manifest of %s:%s", repository, tag)
+		}
+	}
+
+	config := imageConfig{}
+	if err := readJSONBlob(ctx, client, repository, image.Config.Digest, &config); err != nil {
+		return "", nil, nil, errors.Wrapf(err, "reading the config of %s:%s", repository, tag)
+	}
+
+	buildpacks, err := buildpacksOf(config.Config.Labels)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	layer, err := sbomLayer(image, config)
+	if err != nil || layer == nil {
+		return digest, buildpacks, []models.SBOMDocument{}, err
+	}
+
+	blob, err := client.Blob(ctx, repository, layer.Digest)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	defer blob.Close()
+
+	var reader io.Reader = blob
+	if strings.HasSuffix(layer.MediaType, "gzip") {
+		gz, err := gzip.NewReader(blob)
+		if err != nil {
+			return "", nil, nil, errors.Wrapf(err, "reading the SBOM layer of %s:%s", repository, tag)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	documents, err := readSBOMLayer(reader)
+	if err != nil {
+		return "", nil, nil, errors.Wrapf(err, "reading the SBOM layer of %s:%s", repository, tag)
+	}
+
+	return digest, buildpacks, documents, nil
+}
+
+// readJSONBlob decodes the blob of the repository into the result.
+func readJSONBlob(ctx context.Context, client *registry.Client, repository, digest string, result interface{}) error {
+	blob, err := client.Blob(ctx, repository, digest)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	return json.NewDecoder(blob).Decode(result)
+}
+
+// buildpacksOf returns the buildpacks listed in the build metadata of an image. Images
+// without the metadata have no buildpacks.
+func buildpacksOf(labels map[string]string) ([]models.Buildpack, error) {
+	metadata := struct {
+		Buildpacks []models.Buildpack `json:"buildpacks"`
+	}{}
+
+	encoded, ok := labels[buildMetadataLabel]
+	if !ok {
+		return []models.Buildpack{}, nil
+	}
+	if err := json.Unmarshal([]byte(encoded), &metadata); err != nil {
+		return nil, errors.Wrap(err, "bad buildpack metadata of the image")
+	}
+	if metadata.Buildpacks == nil {
+		metadata.Buildpacks = []models.Buildpack{}
+	}
+
+	return metadata.Buildpacks, nil
+}
+
+// sbomLayer returns the layer of the image holding the SBOM documents, or nil if there is
+// none. The lifecycle metadata references the layer by its diff id, i.e. the digest of its
+// uncompressed contents.
+func sbomLayer(image manifest, config imageConfig) (*descriptor, error) {
+	metadata := struct {
+		SBOM *struct {
+			SHA string `json:"sha"`
+		} `json:"sbom"`
+	}{}
+
+	encoded, ok := config.Config.Labels[lifecycleMetadataLabel]
+	if !ok {
+		return nil, nil
+	}
+	if err := json.Unmarshal([]byte(encoded), &metadata); err != nil {
+		return nil, errors.Wrap(err, "bad lifecycle metadata of the image")
+	}
+	if metadata.SBOM == nil || metadata.SBOM.SHA == "" {
+		return nil, nil
+	}
+
+	for i, diffID := range config.RootFS.DiffIDs {
+		if diffID == metadata.SBOM.SHA && i < len(image.Layers) {
+			return &image.Layers[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("SBOM layer %s not found in the image", metadata.SBOM.SHA)
+}
+
+// readSBOMLayer returns the SBOM documents in the uncompressed tarball of an SBOM layer,
+// ordered by path. The paths are relative to the SBOM directory of the layer.
+func readSBOMLayer(layer io.Reader) ([]models.SBOMDocument, error) {
+	documents := []models.SBOMDocument{}
+
+	archive := tar.NewReader(layer)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		format := sbomFormat(header.Name)
+		if format == "" {
+			continue
+		}
+		if header.Size > sbomDocumentLimit {
+			return nil, fmt.Errorf("SBOM document %s exceeds %d bytes", header.Name, sbomDocumentLimit)
+		}
+
+		content := bytes.Buffer{}
+		if _, err := io.Copy(&content, archive); err != nil { // nolint:gosec // size checked above
+			return nil, err
+		}
+		if !json.Valid(content.Bytes()) {
+			return nil, fmt.Errorf("SBOM document %s is not JSON", header.Name)
+		}
+
+		name := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		if _, rest, ok := strings.Cut(name, "sbom/"); ok {
+			name = rest
+		}
+
+		documents = append(documents, models.SBOMDocument{
+			Path:    name,
+			Format:  format,
+			Content: content.Bytes(),
+		})
+	}
+
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].Path < documents[j].Path
+	})
+
+	return documents, nil
+}
+
+// sbomFormat returns the format of the SBOM document with the file name, per the naming
+// of the buildpack specification, or the empty string for other files.
+func sbomFormat(name string) string {
+	switch path.Base(name) {
+	case "sbom.cdx.json":
+		return models.SBOMCycloneDX
+	case "sbom.spdx.json":
+		return models.SBOMSPDX
+	case "sbom.syft.json":
+		return models.SBOMSyft
+	}
+	return ""
+}
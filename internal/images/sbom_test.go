@@ -0,0 +1,117 @@
+package images
+
+import (
+	"archive/tar"
+	"bytes"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SBOM", func() {
+	Describe("readSBOMLayer", func() {
+		layer := func(files map[string]string) *bytes.Buffer {
+			buffer := &bytes.Buffer{}
+			archive := tar.NewWriter(buffer)
+			for name, content := range files {
+				Expect(archive.WriteHeader(&tar.Header{
+					Name:     name,
+					Mode:     0644,
+					Size:     int64(len(content)),
+					Typeflag: tar.TypeReg,
+				})).To(Succeed())
+				_, err := archive.Write([]byte(content))
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(archive.Close()).To(Succeed())
+			return buffer
+		}
+
+		It("returns the SBOM documents, ordered by path", func() {
+			documents, err := readSBOMLayer(layer(map[string]string{
+				"/layers/sbom/launch/paketo-buildpacks_go-dist/go/sbom.spdx.json": `{"spdxVersion":"SPDX-2.2"}`,
+				"/layers/sbom/launch/paketo-buildpacks_go-dist/go/sbom.cdx.json":  `{"bomFormat":"CycloneDX"}`,
+				"/layers/sbom/launch/paketo-buildpacks_go-dist/go/other.json":     `{}`,
+			}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(documents).To(HaveLen(2))
+
+			Expect(documents[0].Path).To(Equal("launch/paketo-buildpacks_go-dist/go/sbom.cdx.json"))
+			Expect(documents[0].Format).To(Equal(models.SBOMCycloneDX))
+			Expect(string(documents[0].Content)).To(Equal(`{"bomFormat":"CycloneDX"}`))
+
+			Expect(documents[1].Path).To(Equal("launch/paketo-buildpacks_go-dist/go/sbom.spdx.json"))
+			Expect(documents[1].Format).To(Equal(models.SBOMSPDX))
+		})
+
+		It("rejects documents which are not JSON", func() {
+			_, err := readSBOMLayer(layer(map[string]string{
+				"/layers/sbom/launch/bp/sbom.syft.json": "not json",
+			}))
+			Expect(err).To(MatchError("SBOM document /layers/sbom/launch/bp/sbom.syft.json is not JSON"))
+		})
+	})
+
+	Describe("buildpacksOf", func() {
+		It("returns the buildpacks of the build metadata", func() {
+			buildpacks, err := buildpacksOf(map[string]string{
+				buildMetadataLabel: `{"buildpacks":[{"id":"paketo-buildpacks/go","version":"1.2.3","homepage":"https://paketo.io"}]}`,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buildpacks).To(Equal([]models.Buildpack{
+				{ID: "paketo-buildpacks/go", Version: "1.2.3", Homepage: "https://paketo.io"},
+			}))
+		})
+
+		It("returns no buildpacks for images without build metadata", func() {
+			buildpacks, err := buildpacksOf(map[string]string{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buildpacks).To(BeEmpty())
+		})
+	})
+
+	Describe("sbomLayer", func() {
+		image := manifest{
+			Layers: []descriptor{
+				{Digest: "sha256:aaa", MediaType: "application/vnd.docker.image.rootfs.diff.tar.gzip"},
+				{Digest: "sha256:bbb", MediaType: "application/vnd.docker.image.rootfs.diff.tar.gzip"},
+			},
+		}
+
+		It("locates the SBOM layer by its diff id", func() {
+			config := imageConfig{}
+			config.Config.Labels = map[string]string{lifecycleMetadataLabel: `{"sbom":{"sha":"sha256:222"}}`}
+			config.RootFS.DiffIDs = []string{"sha256:111", "sha256:222"}
+
+			layer, err := sbomLayer(image, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(layer.Digest).To(Equal("sha256:bbb"))
+		})
+
+		It("returns no layer for images without SBOM", func() {
+			config := imageConfig{}
+			config.Config.Labels = map[string]string{lifecycleMetadataLabel: `{"app":[]}`}
+
+			layer, err := sbomLayer(image, config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(layer).To(BeNil())
+		})
+	})
+
+	Describe("ExportsSBOM", func() {
+		It("requires platform API 0.8 or higher", func() {
+			Expect(ExportsSBOM("0.4")).To(BeFalse())
+			Expect(ExportsSBOM("0.7")).To(BeFalse())
+			Expect(ExportsSBOM("0.8")).To(BeTrue())
+			Expect(ExportsSBOM("0.10")).To(BeTrue())
+			Expect(ExportsSBOM("1.0")).To(BeTrue())
+		})
+
+		It("assumes unknown platform APIs to export SBOMs", func() {
+			Expect(ExportsSBOM("")).To(BeTrue())
+			Expect(ExportsSBOM("bogus")).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,186 @@
+package images
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/internal/helmchart"
+	"github.com/epinio/epinio/internal/registry"
+	"github.com/epinio/epinio/internal/s3manager"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// sbomPrefix is the prefix of the keys of the objects in the S3 storage holding the SBOMs
+// of the stagings, as JSON. The SBOM of a staging is recorded when it completes, and kept
+// independent of the retention of its image.
+const sbomPrefix = "sboms/"
+
+// SBOMKey returns the key of the object holding the SBOM of the staging of the application.
+func SBOMKey(appRef models.AppRef, stageID string) string {
+	return fmt.Sprintf("%s%s.json", sbomAppPrefix(appRef), stageID)
+}
+
+// sbomAppPrefix returns the prefix of the keys of the SBOMs of the application.
+func sbomAppPrefix(appRef models.AppRef) string {
+	return fmt.Sprintf("%s%s/%s/", sbomPrefix, appRef.Namespace, appRef.Name)
+}
+
+// SBOMManager returns the S3 manager for the storage of the SBOMs.
+func SBOMManager(ctx context.Context, cluster *kubernetes.Cluster) (*s3manager.Manager, error) {
+	connectionDetails, err := s3manager.GetConnectionDetails(ctx, cluster,
+		helmchart.Namespace(), helmchart.S3ConnectionDetailsSecretName)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching the S3 connection details")
+	}
+
+	return s3manager.New(connectionDetails)
+}
+
+// ReadSBOM reads the SBOM of the image with the tag of the repository from the registry.
+// The record describes the staging of the application which built the image.
+func ReadSBOM(ctx context.Context, client *registry.Client, registryURL string, appRef models.AppRef,
+	record models.StageRecord, repository, tag string) (*models.AppSBOM, error) {
+
+	digest, buildpacks, documents, err := SBOM(ctx, client, repository, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AppSBOM{
+		Namespace:  appRef.Namespace,
+		App:        appRef.Name,
+		Stage:      record,
+		Image:      fmt.Sprintf("%s/%s:%s", registryURL, repository, tag),
+		Digest:     digest,
+		Buildpacks: buildpacks,
+		Documents:  documents,
+	}, nil
+}
+
+// StoreSBOM stores the SBOM of the staging of the application, replacing any SBOM stored
+// for it before.
+func StoreSBOM(ctx context.Context, manager *s3manager.Manager, sbom models.AppSBOM) error {
+	encoded, err := json.Marshal(sbom)
+	if err != nil {
+		return errors.Wrap(err, "encoding the SBOM")
+	}
+
+	appRef := models.NewAppRef(sbom.App, sbom.Namespace)
+	return manager.Put(ctx, SBOMKey(appRef, sbom.Stage.ID), encoded, "application/json")
+}
+
+// LoadSBOM returns the stored SBOM of the staging of the application, or nil if there is
+// none.
+func LoadSBOM(ctx context.Context, manager *s3manager.Manager, appRef models.AppRef, stageID string) (*models.AppSBOM, error) {
+	encoded, found, err := manager.Get(ctx, SBOMKey(appRef, stageID))
+	if err != nil || !found {
+		return nil, err
+	}
+
+	sbom := models.AppSBOM{}
+	if err := json.Unmarshal(encoded, &sbom); err != nil {
+		return nil, errors.Wrapf(err, "decoding the stored SBOM of stage %s", stageID)
+	}
+
+	return &sbom, nil
+}
+
+// RecordSBOM reads the SBOM of the image of the staging of the application from the
+// registry, and stores it together with the record of the staging, unless it is stored
+// already. Stagings without record, and deleted applications, are skipped. The stored SBOMs
+// of stagings no longer recorded for the application are deleted, bounding them by the
+// number of stage records.
+func RecordSBOM(ctx context.Context, cluster *kubernetes.Cluster, client *registry.Client, appRef models.AppRef, stageID string) error {
+	manager, err := SBOMManager(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	exists, err := manager.Exists(ctx, SBOMKey(appRef, stageID))
+	if err != nil || exists {
+		return err
+	}
+
+	appClient, err := cluster.ClientApp()
+	if err != nil {
+		return err
+	}
+	app, err := appClient.Namespace(appRef.Namespace).Get(ctx, appRef.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "reading application %s", appRef.Name)
+	}
+	records, err := StageRecords(app)
+	if err != nil {
+		return err
+	}
+	record, found, err := FindStageRecord(app, stageID)
+	if err != nil || !found {
+		return err
+	}
+
+	details, err := registry.GetConnectionDetails(ctx, cluster, helmchart.Namespace(), registry.CredentialsSecretName)
+	if err != nil {
+		return err
+	}
+	registryURL, err := details.PublicRegistryURL()
+	if err != nil {
+		return err
+	}
+
+	sbom, err := ReadSBOM(ctx, client, registryURL, appRef, record, Repository(details.Namespace, appRef), stageID)
+	if err != nil {
+		return errors.Wrapf(err, "reading the SBOM of stage %s", stageID)
+	}
+	if err := StoreSBOM(ctx, manager, *sbom); err != nil {
+		return errors.Wrapf(err, "storing the SBOM of stage %s", stageID)
+	}
+
+	recorded := map[string]struct{}{}
+	for _, record := range records {
+		recorded[record.ID] = struct{}{}
+	}
+
+	return deleteSBOMs(ctx, manager, appRef, recorded)
+}
+
+// DeleteSBOMs deletes the stored SBOMs of the deleted application.
+func DeleteSBOMs(ctx context.Context, cluster *kubernetes.Cluster, appRef models.AppRef) error {
+	manager, err := SBOMManager(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	return deleteSBOMs(ctx, manager, appRef, nil)
+}
+
+// deleteSBOMs deletes the stored SBOMs of the application, except those of the kept
+// stagings.
+func deleteSBOMs(ctx context.Context, manager *s3manager.Manager, appRef models.AppRef, keep map[string]struct{}) error {
+	prefix := sbomAppPrefix(appRef)
+
+	objects, err := manager.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		stageID := strings.TrimSuffix(strings.TrimPrefix(object.Key, prefix), ".json")
+		if _, ok := keep[stageID]; ok {
+			continue
+		}
+		if err := manager.DeleteObject(ctx, object.Key); err != nil {
+			return errors.Wrapf(err, "deleting the SBOM of stage %s", stageID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package images
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SBOMKey", func() {
+	It("keys the SBOMs by application and staging", func() {
+		appRef := models.NewAppRef("app", "workspace")
+		Expect(SBOMKey(appRef, "s1")).To(Equal("sboms/workspace/app/s1.json"))
+		Expect(SBOMKey(appRef, "s1")).To(HavePrefix(sbomAppPrefix(appRef)))
+	})
+
+	It("keeps the SBOMs of applications sharing a name prefix apart", func() {
+		Expect(SBOMKey(models.NewAppRef("app", "workspace"), "s1")).ToNot(
+			HavePrefix(sbomAppPrefix(models.NewAppRef("ap", "workspace"))))
+	})
+})
@@ -0,0 +1,77 @@
+package images
+
+import (
+	"encoding/json"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// StageRecordsAnnotationKey is the annotation of the application resource holding the
+// records of its stagings, oldest first, as JSON.
+const StageRecordsAnnotationKey = "epinio.suse.org/stage-records"
+
+// stageRecordLimit is the maximal number of stage records kept per application. It
+// exceeds the number of images kept by the image retention, by default.
+const stageRecordLimit = 50
+
+// StageRecords returns the records of the stagings of the application, oldest first.
+func StageRecords(app *unstructured.Unstructured) ([]models.StageRecord, error) {
+	records := []models.StageRecord{}
+
+	encoded, ok := app.GetAnnotations()[StageRecordsAnnotationKey]
+	if !ok {
+		return records, nil
+	}
+	if err := json.Unmarshal([]byte(encoded), &records); err != nil {
+		return records, errors.Wrap(err, "bad stage records of the application")
+	}
+
+	return records, nil
+}
+
+// FindStageRecord returns the record of the staging with the given id. The bool result is
+// false if the staging is not recorded, e.g. for stagings older than the kept records.
+func FindStageRecord(app *unstructured.Unstructured, stageID string) (models.StageRecord, bool, error) {
+	records, err := StageRecords(app)
+	if err != nil {
+		return models.StageRecord{}, false, err
+	}
+
+	for _, record := range records {
+		if record.ID == stageID {
+			return record, true, nil
+		}
+	}
+
+	return models.StageRecord{ID: stageID}, false, nil
+}
+
+// AddStageRecord adds the record of a staging to the application resource. Only the
+// newest records are kept. The caller has to save the resource.
+func AddStageRecord(app *unstructured.Unstructured, record models.StageRecord) error {
+	records, err := StageRecords(app)
+	if err != nil {
+		return err
+	}
+
+	records = append(records, record)
+	if len(records) > stageRecordLimit {
+		records = records[len(records)-stageRecordLimit:]
+	}
+
+	encoded, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	annotations := app.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[StageRecordsAnnotationKey] = string(encoded)
+	app.SetAnnotations(annotations)
+
+	return nil
+}
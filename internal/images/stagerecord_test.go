@@ -0,0 +1,41 @@
+package images
+
+import (
+	"fmt"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stage records", func() {
+	It("finds the recorded stagings", func() {
+		app := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		Expect(AddStageRecord(app, models.StageRecord{ID: "s1", BlobUID: "b1"})).To(Succeed())
+		Expect(AddStageRecord(app, models.StageRecord{ID: "s2", GitCommit: "abc"})).To(Succeed())
+
+		record, found, err := FindStageRecord(app, "s2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(record.GitCommit).To(Equal("abc"))
+
+		record, found, err = FindStageRecord(app, "s9")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+		Expect(record).To(Equal(models.StageRecord{ID: "s9"}))
+	})
+
+	It("keeps only the newest records", func() {
+		app := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		for i := 0; i < 60; i++ {
+			Expect(AddStageRecord(app, models.StageRecord{ID: fmt.Sprintf("s%d", i)})).To(Succeed())
+		}
+
+		records, err := StageRecords(app)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(records).To(HaveLen(50))
+		Expect(records[0].ID).To(Equal("s10"))
+	})
+})
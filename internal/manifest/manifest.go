@@ -34,8 +34,9 @@ func UpdateRoutes(manifest models.ApplicationManifest, cmd *cobra.Command) (mode
 }
 
 // UpdateBASN updates the incoming manifest with information pulled from the --builder,
-// sources (--path, --git, and --container-imageurl), --app-chart, and --name options.
-// Option information replaces any existing information.
+// staging strategy (--strategy, --dockerfile, and --build-arg), sources (--path, --git,
+// and --container-imageurl), --app-chart, and --name options. Option information replaces
+// any existing information, except for build args, which are merged.
 func UpdateBASN(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
 	var err error
 	// BASN - Builder, AppChart, Source origin, Name
@@ -46,6 +47,11 @@ func UpdateBASN(manifest models.ApplicationManifest, cmd *cobra.Command) (models
 		return manifest, err
 	}
 
+	manifest, err = UpdateStrategy(manifest, cmd)
+	if err != nil {
+		return manifest, err
+	}
+
 	// A:ppChart - Retrieve from options
 	manifest, err = UpdateAppChart(manifest, cmd)
 	if err != nil {
@@ -83,6 +89,117 @@ func UpdateBuilder(manifest models.ApplicationManifest, cmd *cobra.Command) (mod
 	return manifest, nil
 }
 
+// UpdateStrategy updates the incoming manifest with information pulled from the --strategy,
+// --dockerfile, and --build-arg options. The build args are merged into the existing
+// information, replacing the values of args found in both.
+func UpdateStrategy(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
+	strategy, err := cmd.Flags().GetString("strategy")
+	if err != nil {
+		return manifest, errors.Wrap(err, "could not read option --strategy")
+	}
+	dockerfile, err := cmd.Flags().GetString("dockerfile")
+	if err != nil {
+		return manifest, errors.Wrap(err, "could not read option --dockerfile")
+	}
+	buildArgs, err := cmd.Flags().GetStringArray("build-arg")
+	if err != nil {
+		return manifest, errors.Wrap(err, "could not read option --build-arg")
+	}
+
+	if strategy != "" {
+		manifest.Staging.Strategy = strategy
+	}
+	if dockerfile != "" {
+		manifest.Staging.Dockerfile = dockerfile
+	}
+
+	for _, assignment := range buildArgs {
+		key, value, ok := strings.Cut(assignment, "=")
+		if !ok || key == "" {
+			return manifest, errors.New("Bad --build-arg assignment `" + assignment + "`, expected `name=value` as value")
+		}
+		if manifest.Staging.BuildArgs == nil {
+			manifest.Staging.BuildArgs = map[string]string{}
+		}
+		manifest.Staging.BuildArgs[key] = value
+	}
+
+	switch manifest.Staging.Strategy {
+	case "", models.StrategyBuildpacks:
+		if manifest.Staging.Dockerfile != "" || len(manifest.Staging.BuildArgs) > 0 {
+			return manifest, errors.New("Dockerfile and build args require the `dockerfile` staging strategy")
+		}
+	case models.StrategyDockerfile:
+		if manifest.Staging.Builder != "" {
+			return manifest, errors.New("A builder image cannot be used with the `dockerfile` staging strategy")
+		}
+	default:
+		return manifest, errors.Errorf("Bad staging strategy `%s`, expected `%s` or `%s`",
+			manifest.Staging.Strategy, models.StrategyBuildpacks, models.StrategyDockerfile)
+	}
+
+	return manifest, nil
+}
+
+// UpdateBuildEnvironment updates the incoming manifest with information pulled from the
+// --build-env and --build-secret options. Option information is merged into the existing
+// information, replacing the variables found in both.
+func UpdateBuildEnvironment(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
+	evAssignments, err := cmd.Flags().GetStringArray("build-env")
+	if err != nil {
+		return manifest, errors.Wrap(err, "could not read option --build-env")
+	}
+	secretAssignments, err := cmd.Flags().GetStringSlice("build-secret")
+	if err != nil {
+		return manifest, errors.Wrap(err, "could not read option --build-secret")
+	}
+
+	for _, assignment := range evAssignments {
+		name, value, ok := strings.Cut(assignment, "=")
+		if !ok || name == "" {
+			return manifest, errors.New("Bad --build-env assignment `" + assignment + "`, expected `name=value` as value")
+		}
+		if manifest.Staging.Environment == nil {
+			manifest.Staging.Environment = models.EnvVariableMap{}
+		}
+		manifest.Staging.Environment[name] = value
+		delete(manifest.Staging.Secrets, name)
+	}
+
+	for _, assignment := range secretAssignments {
+		name, reference, ok := strings.Cut(assignment, "=")
+		if !ok || name == "" {
+			return manifest, errors.New("Bad --build-secret assignment `" + assignment + "`, expected `name=CONFIGURATION/KEY` as value")
+		}
+		source, err := models.ParseEnvVariableSource(reference)
+		if err != nil {
+			return manifest, errors.Wrapf(err, "Bad --build-secret assignment `%s`", assignment)
+		}
+		if manifest.Staging.Secrets == nil {
+			manifest.Staging.Secrets = models.EnvVariableRefMap{}
+		}
+		manifest.Staging.Secrets[name] = source
+		delete(manifest.Staging.Environment, name)
+	}
+
+	return manifest, nil
+}
+
+// UpdateExcludes updates the incoming manifest with information pulled from the --exclude
+// option. Option information is added to the existing information.
+func UpdateExcludes(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
+	excludes, err := cmd.Flags().GetStringSlice("exclude")
+	if err != nil {
+		return manifest, errors.Wrap(err, "could not read option --exclude")
+	}
+
+	// E:xcludes - Add
+
+	manifest.Staging.Exclude = append(manifest.Staging.Exclude, excludes...)
+
+	return manifest, nil
+}
+
 // UpdateAppChart updates the incoming manifest with information pulled from the --app-chart option
 func UpdateAppChart(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
 	appChart, err := cmd.Flags().GetString("app-chart")
@@ -287,6 +404,44 @@ func UpdateEnvironment(manifest models.ApplicationManifest, cmd *cobra.Command)
 	return manifest, nil
 }
 
+// UpdateMetadata updates the incoming manifest with information pulled from the --label and
+// --annotation options. Option information is merged into the existing information,
+// replacing the values of keys found in both.
+func UpdateMetadata(manifest models.ApplicationManifest, cmd *cobra.Command) (models.ApplicationManifest, error) {
+	labels, err := cmd.Flags().GetStringSlice("label")
+	if err != nil {
+		return manifest, errors.Wrap(err, "failed to read option --label")
+	}
+	annotations, err := cmd.Flags().GetStringArray("annotation")
+	if err != nil {
+		return manifest, errors.Wrap(err, "failed to read option --annotation")
+	}
+
+	for _, assignment := range labels {
+		key, value, ok := strings.Cut(assignment, "=")
+		if !ok || key == "" {
+			return manifest, errors.New("Bad --label assignment `" + assignment + "`, expected `key=value` as value")
+		}
+		if manifest.Configuration.Labels == nil {
+			manifest.Configuration.Labels = map[string]string{}
+		}
+		manifest.Configuration.Labels[key] = value
+	}
+
+	for _, assignment := range annotations {
+		key, value, ok := strings.Cut(assignment, "=")
+		if !ok || key == "" {
+			return manifest, errors.New("Bad --annotation assignment `" + assignment + "`, expected `key=value` as value")
+		}
+		if manifest.Configuration.Annotations == nil {
+			manifest.Configuration.Annotations = map[string]string{}
+		}
+		manifest.Configuration.Annotations[key] = value
+	}
+
+	return manifest, nil
+}
+
 // Get reads the manifest at the spcified path into
 // memory. Note that a missing file is not an error. It simply maps to
 // an empty manifest.
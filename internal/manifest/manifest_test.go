@@ -9,6 +9,7 @@ import (
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
 )
 
 var _ = Describe("Manifest", func() {
@@ -141,5 +142,131 @@ configuration:
 
 			})
 		})
+
+		When("the desired manifest file configures the staging job", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile("stagingjob.yml", []byte(`name: foo
+staging:
+  memory_request: 512Mi
+  memory_limit: 2Gi
+  node_selector:
+    pool: builders
+  tolerations:
+  - dedicated=builders:NoSchedule
+  timeout: 30m
+  backoff_limit: 1
+`), 0600)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				err := os.Remove("stagingjob.yml")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("reads the job settings", func() {
+				m, err := manifest.Get("stagingjob.yml")
+				Expect(err).ToNot(HaveOccurred())
+				var backoffLimit int32 = 1
+				Expect(m.Staging.Job).To(Equal(models.StagingSettings{
+					MemoryRequest: "512Mi",
+					MemoryLimit:   "2Gi",
+					NodeSelector:  map[string]string{"pool": "builders"},
+					Tolerations:   []string{"dedicated=builders:NoSchedule"},
+					Timeout:       "30m",
+					BackoffLimit:  &backoffLimit,
+				}))
+			})
+		})
+	})
+
+	Describe("UpdateStrategy", func() {
+		var cmd *cobra.Command
+
+		BeforeEach(func() {
+			cmd = &cobra.Command{}
+			cmd.Flags().String("strategy", "", "")
+			cmd.Flags().String("dockerfile", "", "")
+			cmd.Flags().StringArray("build-arg", []string{}, "")
+		})
+
+		It("merges the options into the manifest", func() {
+			Expect(cmd.Flags().Parse([]string{"--dockerfile", "build/Dockerfile",
+				"--build-arg", "VERSION=2", "--build-arg", "MODE=a=b"})).To(Succeed())
+
+			m, err := manifest.UpdateStrategy(models.ApplicationManifest{
+				Staging: models.ApplicationStage{
+					Strategy:  models.StrategyDockerfile,
+					BuildArgs: map[string]string{"VERSION": "1", "DEBUG": "0"},
+				},
+			}, cmd)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Staging).To(Equal(models.ApplicationStage{
+				Strategy:   models.StrategyDockerfile,
+				Dockerfile: "build/Dockerfile",
+				BuildArgs:  map[string]string{"VERSION": "2", "DEBUG": "0", "MODE": "a=b"},
+			}))
+		})
+
+		It("rejects unknown strategies", func() {
+			Expect(cmd.Flags().Parse([]string{"--strategy", "magic"})).To(Succeed())
+
+			_, err := manifest.UpdateStrategy(models.ApplicationManifest{}, cmd)
+			Expect(err).To(MatchError("Bad staging strategy `magic`, expected `buildpacks` or `dockerfile`"))
+		})
+
+		It("rejects a Dockerfile without the dockerfile strategy", func() {
+			Expect(cmd.Flags().Parse([]string{"--dockerfile", "Dockerfile"})).To(Succeed())
+
+			_, err := manifest.UpdateStrategy(models.ApplicationManifest{}, cmd)
+			Expect(err).To(MatchError(ContainSubstring("require the `dockerfile` staging strategy")))
+		})
+
+		It("rejects a builder image with the dockerfile strategy", func() {
+			Expect(cmd.Flags().Parse([]string{"--strategy", "dockerfile"})).To(Succeed())
+
+			_, err := manifest.UpdateStrategy(models.ApplicationManifest{
+				Staging: models.ApplicationStage{Builder: "paketobuildpacks/builder:full"},
+			}, cmd)
+			Expect(err).To(MatchError(ContainSubstring("cannot be used with the `dockerfile` staging strategy")))
+		})
+	})
+
+	Describe("UpdateBuildEnvironment", func() {
+		var cmd *cobra.Command
+
+		BeforeEach(func() {
+			cmd = &cobra.Command{}
+			cmd.Flags().StringArray("build-env", []string{}, "")
+			cmd.Flags().StringSlice("build-secret", []string{}, "")
+		})
+
+		It("merges the options into the manifest", func() {
+			Expect(cmd.Flags().Parse([]string{"--build-env", "BP_NODE_VERSION=18",
+				"--build-env", "TOKEN=plain", "--build-secret", "NPM_TOKEN=npm/token"})).To(Succeed())
+
+			m, err := manifest.UpdateBuildEnvironment(models.ApplicationManifest{
+				Staging: models.ApplicationStage{
+					Secrets: models.EnvVariableRefMap{
+						"TOKEN": {Configuration: "registry", Key: "token"},
+					},
+				},
+			}, cmd)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.Staging.Environment).To(Equal(models.EnvVariableMap{
+				"BP_NODE_VERSION": "18",
+				"TOKEN":           "plain",
+			}))
+			Expect(m.Staging.Secrets).To(Equal(models.EnvVariableRefMap{
+				"NPM_TOKEN": {Configuration: "npm", Key: "token"},
+			}))
+		})
+
+		It("rejects bad secret references", func() {
+			Expect(cmd.Flags().Parse([]string{"--build-secret", "NPM_TOKEN=npm"})).To(Succeed())
+
+			_, err := manifest.UpdateBuildEnvironment(models.ApplicationManifest{}, cmd)
+			Expect(err).To(MatchError(ContainSubstring("bad reference `npm`")))
+		})
 	})
 })
@@ -0,0 +1,267 @@
+package namespaces
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// DefaultsSecretName is the name of the secret holding the defaults of a namespace.
+	// The data of the secret are the default environment variables of the apps.
+	DefaultsSecretName = "epinio-namespace-defaults"
+	// DefaultConfigurationsAnnotationKey is the annotation of the defaults secret holding
+	// the names of the configurations bound to all apps, as JSON.
+	DefaultConfigurationsAnnotationKey = "epinio.suse.org/default-configurations"
+	// DefaultBuilderAnnotationKey is the annotation of the defaults secret holding the
+	// name of the builder used to stage apps which do not specify one.
+	DefaultBuilderAnnotationKey = "epinio.suse.org/default-builder"
+)
+
+// Defaults returns the default environment variables and configuration bindings of the
+// apps in the namespace. A namespace without defaults returns empty results.
+func Defaults(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (models.EnvVariableMap, []string, error) {
+	secret, err := cluster.GetSecret(ctx, namespace, DefaultsSecretName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return models.EnvVariableMap{}, []string{}, nil
+		}
+		return nil, nil, err
+	}
+
+	environment := models.EnvVariableMap{}
+	for name, value := range secret.Data {
+		environment[name] = string(value)
+	}
+
+	configurations, err := defaultConfigurations(secret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return environment, configurations, nil
+}
+
+// MergeEnvironment returns the environment of an app with the namespace defaults merged
+// in. Values set by the app take precedence over the defaults, as do the variables the app
+// references from configurations.
+func MergeEnvironment(defaults, environment models.EnvVariableMap, references models.EnvVariableRefMap) models.EnvVariableMap {
+	result := models.EnvVariableMap{}
+	for name, value := range defaults {
+		if _, ok := references[name]; ok {
+			continue
+		}
+		result[name] = value
+	}
+	for name, value := range environment {
+		result[name] = value
+	}
+	return result
+}
+
+// MergeConfigurations returns the default configurations of the namespace which are not
+// already in the set of configurations bound to an app.
+func MergeConfigurations(defaults []string, bound []string) []string {
+	known := map[string]struct{}{}
+	for _, name := range bound {
+		known[name] = struct{}{}
+	}
+
+	result := []string{}
+	for _, name := range defaults {
+		if _, ok := known[name]; ok {
+			continue
+		}
+		known[name] = struct{}{}
+		result = append(result, name)
+	}
+	return result
+}
+
+// DefaultEnvironmentSet adds or modifies the specified default environment variables.
+func DefaultEnvironmentSet(ctx context.Context, cluster *kubernetes.Cluster, namespace string, assignments models.EnvVariableMap) error {
+	return defaultsUpdate(ctx, cluster, namespace, func(secret *corev1.Secret, configurations map[string]struct{}) {
+		for name, value := range assignments {
+			secret.Data[name] = []byte(value)
+		}
+	})
+}
+
+// DefaultEnvironmentUnset removes the specified default environment variable.
+func DefaultEnvironmentUnset(ctx context.Context, cluster *kubernetes.Cluster, namespace, varName string) error {
+	return defaultsUpdate(ctx, cluster, namespace, func(secret *corev1.Secret, configurations map[string]struct{}) {
+		delete(secret.Data, varName)
+	})
+}
+
+// DefaultConfigurationsAdd adds the named configurations to the configurations bound to
+// all apps of the namespace.
+func DefaultConfigurationsAdd(ctx context.Context, cluster *kubernetes.Cluster, namespace string, names []string) error {
+	return defaultsUpdate(ctx, cluster, namespace, func(secret *corev1.Secret, configurations map[string]struct{}) {
+		for _, name := range names {
+			configurations[name] = struct{}{}
+		}
+	})
+}
+
+// DefaultConfigurationsRemove removes the named configuration from the configurations
+// bound to all apps of the namespace. Removing a configuration which is not a default is
+// a no-op.
+func DefaultConfigurationsRemove(ctx context.Context, cluster *kubernetes.Cluster, namespace, name string) error {
+	return defaultsUpdate(ctx, cluster, namespace, func(secret *corev1.Secret, configurations map[string]struct{}) {
+		delete(configurations, name)
+	})
+}
+
+// DefaultBuilder returns the name of the default builder of the apps in the namespace, or
+// the empty string.
+func DefaultBuilder(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (string, error) {
+	secret, err := cluster.GetSecret(ctx, namespace, DefaultsSecretName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return secret.Annotations[DefaultBuilderAnnotationKey], nil
+}
+
+// DefaultBuilderSet sets the name of the default builder of the apps in the namespace. The
+// empty name removes the default.
+func DefaultBuilderSet(ctx context.Context, cluster *kubernetes.Cluster, namespace, name string) error {
+	return defaultsUpdate(ctx, cluster, namespace, func(secret *corev1.Secret, configurations map[string]struct{}) {
+		if name == "" {
+			delete(secret.Annotations, DefaultBuilderAnnotationKey)
+			return
+		}
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[DefaultBuilderAnnotationKey] = name
+	})
+}
+
+// NamesUsingBuilder returns the names of the namespaces using the named builder as their
+// default builder, sorted.
+func NamesUsingBuilder(ctx context.Context, cluster *kubernetes.Cluster, builder string) ([]string, error) {
+	namespaceList, err := List(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	for _, namespace := range namespaceList {
+		name, err := DefaultBuilder(ctx, cluster, namespace.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading the default builder of namespace %s", namespace.Name)
+		}
+		if name == builder {
+			result = append(result, namespace.Name)
+		}
+	}
+
+	sort.Strings(result)
+	return result, nil
+}
+
+// defaultsUpdate encapsulates the read/modify/write cycle for the defaults of the
+// namespace. The secret holding them is created if necessary. The modifier gets the
+// default configurations in decoded form.
+func defaultsUpdate(ctx context.Context, cluster *kubernetes.Cluster, namespace string,
+	modifyDefaults func(*corev1.Secret, map[string]struct{})) error {
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := defaultsLoad(ctx, cluster, namespace)
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+
+		names, err := defaultConfigurations(secret)
+		if err != nil {
+			return err
+		}
+		configurations := map[string]struct{}{}
+		for _, name := range names {
+			configurations[name] = struct{}{}
+		}
+
+		modifyDefaults(secret, configurations)
+
+		names = []string{}
+		for name := range configurations {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		encoded, err := json.Marshal(names)
+		if err != nil {
+			return errors.Wrap(err, "encoding default configurations")
+		}
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[DefaultConfigurationsAnnotationKey] = string(encoded)
+
+		_, err = cluster.Kubectl.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// defaultsLoad returns the secret holding the defaults of the namespace, creating it if
+// necessary.
+func defaultsLoad(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (*corev1.Secret, error) {
+	secret, err := cluster.GetSecret(ctx, namespace, DefaultsSecretName)
+	if err == nil {
+		return secret, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "error getting secret %s", DefaultsSecretName)
+	}
+
+	err = cluster.CreateSecret(ctx, namespace, corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DefaultsSecretName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "epinio",
+				"app.kubernetes.io/part-of":    namespace,
+				"epinio.suse.org/area":         "namespace-defaults",
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster.GetSecret(ctx, namespace, DefaultsSecretName)
+}
+
+// defaultConfigurations decodes the default configurations stored in the secret.
+func defaultConfigurations(secret *corev1.Secret) ([]string, error) {
+	names := []string{}
+
+	encoded, ok := secret.Annotations[DefaultConfigurationsAnnotationKey]
+	if !ok || encoded == "" {
+		return names, nil
+	}
+
+	err := json.Unmarshal([]byte(encoded), &names)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding default configurations")
+	}
+
+	return names, nil
+}
@@ -0,0 +1,31 @@
+package namespaces_test
+
+import (
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Namespace defaults", func() {
+	It("merges the default environment under the app environment", func() {
+		merged := namespaces.MergeEnvironment(
+			models.EnvVariableMap{"LOG_LEVEL": "info", "REGION": "eu", "DB_URL": "default"},
+			models.EnvVariableMap{"LOG_LEVEL": "debug"},
+			models.EnvVariableRefMap{"DB_URL": {Configuration: "db", Key: "url"}})
+
+		Expect(merged).To(Equal(models.EnvVariableMap{
+			"LOG_LEVEL": "debug",
+			"REGION":    "eu",
+		}))
+	})
+
+	It("adds only the default configurations not bound already", func() {
+		Expect(namespaces.MergeConfigurations(
+			[]string{"db", "cache", "cache"},
+			[]string{"db", "queue"})).To(Equal([]string{"cache"}))
+
+		Expect(namespaces.MergeConfigurations(nil, []string{"db"})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,108 @@
+package namespaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// ProtectedAnnotationKey is the annotation marking a namespace as protected against
+	// deletion
+	ProtectedAnnotationKey = "epinio.suse.org/protected"
+	// DeleteAfterAnnotationKey is the annotation of a soft-deleted namespace holding the
+	// time after which it is deleted for good, in RFC3339 format
+	DeleteAfterAnnotationKey = "epinio.suse.org/delete-after"
+)
+
+// ProtectedError is returned when trying to delete a protected namespace
+type ProtectedError struct {
+	Namespace string
+}
+
+func (e ProtectedError) Error() string {
+	return "namespace '" + e.Namespace + "' is protected against deletion"
+}
+
+// ScheduleDeletion marks the namespace for deletion at the given time. Protected
+// namespaces are refused with a ProtectedError.
+func ScheduleDeletion(ctx context.Context, cluster *kubernetes.Cluster, namespace string, when time.Time) error {
+	return namespaceUpdate(ctx, cluster, namespace, func(ns *corev1.Namespace) error {
+		if ns.Annotations[ProtectedAnnotationKey] == "true" {
+			return ProtectedError{Namespace: namespace}
+		}
+		ns.Annotations[DeleteAfterAnnotationKey] = when.UTC().Format(time.RFC3339)
+		return nil
+	})
+}
+
+// CancelDeletion removes the deletion mark of the namespace
+func CancelDeletion(ctx context.Context, cluster *kubernetes.Cluster, namespace string) error {
+	return namespaceUpdate(ctx, cluster, namespace, func(ns *corev1.Namespace) error {
+		delete(ns.Annotations, DeleteAfterAnnotationKey)
+		return nil
+	})
+}
+
+// ProtectedSet protects the namespace against deletion, or removes that protection
+func ProtectedSet(ctx context.Context, cluster *kubernetes.Cluster, namespace string, protected bool) error {
+	return namespaceUpdate(ctx, cluster, namespace, func(ns *corev1.Namespace) error {
+		if protected {
+			ns.Annotations[ProtectedAnnotationKey] = "true"
+		} else {
+			delete(ns.Annotations, ProtectedAnnotationKey)
+		}
+		return nil
+	})
+}
+
+// deleteAfter returns the time after which a soft-deleted namespace is deleted for good,
+// or nil if the namespace is not marked for deletion.
+func deleteAfter(annotations map[string]string) *time.Time {
+	value, ok := annotations[DeleteAfterAnnotationKey]
+	if !ok {
+		return nil
+	}
+
+	when, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+
+	return &when
+}
+
+// namespaceUpdate is a helper for the public functions. It encapsulates the
+// read/modify/write cycle necessary to update the labels and annotations of a namespace.
+func namespaceUpdate(ctx context.Context, cluster *kubernetes.Cluster, namespace string,
+	modifyNamespace func(*corev1.Namespace) error) error {
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		namespaces := cluster.Kubectl.CoreV1().Namespaces()
+
+		ns, err := namespaces.Get(ctx, namespace, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if ns.Labels == nil {
+			ns.Labels = map[string]string{}
+		}
+		if ns.Annotations == nil {
+			ns.Annotations = map[string]string{}
+		}
+
+		err = modifyNamespace(ns)
+		if err != nil {
+			return err
+		}
+
+		_, err = namespaces.Update(ctx, ns, metav1.UpdateOptions{})
+		return errors.Wrap(err, "updating namespace")
+	})
+}
@@ -0,0 +1,177 @@
+package namespaces
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// DescriptionAnnotationKey is the annotation of a namespace holding its description
+	DescriptionAnnotationKey = "epinio.suse.org/description"
+	// OwnerAnnotationKey is the annotation of a namespace holding the team owning it
+	OwnerAnnotationKey = "epinio.suse.org/owner"
+	// DefaultAnnotations are the annotations of new namespaces, when the server is not
+	// configured with others.
+	DefaultAnnotations = "linkerd.io/inject=enabled"
+)
+
+// reservedLabelDomains are the domains of label keys which users cannot set. They are
+// used by kubernetes and epinio.
+var reservedLabelDomains = []string{"kubernetes.io", "k8s.io", "epinio.suse.org", "epinio.io"}
+
+// Metadata holds the user-defined labels, description and owner of a namespace
+type Metadata struct {
+	Labels      map[string]string
+	Description string
+	Owner       string
+}
+
+// MetadataUpdate holds changes to the metadata of a namespace. Labels are added or
+// replaced, or removed. Nil strings are not changed.
+type MetadataUpdate struct {
+	Labels       map[string]string
+	RemoveLabels []string
+	Description  *string
+	Owner        *string
+}
+
+// ValidateLabels checks that the labels are valid kubernetes labels which are not reserved
+// for the system.
+func ValidateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if err := ValidateLabelKey(key); err != nil {
+			return err
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid value '%s' of label '%s': %s", value, key, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// ValidateSelector checks that the selector is a valid kubernetes label selector
+func ValidateSelector(selector string) error {
+	_, err := labels.Parse(selector)
+	return err
+}
+
+// ConfiguredAnnotations returns the annotations new namespaces get, as configured for the
+// server. The configuration is a comma-separated list of KEY=VALUE. Keys without a value
+// are dropped, allowing the removal of a default annotation.
+func ConfiguredAnnotations() (map[string]string, error) {
+	result := map[string]string{}
+
+	config := DefaultAnnotations
+	if viper.IsSet("namespace-annotations") {
+		config = viper.GetString("namespace-annotations")
+	}
+
+	for _, entry := range strings.Split(config, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("bad namespace annotation '%s', expected KEY=VALUE", entry)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid namespace annotation '%s': %s", key, strings.Join(errs, ", "))
+		}
+		if value == "" {
+			delete(result, key)
+			continue
+		}
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+// UpdateMetadata changes the labels, description, and owner of the namespace.
+func UpdateMetadata(ctx context.Context, cluster *kubernetes.Cluster, namespace string, update MetadataUpdate) error {
+	if err := ValidateLabels(update.Labels); err != nil {
+		return err
+	}
+	for _, key := range update.RemoveLabels {
+		if err := ValidateLabelKey(key); err != nil {
+			return err
+		}
+	}
+
+	return namespaceUpdate(ctx, cluster, namespace, func(ns *corev1.Namespace) error {
+		for _, key := range update.RemoveLabels {
+			delete(ns.Labels, key)
+		}
+		for key, value := range update.Labels {
+			ns.Labels[key] = value
+		}
+
+		setAnnotation(ns.Annotations, DescriptionAnnotationKey, update.Description)
+		setAnnotation(ns.Annotations, OwnerAnnotationKey, update.Owner)
+		return nil
+	})
+}
+
+// setAnnotation is a helper to UpdateMetadata. It sets or, for an empty value, removes the
+// annotation. A nil value leaves the annotation as is.
+func setAnnotation(annotations map[string]string, key string, value *string) {
+	if value == nil {
+		return
+	}
+	if *value == "" {
+		delete(annotations, key)
+		return
+	}
+	annotations[key] = *value
+}
+
+// UserLabels returns the labels of a resource which were set by users, i.e. all which are
+// not reserved for the system. It applies to annotations as well.
+func UserLabels(all map[string]string) map[string]string {
+	result := map[string]string{}
+	for key, value := range all {
+		if ReservedKey(key) {
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// ValidateLabelKey checks that the key is a valid label key which is not reserved
+func ValidateLabelKey(key string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("invalid label '%s': %s", key, strings.Join(errs, ", "))
+	}
+	if ReservedKey(key) {
+		return fmt.Errorf("label '%s' is reserved", key)
+	}
+	return nil
+}
+
+// ReservedKey returns true for the keys of labels and annotations set by kubernetes or epinio
+func ReservedKey(key string) bool {
+	if key == "kubed-sync" {
+		return true
+	}
+
+	prefix, _, ok := strings.Cut(key, "/")
+	if !ok {
+		return false
+	}
+	for _, domain := range reservedLabelDomains {
+		if prefix == domain || strings.HasSuffix(prefix, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
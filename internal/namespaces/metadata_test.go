@@ -0,0 +1,57 @@
+package namespaces_test
+
+import (
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/spf13/viper"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Namespace metadata", func() {
+	It("validates labels", func() {
+		Expect(namespaces.ValidateLabels(map[string]string{"team": "payments", "example.com/tier": "gold"})).To(Succeed())
+		Expect(namespaces.ValidateLabels(map[string]string{"team": "pay ments"})).
+			To(MatchError(ContainSubstring("invalid value 'pay ments' of label 'team'")))
+		Expect(namespaces.ValidateLabels(map[string]string{"bad key": "x"})).
+			To(MatchError(ContainSubstring("invalid label 'bad key'")))
+	})
+
+	It("rejects labels reserved for the system", func() {
+		for _, key := range []string{"kubed-sync", "app.kubernetes.io/component", "epinio.suse.org/owner", "node.k8s.io/x"} {
+			Expect(namespaces.ValidateLabels(map[string]string{key: "x"})).
+				To(MatchError("label '" + key + "' is reserved"))
+		}
+	})
+
+	It("validates selectors", func() {
+		Expect(namespaces.ValidateSelector("team=payments,tier!=free")).To(Succeed())
+		Expect(namespaces.ValidateSelector("")).To(Succeed())
+		Expect(namespaces.ValidateSelector("team in payments")).ToNot(Succeed())
+	})
+
+	Describe("ConfiguredAnnotations", func() {
+		AfterEach(func() {
+			viper.Set("namespace-annotations", nil)
+		})
+
+		It("defaults to linkerd injection", func() {
+			Expect(namespaces.ConfiguredAnnotations()).To(Equal(map[string]string{
+				"linkerd.io/inject": "enabled",
+			}))
+		})
+
+		It("uses the configured annotations, dropping those without value", func() {
+			viper.Set("namespace-annotations", "linkerd.io/inject=enabled, istio.io/rev=stable,linkerd.io/inject=")
+			Expect(namespaces.ConfiguredAnnotations()).To(Equal(map[string]string{
+				"istio.io/rev": "stable",
+			}))
+		})
+
+		It("rejects bad annotations", func() {
+			viper.Set("namespace-annotations", "linkerd.io/inject")
+			_, err := namespaces.ConfiguredAnnotations()
+			Expect(err).To(MatchError(ContainSubstring("expected KEY=VALUE")))
+		})
+	})
+})
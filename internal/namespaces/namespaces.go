@@ -5,6 +5,7 @@ package namespaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/duration"
@@ -18,11 +19,28 @@ import (
 type Namespace struct {
 	Name      string
 	CreatedAt metav1.Time
+	Metadata
+	Protected   bool
+	DeleteAfter *time.Time
 }
 
 func List(ctx context.Context, kubeClient *kubernetes.Cluster) ([]Namespace, error) {
+	return ListSelected(ctx, kubeClient, "")
+}
+
+// ListSelected returns the epinio-controlled namespaces whose labels match the selector.
+// An empty selector matches all namespaces.
+func ListSelected(ctx context.Context, kubeClient *kubernetes.Cluster, selector string) ([]Namespace, error) {
+	labelSelector := kubernetes.EpinioNamespaceLabelKey + "=" + kubernetes.EpinioNamespaceLabelValue
+	if selector != "" {
+		if err := ValidateSelector(selector); err != nil {
+			return []Namespace{}, err
+		}
+		labelSelector += "," + selector
+	}
+
 	listOptions := metav1.ListOptions{
-		LabelSelector: kubernetes.EpinioNamespaceLabelKey + "=" + kubernetes.EpinioNamespaceLabelValue,
+		LabelSelector: labelSelector,
 	}
 
 	namespaceList, err := kubeClient.Kubectl.CoreV1().Namespaces().List(ctx, listOptions)
@@ -35,6 +53,13 @@ func List(ctx context.Context, kubeClient *kubernetes.Cluster) ([]Namespace, err
 		result = append(result, Namespace{
 			Name:      namespace.ObjectMeta.Name,
 			CreatedAt: namespace.ObjectMeta.CreationTimestamp,
+			Metadata: Metadata{
+				Labels:      UserLabels(namespace.ObjectMeta.Labels),
+				Description: namespace.ObjectMeta.Annotations[DescriptionAnnotationKey],
+				Owner:       namespace.ObjectMeta.Annotations[OwnerAnnotationKey],
+			},
+			Protected:   namespace.ObjectMeta.Annotations[ProtectedAnnotationKey] == "true",
+			DeleteAfter: deleteAfter(namespace.ObjectMeta.Annotations),
 		})
 	}
 
@@ -73,20 +98,38 @@ func Get(ctx context.Context, kubeClient *kubernetes.Cluster, lookupNamespace st
 }
 
 // Create generates a new epinio-controlled namespace, i.e. a kube
-// namespace plus a configuration account.
-func Create(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string) error {
+// namespace plus a configuration account. The namespace gets the
+// user's metadata, and the annotations configured for the server.
+func Create(ctx context.Context, kubeClient *kubernetes.Cluster, namespace string, metadata Metadata) error {
+	if err := ValidateLabels(metadata.Labels); err != nil {
+		return err
+	}
+
+	annotations, err := ConfiguredAnnotations()
+	if err != nil {
+		return err
+	}
+	if metadata.Description != "" {
+		annotations[DescriptionAnnotationKey] = metadata.Description
+	}
+	if metadata.Owner != "" {
+		annotations[OwnerAnnotationKey] = metadata.Owner
+	}
+
+	labels := map[string]string{}
+	for key, value := range metadata.Labels {
+		labels[key] = value
+	}
+	labels["kubed-sync"] = "registry-creds" // Instruct kubed to copy image pull secrets over.
+	labels[kubernetes.EpinioNamespaceLabelKey] = kubernetes.EpinioNamespaceLabelValue
+
 	if _, err := kubeClient.Kubectl.CoreV1().Namespaces().Create(
 		ctx,
 		&corev1.Namespace{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: namespace,
-				Labels: map[string]string{
-					"kubed-sync":                       "registry-creds", // Instruct kubed to copy image pull secrets over.
-					kubernetes.EpinioNamespaceLabelKey: kubernetes.EpinioNamespaceLabelValue,
-				},
-				Annotations: map[string]string{
-					"linkerd.io/inject": "enabled",
-				},
+				Name:        namespace,
+				Labels:      labels,
+				Annotations: annotations,
 			},
 		},
 		metav1.CreateOptions{},
@@ -0,0 +1,261 @@
+package namespaces
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/epinio/epinio/helpers/kubernetes"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// QuotaName is the name of the ResourceQuota and LimitRange backing the quota of a
+	// namespace.
+	QuotaName = "epinio-quota"
+	// QuotaAnnotationKey is the annotation of the ResourceQuota holding the full quota
+	// of the namespace, as JSON. Not all of it can be expressed as a kubernetes quota.
+	QuotaAnnotationKey = "epinio.suse.org/quota"
+	// DefaultCPURequest is the CPU request of containers without one, in namespaces
+	// with a CPU quota. Kubernetes rejects such containers otherwise.
+	DefaultCPURequest = "100m"
+	// DefaultMemoryRequest is the memory request of containers without one, in
+	// namespaces with a memory quota.
+	DefaultMemoryRequest = "128Mi"
+
+	// appsCountResource is the kubernetes quota resource counting the apps.
+	appsCountResource = "count/apps.application.epinio.io"
+)
+
+// QuotaError is returned by CheckQuota when a change would take a namespace beyond its
+// quota.
+type QuotaError struct {
+	Resource  string
+	Limit     int64
+	Requested int64
+}
+
+func (e QuotaError) Error() string {
+	return fmt.Sprintf("quota exceeded: %d %s requested, %d allowed", e.Requested, e.Resource, e.Limit)
+}
+
+// ValidateQuota checks that the counts of the quota are not negative, and that CPU and
+// memory are valid resource quantities.
+func ValidateQuota(quota models.NamespaceQuota) error {
+	counts := []struct {
+		name  string
+		value int64
+	}{
+		{"apps", quota.Apps},
+		{"instances", quota.Instances},
+		{"services", quota.Services},
+		{"configurations", quota.Configurations},
+	}
+	for _, count := range counts {
+		if count.value < 0 {
+			return fmt.Errorf("quota for %s must not be negative", count.name)
+		}
+	}
+
+	for name, value := range map[string]string{"cpu": quota.CPU, "memory": quota.Memory} {
+		if value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return errors.Wrapf(err, "invalid %s quota '%s'", name, value)
+		}
+	}
+
+	return nil
+}
+
+// CheckQuota checks if adding the delta to the usage stays within the quota. It returns a
+// QuotaError for the first resource exceeding it. Only the counts are checked. CPU and
+// memory are enforced by kubernetes.
+func CheckQuota(quota models.NamespaceQuota, usage, delta models.NamespaceUsage) error {
+	counts := []struct {
+		name         string
+		limit        int64
+		used, change int64
+	}{
+		{"apps", quota.Apps, usage.Apps, delta.Apps},
+		{"instances", quota.Instances, usage.Instances, delta.Instances},
+		{"services", quota.Services, usage.Services, delta.Services},
+		{"configurations", quota.Configurations, usage.Configurations, delta.Configurations},
+	}
+
+	for _, count := range counts {
+		if count.limit == 0 || count.change <= 0 {
+			continue
+		}
+		if count.used+count.change > count.limit {
+			return QuotaError{
+				Resource:  count.name,
+				Limit:     count.limit,
+				Requested: count.used + count.change,
+			}
+		}
+	}
+
+	return nil
+}
+
+// Quota returns the quota of the namespace, or nil if it has none.
+func Quota(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (*models.NamespaceQuota, error) {
+	resourceQuota, err := cluster.Kubectl.CoreV1().ResourceQuotas(namespace).Get(ctx, QuotaName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	quota := models.NamespaceQuota{}
+	err = json.Unmarshal([]byte(resourceQuota.Annotations[QuotaAnnotationKey]), &quota)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding namespace quota")
+	}
+
+	return &quota, nil
+}
+
+// QuotaUsed returns the CPU and memory requested by the pods of the namespace, as tracked
+// by its kubernetes quota. The results are empty for resources not limited.
+func QuotaUsed(ctx context.Context, cluster *kubernetes.Cluster, namespace string) (string, string, error) {
+	resourceQuota, err := cluster.Kubectl.CoreV1().ResourceQuotas(namespace).Get(ctx, QuotaName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+
+	cpu := ""
+	if used, ok := resourceQuota.Status.Used[corev1.ResourceRequestsCPU]; ok {
+		cpu = used.String()
+	}
+	memory := ""
+	if used, ok := resourceQuota.Status.Used[corev1.ResourceRequestsMemory]; ok {
+		memory = used.String()
+	}
+
+	return cpu, memory, nil
+}
+
+// QuotaSet replaces the quota of the namespace. An empty quota removes it.
+func QuotaSet(ctx context.Context, cluster *kubernetes.Cluster, namespace string, quota models.NamespaceQuota) error {
+	if err := ValidateQuota(quota); err != nil {
+		return err
+	}
+
+	resourceQuota, limitRange, err := quotaResources(namespace, quota)
+	if err != nil {
+		return err
+	}
+
+	quotas := cluster.Kubectl.CoreV1().ResourceQuotas(namespace)
+	if resourceQuota == nil {
+		err = quotas.Delete(ctx, QuotaName, metav1.DeleteOptions{})
+	} else {
+		var current *corev1.ResourceQuota
+		current, err = quotas.Get(ctx, QuotaName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			_, err = quotas.Create(ctx, resourceQuota, metav1.CreateOptions{})
+		} else if err == nil {
+			resourceQuota.ResourceVersion = current.ResourceVersion
+			_, err = quotas.Update(ctx, resourceQuota, metav1.UpdateOptions{})
+		}
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "saving resource quota")
+	}
+
+	limits := cluster.Kubectl.CoreV1().LimitRanges(namespace)
+	if limitRange == nil {
+		err = limits.Delete(ctx, QuotaName, metav1.DeleteOptions{})
+	} else {
+		var current *corev1.LimitRange
+		current, err = limits.Get(ctx, QuotaName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			_, err = limits.Create(ctx, limitRange, metav1.CreateOptions{})
+		} else if err == nil {
+			limitRange.ResourceVersion = current.ResourceVersion
+			_, err = limits.Update(ctx, limitRange, metav1.UpdateOptions{})
+		}
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "saving limit range")
+	}
+
+	return nil
+}
+
+// quotaResources returns the kubernetes resources backing the quota. The ResourceQuota
+// is nil for an empty quota. The LimitRange, providing default requests, is nil when
+// neither CPU nor memory are limited.
+func quotaResources(namespace string, quota models.NamespaceQuota) (*corev1.ResourceQuota, *corev1.LimitRange, error) {
+	if quota.IsEmpty() {
+		return nil, nil, nil
+	}
+
+	encoded, err := json.Marshal(quota)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "encoding namespace quota")
+	}
+
+	meta := metav1.ObjectMeta{
+		Name:      QuotaName,
+		Namespace: namespace,
+		Labels: map[string]string{
+			"app.kubernetes.io/managed-by": "epinio",
+			"app.kubernetes.io/part-of":    namespace,
+		},
+	}
+
+	hard := corev1.ResourceList{}
+	defaults := corev1.ResourceList{}
+	if quota.Apps > 0 {
+		hard[appsCountResource] = *resource.NewQuantity(quota.Apps, resource.DecimalSI)
+	}
+	if quota.CPU != "" {
+		hard[corev1.ResourceRequestsCPU] = resource.MustParse(quota.CPU)
+		defaults[corev1.ResourceCPU] = resource.MustParse(DefaultCPURequest)
+	}
+	if quota.Memory != "" {
+		hard[corev1.ResourceRequestsMemory] = resource.MustParse(quota.Memory)
+		defaults[corev1.ResourceMemory] = resource.MustParse(DefaultMemoryRequest)
+	}
+
+	resourceQuota := &corev1.ResourceQuota{
+		ObjectMeta: *meta.DeepCopy(),
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: hard,
+		},
+	}
+	resourceQuota.Annotations = map[string]string{
+		QuotaAnnotationKey: string(encoded),
+	}
+
+	if len(defaults) == 0 {
+		return resourceQuota, nil, nil
+	}
+
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: *meta.DeepCopy(),
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{
+				{
+					Type:           corev1.LimitTypeContainer,
+					DefaultRequest: defaults,
+				},
+			},
+		},
+	}
+
+	return resourceQuota, limitRange, nil
+}
@@ -0,0 +1,31 @@
+package namespaces_test
+
+import (
+	"github.com/epinio/epinio/internal/namespaces"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Namespace quotas", func() {
+	It("validates the quota", func() {
+		Expect(namespaces.ValidateQuota(models.NamespaceQuota{Apps: 3, CPU: "2", Memory: "4Gi"})).To(Succeed())
+		Expect(namespaces.ValidateQuota(models.NamespaceQuota{Apps: -1})).
+			To(MatchError("quota for apps must not be negative"))
+		Expect(namespaces.ValidateQuota(models.NamespaceQuota{Memory: "lots"})).
+			To(MatchError(ContainSubstring("invalid memory quota 'lots'")))
+	})
+
+	It("reports the first resource exceeding the quota", func() {
+		quota := models.NamespaceQuota{Apps: 2, Instances: 4}
+		usage := models.NamespaceUsage{Apps: 1, Instances: 3, Services: 10}
+
+		Expect(namespaces.CheckQuota(quota, usage, models.NamespaceUsage{Apps: 1, Instances: 1})).To(Succeed())
+		Expect(namespaces.CheckQuota(quota, usage, models.NamespaceUsage{Services: 1})).To(Succeed())
+		Expect(namespaces.CheckQuota(quota, usage, models.NamespaceUsage{Instances: -2})).To(Succeed())
+
+		err := namespaces.CheckQuota(quota, usage, models.NamespaceUsage{Apps: 1, Instances: 2})
+		Expect(err).To(Equal(namespaces.QuotaError{Resource: "instances", Limit: 4, Requested: 5}))
+	})
+})
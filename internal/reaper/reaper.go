@@ -0,0 +1,34 @@
+// Package reaper runs the periodic background tasks of the API server, like the deletion
+// of soft-deleted namespaces, and the garbage collection of blobs and images.
+package reaper
+
+import (
+	"context"
+	"time"
+
+	"github.com/epinio/epinio/internal/cli/server/requestctx"
+	"github.com/go-logr/logr"
+)
+
+// Run calls reap every interval, until the context is done. The logger is made available
+// to reap through the context as well, see requestctx.Logger. Failures of reap are logged
+// with the message, and do not end the loop.
+func Run(ctx context.Context, logger logr.Logger, interval time.Duration, message string,
+	reap func(context.Context, logr.Logger) error) {
+
+	ctx = requestctx.WithLogger(ctx, logger)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := reap(ctx, logger); err != nil {
+				logger.Error(err, message)
+			}
+		}
+	}
+}
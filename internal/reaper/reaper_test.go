@@ -0,0 +1,37 @@
+package reaper_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/epinio/epinio/internal/reaper"
+	"github.com/go-logr/logr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Run", func() {
+	It("reaps every interval, despite failures, until the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var calls int32
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			reaper.Run(ctx, logr.Discard(), 10*time.Millisecond, "reaping",
+				func(context.Context, logr.Logger) error {
+					atomic.AddInt32(&calls, 1)
+					return errors.New("failed")
+				})
+		}()
+
+		Eventually(func() int32 { return atomic.LoadInt32(&calls) }).Should(BeNumerically(">=", 3))
+
+		cancel()
+		Eventually(done).Should(BeClosed())
+	})
+})
@@ -0,0 +1,320 @@
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	parser "github.com/novln/docker-parser"
+	"github.com/pkg/errors"
+)
+
+// manifestTypes are the media types of the image manifests accepted from the registry.
+// The digest of a manifest depends on its type, and deletion requires the digest of the
+// stored manifest.
+var manifestTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
+// Client talks to the HTTP API (v2) of a container registry. It supports basic and bearer
+// token authentication.
+type Client struct {
+	baseURL  string
+	username string
+	password string
+	http     *http.Client
+}
+
+// NewClient returns a client for the public registry of the connection details. The CA
+// certificate is optional, and trusted in addition to the system roots.
+func NewClient(details *ConnectionDetails, ca []byte) (*Client, error) {
+	publicURL, err := details.PublicRegistryURL()
+	if err != nil {
+		return nil, err
+	}
+	if publicURL == "" {
+		return nil, errors.New("no public registry URL found")
+	}
+
+	client := &Client{
+		baseURL: "https://" + strings.TrimPrefix(publicURL, "https://"),
+		http:    &http.Client{},
+	}
+	for _, credentials := range details.RegistryCredentials {
+		if credentials.URL == publicURL {
+			client.username = credentials.Username
+			client.password = credentials.Password
+		}
+	}
+
+	if len(ca) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if ok := rootCAs.AppendCertsFromPEM(ca); !ok {
+			return nil, errors.New("cannot append registry ca to client")
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+		client.http.Transport = transport
+	}
+
+	return client, nil
+}
+
+// Tags returns the tags of the repository. A missing repository has no tags.
+func (c *Client) Tags(ctx context.Context, repository string) ([]string, error) {
+	response, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/%s/tags/list", repository), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return []string{}, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, responseError(response, "listing the tags of "+repository)
+	}
+
+	var list struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+		return nil, errors.Wrap(err, "decoding the tags of "+repository)
+	}
+	if list.Tags == nil {
+		list.Tags = []string{}
+	}
+
+	return list.Tags, nil
+}
+
+// Digest returns the digest of the manifest the tag of the repository refers to.
+func (c *Client) Digest(ctx context.Context, repository, tag string) (string, error) {
+	response, err := c.do(ctx, http.MethodHead, fmt.Sprintf("/v2/%s/manifests/%s", repository, tag),
+		map[string]string{"Accept": strings.Join(manifestTypes, ", ")})
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", responseError(response, fmt.Sprintf("reading the manifest of %s:%s", repository, tag))
+	}
+
+	digest := response.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("registry returned no digest for %s:%s", repository, tag)
+	}
+
+	return digest, nil
+}
+
+// Manifest returns the manifest the reference, a tag or digest, of the repository refers to,
+// and its media type.
+func (c *Client) Manifest(ctx context.Context, repository, reference string) ([]byte, string, error) {
+	response, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/%s/manifests/%s", repository, reference),
+		map[string]string{"Accept": strings.Join(manifestTypes, ", ")})
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, "", responseError(response, fmt.Sprintf("reading the manifest of %s:%s", repository, reference))
+	}
+
+	manifest, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "reading the manifest of %s:%s", repository, reference)
+	}
+
+	return manifest, response.Header.Get("Content-Type"), nil
+}
+
+// Blob returns the contents of the blob of the repository, e.g. an image layer or config.
+// The caller has to close it.
+func (c *Client) Blob(ctx context.Context, repository, digest string) (io.ReadCloser, error) {
+	response, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v2/%s/blobs/%s", repository, digest), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, responseError(response, fmt.Sprintf("reading blob %s of %s", digest, repository))
+	}
+
+	return response.Body, nil
+}
+
+// DeleteManifest deletes the manifest from the repository, and with it all tags referring
+// to it. The registry has to allow deletion. It reclaims the storage of the image layers
+// in its own garbage collection.
+func (c *Client) DeleteManifest(ctx context.Context, repository, digest string) error {
+	response, err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/v2/%s/manifests/%s", repository, digest), nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusAccepted, http.StatusOK, http.StatusNotFound:
+		return nil
+	}
+
+	return responseError(response, fmt.Sprintf("deleting %s@%s", repository, digest))
+}
+
+// do performs the request, authenticating with basic credentials, or with a bearer token
+// if the registry asks for one.
+func (c *Client) do(ctx context.Context, method, path string, headers map[string]string) (*http.Response, error) {
+	request := func(authorization string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		} else if c.username != "" {
+			req.SetBasicAuth(c.username, c.password)
+		}
+		return c.http.Do(req)
+	}
+
+	response, err := request("")
+	if err != nil {
+		return nil, errors.Wrap(err, "contacting the registry")
+	}
+
+	challenge := response.Header.Get("WWW-Authenticate")
+	if response.StatusCode != http.StatusUnauthorized || !strings.HasPrefix(challenge, "Bearer ") {
+		return response, nil
+	}
+	response.Body.Close()
+
+	token, err := c.token(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err = request("Bearer " + token)
+	if err != nil {
+		return nil, errors.Wrap(err, "contacting the registry")
+	}
+
+	return response, nil
+}
+
+// token is a helper for do. It returns the bearer token requested by the challenge of the
+// registry, authenticating at the token service with the basic credentials.
+func (c *Client) token(ctx context.Context, challenge string) (string, error) {
+	params := parseChallenge(strings.TrimPrefix(challenge, "Bearer "))
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("bad registry authentication challenge '%s'", challenge)
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if value, ok := params[key]; ok {
+			query.Set(key, value)
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	response, err := c.http.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "requesting a registry token")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", responseError(response, "requesting a registry token")
+	}
+
+	var result struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return "", errors.Wrap(err, "decoding the registry token")
+	}
+	if result.Token == "" {
+		result.Token = result.AccessToken
+	}
+
+	return result.Token, nil
+}
+
+// parseChallenge returns the parameters of an authentication challenge, i.e. the
+// comma-separated KEY="VALUE" pairs following the scheme.
+func parseChallenge(challenge string) map[string]string {
+	params := map[string]string{}
+
+	for challenge != "" {
+		key, rest, ok := strings.Cut(challenge, "=")
+		if !ok {
+			break
+		}
+		key = strings.TrimSpace(key)
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+			rest = strings.TrimPrefix(rest, ",")
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+
+		params[key] = value
+		challenge = strings.TrimSpace(rest)
+	}
+
+	return params
+}
+
+// responseError returns an error describing the unexpected response of the registry.
+func responseError(response *http.Response, action string) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return fmt.Errorf("%s: registry responded with %s: %s", action, response.Status, strings.TrimSpace(string(body)))
+}
+
+// ImageRepositoryTag returns the repository, i.e. the path without the registry, and the
+// tag of the container image URL. Empty path segments are ignored, as for the images of
+// a registry without namespace.
+func ImageRepositoryTag(imageURL string) (string, string, error) {
+	imageURL = strings.TrimPrefix(strings.TrimPrefix(imageURL, "https://"), "http://")
+	for strings.Contains(imageURL, "//") {
+		imageURL = strings.ReplaceAll(imageURL, "//", "/")
+	}
+
+	ref, err := parser.Parse(imageURL)
+	if err != nil {
+		return "", "", err
+	}
+
+	return ref.ShortName(), ref.Tag(), nil
+}
@@ -0,0 +1,164 @@
+package registry_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/epinio/epinio/internal/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client", func() {
+	var server *httptest.Server
+	var client *registry.Client
+	var deleted []string
+
+	BeforeEach(func() {
+		deleted = []string{}
+
+		server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/token" {
+				if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				_, _ = w.Write([]byte(`{"token":"secret"}`))
+				return
+			}
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.Header().Set("WWW-Authenticate",
+					`Bearer realm="https://`+r.Host+`/token",service="registry",scope="repository:apps/app:*"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/v2/apps/app/tags/list":
+				_, _ = w.Write([]byte(`{"name":"apps/app","tags":["s1","s2"]}`))
+			case r.Method == http.MethodHead && r.URL.Path == "/v2/apps/app/manifests/s1":
+				w.Header().Set("Docker-Content-Digest", "sha256:abc")
+			case r.Method == http.MethodGet && r.URL.Path == "/v2/apps/app/manifests/s1":
+				w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+				_, _ = w.Write([]byte(`{"schemaVersion":2}`))
+			case r.Method == http.MethodGet && r.URL.Path == "/v2/apps/app/blobs/sha256:def":
+				_, _ = w.Write([]byte("layer"))
+			case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v2/apps/app/manifests/"):
+				deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/v2/apps/app/manifests/"))
+				w.WriteHeader(http.StatusAccepted)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		// The registry is addressed as localhost, as 127.0.0.1 is the private registry
+		cert, ca := localhostCertificate()
+		server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+		server.StartTLS()
+		host := strings.Replace(strings.TrimPrefix(server.URL, "https://"), "127.0.0.1", "localhost", 1)
+
+		var err error
+		client, err = registry.NewClient(&registry.ConnectionDetails{
+			RegistryCredentials: []registry.RegistryCredentials{
+				{URL: host, Username: "user", Password: "pass"},
+			},
+		}, ca)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("lists the tags of a repository", func() {
+		tags, err := client.Tags(context.Background(), "apps/app")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tags).To(Equal([]string{"s1", "s2"}))
+	})
+
+	It("returns no tags for a missing repository", func() {
+		tags, err := client.Tags(context.Background(), "apps/missing")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tags).To(BeEmpty())
+	})
+
+	It("returns the digest of a tag", func() {
+		digest, err := client.Digest(context.Background(), "apps/app", "s1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest).To(Equal("sha256:abc"))
+
+		_, err = client.Digest(context.Background(), "apps/app", "s9")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("reads manifests and blobs", func() {
+		manifest, mediaType, err := client.Manifest(context.Background(), "apps/app", "s1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(manifest)).To(Equal(`{"schemaVersion":2}`))
+		Expect(mediaType).To(Equal("application/vnd.oci.image.manifest.v1+json"))
+
+		blob, err := client.Blob(context.Background(), "apps/app", "sha256:def")
+		Expect(err).ToNot(HaveOccurred())
+		defer blob.Close()
+		content, err := io.ReadAll(blob)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("layer"))
+
+		_, err = client.Blob(context.Background(), "apps/app", "sha256:000")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("deletes manifests", func() {
+		Expect(client.DeleteManifest(context.Background(), "apps/app", "sha256:abc")).To(Succeed())
+		Expect(deleted).To(Equal([]string{"sha256:abc"}))
+	})
+})
+
+// localhostCertificate returns a self-signed certificate for localhost, and its PEM encoding.
+func localhostCertificate() (tls.Certificate, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key},
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+var _ = Describe("ImageRepositoryTag", func() {
+	It("returns the repository and tag of image URLs", func() {
+		repo, tag, err := registry.ImageRepositoryTag("registry.example.com:5000/apps/workspace-app:s1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repo).To(Equal("apps/workspace-app"))
+		Expect(tag).To(Equal("s1"))
+	})
+
+	It("ignores the scheme and empty path segments", func() {
+		repo, tag, err := registry.ImageRepositoryTag("https://registry.example.com:5000//workspace-app:s2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repo).To(Equal("workspace-app"))
+		Expect(tag).To(Equal("s2"))
+	})
+})
@@ -3,12 +3,18 @@
 package s3manager
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/epinio/epinio/helpers/kubernetes"
 	"github.com/epinio/epinio/internal/helmchart"
@@ -234,6 +240,45 @@ func (m *Manager) Upload(ctx context.Context, filepath string, metadata map[stri
 	return objectName, nil
 }
 
+// Put stores the data as the specified object, replacing any existing object of that name.
+func (m *Manager) Put(ctx context.Context, objectID string, data []byte, contentType string) error {
+	if err := m.EnsureBucket(ctx); err != nil {
+		return errors.Wrap(err, "ensuring bucket")
+	}
+
+	_, err := m.minioClient.PutObject(ctx, m.connectionDetails.Bucket,
+		objectID, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+			ContentType: contentType,
+		})
+	if err != nil {
+		return errors.Wrap(err, "writing the object")
+	}
+
+	return nil
+}
+
+// Get returns the content of the specified object. The bool result is false if the object
+// does not exist.
+func (m *Manager) Get(ctx context.Context, objectID string) ([]byte, bool, error) {
+	object, err := m.minioClient.GetObject(ctx, m.connectionDetails.Bucket, objectID,
+		minio.GetObjectOptions{})
+	if err != nil {
+		return nil, false, errors.Wrap(err, "reading the object")
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		code := minio.ToErrorResponse(err).Code
+		if code == "NoSuchKey" || code == "NoSuchBucket" {
+			return nil, false, nil
+		}
+		return nil, false, errors.Wrap(err, "reading the object")
+	}
+
+	return data, true, nil
+}
+
 // EnsureBucket creates our bucket if it's missing
 func (m *Manager) EnsureBucket(ctx context.Context) error {
 	exists, err := m.minioClient.BucketExists(ctx, m.connectionDetails.Bucket)
@@ -253,3 +298,268 @@ func (m *Manager) DeleteObject(ctx context.Context, objectID string) error {
 	return m.minioClient.RemoveObject(ctx, m.connectionDetails.Bucket, objectID,
 		minio.RemoveObjectOptions{})
 }
+
+// Object describes an object in the storage
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// List returns the objects in the storage whose keys start with the prefix. A missing
+// bucket has no objects.
+func (m *Manager) List(ctx context.Context, prefix string) ([]Object, error) {
+	result := []Object{}
+
+	exists, err := m.minioClient.BucketExists(ctx, m.connectionDetails.Bucket)
+	if err != nil {
+		return result, errors.Wrapf(err, "checking bucket %s exists", m.connectionDetails.Bucket)
+	}
+	if !exists {
+		return result, nil
+	}
+
+	for info := range m.minioClient.ListObjects(ctx, m.connectionDetails.Bucket,
+		minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if info.Err != nil {
+			return result, errors.Wrap(info.Err, "listing objects")
+		}
+		result = append(result, Object{
+			Key:          info.Key,
+			Size:         info.Size,
+			LastModified: info.LastModified,
+		})
+	}
+
+	return result, nil
+}
+
+// PresignedUploadURL returns a URL allowing the holder to upload the specified object,
+// until the expiry duration has passed. This enables jobs to store their results without
+// access to the credentials of the storage.
+func (m *Manager) PresignedUploadURL(ctx context.Context, objectID string, expiry time.Duration) (*url.URL, error) {
+	if err := m.EnsureBucket(ctx); err != nil {
+		return nil, errors.Wrap(err, "ensuring bucket")
+	}
+
+	return m.minioClient.PresignedPutObject(ctx, m.connectionDetails.Bucket, objectID, expiry)
+}
+
+// PresignedDownloadURL returns a URL allowing the holder to download the specified
+// object, until the expiry duration has passed.
+func (m *Manager) PresignedDownloadURL(ctx context.Context, objectID string, expiry time.Duration) (*url.URL, error) {
+	return m.minioClient.PresignedGetObject(ctx, m.connectionDetails.Bucket, objectID, expiry, url.Values{})
+}
+
+// ContentKey returns the key of the blob holding the sources of the application with the
+// given SHA-256 checksum (hex encoded). The key is deterministic, so that sources already
+// stored for the application are found again, and not uploaded a second time. It is a
+// UUID, like the keys of the other blobs.
+func ContentKey(namespace, app, checksum string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL,
+		[]byte(fmt.Sprintf("epinio:%s/%s/%s", namespace, app, checksum))).String()
+}
+
+// Part describes a part of an incomplete multipart upload
+type Part struct {
+	Number int
+	Size   int64
+	ETag   string
+}
+
+// Exists returns true if the specified object is in the storage, and false otherwise.
+func (m *Manager) Exists(ctx context.Context, objectID string) (bool, error) {
+	_, err := m.minioClient.StatObject(ctx, m.connectionDetails.Bucket, objectID,
+		minio.StatObjectOptions{})
+	if err != nil {
+		code := minio.ToErrorResponse(err).Code
+		if code == "NoSuchKey" || code == "NoSuchBucket" {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "reading the object meta data")
+	}
+
+	return true, nil
+}
+
+// Checksum returns the SHA-256 checksum (hex encoded) of the content of the specified
+// object.
+func (m *Manager) Checksum(ctx context.Context, objectID string) (string, error) {
+	object, err := m.minioClient.GetObject(ctx, m.connectionDetails.Bucket, objectID,
+		minio.GetObjectOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, "reading the object")
+	}
+	defer object.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, object); err != nil {
+		return "", errors.Wrap(err, "reading the object")
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// MultipartUploadID returns the id of the newest incomplete multipart upload of the
+// specified object, or the empty string if there is none.
+func (m *Manager) MultipartUploadID(ctx context.Context, objectID string) (string, error) {
+	core := minio.Core{Client: m.minioClient}
+
+	var uploadID string
+	var initiated time.Time
+	keyMarker, uploadIDMarker := "", ""
+	for {
+		result, err := core.ListMultipartUploads(ctx, m.connectionDetails.Bucket, objectID,
+			keyMarker, uploadIDMarker, "", 1000)
+		if err != nil {
+			return "", errors.Wrap(err, "listing the incomplete uploads")
+		}
+		for _, upload := range result.Uploads {
+			if upload.Key == objectID && upload.Initiated.After(initiated) {
+				uploadID = upload.UploadID
+				initiated = upload.Initiated
+			}
+		}
+		if !result.IsTruncated {
+			return uploadID, nil
+		}
+		keyMarker, uploadIDMarker = result.NextKeyMarker, result.NextUploadIDMarker
+	}
+}
+
+// MultipartUpload describes an incomplete multipart upload
+type MultipartUpload struct {
+	Key       string
+	UploadID  string
+	Initiated time.Time
+}
+
+// MultipartUploads returns the incomplete multipart uploads of all objects. A missing
+// bucket has no uploads.
+func (m *Manager) MultipartUploads(ctx context.Context) ([]MultipartUpload, error) {
+	result := []MultipartUpload{}
+
+	exists, err := m.minioClient.BucketExists(ctx, m.connectionDetails.Bucket)
+	if err != nil {
+		return result, errors.Wrapf(err, "checking bucket %s exists", m.connectionDetails.Bucket)
+	}
+	if !exists {
+		return result, nil
+	}
+
+	core := minio.Core{Client: m.minioClient}
+	keyMarker, uploadIDMarker := "", ""
+	for {
+		uploads, err := core.ListMultipartUploads(ctx, m.connectionDetails.Bucket, "",
+			keyMarker, uploadIDMarker, "", 1000)
+		if err != nil {
+			return result, errors.Wrap(err, "listing the incomplete uploads")
+		}
+		for _, upload := range uploads.Uploads {
+			result = append(result, MultipartUpload{
+				Key:       upload.Key,
+				UploadID:  upload.UploadID,
+				Initiated: upload.Initiated,
+			})
+		}
+		if !uploads.IsTruncated {
+			return result, nil
+		}
+		keyMarker, uploadIDMarker = uploads.NextKeyMarker, uploads.NextUploadIDMarker
+	}
+}
+
+// MultipartAbort aborts the multipart upload, discarding the parts uploaded so far
+func (m *Manager) MultipartAbort(ctx context.Context, objectID, uploadID string) error {
+	core := minio.Core{Client: m.minioClient}
+	err := core.AbortMultipartUpload(ctx, m.connectionDetails.Bucket, objectID, uploadID)
+	if err != nil {
+		return errors.Wrap(err, "aborting the upload")
+	}
+
+	return nil
+}
+
+// MultipartStart starts a multipart upload of the specified object, and returns its id.
+func (m *Manager) MultipartStart(ctx context.Context, objectID string, metadata map[string]string) (string, error) {
+	if err := m.EnsureBucket(ctx); err != nil {
+		return "", errors.Wrap(err, "ensuring bucket")
+	}
+
+	core := minio.Core{Client: m.minioClient}
+	uploadID, err := core.NewMultipartUpload(ctx, m.connectionDetails.Bucket, objectID,
+		minio.PutObjectOptions{
+			ContentType:  "application/tar",
+			UserMetadata: metadata,
+		})
+	if err != nil {
+		return "", errors.Wrap(err, "starting the upload")
+	}
+
+	return uploadID, nil
+}
+
+// MultipartParts returns the parts uploaded so far for the multipart upload, ordered by
+// their number.
+func (m *Manager) MultipartParts(ctx context.Context, objectID, uploadID string) ([]Part, error) {
+	core := minio.Core{Client: m.minioClient}
+	parts := []Part{}
+
+	marker := 0
+	for {
+		result, err := core.ListObjectParts(ctx, m.connectionDetails.Bucket, objectID,
+			uploadID, marker, 1000)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing the uploaded parts")
+		}
+		for _, part := range result.ObjectParts {
+			parts = append(parts, Part{
+				Number: part.PartNumber,
+				Size:   part.Size,
+				ETag:   strings.Trim(part.ETag, `"`),
+			})
+		}
+		if !result.IsTruncated {
+			return parts, nil
+		}
+		marker = result.NextPartNumberMarker
+	}
+}
+
+// MultipartPut uploads a part of the multipart upload, replacing any part with the same
+// number. The storage verifies the data against the SHA-256 checksum (hex encoded).
+func (m *Manager) MultipartPut(ctx context.Context, objectID, uploadID string, number int, data io.Reader, size int64, checksum string) (Part, error) {
+	core := minio.Core{Client: m.minioClient}
+	part, err := core.PutObjectPart(ctx, m.connectionDetails.Bucket, objectID, uploadID,
+		number, data, size, "", checksum, nil)
+	if err != nil {
+		return Part{}, errors.Wrapf(err, "uploading part %d", number)
+	}
+
+	return Part{
+		Number: part.PartNumber,
+		Size:   part.Size,
+		ETag:   strings.Trim(part.ETag, `"`),
+	}, nil
+}
+
+// MultipartComplete assembles the object from the parts of the multipart upload
+func (m *Manager) MultipartComplete(ctx context.Context, objectID, uploadID string, parts []Part) error {
+	core := minio.Core{Client: m.minioClient}
+
+	completeParts := make([]minio.CompletePart, 0, len(parts))
+	for _, part := range parts {
+		completeParts = append(completeParts, minio.CompletePart{
+			PartNumber: part.Number,
+			ETag:       part.ETag,
+		})
+	}
+
+	_, err := core.CompleteMultipartUpload(ctx, m.connectionDetails.Bucket, objectID,
+		uploadID, completeParts, minio.PutObjectOptions{})
+	if err != nil {
+		return errors.Wrap(err, "completing the upload")
+	}
+
+	return nil
+}
@@ -74,3 +74,23 @@ var _ = Describe("ConnectionDetails", func() {
 		})
 	})
 })
+
+var _ = Describe("ContentKey", func() {
+	checksum := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	It("is the same for the same sources of an application", func() {
+		Expect(s3manager.ContentKey("workspace", "app", checksum)).To(
+			Equal(s3manager.ContentKey("workspace", "app", checksum)))
+	})
+
+	It("differs between applications and sources", func() {
+		key := s3manager.ContentKey("workspace", "app", checksum)
+		Expect(s3manager.ContentKey("workspace", "other", checksum)).ToNot(Equal(key))
+		Expect(s3manager.ContentKey("other", "app", checksum)).ToNot(Equal(key))
+		Expect(s3manager.ContentKey("workspace", "app", "0"+checksum[1:])).ToNot(Equal(key))
+	})
+
+	It("is usable as a label value", func() {
+		Expect(s3manager.ContentKey("workspace", "app", checksum)).To(MatchRegexp(`^[0-9a-f-]{36}$`))
+	})
+})
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/epinio/epinio/internal/duration"
+	"github.com/epinio/epinio/internal/names"
+	"github.com/epinio/epinio/internal/s3manager"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	// BackupJobAnnotationKey holds the optional template for the pod of the job saving the
+	// data of an instance of a catalog service. It is an annotation because the CRD has
+	// no field for it.
+	BackupJobAnnotationKey = "application.epinio.io/backup-job"
+	// RestoreJobAnnotationKey holds the optional template for the pod of the job restoring
+	// the data of an instance of a catalog service from a backup.
+	RestoreJobAnnotationKey = "application.epinio.io/restore-job"
+
+	// BackupURLEnvKey is the environment variable holding the url of the backup artifact in
+	// the containers of backup and restore jobs. Backup jobs upload (PUT) the artifact to
+	// it, restore jobs download (GET) the artifact from it.
+	BackupURLEnvKey = "EPINIO_BACKUP_URL"
+
+	// BackupComponent and RestoreComponent are the values of the component label of the
+	// backup and restore jobs.
+	BackupComponent  = "service-backup"
+	RestoreComponent = "service-restore"
+
+	// BackupIDLabelKey is the label of the backup and restore jobs holding the id of the
+	// backup saved or restored.
+	BackupIDLabelKey = "application.epinio.io/backup-id"
+
+	// jobLogsTail is the number of lines of logs collected from each container of a
+	// failed job.
+	jobLogsTail = 100
+)
+
+// jobRetention is the time a finished backup or restore job is kept at most. Finished jobs
+// are deleted when their status is reported, see JobStatus. This bounds the lifetime of
+// jobs whose status is never asked for.
+const jobRetention = 24 * time.Hour
+
+// BackupJobData is the data available to the templates of the backup and restore jobs.
+type BackupJobData struct {
+	Name      string // Name of the service instance
+	Namespace string // Namespace of the service instance, and of the job
+	Release   string // Name of the helm release of the service instance
+	BackupID  string // ID of the backup saved or restored
+}
+
+// BackupPrefix returns the prefix of the keys of the backups of the named service
+// instance in the S3 storage.
+func BackupPrefix(namespace, name string) string {
+	return fmt.Sprintf("backups/%s/%s/", namespace, name)
+}
+
+// BackupKey returns the key of the specified backup in the S3 storage.
+func BackupKey(namespace, name, backupID string) string {
+	return BackupPrefix(namespace, name) + backupID
+}
+
+// Backups returns the backups of the named service instance found in the S3 storage, in
+// order of creation.
+func Backups(ctx context.Context, manager *s3manager.Manager, namespace, name string) (models.ServiceBackupList, error) {
+	prefix := BackupPrefix(namespace, name)
+
+	objects, err := manager.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.ServiceBackupList{}
+	for _, object := range objects {
+		result = append(result, models.ServiceBackup{
+			ID:        strings.TrimPrefix(object.Key, prefix),
+			Size:      object.Size,
+			CreatedAt: metav1.NewTime(object.LastModified),
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(&result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+// NewBackupJob renders the job template of a catalog service for the named service
+// instance. The template is a pod spec in YAML format, which may reference the fields of
+// BackupJobData. The url of the backup artifact is provided to all containers of the pod
+// in the environment variable BackupURLEnvKey.
+func NewBackupJob(jobTemplate, component, namespace, name, backupID, artifactURL string) (*batchv1.Job, error) {
+	data := BackupJobData{
+		Name:      name,
+		Namespace: namespace,
+		Release:   names.ServiceHelmChartName(name, namespace),
+		BackupID:  backupID,
+	}
+
+	tmpl, err := template.New(component).Option("missingkey=error").Parse(jobTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing the job template")
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return nil, errors.Wrap(err, "rendering the job template")
+	}
+
+	podSpec := corev1.PodSpec{}
+	err = yaml.NewYAMLOrJSONDecoder(&rendered, rendered.Len()).Decode(&podSpec)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding the pod spec of the job")
+	}
+	if len(podSpec.Containers) == 0 {
+		return nil, errors.New("the job template has no containers")
+	}
+
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Env = append(podSpec.Containers[i].Env, corev1.EnvVar{
+			Name:  BackupURLEnvKey,
+			Value: artifactURL,
+		})
+	}
+
+	labels := map[string]string{
+		ServiceNameLabelKey:            name,
+		BackupIDLabelKey:               backupID,
+		"app.kubernetes.io/part-of":    namespace,
+		"app.kubernetes.io/managed-by": "epinio",
+		"app.kubernetes.io/component":  component,
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.GenerateResourceName(component, name, backupID),
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            pointer.Int32(0),
+			ActiveDeadlineSeconds:   pointer.Int64(int64(duration.ToServiceBackup().Seconds())),
+			TTLSecondsAfterFinished: pointer.Int32(int32(jobRetention.Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}, nil
+}
+
+// StartJob starts the given backup or restore job. It does not wait for the job to
+// complete, see JobStatus.
+func (s *ServiceClient) StartJob(ctx context.Context, job *batchv1.Job) error {
+	err := s.kubeClient.CreateJob(ctx, job.Namespace, job)
+	if err != nil {
+		return errors.Wrap(err, "creating the job")
+	}
+	return nil
+}
+
+// JobStatus returns the status of the named backup or restore job of the service instance,
+// or nil if there is no such job. The status of a failed job includes the reason of the
+// failure, and the tail of the logs of its containers. The job is kept until then, so
+// that the logs are available.
+func (s *ServiceClient) JobStatus(ctx context.Context, namespace, name, jobName string) (*models.ServiceJob, error) {
+	cluster := s.kubeClient
+
+	job, err := cluster.Kubectl.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "reading the job")
+	}
+	if job.Labels[ServiceNameLabelKey] != name {
+		return nil, nil
+	}
+
+	var kind string
+	switch job.Labels["app.kubernetes.io/component"] {
+	case BackupComponent:
+		kind = models.ServiceJobBackup
+	case RestoreComponent:
+		kind = models.ServiceJobRestore
+	default:
+		return nil, nil
+	}
+
+	result := &models.ServiceJob{
+		Name:     job.Name,
+		Kind:     kind,
+		BackupID: job.Labels[BackupIDLabelKey],
+		Status:   models.ServiceJobRunning,
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			result.Status = models.ServiceJobSucceeded
+		case batchv1.JobFailed:
+			result.Status = models.ServiceJobFailed
+			result.Message = condition.Message
+
+			result.Logs, err = s.jobLogs(ctx, job)
+			if err != nil {
+				return nil, errors.Wrap(err, "collecting the logs of the job")
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// DeleteJob deletes the named backup or restore job.
+func (s *ServiceClient) DeleteJob(ctx context.Context, namespace, jobName string) error {
+	err := s.kubeClient.DeleteJob(ctx, namespace, jobName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// jobLogs returns the tail of the logs of the containers of the job's pods.
+func (s *ServiceClient) jobLogs(ctx context.Context, job *batchv1.Job) (string, error) {
+	pods, err := s.kubeClient.Kubectl.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "job-name=" + job.Name,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var logs strings.Builder
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			raw, err := s.kubeClient.Kubectl.CoreV1().Pods(job.Namespace).GetLogs(pod.Name,
+				&corev1.PodLogOptions{
+					Container: container.Name,
+					TailLines: pointer.Int64(jobLogsTail),
+				}).DoRaw(ctx)
+			if err != nil {
+				// The container may not have started, e.g. for a bad image
+				raw = []byte(err.Error() + "\n")
+			}
+			fmt.Fprintf(&logs, "[%s/%s]\n%s", pod.Name, container.Name, raw)
+		}
+	}
+
+	return logs.String(), nil
+}
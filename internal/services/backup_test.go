@@ -0,0 +1,59 @@
+package services
+
+import (
+	"github.com/epinio/epinio/internal/names"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("NewBackupJob", func() {
+	It("renders the template into a job for the service", func() {
+		job, err := NewBackupJob(`
+containers:
+- name: dump
+  image: postgres
+  command: ["pg_dump", "-h", "{{ .Release }}-postgresql"]
+`, BackupComponent, "workspace", "db", "1234", "http://s3/backup")
+		Expect(err).ToNot(HaveOccurred())
+
+		release := names.ServiceHelmChartName("db", "workspace")
+
+		Expect(job.Namespace).To(Equal("workspace"))
+		Expect(job.Labels["app.kubernetes.io/component"]).To(Equal(BackupComponent))
+		Expect(job.Labels[ServiceNameLabelKey]).To(Equal("db"))
+		Expect(job.Labels[BackupIDLabelKey]).To(Equal("1234"))
+		Expect(job.Spec.TTLSecondsAfterFinished).ToNot(BeNil())
+
+		pod := job.Spec.Template.Spec
+		Expect(pod.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+		Expect(pod.Containers).To(HaveLen(1))
+		Expect(pod.Containers[0].Command).To(Equal([]string{"pg_dump", "-h", release + "-postgresql"}))
+		Expect(pod.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+			Name:  BackupURLEnvKey,
+			Value: "http://s3/backup",
+		}))
+	})
+
+	It("names jobs for different backups differently", func() {
+		template := "containers: [{name: c, image: busybox}]"
+
+		one, err := NewBackupJob(template, BackupComponent, "workspace", "db", "1", "url")
+		Expect(err).ToNot(HaveOccurred())
+		two, err := NewBackupJob(template, BackupComponent, "workspace", "db", "2", "url")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(one.Name).ToNot(Equal(two.Name))
+	})
+
+	It("fails for templates referencing unknown fields", func() {
+		_, err := NewBackupJob("containers: [{name: {{ .Bogus }}}]",
+			BackupComponent, "workspace", "db", "1", "url")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails for templates without containers", func() {
+		_, err := NewBackupJob("hostNetwork: true", RestoreComponent, "workspace", "db", "1", "url")
+		Expect(err).To(HaveOccurred())
+	})
+})
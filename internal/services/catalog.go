@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
 )
 
 const (
@@ -21,8 +22,109 @@ const (
 	// ServiceNameLabelKey is used to keep the original name
 	// since the name in the metadata is combined with the namespace
 	ServiceNameLabelKey = "application.epinio.io/service-name"
+	// ValuesSchemaAnnotationKey holds the optional JSON schema for the values of the
+	// instances of a catalog service. It is an annotation because the CRD has no field
+	// for it.
+	ValuesSchemaAnnotationKey = "application.epinio.io/values-schema"
 )
 
+// CreateCatalogService adds the given service to the catalog.
+func (s *ServiceClient) CreateCatalogService(ctx context.Context, catalogService models.CatalogService) error {
+	service := &apiv1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "application.epinio.io/v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      catalogService.Meta.Name,
+			Namespace: helmchart.Namespace(),
+		},
+	}
+	setCatalogService(service, catalogService)
+
+	mapService, err := runtime.DefaultUnstructuredConverter.ToUnstructured(service)
+	if err != nil {
+		return errors.Wrap(err, "error converting catalog service to unstructured")
+	}
+
+	unstructuredService := &unstructured.Unstructured{}
+	unstructuredService.SetUnstructuredContent(mapService)
+
+	_, err = s.serviceKubeClient.Namespace(helmchart.Namespace()).Create(ctx,
+		unstructuredService, metav1.CreateOptions{})
+	return errors.Wrap(err, "error creating catalog service")
+}
+
+// UpdateCatalogService replaces the definition of the named catalog service with the
+// given data.
+func (s *ServiceClient) UpdateCatalogService(ctx context.Context, catalogService models.CatalogService) error {
+	client := s.serviceKubeClient.Namespace(helmchart.Namespace())
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		result, err := client.Get(ctx, catalogService.Meta.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		service := apiv1.Service{}
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(result.Object, &service)
+		if err != nil {
+			return errors.Wrap(err, "error converting catalog service")
+		}
+
+		setCatalogService(&service, catalogService)
+
+		mapService, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&service)
+		if err != nil {
+			return errors.Wrap(err, "error converting catalog service to unstructured")
+		}
+		result.SetUnstructuredContent(mapService)
+
+		_, err = client.Update(ctx, result, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// DeleteCatalogService removes the named service from the catalog.
+func (s *ServiceClient) DeleteCatalogService(ctx context.Context, serviceName string) error {
+	err := s.serviceKubeClient.Namespace(helmchart.Namespace()).Delete(ctx, serviceName, metav1.DeleteOptions{})
+	return errors.Wrap(err, fmt.Sprintf("error deleting catalog service %s", serviceName))
+}
+
+// setCatalogService transfers the API data of a catalog service into the CR.
+func setCatalogService(service *apiv1.Service, catalogService models.CatalogService) {
+	service.Spec = apiv1.ServiceSpec{
+		Name:             catalogService.Meta.Name,
+		Description:      catalogService.Description,
+		ShortDescription: catalogService.ShortDescription,
+		HelmChart:        catalogService.HelmChart,
+		ChartVersion:     catalogService.ChartVersion,
+		AppVersion:       catalogService.AppVersion,
+		HelmRepo: apiv1.HelmRepo{
+			Name: catalogService.HelmRepo.Name,
+			URL:  catalogService.HelmRepo.URL,
+		},
+		Values: catalogService.Values,
+	}
+
+	annotations := service.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for key, value := range map[string]string{
+		ValuesSchemaAnnotationKey: catalogService.ValuesSchema,
+		BackupJobAnnotationKey:    catalogService.BackupJob,
+		RestoreJobAnnotationKey:   catalogService.RestoreJob,
+	} {
+		if value == "" {
+			delete(annotations, key)
+		} else {
+			annotations[key] = value
+		}
+	}
+	service.SetAnnotations(annotations)
+}
+
 func (s *ServiceClient) GetCatalogService(ctx context.Context, serviceName string) (*models.CatalogService, error) {
 	result, err := s.serviceKubeClient.Namespace(helmchart.Namespace()).Get(ctx, serviceName, metav1.GetOptions{})
 	if err != nil {
@@ -86,6 +188,9 @@ func convertUnstructuredIntoCatalogService(unstructured unstructured.Unstructure
 			Name: catalogService.Spec.HelmRepo.Name,
 			URL:  catalogService.Spec.HelmRepo.URL,
 		},
-		Values: catalogService.Spec.Values,
+		Values:       catalogService.Spec.Values,
+		ValuesSchema: unstructured.GetAnnotations()[ValuesSchemaAnnotationKey],
+		BackupJob:    unstructured.GetAnnotations()[BackupJobAnnotationKey],
+		RestoreJob:   unstructured.GetAnnotations()[RestoreJobAnnotationKey],
 	}, nil
 }
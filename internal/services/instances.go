@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/epinio/epinio/helpers/tracelog"
 	"github.com/epinio/epinio/internal/helm"
@@ -14,9 +15,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	helmapiv1 "github.com/k3s-io/helm-controller/pkg/apis/helm.cattle.io/v1"
+	helmrelease "helm.sh/helm/v3/pkg/release"
 	helmdriver "helm.sh/helm/v3/pkg/storage/driver"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
 )
 
 // Get returns a Service "instance" object if one is exist, or nil otherwise.
@@ -58,6 +63,12 @@ func (s *ServiceClient) Get(ctx context.Context, namespace, name string) (*model
 		return nil, errors.New("targetNamespace field not found")
 	}
 
+	helmChart := helmapiv1.HelmChart{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(srv.Object, &helmChart)
+	if err != nil {
+		return nil, errors.Wrap(err, "error converting helmchart")
+	}
+
 	service = models.Service{
 		Meta: models.Meta{
 			Name:      name,
@@ -65,20 +76,15 @@ func (s *ServiceClient) Get(ctx context.Context, namespace, name string) (*model
 			CreatedAt: srv.GetCreationTimestamp(),
 		},
 		CatalogService: fmt.Sprintf("%s%s", catalogServicePrefix, catalogServiceName),
+		ChartVersion:   helmChart.Spec.Version,
+		Settings:       settingsOf(helmChart),
 	}
 
-	logger := tracelog.NewLogger().WithName("ServiceStatus")
-	serviceStatus, err := helm.Status(ctx, logger, s.kubeClient, targetNamespace, helmChartName)
+	service.Status, service.Details, err = s.status(ctx, helmChart)
 	if err != nil {
-		if errors.Is(err, helmdriver.ErrReleaseNotFound) {
-			serviceStatus = "Not Ready" // The installation job is still running?
-		} else {
-			return &service, errors.Wrap(err, "finding helm release status")
-		}
+		return &service, err
 	}
 
-	service.Status = models.NewServiceStatusFromHelmRelease(serviceStatus)
-
 	return &service, nil
 }
 
@@ -120,6 +126,135 @@ func (s *ServiceClient) Create(ctx context.Context, namespace, name string, cata
 	return errors.Wrap(err, "error creating helm chart")
 }
 
+// UpdateSettings modifies the helm values set on the service instance as per the
+// instructions, and writes the result back to the helmchart resource. The helm controller
+// picks the change up and upgrades the release of the service.
+func (s *ServiceClient) UpdateSettings(ctx context.Context, namespace, name string, changes models.ServiceUpdateRequest) error {
+	return s.updateHelmChart(ctx, namespace, name, func(helmChart *helmapiv1.HelmChart) {
+		if helmChart.Spec.Set == nil {
+			helmChart.Spec.Set = map[string]intstr.IntOrString{}
+		}
+		for _, remove := range changes.Remove {
+			delete(helmChart.Spec.Set, remove)
+		}
+		for key, value := range changes.Set {
+			helmChart.Spec.Set[key] = intstr.FromString(value)
+		}
+	})
+}
+
+// Upgrade moves the service instance to the chart, chart version and default values of
+// the given catalog service. Settings made through UpdateSettings are kept. It returns
+// the chart version the instance used before the upgrade.
+func (s *ServiceClient) Upgrade(ctx context.Context, namespace, name string, catalogService models.CatalogService) (string, error) {
+	var oldVersion string
+
+	err := s.updateHelmChart(ctx, namespace, name, func(helmChart *helmapiv1.HelmChart) {
+		oldVersion = helmChart.Spec.Version
+
+		helmChart.Spec.Chart = catalogService.HelmChart
+		helmChart.Spec.Version = catalogService.ChartVersion
+		helmChart.Spec.Repo = catalogService.HelmRepo.URL
+		helmChart.Spec.ValuesContent = catalogService.Values
+	})
+
+	return oldVersion, err
+}
+
+// CatalogServiceOf returns the name of the catalog service the named service instance was
+// created from, whether that catalog service still exists or not.
+func (s *ServiceClient) CatalogServiceOf(ctx context.Context, namespace, name string) (string, error) {
+	srv, err := s.helmChartsKubeClient.Namespace(helmchart.Namespace()).Get(ctx,
+		names.ServiceHelmChartName(name, namespace), metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, "fetching the service instance")
+	}
+
+	return srv.GetLabels()[CatalogServiceLabelKey], nil
+}
+
+// ReleaseRevision returns the revision number of the service's helm release. A release not
+// yet installed by the helm controller is reported as revision 0.
+func (s *ServiceClient) ReleaseRevision(ctx context.Context, namespace, name string) (int, error) {
+	logger := tracelog.NewLogger().WithName("ServiceRelease")
+
+	release, err := helm.Release(ctx, logger, s.kubeClient, namespace, names.ServiceHelmChartName(name, namespace))
+	if err != nil {
+		if errors.Is(err, helmdriver.ErrReleaseNotFound) {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "finding helm release")
+	}
+
+	return release.Version, nil
+}
+
+// WaitForRelease waits until the helm release of the service is deployed at a revision
+// newer than the specified one, i.e. until the helm controller has applied a change made
+// to the service instance.
+func (s *ServiceClient) WaitForRelease(ctx context.Context, namespace, name string, revision int, timeout time.Duration) error {
+	logger := tracelog.NewLogger().WithName("ServiceRelease")
+	releaseName := names.ServiceHelmChartName(name, namespace)
+
+	return wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		release, err := helm.Release(ctx, logger, s.kubeClient, namespace, releaseName)
+		if err != nil {
+			if errors.Is(err, helmdriver.ErrReleaseNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		return release.Version > revision && release.Info.Status == helmrelease.StatusDeployed, nil
+	})
+}
+
+// updateHelmChart is a helper for the public functions. It encapsulates the
+// read/modify/write cycle necessary to update the helmchart resource of the service
+// instance.
+func (s *ServiceClient) updateHelmChart(ctx context.Context, namespace, name string, modify func(*helmapiv1.HelmChart)) error {
+	client := s.helmChartsKubeClient.Namespace(helmchart.Namespace())
+	helmChartName := names.ServiceHelmChartName(name, namespace)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		srv, err := client.Get(ctx, helmChartName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		helmChart := helmapiv1.HelmChart{}
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(srv.Object, &helmChart)
+		if err != nil {
+			return errors.Wrap(err, "error converting helmchart")
+		}
+
+		modify(&helmChart)
+
+		mapHelmChart, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&helmChart)
+		if err != nil {
+			return errors.Wrap(err, "error converting helmChart to unstructured")
+		}
+		srv.SetUnstructuredContent(mapHelmChart)
+
+		_, err = client.Update(ctx, srv, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// settingsOf returns the helm values set on the service instance, as strings.
+func settingsOf(helmChart helmapiv1.HelmChart) map[string]string {
+	if len(helmChart.Spec.Set) == 0 {
+		return nil
+	}
+
+	settings := map[string]string{}
+	for key, value := range helmChart.Spec.Set {
+		settings[key] = value.String()
+	}
+
+	return settings
+}
+
 // Delete deletes the helmcharts that matches the given service which is
 // installed on the namespace (that's the targetNamespace).
 func (s *ServiceClient) Delete(ctx context.Context, namespace, service string) error {
@@ -146,6 +281,29 @@ func (s *ServiceClient) DeleteAll(ctx context.Context, targetNamespace string) e
 	return errors.Wrap(err, "error deleting helm charts")
 }
 
+// InstancesOf returns the names of the service instances created from the named catalog
+// service, across all namespaces. The names are qualified by the namespace of the instance,
+// i.e. `namespace/name`.
+func (s *ServiceClient) InstancesOf(ctx context.Context, catalogServiceName string) ([]string, error) {
+	listOpts := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", CatalogServiceLabelKey, catalogServiceName),
+	}
+
+	list, err := s.helmChartsKubeClient.Namespace(helmchart.Namespace()).List(ctx, listOpts)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing the service instances")
+	}
+
+	result := []string{}
+	for _, srv := range list.Items {
+		result = append(result, fmt.Sprintf("%s/%s",
+			srv.GetLabels()[TargetNamespaceLabelKey],
+			srv.GetLabels()[ServiceNameLabelKey]))
+	}
+
+	return result, nil
+}
+
 // ListAll will return all the Epinio Service instances
 func (s *ServiceClient) ListAll(ctx context.Context) ([]*models.Service, error) {
 	return s.list(ctx, "")
@@ -211,20 +369,15 @@ func (s *ServiceClient) list(ctx context.Context, namespace string) ([]*models.S
 				CreatedAt: srv.GetCreationTimestamp(),
 			},
 			CatalogService: catalogServiceName,
+			ChartVersion:   srv.Spec.Version,
+			Settings:       settingsOf(srv),
 		}
 
-		logger := tracelog.NewLogger().WithName("ServiceStatus")
-		serviceStatus, err := helm.Status(ctx, logger, s.kubeClient, srv.Spec.TargetNamespace, srv.Name)
+		service.Status, service.Details, err = s.status(ctx, srv)
 		if err != nil {
-			if errors.Is(err, helmdriver.ErrReleaseNotFound) {
-				serviceStatus = "Not Ready" // The installation job is still running?
-			} else {
-				return nil, errors.Wrap(err, "finding helm release status")
-			}
+			return nil, err
 		}
 
-		service.Status = models.NewServiceStatusFromHelmRelease(serviceStatus)
-
 		serviceList = append(serviceList, &service)
 	}
 
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/epinio/epinio/helpers/tracelog"
+	"github.com/epinio/epinio/internal/helm"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	"github.com/pkg/errors"
+
+	helmapiv1 "github.com/k3s-io/helm-controller/pkg/apis/helm.cattle.io/v1"
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	helmdriver "helm.sh/helm/v3/pkg/storage/driver"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// releaseSelectorKey is the standard label helm charts place on the resources of a release
+const releaseSelectorKey = "app.kubernetes.io/instance"
+
+// status determines the status of the service instance deployed by the helm chart, from
+// the helm release, the helm controller job (un)installing it, and the pods of the release.
+// Missing pieces, like a job already cleaned up, or a release not yet created, are not
+// errors.
+func (s *ServiceClient) status(ctx context.Context, helmChart helmapiv1.HelmChart) (models.ServiceStatus, models.ServiceStatusDetails, error) {
+	var job *batchv1.Job
+	if helmChart.Status.JobName != "" {
+		j, err := s.kubeClient.Kubectl.BatchV1().Jobs(helmChart.Namespace).Get(ctx,
+			helmChart.Status.JobName, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return models.ServiceStatusUnknown, models.ServiceStatusDetails{},
+				errors.Wrap(err, "finding helm controller job")
+		}
+		if err == nil {
+			job = j
+		}
+	}
+
+	logger := tracelog.NewLogger().WithName("ServiceStatus")
+	release, err := helm.Release(ctx, logger, s.kubeClient, helmChart.Spec.TargetNamespace, helmChart.Name)
+	if err != nil {
+		if !errors.Is(err, helmdriver.ErrReleaseNotFound) {
+			return models.ServiceStatusUnknown, models.ServiceStatusDetails{},
+				errors.Wrap(err, "finding helm release status")
+		}
+		release = nil // The installation job is still running?
+	}
+
+	pods, err := s.kubeClient.ListPods(ctx, helmChart.Spec.TargetNamespace,
+		fmt.Sprintf("%s=%s", releaseSelectorKey, helmChart.Name))
+	if err != nil {
+		return models.ServiceStatusUnknown, models.ServiceStatusDetails{},
+			errors.Wrap(err, "listing the pods of the service")
+	}
+
+	status, details := statusOf(job, release, pods.Items)
+	return status, details, nil
+}
+
+// statusOf computes the status of a service instance from the state of its parts. The
+// service is deployed when its release is, and all of its pods are ready. It has failed
+// when either the helm controller job or the release failed. Anything else is not ready.
+func statusOf(job *batchv1.Job, release *helmrelease.Release, pods []corev1.Pod) (models.ServiceStatus, models.ServiceStatusDetails) {
+	details := models.ServiceStatusDetails{}
+
+	if job != nil {
+		details.JobStatus = models.ServiceJobRunning
+		if job.Status.Succeeded > 0 {
+			details.JobStatus = models.ServiceJobSucceeded
+		}
+		for _, condition := range job.Status.Conditions {
+			if condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch condition.Type {
+			case batchv1.JobComplete:
+				details.JobStatus = models.ServiceJobSucceeded
+			case batchv1.JobFailed:
+				details.JobStatus = models.ServiceJobFailed
+				details.LastError = fmt.Sprintf("helm job %s: %s", job.Name, condition.Message)
+			}
+		}
+	}
+
+	helmStatus := helmrelease.StatusUnknown
+	if release != nil && release.Info != nil {
+		helmStatus = release.Info.Status
+		details.HelmStatus = helmStatus.String()
+		if helmStatus == helmrelease.StatusFailed && details.LastError == "" {
+			details.LastError = fmt.Sprintf("helm release: %s", release.Info.Description)
+		}
+	}
+
+	for _, pod := range pods {
+		details.PodsTotal++
+		if podReady(pod) {
+			details.PodsReady++
+			continue
+		}
+		if details.LastError == "" {
+			details.LastError = podError(pod)
+		}
+	}
+
+	if details.JobStatus == models.ServiceJobFailed {
+		return models.ServiceStatusFailed, details
+	}
+
+	status := models.NewServiceStatusFromHelmRelease(helmStatus)
+	if status == models.ServiceStatusDeployed && details.PodsReady < details.PodsTotal {
+		status = models.ServiceStatusNotReady
+	}
+
+	return status, details
+}
+
+// podReady returns true if the pod has the ready condition
+func podReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podError returns the reason a container of the pod is waiting for or was terminated
+// with, if there is any
+func podError(pod corev1.Pod) string {
+	for _, container := range pod.Status.ContainerStatuses {
+		if waiting := container.State.Waiting; waiting != nil && waiting.Message != "" {
+			return fmt.Sprintf("pod %s: %s: %s", pod.Name, waiting.Reason, waiting.Message)
+		}
+		if terminated := container.LastTerminationState.Terminated; terminated != nil && terminated.ExitCode != 0 {
+			return fmt.Sprintf("pod %s: %s (exit code %d)", pod.Name, terminated.Reason, terminated.ExitCode)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	helmrelease "helm.sh/helm/v3/pkg/release"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("statusOf", func() {
+	release := func(status helmrelease.Status, description string) *helmrelease.Release {
+		return &helmrelease.Release{
+			Info: &helmrelease.Info{Status: status, Description: description},
+		}
+	}
+
+	pod := func(name string, ready bool) corev1.Pod {
+		condition := corev1.ConditionFalse
+		if ready {
+			condition = corev1.ConditionTrue
+		}
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: condition}},
+			},
+		}
+	}
+
+	It("is not ready while the release does not exist", func() {
+		job := &batchv1.Job{Status: batchv1.JobStatus{Active: 1}}
+
+		status, details := statusOf(job, nil, nil)
+		Expect(status).To(Equal(models.ServiceStatusNotReady))
+		Expect(details.JobStatus).To(Equal(models.ServiceJobRunning))
+		Expect(details.HelmStatus).To(BeEmpty())
+	})
+
+	It("is deployed when the release is deployed and all pods are ready", func() {
+		status, details := statusOf(nil, release(helmrelease.StatusDeployed, ""),
+			[]corev1.Pod{pod("a", true), pod("b", true)})
+		Expect(status).To(Equal(models.ServiceStatusDeployed))
+		Expect(details.PodsReady).To(Equal(2))
+		Expect(details.PodsTotal).To(Equal(2))
+		Expect(details.LastError).To(BeEmpty())
+	})
+
+	It("is not ready while pods of a deployed release are not ready", func() {
+		waiting := pod("b", false)
+		waiting.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+				Reason:  "ImagePullBackOff",
+				Message: "image not found",
+			}},
+		}}
+
+		status, details := statusOf(nil, release(helmrelease.StatusDeployed, ""),
+			[]corev1.Pod{pod("a", true), waiting})
+		Expect(status).To(Equal(models.ServiceStatusNotReady))
+		Expect(details.PodsReady).To(Equal(1))
+		Expect(details.LastError).To(Equal("pod b: ImagePullBackOff: image not found"))
+	})
+
+	It("has failed when the helm controller job failed", func() {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "helm-install-x"},
+			Status: batchv1.JobStatus{
+				Failed: 1,
+				Conditions: []batchv1.JobCondition{{
+					Type:    batchv1.JobFailed,
+					Status:  corev1.ConditionTrue,
+					Message: "BackoffLimitExceeded",
+				}},
+			},
+		}
+
+		status, details := statusOf(job, nil, nil)
+		Expect(status).To(Equal(models.ServiceStatusFailed))
+		Expect(details.JobStatus).To(Equal(models.ServiceJobFailed))
+		Expect(details.LastError).To(Equal("helm job helm-install-x: BackoffLimitExceeded"))
+	})
+
+	It("has failed when the release failed", func() {
+		status, details := statusOf(nil, release(helmrelease.StatusFailed, "timed out"), nil)
+		Expect(status).To(Equal(models.ServiceStatusFailed))
+		Expect(details.HelmStatus).To(Equal("failed"))
+		Expect(details.LastError).To(Equal("helm release: timed out"))
+	})
+})
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/strvals"
+)
+
+// ValidateValues verifies that the helm values of a service instance conform to the given
+// JSON schema. The values are the default values of the catalog service (YAML), overridden
+// by the settings of the instance (helm value paths). An empty schema accepts everything.
+func ValidateValues(values string, settings map[string]string, schema string) error {
+	parsed, err := chartutil.ReadValues([]byte(values))
+	if err != nil {
+		return errors.Wrap(err, "parsing the values")
+	}
+
+	for key, value := range settings {
+		err := strvals.ParseInto(fmt.Sprintf("%s=%s", key, value), parsed)
+		if err != nil {
+			return errors.Wrapf(err, "applying setting %s", key)
+		}
+	}
+
+	if schema == "" {
+		return nil
+	}
+
+	return chartutil.ValidateAgainstSingleSchema(parsed, []byte(schema))
+}
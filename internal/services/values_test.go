@@ -0,0 +1,49 @@
+package services_test
+
+import (
+	. "github.com/epinio/epinio/internal/services"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateValues", func() {
+	var schema string
+
+	BeforeEach(func() {
+		schema = `{
+  "type": "object",
+  "properties": {
+    "replicaCount": { "type": "integer", "minimum": 1 },
+    "service": {
+      "type": "object",
+      "properties": { "type": { "enum": ["ClusterIP", "NodePort"] } }
+    }
+  }
+}`
+	})
+
+	It("accepts everything without a schema", func() {
+		err := ValidateValues("replicaCount: 0", map[string]string{"foo.bar": "baz"}, "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("accepts conforming values", func() {
+		err := ValidateValues("service:\n  type: ClusterIP\n", map[string]string{"replicaCount": "2"}, schema)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("rejects non-conforming default values", func() {
+		err := ValidateValues("service:\n  type: LoadBalancer\n", nil, schema)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects non-conforming settings", func() {
+		err := ValidateValues("", map[string]string{"replicaCount": "0"}, schema)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects bad YAML", func() {
+		err := ValidateValues("a: b: c", nil, schema)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,130 @@
+package client_test
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/epinio/epinio/pkg/api/core/v1/client"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func DescribeAppUpload() {
+
+	const chunkSize = 8 * 1024 * 1024
+
+	var epinioClient *client.Client
+	var tmpDir, tarball string
+	var content []byte
+	var start models.UploadStartResponse
+	var puts []string
+	var completed models.UploadCompleteRequest
+
+	hexSum := func(data []byte) string {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "epinio-upload")
+		Expect(err).ToNot(HaveOccurred())
+
+		content = []byte(strings.Repeat("epinio", chunkSize/6+100))
+		tarball = filepath.Join(tmpDir, "blob.tar")
+		Expect(ioutil.WriteFile(tarball, content, 0644)).To(Succeed())
+
+		start = models.UploadStartResponse{BlobUID: "blob"}
+		puts = []string{}
+		completed = models.UploadCompleteRequest{}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		checksum := hexSum(content)
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+
+			body, err := ioutil.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/uploads"):
+				req := models.UploadStartRequest{}
+				Expect(json.Unmarshal(body, &req)).To(Succeed())
+				Expect(req.Checksum).To(Equal(checksum))
+				Expect(req.Size).To(Equal(int64(len(content))))
+
+				Expect(json.NewEncoder(w).Encode(start)).To(Succeed())
+			case strings.Contains(r.URL.Path, "/uploads/"+checksum+"/parts/"):
+				Expect(r.Method).To(Equal("PUT"))
+				Expect(r.URL.Query().Get("sha256")).To(Equal(hexSum(body)))
+
+				puts = append(puts, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+				fmt.Fprint(w, `{}`)
+			case strings.HasSuffix(r.URL.Path, "/uploads/"+checksum+"/complete"):
+				Expect(json.Unmarshal(body, &completed)).To(Succeed())
+				fmt.Fprint(w, `{ "blobuid": "blob" }`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		epinioClient = client.New(srv.URL, "", "", "")
+	})
+
+	When("the server has the sources already", func() {
+		BeforeEach(func() {
+			start.Existing = true
+		})
+
+		It("uploads nothing", func() {
+			resp, err := epinioClient.AppUpload("namespace-foo", "appname", tarball)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp).To(Equal(models.UploadResponse{BlobUID: "blob", Existing: true}))
+			Expect(puts).To(BeEmpty())
+			Expect(completed.Parts).To(BeZero())
+		})
+	})
+
+	When("the server has none of the sources", func() {
+		It("uploads all parts", func() {
+			resp, err := epinioClient.AppUpload("namespace-foo", "appname", tarball)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp).To(Equal(models.UploadResponse{BlobUID: "blob"}))
+			Expect(puts).To(Equal([]string{"1", "2"}))
+			Expect(completed.Parts).To(Equal(2))
+		})
+	})
+
+	When("an earlier upload was not completed", func() {
+		BeforeEach(func() {
+			md5sum := md5.Sum(content[:chunkSize])
+			start.Parts = []models.UploadPart{
+				{Number: 1, Size: chunkSize, ETag: hex.EncodeToString(md5sum[:])},
+				{Number: 2, Size: 3, ETag: "0123"},
+			}
+		})
+
+		It("uploads only the missing or different parts", func() {
+			_, err := epinioClient.AppUpload("namespace-foo", "appname", tarball)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(puts).To(Equal([]string{"2"}))
+			Expect(completed.Parts).To(Equal(2))
+		})
+	})
+}
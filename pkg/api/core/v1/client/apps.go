@@ -1,6 +1,9 @@
 package client
 
 import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -84,11 +87,16 @@ func (c *Client) AppCreate(req models.ApplicationCreateRequest, namespace string
 	return resp, nil
 }
 
-// Apps returns a list of all apps in an namespace
-func (c *Client) Apps(namespace string) (models.AppList, error) {
+// Apps returns a list of all apps in an namespace, or of those matching the label selector
+func (c *Client) Apps(namespace string, selector string) (models.AppList, error) {
 	var resp models.AppList
 
-	data, err := c.get(api.Routes.Path("Apps", namespace))
+	endpoint := api.Routes.Path("Apps", namespace)
+	if selector != "" {
+		endpoint += "?selector=" + url.QueryEscape(selector)
+	}
+
+	data, err := c.get(endpoint)
 	if err != nil {
 		return resp, err
 	}
@@ -102,11 +110,16 @@ func (c *Client) Apps(namespace string) (models.AppList, error) {
 	return resp, nil
 }
 
-// AllApps returns a list of all apps
-func (c *Client) AllApps() (models.AppList, error) {
+// AllApps returns a list of all apps, or of those matching the label selector
+func (c *Client) AllApps(selector string) (models.AppList, error) {
 	var resp models.AppList
 
-	data, err := c.get(api.Routes.Path("AllApps"))
+	endpoint := api.Routes.Path("AllApps")
+	if selector != "" {
+		endpoint += "?selector=" + url.QueryEscape(selector)
+	}
+
+	data, err := c.get(endpoint)
 	if err != nil {
 		return resp, err
 	}
@@ -199,6 +212,31 @@ func (c *Client) AppGetPart(namespace, appName, part, destinationPath string) er
 	return err
 }
 
+// AppSBOM returns the software bills of materials of the image of the staging with the id,
+// or of the deployed image, without id
+func (c *Client) AppSBOM(namespace, appName, stageID string) (models.AppSBOM, error) {
+	var resp models.AppSBOM
+
+	endpoint := api.Routes.Path("AppPart", namespace, appName, "sbom")
+	if stageID != "" {
+		endpoint += "?stage=" + url.QueryEscape(stageID)
+	}
+
+	data, err := c.get(endpoint)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "namespace", resp.Namespace, "app", resp.App,
+		"stage", resp.Stage.ID, "documents", len(resp.Documents))
+
+	return resp, nil
+}
+
 // AppUpdate updates an app
 func (c *Client) AppUpdate(req models.ApplicationUpdateRequest, namespace string, appName string) (models.Response, error) {
 	var resp models.Response
@@ -240,11 +278,90 @@ func (c *Client) AppDelete(namespace string, name string) (models.ApplicationDel
 	return resp, nil
 }
 
-// AppUpload uploads a tarball for the named app, which is later used in staging
+// uploadChunkSize is the size of the parts of a chunked upload. The storage requires all
+// parts but the last to have a size of at least 5 MiB.
+const uploadChunkSize = 8 * 1024 * 1024
+
+// AppUpload uploads a tarball for the named app, which is later used in staging. The
+// tarball is uploaded in chunks, resuming an incomplete upload of the same tarball. When
+// the server has the tarball already nothing is uploaded.
 func (c *Client) AppUpload(namespace string, name string, tarball string) (models.UploadResponse, error) {
 	resp := models.UploadResponse{}
 
-	data, err := c.upload(api.Routes.Path("AppUpload", namespace, name), tarball)
+	file, err := os.Open(tarball)
+	if err != nil {
+		return resp, errors.Wrap(err, "failed to open tarball")
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return resp, errors.Wrap(err, "failed to read tarball")
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+
+	b, err := json.Marshal(models.UploadStartRequest{Checksum: checksum, Size: size})
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("AppUploadStart", namespace, name), string(b))
+	if err != nil {
+		return resp, errors.Wrap(err, "can't upload archive")
+	}
+
+	start := models.UploadStartResponse{}
+	if err := json.Unmarshal(data, &start); err != nil {
+		return resp, errors.Wrap(err, "response body is not JSON")
+	}
+
+	if start.Existing {
+		resp.BlobUID = start.BlobUID
+		resp.Existing = true
+
+		c.log.V(1).Info("archive exists, skipped upload", "response", resp)
+
+		return resp, nil
+	}
+
+	uploaded := map[int]models.UploadPart{}
+	for _, part := range start.Parts {
+		uploaded[part.Number] = part
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return resp, errors.Wrap(err, "failed to read tarball")
+	}
+
+	chunk := make([]byte, uploadChunkSize)
+	parts := 0
+	for {
+		n, err := io.ReadFull(file, chunk)
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return resp, errors.Wrap(err, "failed to read tarball")
+		}
+
+		parts++
+		err = c.appUploadPart(namespace, name, checksum, parts, chunk[:n], uploaded[parts])
+		if err != nil {
+			return resp, errors.Wrap(err, "can't upload archive")
+		}
+
+		if n < uploadChunkSize {
+			break
+		}
+	}
+
+	b, err = json.Marshal(models.UploadCompleteRequest{Parts: parts})
+	if err != nil {
+		return resp, err
+	}
+
+	data, err = c.post(api.Routes.Path("AppUploadComplete", namespace, name, checksum), string(b))
 	if err != nil {
 		return resp, errors.Wrap(err, "can't upload archive")
 	}
@@ -258,11 +375,52 @@ func (c *Client) AppUpload(namespace string, name string, tarball string) (model
 	return resp, nil
 }
 
-// AppImportGit asks the server to import a git repo and put in into the blob store
-func (c *Client) AppImportGit(app models.AppRef, gitRef models.GitRef) (*models.ImportGitResponse, error) {
+// appUploadPart is a helper for AppUpload. It uploads a single part of the tarball, unless
+// the server has the same data for it already, from an earlier incomplete upload.
+func (c *Client) appUploadPart(namespace, name, checksum string, number int, chunk []byte, uploaded models.UploadPart) error {
+	details := c.log.V(1)
+
+	md5sum := md5.Sum(chunk)
+	if uploaded.Size == int64(len(chunk)) && uploaded.ETag == hex.EncodeToString(md5sum[:]) {
+		details.Info("part already uploaded", "part", number)
+		return nil
+	}
+
+	sum := sha256.Sum256(chunk)
+	endpoint := api.Routes.Path("AppUploadPart", namespace, name, checksum, strconv.Itoa(number)) +
+		"?sha256=" + hex.EncodeToString(sum[:])
+
+	return retry.Do(
+		func() error {
+			_, err := c.put(endpoint, string(chunk))
+			return err
+		},
+		retry.RetryIf(func(err error) bool {
+			if r, ok := err.(interface{ StatusCode() int }); ok {
+				return helpers.RetryableCode(r.StatusCode())
+			}
+			return helpers.Retryable(err.Error())
+		}),
+		retry.OnRetry(func(n uint, err error) {
+			details.WithValues(
+				"tries", fmt.Sprintf("%d/%d", n, duration.RetryMax),
+				"error", err.Error(),
+			).Info("Retrying AppUploadPart", "part", number)
+		}),
+		retry.Delay(time.Second),
+		retry.Attempts(duration.RetryMax),
+	)
+}
+
+// AppImportGit asks the server to import a git repo and put in into the blob store. Files
+// matching the exclude patterns are left out.
+func (c *Client) AppImportGit(app models.AppRef, gitRef models.GitRef, exclude []string) (*models.ImportGitResponse, error) {
 	data := url.Values{}
 	data.Set("giturl", gitRef.URL)
 	data.Set("gitrev", gitRef.Revision)
+	for _, pattern := range exclude {
+		data.Add("exclude", pattern)
+	}
 
 	url := fmt.Sprintf("%s%s/%s", c.URL, api.Root, api.Routes.Path("AppImportGit", app.Namespace, app.Name))
 	request, err := http.NewRequest("POST", url, strings.NewReader(data.Encode()))
@@ -625,3 +783,85 @@ func (c *Client) AppRestart(namespace string, appName string) error {
 
 	return nil
 }
+
+// AppsRestart restarts the apps in a namespace matching the label selector
+func (c *Client) AppsRestart(namespace string, selector string) (models.AppsRestartResponse, error) {
+	resp := models.AppsRestartResponse{}
+
+	endpoint := api.Routes.Path("AppsRestart", namespace)
+	if selector != "" {
+		endpoint += "?selector=" + url.QueryEscape(selector)
+	}
+
+	data, err := c.post(endpoint, "")
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// AppPromote copies an app to another namespace and deploys its image there
+func (c *Client) AppPromote(req models.AppPromoteRequest, namespace string, appName string) (models.PromoteResponse, error) {
+	resp := models.PromoteResponse{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("AppPromote", namespace, appName), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// AppCacheShow returns the details of the build cache of an app
+func (c *Client) AppCacheShow(namespace string, appName string) (models.AppCache, error) {
+	resp := models.AppCache{}
+
+	data, err := c.get(api.Routes.Path("AppCacheShow", namespace, appName))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// AppCacheClear removes the build cache of an app
+func (c *Client) AppCacheClear(namespace string, appName string) (models.Response, error) {
+	resp := models.Response{}
+
+	data, err := c.delete(api.Routes.Path("AppCacheClear", namespace, appName))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
@@ -6,4 +6,5 @@ import (
 
 var _ = Describe("Client Apps unit tests", func() {
 	Describe("AppRestart", DescribeAppRestart)
+	Describe("AppUpload", DescribeAppUpload)
 })
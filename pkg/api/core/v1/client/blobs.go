@@ -0,0 +1,49 @@
+package client
+
+import (
+	"encoding/json"
+
+	api "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Blobs returns the list of blobs holding application sources
+func (c *Client) Blobs() (models.BlobList, error) {
+	var resp models.BlobList
+
+	data, err := c.get(api.Routes.Path("Blobs"))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// BlobsGC collects the blobs not in use anymore, or only reports them for a dry run
+func (c *Client) BlobsGC(req models.BlobsGCRequest) (models.BlobsGCResponse, error) {
+	resp := models.BlobsGCResponse{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("BlobsGC"), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"encoding/json"
+
+	api "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Builders returns the list of approved builders
+func (c *Client) Builders() (models.BuilderList, error) {
+	var resp models.BuilderList
+
+	data, err := c.get(api.Routes.Path("Builders"))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// BuilderSet adds the builder to the approved builders, or replaces it
+func (c *Client) BuilderSet(builder models.Builder) (models.Response, error) {
+	resp := models.Response{}
+
+	b, err := json.Marshal(builder)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.put(api.Routes.Path("BuilderSet", builder.Name), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// BuilderDelete removes the named builder from the approved builders
+func (c *Client) BuilderDelete(name string) (models.Response, error) {
+	resp := models.Response{}
+
+	data, err := c.delete(api.Routes.Path("BuilderDelete", name))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
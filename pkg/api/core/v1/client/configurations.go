@@ -2,6 +2,7 @@ package client
 
 import (
 	"encoding/json"
+	"strconv"
 
 	"github.com/pkg/errors"
 
@@ -170,6 +171,47 @@ func (c *Client) ConfigurationUpdate(req models.ConfigurationUpdateRequest, name
 	return resp, nil
 }
 
+// ConfigurationHistory returns the revisions of a configuration, newest first
+func (c *Client) ConfigurationHistory(namespace, name string) (models.ConfigurationHistoryResponse, error) {
+	resp := models.ConfigurationHistoryResponse{}
+
+	data, err := c.get(api.Routes.Path("ConfigurationHistory", namespace, name))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, errors.Wrap(err, "response body is not JSON")
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// ConfigurationRevert reverts a configuration to the data of one of its revisions
+func (c *Client) ConfigurationRevert(req models.ConfigurationRevertRequest, namespace, name string, revision int) (models.Response, error) {
+	resp := models.Response{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("ConfigurationRevert", namespace, name, strconv.Itoa(revision)), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, errors.Wrap(err, "response body is not JSON")
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
 // ConfigurationShow shows a configuration
 func (c *Client) ConfigurationShow(namespace string, name string) (models.ConfigurationResponse, error) {
 	var resp models.ConfigurationResponse
@@ -9,11 +9,16 @@ import (
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
 )
 
-// EnvList returns a map of all env vars for an app
-func (c *Client) EnvList(namespace string, appName string) (models.EnvVariableMap, error) {
-	var resp models.EnvVariableMap
+// EnvList returns all env vars for an app. The values of references are only returned when revealed
+func (c *Client) EnvList(namespace string, appName string, reveal bool) (models.EnvVariableDefinitions, error) {
+	var resp models.EnvVariableDefinitions
 
-	data, err := c.get(api.Routes.Path("EnvList", namespace, appName))
+	endpoint := api.Routes.Path("EnvList", namespace, appName)
+	if reveal {
+		endpoint += "?reveal=true"
+	}
+
+	data, err := c.get(endpoint)
 	if err != nil {
 		return resp, err
 	}
@@ -28,7 +33,7 @@ func (c *Client) EnvList(namespace string, appName string) (models.EnvVariableMa
 }
 
 // EnvSet set env vars for an app
-func (c *Client) EnvSet(req models.EnvVariableMap, namespace string, appName string) (models.Response, error) {
+func (c *Client) EnvSet(req models.EnvVariableDefinitions, namespace string, appName string) (models.Response, error) {
 	resp := models.Response{}
 
 	b, err := json.Marshal(req)
@@ -50,11 +55,16 @@ func (c *Client) EnvSet(req models.EnvVariableMap, namespace string, appName str
 	return resp, nil
 }
 
-// EnvShow shows an env variable
-func (c *Client) EnvShow(namespace string, appName string, envName string) (models.EnvVariable, error) {
+// EnvShow shows an env variable. The value of a reference is only returned when revealed
+func (c *Client) EnvShow(namespace string, appName string, envName string, reveal bool) (models.EnvVariable, error) {
 	resp := models.EnvVariable{}
 
-	data, err := c.get(api.Routes.Path("EnvShow", namespace, appName, envName))
+	endpoint := api.Routes.Path("EnvShow", namespace, appName, envName)
+	if reveal {
+		endpoint += "?reveal=true"
+	}
+
+	data, err := c.get(endpoint)
 	if err != nil {
 		return resp, err
 	}
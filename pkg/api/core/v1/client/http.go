@@ -1,16 +1,11 @@
 package client
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
 	"io/ioutil"
-	"mime/multipart"
 	"net/http"
 	"net/url"
-	"os"
-	"path/filepath"
 	"strings"
 
 	api "github.com/epinio/epinio/internal/api/v1"
@@ -45,67 +40,12 @@ func (c *Client) patch(endpoint string, data string) ([]byte, error) {
 	return c.do(endpoint, "PATCH", data)
 }
 
-func (c *Client) delete(endpoint string) ([]byte, error) {
-	return c.do(endpoint, "DELETE", "")
+func (c *Client) put(endpoint string, data string) ([]byte, error) {
+	return c.do(endpoint, "PUT", data)
 }
 
-// upload the given path as param "file" in a multipart form
-func (c *Client) upload(endpoint string, path string) ([]byte, error) {
-	uri := fmt.Sprintf("%s%s/%s", c.URL, api.Root, endpoint)
-
-	// open the tarball
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to open tarball")
-	}
-	defer file.Close()
-
-	// create multipart form
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile("file", filepath.Base(file.Name()))
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create multiform part")
-	}
-
-	_, err = io.Copy(part, file)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to write to multiform part")
-	}
-
-	err = writer.Close()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to close multiform")
-	}
-
-	// make the request
-	request, err := http.NewRequest("POST", uri, body)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to build request")
-	}
-
-	request.SetBasicAuth(c.user, c.password)
-	request.Header.Add("Content-Type", writer.FormDataContentType())
-
-	response, err := (&http.Client{}).Do(request)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to POST to upload")
-	}
-	defer response.Body.Close()
-
-	bodyBytes, _ := ioutil.ReadAll(response.Body)
-	if response.StatusCode == http.StatusCreated {
-		return bodyBytes, nil
-	}
-
-	if response.StatusCode != http.StatusOK {
-		return nil, wrapResponseError(fmt.Errorf("server status code: %s\n%s",
-			http.StatusText(response.StatusCode), string(bodyBytes)),
-			response.StatusCode)
-	}
-
-	// object was not created, but status was ok?
-	return bodyBytes, nil
+func (c *Client) delete(endpoint string) ([]byte, error) {
+	return c.do(endpoint, "DELETE", "")
 }
 
 func (c *Client) do(endpoint, method, requestBody string) ([]byte, error) {
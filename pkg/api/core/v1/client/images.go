@@ -0,0 +1,49 @@
+package client
+
+import (
+	"encoding/json"
+
+	api "github.com/epinio/epinio/internal/api/v1"
+	"github.com/epinio/epinio/pkg/api/core/v1/models"
+)
+
+// Images returns the list of stage images of the applications
+func (c *Client) Images() (models.AppImageList, error) {
+	var resp models.AppImageList
+
+	data, err := c.get(api.Routes.Path("Images"))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// ImagesGC deletes the expired stage images, or only reports them for a dry run
+func (c *Client) ImagesGC(req models.ImagesGCRequest) (models.ImagesGCResponse, error) {
+	resp := models.ImagesGCResponse{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("ImagesGC"), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
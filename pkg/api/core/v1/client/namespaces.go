@@ -2,6 +2,8 @@ package client
 
 import (
 	"encoding/json"
+	"net/url"
+	"time"
 
 	api "github.com/epinio/epinio/internal/api/v1"
 	"github.com/epinio/epinio/pkg/api/core/v1/models"
@@ -48,6 +50,63 @@ func (c *Client) NamespaceDelete(namespace string) (models.Response, error) {
 	return resp, nil
 }
 
+// NamespaceSoftDelete scales the apps of a namespace to zero, and deletes it after the
+// grace period
+func (c *Client) NamespaceSoftDelete(namespace string, grace time.Duration) (models.Response, error) {
+	resp := models.Response{}
+
+	endpoint := api.Routes.Path("NamespaceDelete", namespace) + "?grace=" + url.QueryEscape(grace.String())
+
+	data, err := c.delete(endpoint)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// NamespaceUndelete cancels the pending deletion of a soft-deleted namespace
+func (c *Client) NamespaceUndelete(namespace string) (models.Response, error) {
+	resp := models.Response{}
+
+	data, err := c.post(api.Routes.Path("NamespaceUndelete", namespace), "")
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// NamespaceDeletionPreview returns what the deletion of a namespace would destroy
+func (c *Client) NamespaceDeletionPreview(namespace string) (models.NamespaceDeletionPreview, error) {
+	resp := models.NamespaceDeletionPreview{}
+
+	data, err := c.get(api.Routes.Path("NamespaceDeletionPreview", namespace))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
 // NamespaceShow shows a namespace
 func (c *Client) NamespaceShow(namespace string) (models.Namespace, error) {
 	resp := models.Namespace{}
@@ -84,11 +143,219 @@ func (c *Client) NamespacesMatch(prefix string) (models.NamespacesMatchResponse,
 	return resp, nil
 }
 
-// Namespaces returns a list of namespaces
-func (c *Client) Namespaces() (models.NamespaceList, error) {
+// Namespaces returns a list of namespaces, restricted to those whose labels match the
+// selector, if any
+func (c *Client) Namespaces(selector string) (models.NamespaceList, error) {
 	resp := models.NamespaceList{}
 
-	data, err := c.get(api.Routes.Path("Namespaces"))
+	endpoint := api.Routes.Path("Namespaces")
+	if selector != "" {
+		endpoint += "?selector=" + url.QueryEscape(selector)
+	}
+
+	data, err := c.get(endpoint)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// NamespaceEnvList returns the default environment variables of the apps in a namespace
+func (c *Client) NamespaceEnvList(namespace string) (models.EnvVariableMap, error) {
+	resp := models.EnvVariableMap{}
+
+	data, err := c.get(api.Routes.Path("NamespaceEnvList", namespace))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// NamespaceEnvSet sets default environment variables for the apps in a namespace
+func (c *Client) NamespaceEnvSet(req models.NamespaceEnvSetRequest, namespace string) (models.Response, error) {
+	resp := models.Response{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("NamespaceEnvSet", namespace), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// NamespaceEnvUnset removes a default environment variable of the apps in a namespace
+func (c *Client) NamespaceEnvUnset(namespace, name string, restart bool) (models.Response, error) {
+	resp := models.Response{}
+
+	endpoint := api.Routes.Path("NamespaceEnvUnset", namespace, name)
+	if restart {
+		endpoint += "?restart=true"
+	}
+
+	data, err := c.delete(endpoint)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// NamespaceBind binds configurations to all apps in a namespace
+func (c *Client) NamespaceBind(req models.NamespaceBindRequest, namespace string) (models.Response, error) {
+	resp := models.Response{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("NamespaceBind", namespace), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// NamespaceUnbind removes a configuration from the configurations bound to all apps in a namespace
+func (c *Client) NamespaceUnbind(namespace, configurationName string, restart bool) (models.Response, error) {
+	resp := models.Response{}
+
+	endpoint := api.Routes.Path("NamespaceUnbind", namespace, configurationName)
+	if restart {
+		endpoint += "?restart=true"
+	}
+
+	data, err := c.delete(endpoint)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// NamespaceQuotaUpdate replaces the quota of a namespace
+func (c *Client) NamespaceQuotaUpdate(quota models.NamespaceQuota, namespace string) (models.Response, error) {
+	resp := models.Response{}
+
+	b, err := json.Marshal(quota)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.put(api.Routes.Path("NamespaceQuotaUpdate", namespace), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// NamespaceBuilderUpdate sets the default builder of a namespace
+func (c *Client) NamespaceBuilderUpdate(req models.NamespaceBuilderRequest, namespace string) (models.Response, error) {
+	resp := models.Response{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.put(api.Routes.Path("NamespaceBuilderUpdate", namespace), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// NamespaceClone creates a new namespace as copy of the named one
+func (c *Client) NamespaceClone(req models.NamespaceCloneRequest, namespace string) (models.PromoteResponse, error) {
+	resp := models.PromoteResponse{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.post(api.Routes.Path("NamespaceClone", namespace), string(b))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// NamespaceUpdate changes the labels, description and owner of a namespace
+func (c *Client) NamespaceUpdate(req models.NamespaceUpdateRequest, namespace string) (models.Response, error) {
+	resp := models.Response{}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+
+	data, err := c.patch(api.Routes.Path("NamespaceUpdate", namespace), string(b))
 	if err != nil {
 		return resp, err
 	}
@@ -41,6 +41,31 @@ func (c *Client) ServiceCatalogShow(serviceName string) (*models.ServiceCatalogS
 	return &resp, nil
 }
 
+func (c *Client) ServiceCatalogCreate(req *models.CatalogService) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.post(api.Routes.Path("ServiceCatalogCreate"), string(b))
+	return err
+}
+
+func (c *Client) ServiceCatalogUpdate(req *models.ServiceCatalogUpdateRequest, serviceName string) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.patch(api.Routes.Path("ServiceCatalogUpdate", serviceName), string(b))
+	return err
+}
+
+func (c *Client) ServiceCatalogDelete(serviceName string) error {
+	_, err := c.delete(api.Routes.Path("ServiceCatalogDelete", serviceName))
+	return err
+}
+
 func (c *Client) AllServices() (*models.ServiceListResponse, error) {
 	data, err := c.get(api.Routes.Path("AllServices"))
 	if err != nil {
@@ -68,7 +93,12 @@ func (c *Client) ServiceCreate(req *models.ServiceCreateRequest, namespace strin
 }
 
 func (c *Client) ServiceShow(req *models.ServiceShowRequest, namespace string) (*models.ServiceShowResponse, error) {
-	data, err := c.get(api.Routes.Path("ServiceShow", namespace, req.Name))
+	endpoint := api.Routes.Path("ServiceShow", namespace, req.Name)
+	if req.Secrets {
+		endpoint += "?secrets=true"
+	}
+
+	data, err := c.get(endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -148,3 +178,116 @@ func (c *Client) ServiceList(namespace string) (*models.ServiceListResponse, err
 
 	return &resp, err
 }
+
+func (c *Client) ServiceUpdate(req *models.ServiceUpdateRequest, namespace, name string) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.patch(api.Routes.Path("ServiceUpdate", namespace, name), string(b))
+	return err
+}
+
+func (c *Client) ServiceUpgrade(namespace, name string) (*models.ServiceUpgradeResponse, error) {
+	data, err := c.post(api.Routes.Path("ServiceUpgrade", namespace, name), "")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.ServiceUpgradeResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return &resp, nil
+}
+
+// ServiceBackup starts saving the data of the named service, and returns the job doing so
+func (c *Client) ServiceBackup(namespace, name string) (*models.ServiceJob, error) {
+	data, err := c.post(api.Routes.Path("ServiceBackup", namespace, name), "")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.ServiceJob
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return &resp, nil
+}
+
+// ServiceBackups returns the backups of the named service
+func (c *Client) ServiceBackups(namespace, name string) (models.ServiceBackupList, error) {
+	data, err := c.get(api.Routes.Path("ServiceBackups", namespace, name))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.ServiceBackupList
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
+
+// ServiceRestore starts restoring the data of the named service from the specified backup,
+// and returns the job doing so
+func (c *Client) ServiceRestore(namespace, name, backupID string) (*models.ServiceJob, error) {
+	data, err := c.post(api.Routes.Path("ServiceRestore", namespace, name, backupID), "")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.ServiceJob
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return &resp, nil
+}
+
+// ServiceJob returns the status of the named backup or restore job of the named service
+func (c *Client) ServiceJob(namespace, name, job string) (*models.ServiceJob, error) {
+	data, err := c.get(api.Routes.Path("ServiceJob", namespace, name, job))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.ServiceJob
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return &resp, nil
+}
+
+// ServiceJobDelete deletes the named backup or restore job of the named service
+func (c *Client) ServiceJobDelete(namespace, name, job string) (models.Response, error) {
+	resp := models.Response{}
+
+	data, err := c.delete(api.Routes.Path("ServiceJobDelete", namespace, name, job))
+	if err != nil {
+		return resp, err
+	}
+
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return resp, err
+	}
+
+	c.log.V(1).Info("response decoded", "response", resp)
+
+	return resp, nil
+}
@@ -124,6 +124,15 @@ func NamespaceIsNotKnown(namespace string) APIError {
 		http.StatusNotFound)
 }
 
+// NamespaceIsPendingDeletion constructs an API error for when the desired namespace is
+// soft-deleted, and does not accept changes anymore
+func NamespaceIsPendingDeletion(namespace string) APIError {
+	return NewAPIError(
+		fmt.Sprintf("Namespace '%s' is scheduled for deletion. Undelete it to make changes", namespace),
+		"",
+		http.StatusConflict)
+}
+
 // AppAlreadyKnown constructs an API error for when we have a conflict with an existing app
 func AppAlreadyKnown(app string) APIError {
 	return NewAPIError(
@@ -148,6 +157,48 @@ func ServiceIsNotKnown(service string) APIError {
 		http.StatusNotFound)
 }
 
+// ServiceIsNotReady constructs an API error for when the desired service is not deployed,
+// or not all of its pods are ready
+func ServiceIsNotReady(service string, status string) APIError {
+	return NewAPIError(
+		fmt.Sprintf("Service '%s' is not ready", service),
+		status,
+		http.StatusBadRequest)
+}
+
+// ServiceBackupIsNotKnown constructs an API error for when the desired backup of a service does not exist
+func ServiceBackupIsNotKnown(service, backupID string) APIError {
+	return NewAPIError(
+		fmt.Sprintf("Backup '%s' of service '%s' does not exist", backupID, service),
+		"",
+		http.StatusNotFound)
+}
+
+// ServiceJobIsNotKnown constructs an API error for when the desired backup or restore job
+// of a service does not exist, e.g. as its status was reported already
+func ServiceJobIsNotKnown(service, job string) APIError {
+	return NewAPIError(
+		fmt.Sprintf("Job '%s' of service '%s' does not exist", job, service),
+		"",
+		http.StatusNotFound)
+}
+
+// CatalogServiceIsNotKnown constructs an API error for when the desired catalog service does not exist
+func CatalogServiceIsNotKnown(catalogService string) APIError {
+	return NewAPIError(
+		fmt.Sprintf("Catalog service '%s' does not exist", catalogService),
+		"",
+		http.StatusNotFound)
+}
+
+// CatalogServiceAlreadyKnown constructs an API error for when we have a conflict with an existing catalog service
+func CatalogServiceAlreadyKnown(catalogService string) APIError {
+	return NewAPIError(
+		fmt.Sprintf("Catalog service '%s' already exists", catalogService),
+		"",
+		http.StatusConflict)
+}
+
 // ConfigurationIsNotKnown constructs an API error for when the desired configuration instance does not exist
 func ConfigurationIsNotKnown(configuration string) APIError {
 	return NewAPIError(
@@ -203,3 +254,56 @@ func AppChartIsNotKnown(app string) APIError {
 		"",
 		http.StatusNotFound)
 }
+
+// QuotaExceeded constructs an API error for when a request would take the namespace beyond
+// its quota for the named resource
+func QuotaExceeded(namespace, resource string, limit, requested int64) APIError {
+	return NewAPIError(
+		fmt.Sprintf("Quota of namespace '%s' exceeded: %d %s requested, %d allowed", namespace, requested, resource, limit),
+		"",
+		http.StatusForbidden)
+}
+
+// NamespaceIsProtected constructs an API error for when a protected namespace is to be
+// deleted
+func NamespaceIsProtected(namespace string) APIError {
+	return NewAPIError(
+		fmt.Sprintf("Namespace '%s' is protected against deletion", namespace),
+		"",
+		http.StatusForbidden)
+}
+
+// BuilderIsNotKnown constructs an API error for when the desired builder does not exist
+func BuilderIsNotKnown(builder string) APIError {
+	return NewAPIError(
+		fmt.Sprintf("Builder '%s' does not exist", builder),
+		"",
+		http.StatusNotFound)
+}
+
+// BuilderIsInUse constructs an API error for when the builder to delete is the default
+// builder of namespaces
+func BuilderIsInUse(builder string, namespaces []string) APIError {
+	return NewAPIError(
+		fmt.Sprintf("Builder '%s' is the default builder of namespaces", builder),
+		fmt.Sprintf("namespaces: %s", strings.Join(namespaces, ", ")),
+		http.StatusBadRequest)
+}
+
+// BuilderImageNotApproved constructs an API error for when staging is requested with a
+// builder image which is not in the registry of approved builders
+func BuilderImageNotApproved(image string, approved []string) APIError {
+	return NewAPIError(
+		fmt.Sprintf("Builder image '%s' is not approved", image),
+		fmt.Sprintf("approved builders: %s", strings.Join(approved, ", ")),
+		http.StatusForbidden)
+}
+
+// StageImageIsNotKnown constructs an API error for when the image of the desired staging
+// of an application does not exist, e.g. as it was deleted by the image retention
+func StageImageIsNotKnown(app, stageID string) APIError {
+	return NewAPIError(
+		fmt.Sprintf("Image of stage '%s' of application '%s' does not exist", stageID, app),
+		"",
+		http.StatusNotFound)
+}
@@ -1,17 +1,48 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 )
 
 // This subsection of models provides structures related to the
 // environment variables of applications.
 
-// EnvVariable represents the Show Response for a single environment variable
+// EnvVariable represents the Show Response for a single environment variable. For a
+// variable referencing a configuration key the value is only present when revealed.
 type EnvVariable struct {
-	Name  string `json:"name"`
-	Value string `json:"value"`
+	Name      string             `json:"name"`
+	Value     string             `json:"value"`
+	ValueFrom *EnvVariableSource `json:"value_from,omitempty"`
+}
+
+// EnvVariableSource references the configuration key providing the value of an
+// environment variable. The reference is resolved when the application is deployed.
+type EnvVariableSource struct {
+	Configuration string `json:"configuration" yaml:"configuration"`
+	Key           string `json:"key"           yaml:"key"`
+}
+
+// EnvVariableRefMap maps the names of environment variables to the configuration keys
+// providing their values.
+type EnvVariableRefMap map[string]EnvVariableSource
+
+// EnvVariableDefinitions is the whole environment of an application, i.e. the variables
+// with plain values, and the variables referencing configuration keys. It is used for
+// Set Requests, and as List Responses. In JSON it is a single object, with plain values
+// as strings, and references as objects of the form
+//
+//	{"value_from": {"configuration": NAME, "key": KEY}, "value": VALUE}
+//
+// where the value is only present when revealed.
+//
+// swagger:type object
+type EnvVariableDefinitions struct {
+	Values     EnvVariableMap
+	References EnvVariableRefMap
+	Revealed   EnvVariableMap // Values of the references, if requested. Ignored by Set.
 }
 
 // EnvVariableList is a collection of EVs.
@@ -25,6 +56,100 @@ type EnvVariableMap map[string]string
 // Responses
 type EnvVarnameList []string
 
+// String returns the reference in the form `CONFIGURATION/KEY`
+func (s EnvVariableSource) String() string {
+	return s.Configuration + "/" + s.Key
+}
+
+// ParseEnvVariableSource converts a reference of the form `CONFIGURATION/KEY` into the
+// structure.
+func ParseEnvVariableSource(spec string) (EnvVariableSource, error) {
+	pieces := strings.SplitN(spec, "/", 2)
+	if len(pieces) != 2 || pieces[0] == "" || pieces[1] == "" {
+		return EnvVariableSource{}, fmt.Errorf("bad reference `%s`, expected `CONFIGURATION/KEY`", spec)
+	}
+	return EnvVariableSource{Configuration: pieces[0], Key: pieces[1]}, nil
+}
+
+// envReference is the JSON form of a reference in EnvVariableDefinitions
+type envReference struct {
+	ValueFrom EnvVariableSource `json:"value_from"`
+	Value     *string           `json:"value,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (evd EnvVariableDefinitions) MarshalJSON() ([]byte, error) {
+	result := map[string]interface{}{}
+	for name, value := range evd.Values {
+		result[name] = value
+	}
+	for name, source := range evd.References {
+		reference := envReference{ValueFrom: source}
+		if value, ok := evd.Revealed[name]; ok {
+			reference.Value = &value
+		}
+		result[name] = reference
+	}
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (evd *EnvVariableDefinitions) UnmarshalJSON(data []byte) error {
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*evd = EnvVariableDefinitions{}
+	for name, item := range raw {
+		var value string
+		if err := json.Unmarshal(item, &value); err == nil {
+			if evd.Values == nil {
+				evd.Values = EnvVariableMap{}
+			}
+			evd.Values[name] = value
+			continue
+		}
+
+		var reference envReference
+		if err := json.Unmarshal(item, &reference); err != nil {
+			return fmt.Errorf("environment variable %s: expected string or reference", name)
+		}
+		if reference.ValueFrom.Configuration == "" || reference.ValueFrom.Key == "" {
+			return fmt.Errorf("environment variable %s: reference without configuration or key", name)
+		}
+
+		if evd.References == nil {
+			evd.References = EnvVariableRefMap{}
+		}
+		evd.References[name] = reference.ValueFrom
+		if reference.Value != nil {
+			if evd.Revealed == nil {
+				evd.Revealed = EnvVariableMap{}
+			}
+			evd.Revealed[name] = *reference.Value
+		}
+	}
+
+	return nil
+}
+
+// List returns all variables, plain and references, sorted by name. References carry
+// their revealed value, if any.
+func (evd EnvVariableDefinitions) List() EnvVariableList {
+	result := evd.Values.List()
+	for name, source := range evd.References {
+		source := source
+		result = append(result, EnvVariable{
+			Name:      name,
+			Value:     evd.Revealed[name],
+			ValueFrom: &source,
+		})
+	}
+	sort.Sort(result)
+	return result
+}
+
 func (evm EnvVariableMap) List() EnvVariableList {
 	result := EnvVariableList{}
 	for name, value := range evm {
@@ -8,7 +8,11 @@
 package models
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"path"
+	"regexp"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -61,8 +65,25 @@ type ConfigurationResponseList []ConfigurationResponse
 type ConfigurationCreateRequest struct {
 	Name string            `json:"name"`
 	Data map[string]string `json:"data"`
+	// BinaryData holds values which are not text, e.g. the contents of binary files.
+	BinaryData map[string][]byte `json:"binary_data,omitempty"`
+	// Type is the type of the configuration, checked by the server. Defaults to custom.
+	Type string `json:"type,omitempty"`
 }
 
+// The types of configurations users can create. All but the custom type require specific
+// keys, and are validated by the server.
+const (
+	ConfigurationTypeCustom         = "custom"
+	ConfigurationTypeTLS            = "tls"             // keys: tls.crt, tls.key
+	ConfigurationTypeDockerRegistry = "docker-registry" // keys: server, username, password, optional email
+	ConfigurationTypeBasicAuth      = "basic-auth"      // keys: username, password
+)
+
+// ConfigurationTypeService is the type of the configurations generated by service
+// instances. Users cannot create configurations of this type.
+const ConfigurationTypeService = "service"
+
 // ConfigurationUpdateRequest represents and contains the data needed to
 // update a configuration instance (add/change, and remove keys)
 type ConfigurationUpdateRequest struct {
@@ -74,6 +95,31 @@ type ConfigurationUpdateRequest struct {
 // replace a configuration instance
 type ConfigurationReplaceRequest map[string]string
 
+// ConfigurationRevision describes a revision of a configuration, i.e. who changed which of
+// its keys when. The values are not part of it.
+type ConfigurationRevision struct {
+	Revision  int         `json:"revision"`
+	Username  string      `json:"username,omitempty"`
+	CreatedAt metav1.Time `json:"createdAt,omitempty"`
+	// Current is true for the revision holding the current data of the configuration
+	Current  bool     `json:"current,omitempty"`
+	Added    []string `json:"added,omitempty"`
+	Modified []string `json:"modified,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+	// RevertedFrom is the revision the data of this revision was taken from, if any
+	RevertedFrom int `json:"reverted_from,omitempty"`
+}
+
+// ConfigurationHistoryResponse lists the revisions of a configuration, newest first
+type ConfigurationHistoryResponse []ConfigurationRevision
+
+// ConfigurationRevertRequest represents and contains the data needed to revert a
+// configuration to one of its revisions
+type ConfigurationRevertRequest struct {
+	// Restart requests the restart of the applications bound to the configuration
+	Restart bool `json:"restart,omitempty"`
+}
+
 // ConfigurationDeleteRequest represents and contains the data needed to delete a configuration
 type ConfigurationDeleteRequest struct {
 	Unbind bool `json:"unbind"`
@@ -87,6 +133,9 @@ type ConfigurationDeleteResponse struct {
 // BindRequest represents and contains the data needed to bind configurations to an application.
 type BindRequest struct {
 	Names []string `json:"names"`
+	// Options specifies how to bind the configurations it mentions. The others are
+	// bound with the default options.
+	Options map[string]BindOptions `json:"options,omitempty"`
 }
 
 // BindResponse represents the server's response to the successful binding of configurations to
@@ -106,11 +155,56 @@ type ApplicationManifest struct {
 }
 
 // ApplicationStage is the part of the manifest holding information
-// relevant to staging the application's sources. This is, currently,
-// only the reference to the Paketo builder image to use.
+// relevant to staging the application's sources. This is the reference
+// to the Paketo builder image to use, and the patterns of the sources
+// to not upload, in gitignore syntax. With the dockerfile strategy the
+// image is built from the Dockerfile at the given path in the sources,
+// using the build args, instead of the builder image.
+// The environment and the secrets are only provided to the staging, never
+// to the running application. The secrets reference configuration keys,
+// and their values are masked in the staging logs.
+// The job settings override the server defaults for the resources, node
+// placement, and limits of the staging job.
 type ApplicationStage struct {
-	Builder string `yaml:"builder,omitempty"`
-}
+	Builder     string            `yaml:"builder,omitempty"`
+	Exclude     []string          `yaml:"exclude,omitempty"`
+	Strategy    string            `yaml:"strategy,omitempty"`
+	Dockerfile  string            `yaml:"dockerfile,omitempty"`
+	BuildArgs   map[string]string `yaml:"build_args,omitempty"`
+	Environment EnvVariableMap    `yaml:"environment,omitempty"`
+	Secrets     EnvVariableRefMap `yaml:"secrets,omitempty"`
+	Job         StagingSettings   `yaml:",inline"`
+}
+
+// StagingSettings holds the resources, node placement, and limits of a staging job.
+// Empty fields use the defaults configured for the server. CPU and memory are resource
+// quantities, as known to kubernetes. Tolerations use the syntax of taints, i.e.
+// KEY[=VALUE][:EFFECT]. The timeout is a duration, e.g. 30m, limiting the run time of
+// the job, across all attempts. The backoff limit is the number of retries of a failed
+// staging. The node selector and tolerations of the server defaults stay in effect, and
+// its CPU and memory limits, and timeout, are the maxima for the settings of applications.
+type StagingSettings struct {
+	CPURequest    string            `json:"cpu_request,omitempty"    yaml:"cpu_request,omitempty"`
+	MemoryRequest string            `json:"memory_request,omitempty" yaml:"memory_request,omitempty"`
+	CPULimit      string            `json:"cpu_limit,omitempty"      yaml:"cpu_limit,omitempty"`
+	MemoryLimit   string            `json:"memory_limit,omitempty"   yaml:"memory_limit,omitempty"`
+	NodeSelector  map[string]string `json:"node_selector,omitempty"  yaml:"node_selector,omitempty"`
+	Tolerations   []string          `json:"tolerations,omitempty"    yaml:"tolerations,omitempty"`
+	Timeout       string            `json:"timeout,omitempty"        yaml:"timeout,omitempty"`
+	BackoffLimit  *int32            `json:"backoff_limit,omitempty"  yaml:"backoff_limit,omitempty"`
+}
+
+// IsEmpty returns true if the settings do not override any default.
+func (s StagingSettings) IsEmpty() bool {
+	return s.CPURequest == "" && s.MemoryRequest == "" && s.CPULimit == "" && s.MemoryLimit == "" &&
+		len(s.NodeSelector) == 0 && len(s.Tolerations) == 0 && s.Timeout == "" && s.BackoffLimit == nil
+}
+
+// Staging strategies. Buildpacks is the default.
+const (
+	StrategyBuildpacks = "buildpacks"
+	StrategyDockerfile = "dockerfile"
+)
 
 // ApplicationOrigin is the part of the manifest describing the origin of the application
 // (sources). At most one of the fields may be specified / not empty.
@@ -163,13 +257,82 @@ type ApplicationCreateRequest struct {
 // Note: Instances is a pointer to give us a nil value separate from
 // actual integers, as means of communicating `default`/`no change`.
 type ApplicationUpdateRequest struct {
-	Instances      *int32         `json:"instances"          yaml:"instances,omitempty"`
-	Configurations []string       `json:"configurations"     yaml:"configurations,omitempty"`
-	Environment    EnvVariableMap `json:"environment"        yaml:"environment,omitempty"`
-	Routes         []string       `json:"routes"             yaml:"routes,omitempty"`
-	AppChart       string         `json:"appchart,omitempty" yaml:"appchart,omitempty"`
+	Instances      *int32                 `json:"instances"              yaml:"instances,omitempty"`
+	Configurations []string               `json:"configurations"         yaml:"configurations,omitempty"`
+	BindOptions    map[string]BindOptions `json:"bind_options,omitempty" yaml:"bind_options,omitempty"`
+	Environment    EnvVariableMap         `json:"environment"            yaml:"environment,omitempty"`
+	Routes         []string               `json:"routes"                 yaml:"routes,omitempty"`
+	AppChart       string                 `json:"appchart,omitempty"     yaml:"appchart,omitempty"`
+	// EnvironmentFrom holds the environment variables taking their values from
+	// configuration keys. They are kept out of Environment so that manifests do not
+	// have to contain the secret values.
+	EnvironmentFrom EnvVariableRefMap `json:"environment_from,omitempty" yaml:"environment_from,omitempty"`
+	// Labels and Annotations are set on the application, and on its deployment and pods.
+	// Updates add to, or replace, the existing ones. An annotation with an empty value is
+	// removed.
+	Labels      map[string]string `json:"labels,omitempty"      yaml:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	// RemoveLabels names the labels an update removes. It is not part of manifests.
+	RemoveLabels []string `json:"remove_labels,omitempty" yaml:"-"`
+}
+
+// BindOptions controls how the keys of a configuration bound to an application are made
+// available to it. Without options all keys are mounted as files, under their own names.
+type BindOptions struct {
+	// Keys maps the keys of the configuration to make available to the names the
+	// application sees them under. When empty all keys are available, unchanged.
+	Keys map[string]string `json:"keys,omitempty"   yaml:"keys,omitempty"`
+	// AsEnv injects the keys as environment variables instead of mounting them as files.
+	AsEnv bool `json:"as_env,omitempty" yaml:"as_env,omitempty"`
+	// EnvPrefix is prepended to the names of the keys injected as environment variables.
+	EnvPrefix string `json:"env_prefix,omitempty" yaml:"env_prefix,omitempty"`
+	// MountPath is the directory the keys are mounted at as files. Defaults to
+	// `/configurations/NAME`.
+	MountPath string `json:"mount_path,omitempty" yaml:"mount_path,omitempty"`
+	// Mode is the permission mode of the mounted files. Defaults to 0644.
+	Mode *int32 `json:"mode,omitempty" yaml:"mode,omitempty"`
+}
+
+// IsDefault returns true if the options do not change the default handling of a binding.
+func (o BindOptions) IsDefault() bool {
+	return len(o.Keys) == 0 && !o.AsEnv && o.EnvPrefix == "" && o.MountPath == "" && o.Mode == nil
+}
+
+// Validate checks that the options are consistent with each other. It does not check the
+// keys against the configuration.
+func (o BindOptions) Validate() error {
+	for key, as := range o.Keys {
+		if as == "" {
+			return fmt.Errorf("empty name for key '%s'", key)
+		}
+	}
+
+	if o.AsEnv {
+		if o.MountPath != "" || o.Mode != nil {
+			return errors.New("mount path and mode cannot be used for keys injected as environment variables")
+		}
+		if o.EnvPrefix != "" && !envNameRegex.MatchString(o.EnvPrefix) {
+			return fmt.Errorf("bad environment variable prefix '%s'", o.EnvPrefix)
+		}
+		return nil
+	}
+
+	if o.EnvPrefix != "" {
+		return errors.New("environment variable prefix requires the injection of keys as environment variables")
+	}
+	if o.MountPath != "" && (!path.IsAbs(o.MountPath) || path.Clean(o.MountPath) != o.MountPath || o.MountPath == "/") {
+		return fmt.Errorf("bad mount path '%s', expected a clean absolute path below the root", o.MountPath)
+	}
+	if o.Mode != nil && (*o.Mode < 0 || *o.Mode > 0777) {
+		return fmt.Errorf("bad file mode %o, expected a value between 0 and 0777", *o.Mode)
+	}
+
+	return nil
 }
 
+// envNameRegex matches names usable as (prefixes of) environment variables.
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 type ImportGitResponse struct {
 	BlobUID string `json:"blobuid,omitempty"`
 }
@@ -179,13 +342,58 @@ type ImportGitResponse struct {
 // UploadResponse represents the server's response to a successful app sources upload
 type UploadResponse struct {
 	BlobUID string `json:"blobuid,omitempty"`
+	// Existing is true when the server already had the sources, and nothing was uploaded
+	Existing bool `json:"existing,omitempty"`
+}
+
+// UploadStartRequest starts, or resumes, the chunked upload of app sources, identified by
+// the SHA-256 checksum (hex encoded) of the archive.
+type UploadStartRequest struct {
+	Checksum string `json:"sha256"`
+	Size     int64  `json:"size"`
+}
+
+// UploadStartResponse represents the server's response to the start of a chunked upload.
+// For sources already stored nothing has to be uploaded, and Existing is true. Otherwise
+// Parts lists the parts an earlier, incomplete upload of the same sources left behind.
+type UploadStartResponse struct {
+	BlobUID  string       `json:"blobuid,omitempty"`
+	Existing bool         `json:"existing,omitempty"`
+	Parts    []UploadPart `json:"parts,omitempty"`
+}
+
+// UploadPart describes an uploaded part of a chunked upload. The ETag is the MD5 checksum
+// (hex encoded) of the part's data.
+type UploadPart struct {
+	Number int    `json:"number"`
+	Size   int64  `json:"size"`
+	ETag   string `json:"etag"`
+}
+
+// UploadCompleteRequest completes a chunked upload consisting of the parts numbered 1 to
+// Parts.
+type UploadCompleteRequest struct {
+	Parts int `json:"parts"`
 }
 
 // StageRequest represents and contains the data needed to stage an application
+// A request without any of strategy, Dockerfile, build args, build environment, and build
+// secrets uses these settings of the previous staging. Otherwise the strategy defaults to
+// buildpacks.
 type StageRequest struct {
-	App          AppRef `json:"app,omitempty"`
-	BlobUID      string `json:"blobuid,omitempty"`
-	BuilderImage string `json:"builderimage,omitempty"`
+	App              AppRef            `json:"app,omitempty"`
+	BlobUID          string            `json:"blobuid,omitempty"`
+	BuilderImage     string            `json:"builderimage,omitempty"`
+	Strategy         string            `json:"strategy,omitempty"`
+	Dockerfile       string            `json:"dockerfile,omitempty"`
+	BuildArgs        map[string]string `json:"build_args,omitempty"`
+	BuildEnvironment EnvVariableMap    `json:"build_environment,omitempty"`
+	BuildSecrets     EnvVariableRefMap `json:"build_secrets,omitempty"`
+	// NoCache clears the build cache of the application before building.
+	NoCache bool `json:"no_cache,omitempty"`
+	// Job overrides the server defaults for the staging job. A request without
+	// overrides uses the overrides of the previous staging.
+	Job StagingSettings `json:"job,omitempty"`
 }
 
 // StageResponse represents the server's response to a successful app staging
@@ -224,8 +432,11 @@ type EnvMatchResponse struct {
 // ConfigurationShowResponse contains details about a configuration
 type ConfigurationShowResponse struct {
 	Username  string            `json:"user"`
+	Type      string            `json:"type,omitempty"`
 	Details   map[string]string `json:"details,omitempty"`
 	BoundApps []string          `json:"boundapps"`
+	// BinaryDetails holds the values which are not text
+	BinaryDetails map[string][]byte `json:"binary_details,omitempty"`
 }
 
 // InfoResponse contains information about Epinio and its components
@@ -240,9 +451,75 @@ type AuthTokenResponse struct {
 	Token string `json:"token,omitempty"`
 }
 
-// NamespaceCreateRequest contains the name of the namespace that should be created
+// NamespaceCreateRequest contains the name of the namespace that should be created, and
+// its optional quota and metadata
 type NamespaceCreateRequest struct {
-	Name string `json:"name,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Quota       *NamespaceQuota   `json:"quota,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Owner       string            `json:"owner,omitempty"`
+}
+
+// NamespaceUpdateRequest contains changes to the metadata of a namespace. Labels are added
+// or replaced, or removed. Description and owner are changed when present, and removed
+// when empty. Protection against deletion is changed when present, by admins only.
+type NamespaceUpdateRequest struct {
+	Labels       map[string]string `json:"labels,omitempty"`
+	RemoveLabels []string          `json:"remove_labels,omitempty"`
+	Description  *string           `json:"description,omitempty"`
+	Owner        *string           `json:"owner,omitempty"`
+	Protected    *bool             `json:"protected,omitempty"`
+}
+
+// NamespaceEnvSetRequest contains the default environment variables to set for the apps
+// of a namespace, and whether to restart the apps to pick them up
+type NamespaceEnvSetRequest struct {
+	Environment EnvVariableMap `json:"environment,omitempty"`
+	Restart     bool           `json:"restart,omitempty"`
+}
+
+// NamespaceBindRequest contains the names of the configurations to bind to all apps of a
+// namespace, and whether to restart the apps to pick them up
+type NamespaceBindRequest struct {
+	Names   []string `json:"names,omitempty"`
+	Restart bool     `json:"restart,omitempty"`
+}
+
+// NamespaceCloneRequest contains the name of the namespace to create as copy of another,
+// and how to copy the apps, configurations, and services
+type NamespaceCloneRequest struct {
+	Name                   string                       `json:"name,omitempty"`
+	RoutePattern           string                       `json:"route_pattern,omitempty"`
+	ConfigurationOverrides map[string]map[string]string `json:"configuration_overrides,omitempty"`
+	SkipServices           bool                         `json:"skip_services,omitempty"`
+}
+
+// AppPromoteRequest contains the namespace to copy an app to, and how to copy its
+// configurations and services. See NamespaceCloneRequest.
+type AppPromoteRequest struct {
+	Namespace              string                       `json:"namespace,omitempty"`
+	RoutePattern           string                       `json:"route_pattern,omitempty"`
+	ConfigurationOverrides map[string]map[string]string `json:"configuration_overrides,omitempty"`
+	SkipServices           bool                         `json:"skip_services,omitempty"`
+}
+
+// PromoteResponse reports what was copied by a clone or promotion. The pending bindings
+// map apps to the configurations of copied services, which do not exist yet. They have to
+// be bound when the services are ready.
+type PromoteResponse struct {
+	Apps            []string            `json:"apps,omitempty"`
+	Routes          map[string][]string `json:"routes,omitempty"`
+	Configurations  []string            `json:"configurations,omitempty"`
+	Services        []string            `json:"services,omitempty"`
+	PendingBindings map[string][]string `json:"pending_bindings,omitempty"`
+}
+
+// AppsRestartResponse reports which of the applications matching a selector were
+// restarted, and which were skipped for not having a workload.
+type AppsRestartResponse struct {
+	Restarted []string `json:"restarted,omitempty"`
+	Skipped   []string `json:"skipped,omitempty"`
 }
 
 // NamespacesMatchResponse contains the list of names for matching namespaces
@@ -281,6 +558,26 @@ type CatalogService struct {
 	AppVersion       string   `json:"appVersion,omitempty"`
 	HelmRepo         HelmRepo `json:"helm_repo,omitempty"`
 	Values           string   `json:"values,omitempty"`
+	ValuesSchema     string   `json:"values_schema,omitempty"`
+	// BackupJob and RestoreJob are optional templates for the pods of the jobs saving and
+	// restoring the data of the instances of the service. See ServiceBackup.
+	BackupJob  string `json:"backup_job,omitempty"`
+	RestoreJob string `json:"restore_job,omitempty"`
+}
+
+// ServiceCatalogUpdateRequest represents and contains the data needed to modify a catalog
+// service. Fields left nil are not changed.
+type ServiceCatalogUpdateRequest struct {
+	Description      *string `json:"description,omitempty"`
+	ShortDescription *string `json:"short_description,omitempty"`
+	HelmChart        *string `json:"chart,omitempty"`
+	ChartVersion     *string `json:"chartVersion,omitempty"`
+	AppVersion       *string `json:"appVersion,omitempty"`
+	HelmRepoURL      *string `json:"helm_repo_url,omitempty"`
+	Values           *string `json:"values,omitempty"`
+	ValuesSchema     *string `json:"values_schema,omitempty"`
+	BackupJob        *string `json:"backup_job,omitempty"`
+	RestoreJob       *string `json:"restore_job,omitempty"`
 }
 
 // HelmRepo matches github.com/epinio/application/api/v1 HelmRepo
@@ -300,8 +597,25 @@ type ServiceDeleteResponse struct {
 	BoundApps []string `json:"boundapps"`
 }
 
+// ServiceUpdateRequest represents and contains the data needed to update a service
+// instance (add/change, and remove helm values). The keys are helm value paths, as used
+// by `helm --set`.
+type ServiceUpdateRequest struct {
+	Remove []string          `json:"remove,omitempty"`
+	Set    map[string]string `json:"edit,omitempty"`
+}
+
+// ServiceUpgradeResponse represents the server's response to a successful service upgrade
+type ServiceUpgradeResponse struct {
+	OldChartVersion string `json:"old_chart_version"`
+	NewChartVersion string `json:"new_chart_version"`
+}
+
 type ServiceBindRequest struct {
 	AppName string `json:"app_name,omitempty"`
+	// Secrets selects the secrets of the service to bind, and how. When empty all
+	// secrets of the service are bound with default options.
+	Secrets map[string]BindOptions `json:"secrets,omitempty"`
 }
 
 type ServiceUnbindRequest struct {
@@ -310,16 +624,30 @@ type ServiceUnbindRequest struct {
 
 type ServiceShowRequest struct {
 	Name string `json:"name,omitempty"`
+	// Secrets requests the values of the service's configurations in the response
+	Secrets bool `json:"secrets,omitempty"`
 }
 
 type ServiceShowResponse struct {
-	Service *Service `json:"service,omitempty"`
+	Service        *Service               `json:"service,omitempty"`
+	Configurations []ServiceConfiguration `json:"configurations,omitempty"`
+}
+
+// ServiceConfiguration describes a configuration provided by a service instance. The values
+// are only present when explicitly requested.
+type ServiceConfiguration struct {
+	Name   string            `json:"name"`
+	Keys   []string          `json:"keys,omitempty"`
+	Values map[string]string `json:"values,omitempty"`
 }
 
 type Service struct {
-	Meta           Meta          `json:"meta,omitempty"`
-	CatalogService string        `json:"catalog_service,omitempty"`
-	Status         ServiceStatus `json:"status,omitempty"`
+	Meta           Meta                 `json:"meta,omitempty"`
+	CatalogService string               `json:"catalog_service,omitempty"`
+	ChartVersion   string               `json:"chart_version,omitempty"`
+	Settings       map[string]string    `json:"settings,omitempty"`
+	Status         ServiceStatus        `json:"status,omitempty"`
+	Details        ServiceStatusDetails `json:"details,omitempty"`
 }
 
 type ServiceStatus string
@@ -327,13 +655,38 @@ type ServiceStatus string
 const (
 	ServiceStatusDeployed ServiceStatus = "deployed"
 	ServiceStatusNotReady ServiceStatus = "not-ready"
+	ServiceStatusFailed   ServiceStatus = "failed"
 	ServiceStatusUnknown  ServiceStatus = "unknown"
 )
 
+// ServiceStatusDetails provides the information the status of a service instance is
+// derived from.
+type ServiceStatusDetails struct {
+	// HelmStatus is the status of the helm release of the service, if there is any
+	HelmStatus string `json:"helm_status,omitempty"`
+	// JobStatus is the state of the helm controller job (un)installing the release
+	JobStatus ServiceJobStatus `json:"job_status,omitempty"`
+	// PodsReady and PodsTotal count the pods of the release
+	PodsReady int `json:"pods_ready"`
+	PodsTotal int `json:"pods_total"`
+	// LastError is the last error reported by any of the above
+	LastError string `json:"last_error,omitempty"`
+}
+
+type ServiceJobStatus string
+
+const (
+	ServiceJobRunning   ServiceJobStatus = "running"
+	ServiceJobSucceeded ServiceJobStatus = "succeeded"
+	ServiceJobFailed    ServiceJobStatus = "failed"
+)
+
 func NewServiceStatusFromHelmRelease(status helmrelease.Status) ServiceStatus {
 	switch status {
 	case helmrelease.StatusDeployed:
 		return ServiceStatusDeployed
+	case helmrelease.StatusFailed:
+		return ServiceStatusFailed
 	default:
 		return ServiceStatusNotReady
 	}
@@ -345,6 +698,36 @@ type ServiceListResponse struct {
 	Services []*Service `json:"services,omitempty"`
 }
 
+// ServiceBackup describes a backup of the data of a service instance, as stored in the
+// Epinio S3 store.
+type ServiceBackup struct {
+	ID        string      `json:"id"`
+	Size      int64       `json:"size"`
+	CreatedAt metav1.Time `json:"createdAt,omitempty"`
+}
+
+// ServiceBackupList is a collection of service backups
+type ServiceBackupList []ServiceBackup
+
+// Kinds of the jobs saving and restoring the data of service instances
+const (
+	ServiceJobBackup  = "backup"
+	ServiceJobRestore = "restore"
+)
+
+// ServiceJob describes a job saving or restoring the data of a service instance. The
+// backup is set for succeeded backup jobs. Failed jobs carry the reason of the failure,
+// and the logs of their containers.
+type ServiceJob struct {
+	Name     string           `json:"name"`
+	Kind     string           `json:"kind"`
+	BackupID string           `json:"backup_id"`
+	Status   ServiceJobStatus `json:"status"`
+	Backup   *ServiceBackup   `json:"backup,omitempty"`
+	Message  string           `json:"message,omitempty"`
+	Logs     string           `json:"logs,omitempty"`
+}
+
 // AppChart matches github.com/epinio/application/api/v1 AppChartSpec
 // Reason for existence: Do not expose the internal CRD struct in the API.
 type AppChart struct {
@@ -353,6 +736,23 @@ type AppChart struct {
 	ShortDescription string   `json:"short_description,omitempty"`
 	HelmChart        string   `json:"helm_chart,omitempty"`
 	HelmRepo         string   `json:"helm_repo,omitempty"`
+	Features         []string `json:"features,omitempty"`
+}
+
+// AppChartFeatureConfigurationValues is the feature of app charts rendering the
+// configurationVolumes, configurationMounts, and configurationEnv values. These carry the
+// configurations bound with non-default options, and environment references. For charts
+// without the feature they are placed into the pod spec of the deployment after rendering.
+const AppChartFeatureConfigurationValues = "configuration-values"
+
+// Supports returns true if the app chart declares the feature.
+func (c AppChart) Supports(feature string) bool {
+	for _, f := range c.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
 }
 
 // AppChartList is a collection of app charts
@@ -362,3 +762,174 @@ type AppChartList []AppChart
 type ChartMatchResponse struct {
 	Names []string `json:"names,omitempty"`
 }
+
+// Builder is a builder image approved for staging. Staging requests and namespace defaults
+// refer to builders by name, or by image.
+type Builder struct {
+	Name        string `json:"name,omitempty"`
+	Image       string `json:"image,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// BuilderList is a collection of builders
+type BuilderList []Builder
+
+// NamespaceBuilderRequest sets the default builder of the apps in a namespace. An empty
+// name removes the default.
+type NamespaceBuilderRequest struct {
+	Builder string `json:"builder,omitempty"`
+}
+
+// AppCache describes the build cache of an application, i.e. the volume staging keeps
+// the buildpack layers in. The capacity is the size of the volume, not the space used.
+type AppCache struct {
+	Capacity     string      `json:"capacity,omitempty"`
+	StorageClass string      `json:"storage_class,omitempty"`
+	CreatedAt    metav1.Time `json:"created_at,omitempty"`
+	LastUsed     metav1.Time `json:"last_used,omitempty"`
+}
+
+// Status of a blob of application sources, as determined by the blob garbage collection.
+const (
+	// BlobInUse is the status of blobs referenced by an application or its staging jobs
+	BlobInUse = "in use"
+	// BlobRecent is the status of unreferenced blobs still within the retention period,
+	// e.g. sources uploaded and not yet staged
+	BlobRecent = "recent"
+	// BlobUnused is the status of blobs not referenced by their existing application,
+	// e.g. the sources of failed or replaced stagings
+	BlobUnused = "unused"
+	// BlobOrphaned is the status of blobs whose application does not exist anymore
+	BlobOrphaned = "orphaned"
+)
+
+// Blob describes a blob of application sources in the S3 storage
+type Blob struct {
+	ID           string      `json:"id"`
+	Namespace    string      `json:"namespace,omitempty"`
+	App          string      `json:"app,omitempty"`
+	Username     string      `json:"username,omitempty"`
+	Size         int64       `json:"size"`
+	LastModified metav1.Time `json:"last_modified"`
+	Status       string      `json:"status"`
+}
+
+// Collectable returns true if the garbage collection deletes the blob
+func (b Blob) Collectable() bool {
+	return b.Status == BlobUnused || b.Status == BlobOrphaned
+}
+
+// BlobList is a collection of blobs
+type BlobList []Blob
+
+// BlobUpload describes an incomplete multipart upload of application sources
+type BlobUpload struct {
+	ID        string      `json:"id"`
+	UploadID  string      `json:"upload_id"`
+	Initiated metav1.Time `json:"initiated"`
+}
+
+// BlobsGCRequest represents and contains the data needed to collect the garbage blobs.
+// A dry run reports what would be collected, without deleting anything.
+type BlobsGCRequest struct {
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// BlobsGCResponse reports the blobs deleted, and the incomplete uploads aborted, by the
+// garbage collection. For a dry run these are the blobs and uploads which would be.
+type BlobsGCResponse struct {
+	DryRun  bool         `json:"dry_run,omitempty"`
+	Deleted BlobList     `json:"deleted"`
+	Aborted []BlobUpload `json:"aborted"`
+	Freed   int64        `json:"freed"`
+}
+
+// Status of a stage image of an application, as determined by the image retention policy.
+const (
+	// ImageDeployed is the status of images deployed by an application
+	ImageDeployed = "deployed"
+	// ImageStaging is the status of images of running or current stagings
+	ImageStaging = "staging"
+	// ImageRetained is the status of images of the last stagings of an application, kept
+	// as rollback targets
+	ImageRetained = "retained"
+	// ImageExpired is the status of images deleted by the retention policy
+	ImageExpired = "expired"
+)
+
+// AppImage describes a stage image of an application in the Epinio registry
+type AppImage struct {
+	Namespace  string `json:"namespace"`
+	App        string `json:"app"`
+	Repository string `json:"repository"`
+	Tag        string `json:"tag"`
+	Digest     string `json:"digest"`
+	Status     string `json:"status"`
+}
+
+// AppImageList is a collection of application images
+type AppImageList []AppImage
+
+// ImagesGCRequest represents and contains the data needed to delete the expired images. The
+// number of images kept per application defaults to the retention configured for the
+// server. A dry run reports what would be deleted, without deleting anything.
+type ImagesGCRequest struct {
+	Keep   *int `json:"keep,omitempty"`
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// ImagesGCResponse reports the images deleted by the retention policy. For a dry run these
+// are the images which would be.
+type ImagesGCResponse struct {
+	DryRun  bool         `json:"dry_run,omitempty"`
+	Keep    int          `json:"keep"`
+	Deleted AppImageList `json:"deleted"`
+}
+
+// StageRecord describes what a staging built the image of an application from
+type StageRecord struct {
+	ID           string      `json:"id"`
+	CreatedAt    metav1.Time `json:"created_at,omitempty"`
+	Username     string      `json:"username,omitempty"`
+	BlobUID      string      `json:"blobuid,omitempty"`
+	GitURL       string      `json:"giturl,omitempty"`
+	GitCommit    string      `json:"gitcommit,omitempty"`
+	Strategy     string      `json:"strategy,omitempty"`
+	BuilderImage string      `json:"builderimage,omitempty"`
+	PlatformAPI  string      `json:"platformapi,omitempty"`
+}
+
+// Formats of software bill of materials documents
+const (
+	SBOMCycloneDX = "cyclonedx"
+	SBOMSPDX      = "spdx"
+	SBOMSyft      = "syft"
+)
+
+// Buildpack describes a buildpack contributing to an application image
+type Buildpack struct {
+	ID       string `json:"id"`
+	Version  string `json:"version,omitempty"`
+	Homepage string `json:"homepage,omitempty"`
+}
+
+// SBOMDocument is a software bill of materials of an application image, as emitted by one
+// of its buildpacks. The path locates it in the SBOM layer of the image.
+type SBOMDocument struct {
+	Path    string          `json:"path"`
+	Format  string          `json:"format"`
+	Content json.RawMessage `json:"content"`
+}
+
+// AppSBOM describes a stage image of an application: the staging building it, the
+// buildpacks used, and their software bills of materials. Images built from a Dockerfile
+// have neither buildpacks nor documents.
+type AppSBOM struct {
+	Namespace  string         `json:"namespace"`
+	App        string         `json:"app"`
+	Stage      StageRecord    `json:"stage"`
+	Image      string         `json:"image"`
+	Digest     string         `json:"digest"`
+	Buildpacks []Buildpack    `json:"buildpacks"`
+	Documents  []SBOMDocument `json:"documents"`
+}
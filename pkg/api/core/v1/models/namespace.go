@@ -1,11 +1,52 @@
 package models
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // Namespace has all the namespace properties, i.e. name, app names, and configuration names
 // It is used in the CLI and API responses.
 type Namespace struct {
-	Meta           MetaLite `json:"meta,omitempty"`
-	Apps           []string `json:"apps,omitempty"`
-	Configurations []string `json:"configurations,omitempty"`
+	Meta                  MetaLite          `json:"meta,omitempty"`
+	Labels                map[string]string `json:"labels,omitempty"`
+	Description           string            `json:"description,omitempty"`
+	Owner                 string            `json:"owner,omitempty"`
+	Apps                  []string          `json:"apps,omitempty"`
+	Configurations        []string          `json:"configurations,omitempty"`
+	DefaultEnvironment    EnvVariableMap    `json:"default_environment,omitempty"`
+	DefaultConfigurations []string          `json:"default_configurations,omitempty"`
+	DefaultBuilder        string            `json:"default_builder,omitempty"`
+	Quota                 *NamespaceQuota   `json:"quota,omitempty"`
+	Usage                 *NamespaceUsage   `json:"usage,omitempty"`
+	Protected             bool              `json:"protected,omitempty"`
+	DeleteAfter           *metav1.Time      `json:"delete_after,omitempty"`
+}
+
+// NamespaceQuota holds the limits of a namespace. A zero count, or an empty quantity, means
+// that the resource is not limited. CPU and memory are resource quantities, as known to
+// kubernetes, and limit the total requests of the pods in the namespace.
+type NamespaceQuota struct {
+	Apps           int64  `json:"apps,omitempty"`
+	Instances      int64  `json:"instances,omitempty"`
+	CPU            string `json:"cpu,omitempty"`
+	Memory         string `json:"memory,omitempty"`
+	Services       int64  `json:"services,omitempty"`
+	Configurations int64  `json:"configurations,omitempty"`
+}
+
+// IsEmpty returns true if the quota does not limit anything.
+func (q NamespaceQuota) IsEmpty() bool {
+	return q == NamespaceQuota{}
+}
+
+// NamespaceUsage holds the resources used by a namespace, in the same terms as its quota.
+type NamespaceUsage struct {
+	Apps           int64  `json:"apps,omitempty"`
+	Instances      int64  `json:"instances,omitempty"`
+	CPU            string `json:"cpu,omitempty"`
+	Memory         string `json:"memory,omitempty"`
+	Services       int64  `json:"services,omitempty"`
+	Configurations int64  `json:"configurations,omitempty"`
 }
 
 // NamespaceList is a collection of namespaces
@@ -31,3 +72,14 @@ func (al NamespaceList) Swap(i, j int) {
 func (al NamespaceList) Less(i, j int) bool {
 	return al[i].Meta.Name < al[j].Meta.Name
 }
+
+// NamespaceDeletionPreview lists what is destroyed by the deletion of a namespace
+type NamespaceDeletionPreview struct {
+	Apps           []string `json:"apps,omitempty"`
+	Routes         []string `json:"routes,omitempty"`
+	Services       []string `json:"services,omitempty"`
+	Configurations []string `json:"configurations,omitempty"`
+	Volumes        []string `json:"volumes,omitempty"`
+	Blobs          []string `json:"blobs,omitempty"`
+	Protected      bool     `json:"protected,omitempty"`
+}